@@ -2,19 +2,22 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	"podcast-analyzer/internal/agents"
 	"podcast-analyzer/internal/config"
 	"podcast-analyzer/internal/handlers"
 	"podcast-analyzer/internal/middleware"
 	"podcast-analyzer/internal/models"
 	"podcast-analyzer/internal/services"
 	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/metrics"
 	"podcast-analyzer/internal/utils"
 
+	"github.com/google/uuid"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -31,6 +34,7 @@ func main() {
 	}()
 
 	logger.Log.Info("Starting Podcast Analyzer Go Backend Server")
+	startedAt := time.Now()
 	
 	// Load configuration
 	cfg := loadConfiguration()
@@ -43,13 +47,37 @@ func main() {
 
 	// Initialize handlers
 	logger.Log.Info("Initializing handlers")
-	transcriptHandler := handlers.NewTranscriptHandler(transcriptService)
-	analysisHandler := handlers.NewAnalysisHandler(analysisService)
+	pagination := handlers.Pagination{DefaultPerPage: cfg.DefaultPerPage, MaxPerPage: cfg.MaxPerPage}
+	transcriptHandler := handlers.NewTranscriptHandler(transcriptService).WithPagination(pagination).WithMaxBatchSize(cfg.MaxBatchFiles, cfg.MaxFileSize)
+	analysisHandler := handlers.NewAnalysisHandler(analysisService).WithPagination(pagination).WithMaxTextSize(cfg.MaxFileSize)
+	adminHandler := handlers.NewAdminHandler(analysisService).WithTranscriptService(transcriptService)
+	debugHandler := handlers.NewDebugHandler(analysisService)
+	healthHandler := newHealthHandler(cfg, db)
 	logger.Log.Info("Handlers initialized")
 
+	// Fail jobs a previous process left unfinished, in the background so startup isn't delayed
+	if cfg.JobRecoveryEnabled {
+		go recoverInterruptedJobs(analysisService, startedAt)
+	}
+
+	// Keep stored agent stage outputs from growing without bound
+	if cfg.AgentRunCleanupInterval > 0 {
+		go pruneAgentRunsPeriodically(analysisService, cfg.AgentRunCleanupInterval)
+	}
+
+	// Log and publish goroutine and memory stats to spot leaks
+	if cfg.RuntimeMetricsEnabled {
+		metrics.StartRuntimeReporter(context.Background(), cfg.RuntimeMetricsInterval)
+	}
+
+	// Refresh queue gauges when metrics are scraped, at most every few seconds
+	metrics.RegisterCollector(func() {
+		_, _ = analysisService.GetQueueStats()
+	})
+
 	// Setup router
 	logger.Log.Info("Setting up router")
-	router := setupRouter(cfg, transcriptHandler, analysisHandler)
+	router := setupRouter(cfg, transcriptHandler, analysisHandler, adminHandler, debugHandler, healthHandler)
 	logger.Log.Info("Router configured")
 
 	// Create HTTP server
@@ -59,6 +87,26 @@ func main() {
 	runWithGracefulShutdown(server, cfg)
 }
 
+// recoverInterruptedJobs fails jobs left pending or processing before this process started
+func recoverInterruptedJobs(analysisService *services.AnalysisService, startedAt time.Time) {
+	correlationID := uuid.New().String()
+	if _, err := analysisService.RecoverInterruptedJobs(context.Background(), startedAt, correlationID); err != nil {
+		logger.Log.WithError(err).WithField("correlation_id", correlationID).Error("Interrupted job recovery stopped early")
+	}
+}
+
+// pruneAgentRunsPeriodically applies the agent run retention policy every interval
+func pruneAgentRunsPeriodically(analysisService *services.AnalysisService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		correlationID := uuid.New().String()
+		if _, err := analysisService.PruneAgentRuns(correlationID); err != nil {
+			logger.Log.WithError(err).WithField("correlation_id", correlationID).Error("Agent run cleanup stopped early")
+		}
+	}
+}
+
 // maskDatabaseURL masks sensitive information in database URL for logging
 func maskDatabaseURL(dbURL string) string {
 	// Simple masking - replace password with asterisks
@@ -71,31 +119,23 @@ func maskDatabaseURL(dbURL string) string {
 
 // writeError writes a standardized error response
 func writeError(w http.ResponseWriter, status int, code, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    code,
-			"message": message,
-		},
-	})
+	utils.WriteError(w, status, code, message)
 }
 
-// healthHandler handles the health check endpoint
-func healthHandler(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
-	
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	response := map[string]interface{}{
-		"status":  "healthy",
-		"service": "podcast-analyzer-go",
-		"version": "1.0.0",
-	}
-	json.NewEncoder(w).Encode(response)
+// newHealthHandler creates the health check handler with a check for each dependency
+func newHealthHandler(cfg *config.Config, db *gorm.DB) *handlers.HealthHandler {
+	return handlers.NewHealthHandler(cfg.HealthCheckTimeout, cfg.HealthCheckCacheTTL,
+		handlers.HealthCheck{
+			Name: "database",
+			Check: func(ctx context.Context) error {
+				sqlDB, err := db.DB()
+				if err != nil {
+					return err
+				}
+				return sqlDB.PingContext(ctx)
+			},
+		},
+	)
 }
 
 // transcriptsHandler handles /api/transcripts endpoint routing
@@ -118,8 +158,12 @@ func transcriptsHandler(transcriptHandler *handlers.TranscriptHandler) http.Hand
 // transcriptsWithIDHandler handles /api/transcripts/ endpoint routing
 func transcriptsWithIDHandler(transcriptHandler *handlers.TranscriptHandler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodPost {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/reparse") {
+			transcriptHandler.ReparseTranscript(w, r)
+		} else if r.Method == http.MethodPost {
 			transcriptHandler.UploadTranscript(w, r)
+		} else if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/bundle") {
+			transcriptHandler.GetTranscriptBundle(w, r)
 		} else if r.Method == http.MethodGet {
 			transcriptHandler.GetTranscript(w, r)
 		} else if r.Method == http.MethodDelete {
@@ -134,6 +178,21 @@ func transcriptsWithIDHandler(transcriptHandler *handlers.TranscriptHandler) htt
 	}
 }
 
+// jobsWithIDHandler handles /api/jobs/ endpoint routing
+func jobsWithIDHandler(analysisHandler *handlers.AnalysisHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/jobs/" {
+			analysisHandler.ListJobs(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/events") {
+			analysisHandler.GetJobEvents(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/retry") {
+			analysisHandler.RetryJob(w, r)
+		} else {
+			analysisHandler.GetJobStatus(w, r)
+		}
+	}
+}
+
 // analysisResultsHandler handles /api/results endpoint routing
 func analysisResultsHandler(analysisHandler *handlers.AnalysisHandler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -152,7 +211,17 @@ func analysisResultsHandler(analysisHandler *handlers.AnalysisHandler) http.Hand
 // analysisResultsWithIDHandler handles /api/results/ endpoint routing
 func analysisResultsWithIDHandler(analysisHandler *handlers.AnalysisHandler) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodGet {
+		if strings.HasSuffix(r.URL.Path, "/refact-check") {
+			analysisHandler.RerunFactChecks(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/notes") {
+			if r.Method == http.MethodGet {
+				analysisHandler.ListAnalysisNotes(w, r)
+			} else {
+				analysisHandler.CreateAnalysisNote(w, r)
+			}
+		} else if r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/export") {
+			analysisHandler.ExportAnalysisResults(w, r)
+		} else if r.Method == http.MethodGet {
 			analysisHandler.GetAnalysisResults(w, r)
 		} else {
 			writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
@@ -160,24 +229,53 @@ func analysisResultsWithIDHandler(analysisHandler *handlers.AnalysisHandler) htt
 	}
 }
 
-func setupRouter(cfg *config.Config, transcriptHandler *handlers.TranscriptHandler, analysisHandler *handlers.AnalysisHandler) http.Handler {
+func setupRouter(cfg *config.Config, transcriptHandler *handlers.TranscriptHandler, analysisHandler *handlers.AnalysisHandler, adminHandler *handlers.AdminHandler, debugHandler *handlers.DebugHandler, healthHandler *handlers.HealthHandler) http.Handler {
 	mux := http.NewServeMux()
 
 	// Health check endpoint
-	mux.HandleFunc("/health", healthHandler)
+	mux.HandleFunc("/health", healthHandler.GetHealth)
+
+	// Server capabilities for clients
+	mux.HandleFunc("/api/info", handlers.NewInfoHandler(cfg).GetInfo)
+
+	// Metrics endpoint (expvar JSON)
+	mux.Handle("/metrics", metrics.Handler())
 
 	// Register handlers with proper routing
 	mux.HandleFunc("/api/transcripts", transcriptsHandler(transcriptHandler))
 	mux.HandleFunc("/api/transcripts/", transcriptsWithIDHandler(transcriptHandler))
+	mux.HandleFunc("/api/transcripts/batch", transcriptHandler.UploadTranscriptBatch)
+	mux.HandleFunc("/api/transcripts/facets", transcriptHandler.GetTranscriptFacets)
+	mux.HandleFunc("/api/transcripts/exists", transcriptHandler.TranscriptExists)
+	mux.HandleFunc("/api/transcripts/validate", transcriptHandler.ValidateTranscript)
 	mux.HandleFunc("/api/analyze/", analysisHandler.StartAnalysis)
-	mux.HandleFunc("/api/jobs/", analysisHandler.GetJobStatus)
+	mux.HandleFunc("/api/analyze/text", analysisHandler.AnalyzeText)
+	mux.HandleFunc("/api/jobs", analysisHandler.ListJobs)
+	mux.HandleFunc("/api/jobs/", jobsWithIDHandler(analysisHandler))
 	mux.HandleFunc("/api/results", analysisResultsHandler(analysisHandler))
 	mux.HandleFunc("/api/results/", analysisResultsWithIDHandler(analysisHandler))
+	mux.HandleFunc("/api/fact-checks/", analysisHandler.GetFactCheck)
+
+	// Admin endpoints require the admin API key
+	adminAuth := middleware.AdminAuthMiddleware(cfg.AdminAPIKey)
+	mux.Handle("/api/admin/queue", adminAuth(http.HandlerFunc(adminHandler.GetQueueStats)))
+	mux.Handle("/api/admin/transcripts/reparse", adminAuth(http.HandlerFunc(adminHandler.ReparseTranscripts)))
+
+	// Debug endpoints are only registered when explicitly enabled, and also need the admin key
+	if cfg.EnableDebugEndpoints {
+		logger.Log.Warn("Debug endpoints enabled")
+		mux.Handle("/api/debug/agents/", adminAuth(http.HandlerFunc(debugHandler.RunAgent)))
+	}
 
 	// Chain middleware - CORS is handled directly in utils.SetCORSHeaders
-	handler := middleware.RequestIDMiddleware()(mux)
+	handler := middleware.ErrorNegotiationMiddleware()(mux)
+	if cfg.CompressionEnabled {
+		handler = middleware.CompressionMiddleware(cfg.CompressionMinBytes)(handler)
+	}
 	handler = middleware.LoggingMiddleware()(handler)
 	handler = middleware.RecoveryMiddleware()(handler)
+	// Outermost, so logging, recovery and handlers all see the chosen correlation ID
+	handler = middleware.RequestIDMiddleware(cfg.CorrelationIDHeaders...)(handler)
 
 	return handler
 }
@@ -192,10 +290,14 @@ func loadConfiguration() *config.Config {
 		})
 		logger.Log.WithError(err).Fatal("Failed to load configuration")
 	}
-	logger.Log.WithField("log_level", cfg.LogLevel).Info("Configuration loaded successfully")
-
-	// Set log level
+	// Set log format and level
+	logger.SetFormat(cfg.LogFormat)
 	logger.SetLevel(cfg.LogLevel)
+
+	logger.Log.WithFields(map[string]interface{}{
+		"log_level":  cfg.LogLevel,
+		"log_format": cfg.LogFormat,
+	}).Info("Configuration loaded successfully")
 	
 	return cfg
 }
@@ -248,6 +350,31 @@ func initializeServices(db *gorm.DB, cfg *config.Config) (*services.TranscriptSe
 	logger.Log.Info("Initializing services")
 	transcriptService := services.NewTranscriptService(db, cfg)
 	analysisService := services.NewAnalysisService(db, cfg)
+
+	// Fail fast on a misconfigured storage volume rather than on the first upload
+	if err := transcriptService.ValidateStorage(); err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation":    "validate_storage",
+			"storage_path": cfg.StoragePath,
+		})
+		logger.Log.WithError(err).Fatal("Storage path is not usable")
+	}
+
+	// Reject broken prompt template overrides before any job runs
+	if cfg.PromptTemplateDir != "" {
+		prompts, err := agents.LoadPromptTemplates(cfg.PromptTemplateDir)
+		if err != nil {
+			logger.LogErrorWithStack(err, map[string]interface{}{
+				"operation":           "load_prompt_templates",
+				"prompt_template_dir": cfg.PromptTemplateDir,
+			})
+			logger.Log.WithError(err).Fatal("Prompt templates are invalid")
+		}
+		logger.Log.WithFields(map[string]interface{}{
+			"prompt_template_dir": cfg.PromptTemplateDir,
+			"overrides":           prompts.Names(),
+		}).Info("Prompt template overrides loaded")
+	}
 	logger.Log.Info("Services initialized")
 	
 	return transcriptService, analysisService
@@ -289,10 +416,10 @@ func runWithGracefulShutdown(server *http.Server, cfg *config.Config) {
 	// Wait for interrupt signal to gracefully shutdown the server
 	<-ctx.Done()
 	stop()
-	logger.Log.Info("Shutdown signal received, starting graceful shutdown")
+	logger.Log.WithField("shutdown_timeout", cfg.ShutdownTimeout.String()).Info("Shutdown signal received, starting graceful shutdown")
 
-	// Give outstanding requests 30 seconds to complete
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Give outstanding requests the configured grace period to complete
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
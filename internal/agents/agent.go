@@ -2,6 +2,8 @@ package agents
 
 import (
 	"context"
+
+	"podcast-analyzer/internal/models"
 )
 
 // Agent defines the interface that all AI agents must implement
@@ -18,27 +20,55 @@ type Result struct {
 	// Summary contains generated summary text (for SummarizerAgent)
 	Summary string `json:"summary,omitempty"`
 	
+	// Headline is a one-line version of the summary, when one was requested (for SummarizerAgent)
+	Headline string `json:"headline,omitempty"`
+	
 	// Takeaways contains extracted key insights (for TakeawayExtractorAgent)
 	Takeaways []string `json:"takeaways,omitempty"`
 	
+	// TakeawayImportance scores each takeaway from 1 (minor) to 5 (essential), in the same
+	// order, with 0 where Claude gave no score; only set when ranking is on
+	TakeawayImportance []int `json:"takeaway_importance,omitempty"`
+	
 	// FactChecks contains verification results (for FactCheckerAgent)
 	FactChecks []FactCheck `json:"fact_checks,omitempty"`
+	
+	// Degraded is set when too many claims failed verification for the same reason, so the
+	// fact checks are unreliable for this run (for FactCheckerAgent)
+	Degraded       bool   `json:"degraded,omitempty"`
+	DegradedReason string `json:"degraded_reason,omitempty"`
 }
 
 // FactCheck represents a single fact verification result
 type FactCheck struct {
-	Claim      string   `json:"claim"`
-	Verdict    string   `json:"verdict"`    // "true", "false", "partially_true", "unverifiable"
-	Confidence float64  `json:"confidence"` // 0.0-1.0
-	Evidence   string   `json:"evidence"`
-	Sources    []string `json:"sources"`
+	Claim      string         `json:"claim"`
+	Verdict    models.Verdict `json:"verdict"`
+	Confidence float64        `json:"confidence"` // 0.0-1.0
+	Evidence   string         `json:"evidence"`
+	Sources    []string       `json:"sources"`
+	Cached     bool           `json:"cached,omitempty"` // Reused from an earlier verification of the same claim
+	LowSourceQuality bool     `json:"low_source_quality,omitempty"` // Only blocked domains were found, so they were used anyway
+	OriginalVerdict models.Verdict `json:"original_verdict,omitempty"` // Verdict Claude gave before a low confidence downgraded it
+	SearchQuery string            `json:"search_query,omitempty"` // Optimized query sent to Serper, when the claim was checked that way
 }
 
 // ProcessingOptions contains optional parameters for agent processing
 type ProcessingOptions struct {
-	// Summary provides context for takeaway extraction
+	// Summary provides context for takeaway extraction and synthesis
 	Summary string
 	
 	// MaxResults limits the number of results returned
 	MaxResults int
+	
+	// Instructions holds job-specific guidance appended to the system prompts
+	Instructions string
+	
+	// SummaryStyle sets the summary's tone and shape; empty means prose
+	SummaryStyle SummaryStyle
+	
+	// ClaimCategories limits claim extraction to these kinds of claim; empty means any
+	ClaimCategories []ClaimCategory
+	
+	// Headline asks the summarizer for a one-line headline alongside the summary, in the same call
+	Headline bool
 }
\ No newline at end of file
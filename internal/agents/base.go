@@ -6,13 +6,17 @@ import (
 	"time"
 	
 	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/requestctx"
 	"github.com/sirupsen/logrus"
 )
 
 // BaseAgent provides common functionality for all AI agents
 type BaseAgent struct {
-	name   string
-	logger *logrus.Logger
+	name          string
+	logger        *logrus.Logger
+	maxInputChars int // Transcript characters sent to Claude; 0 uses each agent's own limit
+	parseRetries  int // Extra Claude calls made when a response cannot be parsed
 }
 
 // NewBaseAgent creates a new base agent
@@ -28,26 +32,30 @@ func (b *BaseAgent) Name() string {
 	return b.name
 }
 
+// withContextFields adds the correlation ID, caller and job set on ctx to fields
+func withContextFields(ctx context.Context, fields map[string]interface{}) map[string]interface{} {
+	for key, value := range requestctx.LogFields(ctx) {
+		fields[key] = value
+	}
+	return fields
+}
+
 // LogStart logs the beginning of agent processing
 func (b *BaseAgent) LogStart(ctx context.Context, contentLength int) {
-	correlationID := getCorrelationID(ctx)
-	b.logger.WithFields(map[string]interface{}{
+	b.logger.WithFields(withContextFields(ctx, map[string]interface{}{
 		"agent":          b.name,
-		"correlation_id": correlationID,
 		"content_length": contentLength,
 		"word_count":     estimateWordCount(contentLength),
-	}).Info("Agent processing started")
+	})).Info("Agent processing started")
 }
 
 // LogSuccess logs successful completion of agent processing
 func (b *BaseAgent) LogSuccess(ctx context.Context, result *Result, duration time.Duration) {
-	correlationID := getCorrelationID(ctx)
-	fields := map[string]interface{}{
+	fields := withContextFields(ctx, map[string]interface{}{
 		"agent":            b.name,
-		"correlation_id":   correlationID,
 		"duration_ms":      duration.Milliseconds(),
 		"duration_seconds": duration.Seconds(),
-	}
+	})
 	
 	// Add result-specific metrics
 	if result.Summary != "" {
@@ -63,22 +71,22 @@ func (b *BaseAgent) LogSuccess(ctx context.Context, result *Result, duration tim
 		fields["fact_checks_count"] = len(result.FactChecks)
 		
 		// Count verdicts
-		verdictCounts := make(map[string]int)
+		verdictCounts := make(map[models.Verdict]int)
 		for _, fc := range result.FactChecks {
 			verdictCounts[fc.Verdict]++
 		}
 		
-		if verdictCounts["true"] > 0 {
-			fields["fact_checks_true"] = verdictCounts["true"]
+		if verdictCounts[models.VerdictTrue] > 0 {
+			fields["fact_checks_true"] = verdictCounts[models.VerdictTrue]
 		}
-		if verdictCounts["false"] > 0 {
-			fields["fact_checks_false"] = verdictCounts["false"]
+		if verdictCounts[models.VerdictFalse] > 0 {
+			fields["fact_checks_false"] = verdictCounts[models.VerdictFalse]
 		}
-		if verdictCounts["partially_true"] > 0 {
-			fields["fact_checks_partial"] = verdictCounts["partially_true"]
+		if verdictCounts[models.VerdictPartiallyTrue] > 0 {
+			fields["fact_checks_partial"] = verdictCounts[models.VerdictPartiallyTrue]
 		}
-		if verdictCounts["unverifiable"] > 0 {
-			fields["fact_checks_unverifiable"] = verdictCounts["unverifiable"]
+		if verdictCounts[models.VerdictUnverifiable] > 0 {
+			fields["fact_checks_unverifiable"] = verdictCounts[models.VerdictUnverifiable]
 		}
 	}
 	
@@ -87,37 +95,55 @@ func (b *BaseAgent) LogSuccess(ctx context.Context, result *Result, duration tim
 
 // LogError logs agent processing errors
 func (b *BaseAgent) LogError(ctx context.Context, err error, duration time.Duration) {
-	correlationID := getCorrelationID(ctx)
-	logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+	logger.LogErrorWithStackAndCorrelation(err, requestctx.CorrelationID(ctx), withContextFields(ctx, map[string]interface{}{
 		"agent":            b.name,
 		"duration_ms":      duration.Milliseconds(),
 		"duration_seconds": duration.Seconds(),
 		"operation":        "agent_processing",
-	})
+	}))
+}
+
+// WithTimeout runs process under a deadline of its own. When the deadline expires the
+// error is returned as a *TimeoutError; a parent context that ends first keeps its own
+// error. A zero timeout runs process with ctx unchanged.
+func (b *BaseAgent) WithTimeout(ctx context.Context, timeout time.Duration, process func(ctx context.Context) (Result, error)) (Result, error) {
+	if timeout <= 0 {
+		return process(ctx)
+	}
+
+	agentCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := process(agentCtx)
+	if err != nil && ctx.Err() == nil && agentCtx.Err() == context.DeadlineExceeded {
+		b.logger.WithFields(map[string]interface{}{
+			"agent":          b.name,
+			"correlation_id": requestctx.CorrelationID(ctx),
+			"timeout":        timeout.String(),
+		}).Warn("Agent timed out")
+		return result, NewTimeoutError(b.name, timeout, err)
+	}
+	return result, err
 }
 
 // LogAPICall logs details about external API calls
 func (b *BaseAgent) LogAPICall(ctx context.Context, service string, promptLength int, hasSystem bool) {
-	correlationID := getCorrelationID(ctx)
-	b.logger.WithFields(map[string]interface{}{
-		"agent":          b.name,
-		"correlation_id": correlationID,
-		"service":        service,
-		"prompt_length":  promptLength,
-		"has_system":     hasSystem,
-	}).Info("Making API call")
+	b.logger.WithFields(withContextFields(ctx, map[string]interface{}{
+		"agent":         b.name,
+		"service":       service,
+		"prompt_length": promptLength,
+		"has_system":    hasSystem,
+	})).Info("Making API call")
 }
 
 // LogAPIResponse logs details about API responses
 func (b *BaseAgent) LogAPIResponse(ctx context.Context, service string, responseLength int, duration time.Duration) {
-	correlationID := getCorrelationID(ctx)
-	b.logger.WithFields(map[string]interface{}{
+	b.logger.WithFields(withContextFields(ctx, map[string]interface{}{
 		"agent":           b.name,
-		"correlation_id":  correlationID,
 		"service":         service,
 		"response_length": responseLength,
 		"duration_ms":     duration.Milliseconds(),
-	}).Info("API response received")
+	})).Info("API response received")
 }
 
 // ValidateContent performs basic validation on input content
@@ -155,6 +181,31 @@ func (b *BaseAgent) TruncateContent(content string, maxLength int) string {
 	return truncated + "\n[...content truncated...]"
 }
 
+// TruncateInput caps the transcript sent to Claude at the configured maximum, or at
+// defaultMax when none is configured, and warns when content is cut
+func (b *BaseAgent) TruncateInput(ctx context.Context, content string, defaultMax int) string {
+	limit := b.maxInputChars
+	if limit <= 0 {
+		limit = defaultMax
+	}
+	return b.truncateTo(ctx, content, limit)
+}
+
+// truncateTo caps content at limit characters, warning when content is cut
+func (b *BaseAgent) truncateTo(ctx context.Context, content string, limit int) string {
+	if len(content) <= limit {
+		return content
+	}
+	
+	b.logger.WithFields(map[string]interface{}{
+		"agent":          b.name,
+		"correlation_id": requestctx.CorrelationID(ctx),
+		"content_chars":  len(content),
+		"max_chars":      limit,
+	}).Warn("Transcript truncated to fit agent input limit")
+	return b.TruncateContent(content, limit)
+}
+
 // TruncateForLog truncates text for logging to avoid overly long log messages
 func (b *BaseAgent) TruncateForLog(text string, maxLength int) string {
 	if len(text) <= maxLength {
@@ -165,16 +216,6 @@ func (b *BaseAgent) TruncateForLog(text string, maxLength int) string {
 
 // Helper functions
 
-// getCorrelationID extracts correlation ID from context
-func getCorrelationID(ctx context.Context) string {
-	if id := ctx.Value("correlation_id"); id != nil {
-		if correlationID, ok := id.(string); ok {
-			return correlationID
-		}
-	}
-	return ""
-}
-
 // estimateWordCount provides a rough word count estimate from character count
 func estimateWordCount(charCount int) int {
 	// Rough estimate: average English word is ~5 characters + space
@@ -184,4 +225,13 @@ func estimateWordCount(charCount int) int {
 // IsUpperCase checks if a byte represents an uppercase letter
 func (b *BaseAgent) IsUpperCase(char byte) bool {
 	return char >= 'A' && char <= 'Z'
-}
\ No newline at end of file
+}
+
+// appendInstructions adds job-specific instructions to a system prompt. The built-in
+// output format still takes priority so responses keep parsing.
+func appendInstructions(systemPrompt, instructions string) string {
+	if instructions == "" {
+		return systemPrompt
+	}
+	return systemPrompt + "\n\nAdditional instructions for this analysis (follow them unless they conflict with the required output format):\n" + instructions
+}
@@ -6,9 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"podcast-analyzer/internal/requestctx"
+
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func setupTestLogger() (*logrus.Logger, *test.Hook) {
@@ -39,7 +43,7 @@ func TestBaseAgent_LogStart(t *testing.T) {
 		logger: logger,
 	}
 	
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-123")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-123")
 	
 	agent.LogStart(ctx, 1500)
 	
@@ -53,6 +57,23 @@ func TestBaseAgent_LogStart(t *testing.T) {
 	assert.Equal(t, 250, entry.Data["word_count"])
 }
 
+func TestBaseAgent_LogStart_JobAndUser(t *testing.T) {
+	logger, hook := setupTestLogger()
+	agent := &BaseAgent{
+		name:   "test-agent",
+		logger: logger,
+	}
+	
+	jobID := uuid.New()
+	ctx := requestctx.WithJobID(requestctx.WithUser(context.Background(), "admin"), jobID)
+	
+	agent.LogStart(ctx, 1500)
+	
+	entry := hook.LastEntry()
+	assert.Equal(t, jobID.String(), entry.Data["job_id"])
+	assert.Equal(t, "admin", entry.Data["user"])
+}
+
 func TestBaseAgent_LogSuccess(t *testing.T) {
 	logger, hook := setupTestLogger()
 	agent := &BaseAgent{
@@ -60,7 +81,7 @@ func TestBaseAgent_LogSuccess(t *testing.T) {
 		logger: logger,
 	}
 	
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-456")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-456")
 	result := &Result{
 		Summary:    "Test summary",
 		Takeaways:  []string{"takeaway1", "takeaway2"},
@@ -95,7 +116,7 @@ func TestBaseAgent_LogError(t *testing.T) {
 		logger: logger,
 	}
 	
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-789")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-789")
 	testErr := assert.AnError
 	duration := 500 * time.Millisecond
 	
@@ -113,7 +134,7 @@ func TestBaseAgent_LogAPICall(t *testing.T) {
 		logger: logger,
 	}
 	
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-api")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-api")
 	
 	agent.LogAPICall(ctx, "anthropic", 2000, true)
 	
@@ -135,7 +156,7 @@ func TestBaseAgent_LogAPIResponse(t *testing.T) {
 		logger: logger,
 	}
 	
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-resp")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-resp")
 	duration := 1500 * time.Millisecond
 	
 	agent.LogAPIResponse(ctx, "anthropic", 500, duration)
@@ -207,6 +228,34 @@ func TestBaseAgent_ValidateContent(t *testing.T) {
 	}
 }
 
+func TestBaseAgent_TruncateInput(t *testing.T) {
+	logger, hook := setupTestLogger()
+	agent := &BaseAgent{name: "test-agent", logger: logger}
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-123")
+	content := strings.Repeat("word ", 40) // 200 characters
+
+	// Content within the agent's own limit is sent unchanged
+	assert.Equal(t, content, agent.TruncateInput(ctx, content, 500))
+	assert.Empty(t, hook.Entries)
+
+	// The agent's own limit applies when no global limit is configured
+	truncated := agent.TruncateInput(ctx, content, 150)
+	assert.True(t, strings.HasSuffix(truncated, "[...content truncated...]"))
+	assert.LessOrEqual(t, len(strings.TrimSuffix(truncated, "\n[...content truncated...]")), 150)
+
+	// A configured limit replaces the agent's own
+	agent.maxInputChars = 100
+	truncated = agent.TruncateInput(ctx, content, 500)
+	assert.LessOrEqual(t, len(strings.TrimSuffix(truncated, "\n[...content truncated...]")), 100)
+
+	require.Len(t, hook.Entries, 2)
+	entry := hook.LastEntry()
+	assert.Equal(t, logrus.WarnLevel, entry.Level)
+	assert.Equal(t, "test-correlation-123", entry.Data["correlation_id"])
+	assert.Equal(t, 200, entry.Data["content_chars"])
+	assert.Equal(t, 100, entry.Data["max_chars"])
+}
+
 func TestBaseAgent_TruncateContent(t *testing.T) {
 	agent := &BaseAgent{name: "test-agent"}
 	
@@ -301,37 +350,6 @@ func TestBaseAgent_IsUpperCase(t *testing.T) {
 	}
 }
 
-func TestGetCorrelationID(t *testing.T) {
-	tests := []struct {
-		name     string
-		ctx      context.Context
-		expected string
-	}{
-		{
-			name:     "context with correlation ID",
-			ctx:      context.WithValue(context.Background(), "correlation_id", "test-id-123"),
-			expected: "test-id-123",
-		},
-		{
-			name:     "context without correlation ID",
-			ctx:      context.Background(),
-			expected: "",
-		},
-		{
-			name:     "context with wrong type",
-			ctx:      context.WithValue(context.Background(), "correlation_id", 12345),
-			expected: "",
-		},
-	}
-	
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := getCorrelationID(tt.ctx)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
 func TestEstimateWordCount(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -351,4 +369,53 @@ func TestEstimateWordCount(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestAppendInstructions(t *testing.T) {
+	assert.Equal(t, "System prompt", appendInstructions("System prompt", ""))
+
+	prompt := appendInstructions("System prompt", "Focus on financial claims.")
+	assert.True(t, strings.HasPrefix(prompt, "System prompt\n\n"))
+	assert.True(t, strings.HasSuffix(prompt, "Focus on financial claims."))
+}
+
+func TestBaseAgent_WithTimeout(t *testing.T) {
+	agent := NewBaseAgent("test-agent")
+	agent.logger, _ = setupTestLogger()
+
+	slow := func(ctx context.Context) (Result, error) {
+		<-ctx.Done()
+		return Result{}, NewAgentError("test-agent", "API call failed", ctx.Err())
+	}
+
+	t.Run("deadline exceeded returns timeout error", func(t *testing.T) {
+		_, err := agent.WithTimeout(context.Background(), 10*time.Millisecond, slow)
+
+		var timeoutErr *TimeoutError
+		assert.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "test-agent", timeoutErr.Agent)
+		assert.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("cancelled parent keeps its own error", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := agent.WithTimeout(ctx, time.Minute, slow)
+
+		assert.False(t, IsTimeoutError(err))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("zero timeout leaves context unchanged", func(t *testing.T) {
+		result, err := agent.WithTimeout(context.Background(), 0, func(ctx context.Context) (Result, error) {
+			_, hasDeadline := ctx.Deadline()
+			assert.False(t, hasDeadline)
+			return Result{Summary: "done"}, nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "done", result.Summary)
+	})
+}
@@ -0,0 +1,66 @@
+package agents
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ClaimCategory is a kind of factual claim the fact checker can be limited to
+type ClaimCategory string
+
+const (
+	ClaimCategoryStatistics ClaimCategory = "statistics"
+	ClaimCategoryDates      ClaimCategory = "dates"
+	ClaimCategoryScientific ClaimCategory = "scientific"
+	ClaimCategoryHistorical ClaimCategory = "historical"
+	ClaimCategoryFinancial  ClaimCategory = "financial"
+	ClaimCategoryHealth     ClaimCategory = "health"
+)
+
+// ClaimCategories lists every supported category, in the order they are described to Claude
+var ClaimCategories = []ClaimCategory{ClaimCategoryStatistics, ClaimCategoryDates, ClaimCategoryScientific, ClaimCategoryHistorical, ClaimCategoryFinancial, ClaimCategoryHealth}
+
+// claimCategoryDescriptions describes each category for the claim extraction prompt
+var claimCategoryDescriptions = map[ClaimCategory]string{
+	ClaimCategoryStatistics: "statistics, figures, percentages or other quantities",
+	ClaimCategoryDates:      "dates, years or when something happened",
+	ClaimCategoryScientific: "scientific findings, research results or studies",
+	ClaimCategoryHistorical: "historical events and who was involved in them",
+	ClaimCategoryFinancial:  "money, prices, revenue, valuations or market figures",
+	ClaimCategoryHealth:     "health, medicine or nutrition",
+}
+
+// ParseClaimCategories normalizes requested categories, accepting any case, and returns
+// them deduplicated in canonical order. No categories means every kind of claim.
+func ParseClaimCategories(values []string) ([]ClaimCategory, error) {
+	requested := make(map[ClaimCategory]bool, len(values))
+	for _, value := range values {
+		category := ClaimCategory(strings.ToLower(strings.TrimSpace(value)))
+		if _, ok := claimCategoryDescriptions[category]; !ok {
+			return nil, fmt.Errorf("invalid claim category %q", value)
+		}
+		requested[category] = true
+	}
+
+	var categories []ClaimCategory
+	for _, category := range ClaimCategories {
+		if requested[category] {
+			categories = append(categories, category)
+		}
+	}
+	return categories, nil
+}
+
+// appendClaimCategories limits a claim extraction system prompt to the given categories;
+// no categories leaves the prompt unchanged
+func appendClaimCategories(systemPrompt string, categories []ClaimCategory) string {
+	if len(categories) == 0 {
+		return systemPrompt
+	}
+	descriptions := make([]string, len(categories))
+	for i, category := range categories {
+		descriptions[i] = "- " + claimCategoryDescriptions[category]
+	}
+	return systemPrompt + "\n\nOnly extract claims about:\n" + strings.Join(descriptions, "\n") +
+		"\nSkip claims of any other kind, even if they are verifiable. If none qualify, say there are no claims."
+}
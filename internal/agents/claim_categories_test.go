@@ -0,0 +1,54 @@
+package agents
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClaimCategories(t *testing.T) {
+	categories, err := ParseClaimCategories([]string{" Dates", "statistics", "dates"})
+	require.NoError(t, err)
+	assert.Equal(t, []ClaimCategory{ClaimCategoryStatistics, ClaimCategoryDates}, categories)
+
+	categories, err = ParseClaimCategories(nil)
+	require.NoError(t, err)
+	assert.Empty(t, categories)
+
+	_, err = ParseClaimCategories([]string{"statistics", "gossip"})
+	assert.ErrorContains(t, err, `"gossip"`)
+}
+
+func TestAppendClaimCategories(t *testing.T) {
+	assert.Equal(t, "base", appendClaimCategories("base", nil))
+
+	prompt := appendClaimCategories("base", []ClaimCategory{ClaimCategoryStatistics, ClaimCategoryDates})
+	assert.True(t, strings.HasPrefix(prompt, "base\n\nOnly extract claims about:"))
+	assert.Contains(t, prompt, "- statistics, figures, percentages or other quantities")
+	assert.Contains(t, prompt, "- dates, years or when something happened")
+	assert.NotContains(t, prompt, "health")
+}
+
+func TestFactCheckerAgent_extractClaims_ClaimCategories(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockClient,
+	}
+
+	mockClient.On("CallClaude", mock.Anything, "fact_checker", mock.Anything, mock.MatchedBy(func(systemPrompt string) bool {
+		return strings.Contains(systemPrompt, "Only extract claims about:\n- scientific findings")
+	}), false).Return("1. A 2019 study found that coffee lowers liver disease risk", nil).Once()
+
+	claims, err := agent.extractClaims(context.Background(), "A 2019 study found that coffee lowers liver disease risk.", ProcessingOptions{
+		ClaimCategories: []ClaimCategory{ClaimCategoryScientific},
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, claims, 1)
+	mockClient.AssertExpectations(t)
+}
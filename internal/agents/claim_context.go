@@ -0,0 +1,100 @@
+package agents
+
+import (
+	"regexp"
+	"strings"
+)
+
+// transcriptSentencePattern matches a sentence or line of transcript text
+var transcriptSentencePattern = regexp.MustCompile(`[^.!?\n]+[.!?]*`)
+
+// claimWordPattern matches runs of characters ignored when matching a claim to the transcript
+var claimWordPattern = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// A claim is placed in the transcript sentence sharing the most of its distinctive words,
+// but only when enough of them appear there; claims are usually reworded by extraction
+const (
+	minClaimContextWords  = 2
+	minClaimContextScore  = 0.5
+	minClaimContextLength = 4
+)
+
+// transcriptExcerptNote introduces the transcript excerpt in a verification prompt
+const transcriptExcerptNote = "TRANSCRIPT CONTEXT (the passage the claim came from, to resolve what it refers to; base the verdict on the sources, not on this passage):"
+
+// claimContextWords returns the distinctive lowercase words of text, skipping short ones
+func claimContextWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(claimWordPattern.ReplaceAllString(strings.ToLower(text), " ")) {
+		if len([]rune(word)) >= minClaimContextLength {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// transcriptExcerpt returns about chars characters of transcript centered on the sentence
+// the claim was taken from, or "" when chars is not positive or the claim cannot be placed
+func transcriptExcerpt(content, claim string, chars int) string {
+	if chars <= 0 {
+		return ""
+	}
+	claimWords := claimContextWords(claim)
+	if len(claimWords) < minClaimContextWords {
+		return ""
+	}
+
+	var best []int
+	bestHits := 0
+	for _, sentence := range transcriptSentencePattern.FindAllStringIndex(content, -1) {
+		hits := 0
+		for word := range claimContextWords(content[sentence[0]:sentence[1]]) {
+			if claimWords[word] {
+				hits++
+			}
+		}
+		if hits > bestHits {
+			best, bestHits = sentence, hits
+		}
+	}
+	if best == nil || bestHits < minClaimContextWords || float64(bestHits)/float64(len(claimWords)) < minClaimContextScore {
+		return ""
+	}
+
+	start := (best[0]+best[1])/2 - chars/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + chars
+	if end > len(content) {
+		end = len(content)
+		if start = end - chars; start < 0 {
+			start = 0
+		}
+	}
+
+	// Cut at whitespace so no word (or multi-byte character) is split
+	prefix, suffix := "", ""
+	if start > 0 {
+		if i := strings.IndexAny(content[start:end], " \t\n"); i >= 0 {
+			start += i
+		}
+		prefix = "..."
+	}
+	if end < len(content) {
+		if i := strings.LastIndexAny(content[start:end], " \t\n"); i >= 0 {
+			end = start + i
+		}
+		suffix = "..."
+	}
+	return prefix + strings.TrimSpace(content[start:end]) + suffix
+}
+
+// appendTranscriptExcerpt adds the transcript context around a claim to a verification
+// prompt; an empty excerpt leaves the prompt unchanged
+func appendTranscriptExcerpt(userPrompt, excerpt string) string {
+	if excerpt == "" {
+		return userPrompt
+	}
+	return userPrompt + "\n\n" + transcriptExcerptNote + "\n" + excerpt
+}
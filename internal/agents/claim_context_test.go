@@ -0,0 +1,66 @@
+package agents
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"podcast-analyzer/internal/clients"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const claimContextTranscript = "Welcome back to the show. Last year a dozen eggs cost two dollars at my local store. " +
+	"This year prices doubled, according to the farm bureau. Anyway, let's talk about gardening."
+
+func TestTranscriptExcerpt(t *testing.T) {
+	excerpt := transcriptExcerpt(claimContextTranscript, "Egg prices doubled this year according to the farm bureau", 120)
+	assert.Contains(t, excerpt, "prices doubled, according to the farm bureau.")
+	assert.Contains(t, excerpt, "two dollars")
+	assert.True(t, strings.HasPrefix(excerpt, "..."))
+	assert.True(t, strings.HasSuffix(excerpt, "..."))
+	assert.LessOrEqual(t, len(excerpt), 126)
+
+	assert.Equal(t, claimContextTranscript, transcriptExcerpt(claimContextTranscript, "Prices doubled according to the farm bureau", 1000))
+}
+
+func TestTranscriptExcerpt_NoContext(t *testing.T) {
+	claim := "Prices doubled according to the farm bureau"
+	assert.Empty(t, transcriptExcerpt(claimContextTranscript, claim, 0))
+	assert.Empty(t, transcriptExcerpt(claimContextTranscript, "The Apollo 11 mission landed on the Moon in 1969", 200))
+	assert.Empty(t, transcriptExcerpt(claimContextTranscript, "It was", 200))
+}
+
+func TestAppendTranscriptExcerpt(t *testing.T) {
+	assert.Equal(t, "prompt", appendTranscriptExcerpt("prompt", ""))
+	assert.Equal(t, "prompt\n\n"+transcriptExcerptNote+"\nwhat was said", appendTranscriptExcerpt("prompt", "what was said"))
+}
+
+func TestFactCheckerAgent_verifyClaim_IncludesTranscriptExcerpt(t *testing.T) {
+	mockSerperClient := &MockSerperClient{}
+	mockAnthropicClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		serperClient:    mockSerperClient,
+		anthropicClient: mockAnthropicClient,
+	}
+
+	claim := "Egg prices doubled this year"
+	searchContext := &clients.SearchContext{
+		Sources:  []string{"https://example.com/eggs"},
+		Snippets: []clients.SearchSnippet{{Title: "Egg prices", Snippet: "Egg prices rose", URL: "https://example.com/eggs"}},
+	}
+	mockSerperClient.On("SearchForClaim", mock.Anything, "fact_checker", claim).Return(searchContext, nil)
+	mockSerperClient.On("FormatSearchResultsForAnalysis", searchContext).Return("Result 1: Egg prices rose")
+	mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.MatchedBy(func(prompt string) bool {
+		return strings.HasSuffix(prompt, transcriptExcerptNote+"\nLast year a dozen eggs cost two dollars.")
+	}), mock.Anything, false).Return("VERDICT: true\nCONFIDENCE: 0.8\nEVIDENCE: Prices rose SOURCES: https://example.com/eggs", nil)
+
+	factCheck, err := agent.verifyClaim(context.Background(), claim, "Last year a dozen eggs cost two dollars.", ProcessingOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0.8, factCheck.Confidence)
+	mockAnthropicClient.AssertExpectations(t)
+}
@@ -0,0 +1,245 @@
+package agents
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"podcast-analyzer/internal/requestctx"
+)
+
+// Strategies for finding claims in a transcript longer than one extraction window
+const (
+	claimStrategyTruncate = "truncate" // Only the start of the transcript is searched
+	claimStrategySample   = "sample"   // Excerpts spread evenly across the transcript are searched together
+	claimStrategyWindows  = "windows"  // Claims are extracted from each window and merged
+)
+
+// claimSampleExcerptChars is the length of each excerpt taken by the sample strategy
+const claimSampleExcerptChars = 1000
+
+// defaultClaimMaxWindows caps the windows searched for claims when no cap is configured
+const defaultClaimMaxWindows = 5
+
+// defaultClaimMaxMerged caps the claims the windows strategy keeps when no cap is configured.
+// Each window yields up to three claims, so without it the claims verified grow with the
+// window count.
+const defaultClaimMaxMerged = 10
+
+// claimExcerptSeparator marks the gaps between sampled excerpts for Claude
+const claimExcerptSeparator = "\n[...]\n"
+
+// claimWindowChars returns the transcript length sent in one claim extraction call
+func (f *FactCheckerAgent) claimWindowChars() int {
+	if f.claimWindowSize > 0 {
+		return f.claimWindowSize
+	}
+	if f.maxInputChars > 0 {
+		return f.maxInputChars
+	}
+	return factCheckMaxInputChars
+}
+
+// claimMaxWindows returns the most windows the windows strategy searches
+func (f *FactCheckerAgent) claimMaxWindows() int {
+	if f.claimWindowLimit > 0 {
+		return f.claimWindowLimit
+	}
+	return defaultClaimMaxWindows
+}
+
+// claimMaxMerged returns the most claims the windows strategy keeps after merging
+func (f *FactCheckerAgent) claimMaxMerged() int {
+	if f.claimMergeLimit > 0 {
+		return f.claimMergeLimit
+	}
+	return defaultClaimMaxMerged
+}
+
+// extractClaims extracts factual claims from the transcript that can be verified, using
+// the configured strategy when the transcript is longer than one window
+func (f *FactCheckerAgent) extractClaims(ctx context.Context, content string, opts ProcessingOptions) ([]string, error) {
+	window := f.claimWindowChars()
+	if len(content) <= window {
+		return f.extractClaimsFrom(ctx, content, opts)
+	}
+
+	switch f.claimStrategy {
+	case claimStrategySample:
+		f.logger.WithFields(map[string]interface{}{
+			"agent":          f.Name(),
+			"correlation_id": requestctx.CorrelationID(ctx),
+			"content_chars":  len(content),
+			"sample_chars":   window,
+		}).Info("Sampling transcript for claim extraction")
+		return f.extractClaimsFrom(ctx, sampleExcerpts(content, window, claimSampleExcerptChars), opts)
+	case claimStrategyWindows:
+		return f.extractClaimsFromWindows(ctx, splitWindows(content, window, f.claimMaxWindows()), opts)
+	default:
+		return f.extractClaimsFrom(ctx, f.truncateTo(ctx, content, window), opts)
+	}
+}
+
+// extractClaimsFromWindows extracts claims from each window and merges them, dropping
+// repeats and keeping at most claimMaxMerged. A window that fails is skipped; the call
+// fails only when every window does.
+func (f *FactCheckerAgent) extractClaimsFromWindows(ctx context.Context, windows []string, opts ProcessingOptions) ([]string, error) {
+	log := f.logger.WithFields(map[string]interface{}{
+		"agent":          f.Name(),
+		"correlation_id": requestctx.CorrelationID(ctx),
+		"windows":        len(windows),
+	})
+	log.Info("Extracting claims from transcript windows")
+
+	var perWindow [][]string
+	var lastErr error
+	seen := make(map[string]bool)
+	succeeded := 0
+	for i, window := range windows {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		windowClaims, err := f.extractClaimsFrom(ctx, window, opts)
+		if err != nil {
+			log.WithError(err).WithField("window", i+1).Warn("Claim extraction failed for transcript window")
+			lastErr = err
+			continue
+		}
+		succeeded++
+
+		var unique []string
+		for _, claim := range windowClaims {
+			key := strings.ToLower(strings.Join(strings.Fields(claim), " "))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			unique = append(unique, claim)
+		}
+		perWindow = append(perWindow, unique)
+	}
+
+	if succeeded == 0 {
+		return nil, lastErr
+	}
+
+	claims, dropped := mergeWindowClaims(perWindow, f.claimMaxMerged())
+	if dropped > 0 {
+		log.WithFields(map[string]interface{}{
+			"kept":    len(claims),
+			"dropped": dropped,
+		}).Info("Capped claims merged from transcript windows")
+	}
+	return claims, nil
+}
+
+// mergeWindowClaims takes claims from each window in turn, so a cap keeps claims from
+// across the transcript rather than only its start, and reports how many were left out
+func mergeWindowClaims(perWindow [][]string, limit int) ([]string, int) {
+	var claims []string
+	total := 0
+	for _, windowClaims := range perWindow {
+		total += len(windowClaims)
+	}
+	for round := 0; len(claims) < total && len(claims) < limit; round++ {
+		for _, windowClaims := range perWindow {
+			if round < len(windowClaims) && len(claims) < limit {
+				claims = append(claims, windowClaims[round])
+			}
+		}
+	}
+	return claims, total - len(claims)
+}
+
+// sampleExcerpts shortens content to about budget characters by taking excerpts of
+// excerptChars spread evenly from start to end, cut at word boundaries
+func sampleExcerpts(content string, budget, excerptChars int) string {
+	if len(content) <= budget {
+		return content
+	}
+	if excerptChars > budget {
+		excerptChars = budget
+	}
+
+	count := budget / excerptChars
+	excerpts := make([]string, 0, count)
+	for _, start := range spreadOffsets(len(content), excerptChars, count) {
+		if excerpt := wordAligned(content, start, start+excerptChars); excerpt != "" {
+			excerpts = append(excerpts, excerpt)
+		}
+	}
+	return strings.Join(excerpts, claimExcerptSeparator)
+}
+
+// splitWindows divides content into windows of size characters covering all of it. When
+// that takes more than maxWindows, maxWindows windows are spread evenly instead.
+func splitWindows(content string, size, maxWindows int) []string {
+	if len(content) <= size {
+		return []string{content}
+	}
+
+	count := (len(content) + size - 1) / size
+	if maxWindows > 0 && count > maxWindows {
+		count = maxWindows
+	}
+
+	windows := make([]string, 0, count)
+	for _, start := range spreadOffsets(len(content), size, count) {
+		if window := wordAligned(content, start, start+size); window != "" {
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+// spreadOffsets returns count start offsets for spans of size characters, evenly spaced so
+// the first starts at the beginning of total characters and the last ends at the end
+func spreadOffsets(total, size, count int) []int {
+	if count <= 1 {
+		return []int{0}
+	}
+	offsets := make([]int, count)
+	for i := range offsets {
+		offsets[i] = i * (total - size) / (count - 1)
+	}
+	return offsets
+}
+
+// wordAligned returns content[start:end] trimmed inward to whole words, so a span never
+// starts or ends mid-word or mid-character
+func wordAligned(content string, start, end int) string {
+	if end > len(content) {
+		end = len(content)
+	}
+	if start > 0 && !isSpaceBefore(content, start) {
+		if next := strings.IndexFunc(content[start:end], unicode.IsSpace); next >= 0 {
+			start += next
+		}
+	}
+	if end < len(content) && !isSpaceAt(content, end) {
+		if last := strings.LastIndexFunc(content[start:end], unicode.IsSpace); last > 0 {
+			end = start + last
+		}
+	}
+	for start < end && !utf8.RuneStart(content[start]) {
+		start++
+	}
+	for end > start && end < len(content) && !utf8.RuneStart(content[end]) {
+		end--
+	}
+	return strings.TrimSpace(content[start:end])
+}
+
+// isSpaceBefore reports whether the character before offset is whitespace
+func isSpaceBefore(content string, offset int) bool {
+	r, _ := utf8.DecodeLastRuneInString(content[:offset])
+	return unicode.IsSpace(r)
+}
+
+// isSpaceAt reports whether the character at offset is whitespace
+func isSpaceAt(content string, offset int) bool {
+	r, _ := utf8.DecodeRuneInString(content[offset:])
+	return unicode.IsSpace(r)
+}
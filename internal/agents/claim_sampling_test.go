@@ -0,0 +1,171 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// numberedWords returns count short words separated by spaces
+func numberedWords(count int) string {
+	words := make([]string, count)
+	for i := range words {
+		words[i] = "w" + strings.Repeat("x", i%3) + string(rune('a'+i%26))
+	}
+	return strings.Join(words, " ")
+}
+
+func TestSampleExcerpts_CoversStartAndEnd(t *testing.T) {
+	content := "START " + strings.Repeat("filler words here ", 500) + "END"
+
+	sampled := sampleExcerpts(content, 300, 100)
+
+	assert.LessOrEqual(t, len(sampled), 300+2*len(claimExcerptSeparator))
+	assert.True(t, strings.HasPrefix(sampled, "START"))
+	assert.True(t, strings.HasSuffix(sampled, "END"))
+	assert.Equal(t, 2, strings.Count(sampled, claimExcerptSeparator))
+}
+
+func TestSampleExcerpts_ShortContentUnchanged(t *testing.T) {
+	assert.Equal(t, "short transcript", sampleExcerpts("short transcript", 100, 10))
+}
+
+func TestSplitWindows(t *testing.T) {
+	content := numberedWords(1000)
+
+	windows := splitWindows(content, 1000, 0)
+	require.Greater(t, len(windows), 1)
+	assert.True(t, strings.HasPrefix(content, windows[0]))
+	assert.True(t, strings.HasSuffix(content, windows[len(windows)-1]))
+	for _, window := range windows {
+		assert.LessOrEqual(t, len(window), 1000)
+		assert.Contains(t, content, window)
+	}
+
+	capped := splitWindows(content, 1000, 2)
+	require.Len(t, capped, 2)
+	assert.True(t, strings.HasPrefix(content, capped[0]))
+	assert.True(t, strings.HasSuffix(content, capped[1]))
+}
+
+func TestWordAligned(t *testing.T) {
+	content := "alpha beta gamma delta"
+
+	assert.Equal(t, "beta gamma", wordAligned(content, 3, 18))
+	assert.Equal(t, "alpha beta", wordAligned(content, 0, 11))
+	assert.Equal(t, "delta", wordAligned(content, 16, 100))
+
+	multibyte := "héllo wörld ñandú"
+	for start := 0; start < len(multibyte); start++ {
+		for end := start; end <= len(multibyte); end++ {
+			assert.True(t, utf8.ValidString(wordAligned(multibyte, start, end)))
+		}
+	}
+}
+
+func TestFactCheckerAgent_extractClaims_Strategies(t *testing.T) {
+	content := "Opening claim about the launch in 1969. " + strings.Repeat("Chatter with no claims at all. ", 200) + "Closing claim about the vote in 2020."
+
+	tests := []struct {
+		name      string
+		strategy  string
+		calls     int
+		seesStart bool
+		seesEnd   bool
+	}{
+		{name: "truncate", strategy: claimStrategyTruncate, calls: 1, seesStart: true},
+		{name: "default truncates", strategy: "", calls: 1, seesStart: true},
+		{name: "sample", strategy: claimStrategySample, calls: 1, seesStart: true, seesEnd: true},
+		{name: "windows", strategy: claimStrategyWindows, calls: 3, seesStart: true, seesEnd: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockAnthropicClient{}
+			agent := &FactCheckerAgent{
+				BaseAgent:        NewBaseAgent("fact_checker"),
+				anthropicClient:  mockClient,
+				claimStrategy:    tt.strategy,
+				claimWindowSize:  2000,
+				claimWindowLimit: 3,
+			}
+
+			var prompts []string
+			mockClient.On("CallClaude", mock.Anything, mock.Anything, mock.Anything, mock.Anything, false).
+				Run(func(args mock.Arguments) { prompts = append(prompts, args.String(2)) }).
+				Return("1. The Apollo landing happened in 1969", nil)
+
+			claims, err := agent.extractClaims(context.Background(), content, ProcessingOptions{})
+
+			require.NoError(t, err)
+			assert.Len(t, prompts, tt.calls)
+			// Windows repeat the same claim, which is merged
+			assert.Equal(t, []string{"The Apollo landing happened in 1969"}, claims)
+			all := strings.Join(prompts, "\n")
+			assert.Equal(t, tt.seesStart, strings.Contains(all, "Opening claim"))
+			assert.Equal(t, tt.seesEnd, strings.Contains(all, "Closing claim"))
+		})
+	}
+}
+
+func TestFactCheckerAgent_extractClaimsFromWindows_PartialFailure(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), anthropicClient: mockClient}
+
+	mockClient.On("CallClaude", mock.Anything, mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "first window")
+	}), mock.Anything, false).Return("", errors.New("overloaded")).Once()
+	mockClient.On("CallClaude", mock.Anything, mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "second window")
+	}), mock.Anything, false).Return("1. The second window makes this claim", nil).Once()
+
+	claims, err := agent.extractClaimsFromWindows(context.Background(), []string{"first window", "second window"}, ProcessingOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"The second window makes this claim"}, claims)
+}
+
+func TestFactCheckerAgent_extractClaimsFromWindows_AllFail(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), anthropicClient: mockClient}
+	mockClient.On("CallClaude", mock.Anything, mock.Anything, mock.Anything, mock.Anything, false).Return("", errors.New("overloaded"))
+
+	claims, err := agent.extractClaimsFromWindows(context.Background(), []string{"first window", "second window"}, ProcessingOptions{})
+
+	assert.EqualError(t, err, "overloaded")
+	assert.Nil(t, claims)
+}
+
+func TestMergeWindowClaims(t *testing.T) {
+	perWindow := [][]string{{"a1", "a2", "a3"}, {"b1"}, {"c1", "c2", "c3"}}
+
+	claims, dropped := mergeWindowClaims(perWindow, 4)
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2"}, claims)
+	assert.Equal(t, 3, dropped)
+
+	claims, dropped = mergeWindowClaims(perWindow, 10)
+	assert.Equal(t, []string{"a1", "b1", "c1", "a2", "c2", "a3", "c3"}, claims)
+	assert.Equal(t, 0, dropped)
+}
+
+func TestFactCheckerAgent_extractClaimsFromWindows_CapsMergedClaims(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), anthropicClient: mockClient, claimMergeLimit: 2}
+	mockClient.On("CallClaude", mock.Anything, mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "first window")
+	}), mock.Anything, false).Return("1. The first window makes claim one\n2. The first window makes claim two", nil).Once()
+	mockClient.On("CallClaude", mock.Anything, mock.Anything, mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "second window")
+	}), mock.Anything, false).Return("1. The second window makes claim one", nil).Once()
+
+	claims, err := agent.extractClaimsFromWindows(context.Background(), []string{"first window", "second window"}, ProcessingOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"The first window makes claim one", "The second window makes claim one"}, claims)
+}
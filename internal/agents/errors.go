@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // AgentError represents a general agent processing error
@@ -83,6 +84,36 @@ func NewAPIError(agent string, statusCode int, message string, cause error) *API
 	}
 }
 
+// TimeoutError indicates an agent did not finish within its own deadline
+type TimeoutError struct {
+	Agent   string
+	Timeout time.Duration
+	Cause   error
+}
+
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("agent %s timed out after %v: %v", e.Agent, e.Timeout, e.Cause)
+}
+
+func (e *TimeoutError) Unwrap() error {
+	return e.Cause
+}
+
+// NewTimeoutError creates a new timeout error
+func NewTimeoutError(agent string, timeout time.Duration, cause error) *TimeoutError {
+	return &TimeoutError{
+		Agent:   agent,
+		Timeout: timeout,
+		Cause:   cause,
+	}
+}
+
+// IsTimeoutError checks if an error is an agent timeout error
+func IsTimeoutError(err error) bool {
+	var timeoutErr *TimeoutError
+	return errors.As(err, &timeoutErr)
+}
+
 // IsRateLimitError checks if an error is a rate limit error
 func IsRateLimitError(err error) bool {
 	var rateLimitErr *RateLimitError
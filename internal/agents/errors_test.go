@@ -1,9 +1,11 @@
 package agents
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -325,3 +327,13 @@ func TestIsRetryableError(t *testing.T) {
 	}
 }
 
+
+func TestTimeoutError(t *testing.T) {
+	err := NewTimeoutError("summarizer", 30*time.Second, context.DeadlineExceeded)
+
+	assert.Equal(t, "agent summarizer timed out after 30s: context deadline exceeded", err.Error())
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, IsTimeoutError(NewAgentError("summarizer", "wrapped", err)))
+	assert.False(t, IsTimeoutError(errors.New("standard error")))
+	assert.False(t, IsTimeoutError(nil))
+}
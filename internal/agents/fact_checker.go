@@ -2,7 +2,9 @@ package agents
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"regexp"
 	"strconv"
 	"strings"
@@ -10,6 +12,8 @@ import (
 	
 	"podcast-analyzer/internal/clients"
 	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/requestctx"
 )
 
 // FactCheckerAgent extracts and verifies factual claims from podcast transcripts
@@ -17,19 +21,129 @@ type FactCheckerAgent struct {
 	*BaseAgent
 	anthropicClient clients.AnthropicClientInterface
 	serperClient    clients.SerperClientInterface
+	prompts         *PromptTemplates
+	cache           FactCheckCache
+	maxSources      int
+	degradedRatio   float64
+	linkChecker     clients.LinkCheckerInterface // nil unless source reachability checks are enabled
+	searchBackend   string                       // Empty means Serper
+	strictJSON      bool                         // Ask for JSON responses, falling back to the text parsers
+	blocked         clients.DomainBlocklist      // Domains never used as sources
+	minConfidence   float64                      // true/false verdicts below this become unverifiable; 0 disables
+	minSources      int                          // true/false verdicts with fewer sources become unverifiable; 0 disables
+	contextChars    int                          // Transcript characters around a claim shown when verifying it; 0 disables
+
+	claimStrategy    string // How claims are found in transcripts longer than one window; empty truncates
+	claimWindowSize  int    // Transcript characters per claim extraction call; 0 uses the agent input limit
+	claimWindowLimit int    // Most windows searched by the windows strategy; 0 uses the default
+	claimMergeLimit  int    // Most claims the windows strategy keeps after merging; 0 uses the default
+}
+
+// Search backends for claim verification
+const (
+	searchBackendSerper = "serper"
+	searchBackendNative = "anthropic-native-websearch"
+	searchBackendNone   = "none"
+)
+
+// defaultMaxSources caps the sources kept per fact check when no cap is configured
+const defaultMaxSources = 5
+
+// defaultDegradedRatio is the share of claims that must fail with the same error class
+// before a run is marked degraded, when no ratio is configured
+const defaultDegradedRatio = 0.5
+
+// defaultVerifySystemPrompt is the system prompt for claim verification when not overridden
+const defaultVerifySystemPrompt = `You are a professional fact-checker analyzing web search results. Evaluate claims objectively based on source quality and evidence strength. Be precise and concise in your assessment.`
+
+// sourceURLPattern matches source URLs cited in a verification response, whether listed
+// as text or as JSON strings
+var sourceURLPattern = regexp.MustCompile(`https?://[^\s\],"]+`)
+
+// factCheckMaxInputChars is the transcript length searched for claims in one call when
+// neither a claim window nor a global limit is set
+const factCheckMaxInputChars = 10000
+
+// fallbackSources is how many search results stand in when the response cites none
+const fallbackSources = 2
+
+// FactCheckCache stores verified claims so a claim repeated across transcripts is not
+// searched and analyzed again
+type FactCheckCache interface {
+	Get(ctx context.Context, claim string) (FactCheck, bool)
+	Put(ctx context.Context, factCheck FactCheck)
 }
 
 // NewFactCheckerAgent creates a new fact checker agent
 func NewFactCheckerAgent(cfg *config.Config) *FactCheckerAgent {
-	return &FactCheckerAgent{
+	agent := &FactCheckerAgent{
 		BaseAgent:       NewBaseAgent("fact_checker"),
 		anthropicClient: clients.NewAnthropicClient(cfg),
 		serperClient:    clients.NewSerperClient(cfg),
+		prompts:         promptTemplatesFor(cfg),
+		maxSources:      cfg.MaxFactCheckSources,
+		degradedRatio:   cfg.FactCheckDegradedRatio,
+		searchBackend:   resolveSearchBackend(cfg),
+		strictJSON:      cfg.StrictJSONAgents,
+		blocked:         clients.NewDomainBlocklist(cfg.FactCheckBlockedDomains),
+		minConfidence:   cfg.FactCheckMinConfidence,
+		minSources:      cfg.FactCheckMinSources,
+		contextChars:    cfg.FactCheckContextChars,
+		claimStrategy:    cfg.FactCheckClaimStrategy,
+		claimWindowSize:  cfg.FactCheckClaimWindowChars,
+		claimWindowLimit: cfg.FactCheckClaimMaxWindows,
+		claimMergeLimit:  cfg.FactCheckClaimMaxClaims,
+	}
+	agent.maxInputChars = cfg.AgentMaxInputChars
+	agent.parseRetries = cfg.AgentParseRetries
+	if agent.searchBackend != cfg.FactCheckSearchBackend && cfg.FactCheckSearchBackend != "" {
+		agent.logger.WithFields(map[string]interface{}{
+			"agent":      agent.Name(),
+			"configured": cfg.FactCheckSearchBackend,
+			"using":      agent.searchBackend,
+		}).Warn("Configured search backend has no API key, falling back")
+	}
+	if cfg.CheckSourceReachability {
+		agent.linkChecker = clients.NewLinkChecker(cfg)
 	}
+	return agent
+}
+
+// resolveSearchBackend returns the configured search backend when its API key is set,
+// otherwise whichever backend is usable, or none when neither key is configured
+func resolveSearchBackend(cfg *config.Config) string {
+	available := map[string]bool{
+		searchBackendSerper: cfg.SerperAPIKey != "",
+		searchBackendNative: cfg.AnthropicAPIKey != "",
+	}
+	preferred := cfg.FactCheckSearchBackend
+	if preferred != searchBackendNative {
+		preferred = searchBackendSerper
+	}
+	if available[preferred] {
+		return preferred
+	}
+	for _, backend := range []string{searchBackendSerper, searchBackendNative} {
+		if available[backend] {
+			return backend
+		}
+	}
+	return searchBackendNone
+}
+
+// WithCache makes the agent reuse cached verdicts for claims it has already verified
+func (f *FactCheckerAgent) WithCache(cache FactCheckCache) *FactCheckerAgent {
+	f.cache = cache
+	return f
 }
 
 // Process extracts and verifies factual claims from the transcript
 func (f *FactCheckerAgent) Process(ctx context.Context, content string) (Result, error) {
+	return f.ProcessWithOptions(ctx, content, ProcessingOptions{})
+}
+
+// ProcessWithOptions extracts and verifies claims, applying any job-specific instructions
+func (f *FactCheckerAgent) ProcessWithOptions(ctx context.Context, content string, opts ProcessingOptions) (Result, error) {
 	start := time.Now()
 	
 	// Log start of processing
@@ -42,7 +156,7 @@ func (f *FactCheckerAgent) Process(ctx context.Context, content string) (Result,
 	}
 	
 	// Step 1: Extract factual claims from transcript
-	claims, err := f.extractClaims(ctx, content)
+	claims, err := f.extractClaims(ctx, content, opts)
 	if err != nil {
 		f.LogError(ctx, err, time.Since(start))
 		return Result{}, NewAgentError(f.Name(), "failed to extract claims", err)
@@ -51,7 +165,7 @@ func (f *FactCheckerAgent) Process(ctx context.Context, content string) (Result,
 	if len(claims) == 0 {
 		f.logger.WithFields(map[string]interface{}{
 			"agent": f.Name(),
-			"correlation_id": getCorrelationID(ctx),
+			"correlation_id": requestctx.CorrelationID(ctx),
 		}).Info("No factual claims found in transcript")
 		
 		result := Result{FactChecks: []FactCheck{}}
@@ -61,15 +175,16 @@ func (f *FactCheckerAgent) Process(ctx context.Context, content string) (Result,
 	
 	f.logger.WithFields(map[string]interface{}{
 		"agent":        f.Name(),
-		"correlation_id": getCorrelationID(ctx),
+		"correlation_id": requestctx.CorrelationID(ctx),
 		"claims_count": len(claims),
 	}).Info("Extracted factual claims from transcript")
 	
 	// Step 2: Verify each claim with rate limiting
 	factChecks := make([]FactCheck, 0, len(claims))
+	failures := make(map[string]int)
 	
 	for i, claim := range claims {
-		correlationID := getCorrelationID(ctx)
+		correlationID := requestctx.CorrelationID(ctx)
 		f.logger.WithFields(map[string]interface{}{
 			"agent":          f.Name(),
 			"correlation_id": correlationID,
@@ -78,7 +193,29 @@ func (f *FactCheckerAgent) Process(ctx context.Context, content string) (Result,
 			"claim":          f.TruncateForLog(claim, 100),
 		}).Info("Checking claim")
 		
-		factCheck, err := f.verifyClaim(ctx, claim)
+		if f.cache != nil {
+			if cached, ok := f.cache.Get(ctx, claim); ok {
+				cached.Claim = claim
+				cached.Cached = true
+				// The entry may predate domains added to the blocklist or raised thresholds
+				cached.Sources, cached.LowSourceQuality = f.filterBlockedSources(cached.Sources)
+				cached = f.applyMinSources(ctx, f.applyMinConfidence(ctx, cached))
+				factChecks = append(factChecks, cached)
+				f.logger.WithFields(map[string]interface{}{
+					"agent":          f.Name(),
+					"correlation_id": correlationID,
+					"claim_num":      i + 1,
+					"verdict":        cached.Verdict,
+				}).Info("Claim verification served from cache")
+				continue
+			}
+		}
+		
+		factCheck, err := f.verifyClaim(ctx, claim, transcriptExcerpt(content, claim, f.contextChars), opts)
+		if err == nil && f.cache != nil && factCheck.Verdict != models.VerdictUnverifiable {
+			// Unverifiable results often reflect a transient search gap, so they are not cached
+			f.cache.Put(ctx, factCheck)
+		}
 		if err != nil {
 			f.logger.WithFields(map[string]interface{}{
 				"agent":          f.Name(),
@@ -87,11 +224,12 @@ func (f *FactCheckerAgent) Process(ctx context.Context, content string) (Result,
 				"claim":          claim,
 				"error":          err.Error(),
 			}).Error("Failed to verify claim, marking as unverifiable")
+			failures[claimErrorClass(err)]++
 			
 			// Continue with other claims instead of failing completely
 			factCheck = FactCheck{
 				Claim:      claim,
-				Verdict:    "unverifiable",
+				Verdict:    models.VerdictUnverifiable,
 				Confidence: 0.0,
 				Evidence:   fmt.Sprintf("Verification failed: %s", err.Error()),
 				Sources:    []string{},
@@ -125,31 +263,88 @@ func (f *FactCheckerAgent) Process(ctx context.Context, content string) (Result,
 	verdictCounts := f.countVerdicts(factChecks)
 	f.logger.WithFields(map[string]interface{}{
 		"agent":                        f.Name(),
-		"correlation_id":               getCorrelationID(ctx),
+		"correlation_id":               requestctx.CorrelationID(ctx),
 		"total_claims":                 len(factChecks),
-		"claims_true":                  verdictCounts["true"],
-		"claims_false":                 verdictCounts["false"],
-		"claims_partially_true":        verdictCounts["partially_true"],
-		"claims_unverifiable":          verdictCounts["unverifiable"],
+		"claims_true":                  verdictCounts[models.VerdictTrue],
+		"claims_false":                 verdictCounts[models.VerdictFalse],
+		"claims_partially_true":        verdictCounts[models.VerdictPartiallyTrue],
+		"claims_unverifiable":          verdictCounts[models.VerdictUnverifiable],
 	}).Info("Fact checking completed")
 	
 	result := Result{FactChecks: factChecks}
+	result.Degraded, result.DegradedReason = f.checkDegraded(failures, len(claims))
+	if result.Degraded {
+		f.logger.WithFields(map[string]interface{}{
+			"agent":          f.Name(),
+			"correlation_id": requestctx.CorrelationID(ctx),
+			"reason":         result.DegradedReason,
+		}).Warn("Fact checking degraded")
+	}
 	f.LogSuccess(ctx, &result, time.Since(start))
 	
 	return result, nil
 }
 
-// extractClaims extracts factual claims from the transcript that can be verified
-func (f *FactCheckerAgent) extractClaims(ctx context.Context, content string) ([]string, error) {
-	// Truncate very long transcripts
-	maxTranscriptLength := 10000
-	if len(content) > maxTranscriptLength {
-		content = f.TruncateContent(content, maxTranscriptLength)
+// checkDegraded reports whether more than the configured share of claims failed with the
+// same error class, and if so describes the most common failure
+func (f *FactCheckerAgent) checkDegraded(failures map[string]int, totalClaims int) (bool, string) {
+	ratio := f.degradedRatio
+	if ratio <= 0 {
+		ratio = defaultDegradedRatio
 	}
 	
-	systemPrompt := `You are an expert at identifying specific, verifiable factual claims in text. Focus on concrete statements that make specific assertions about real-world facts, events, dates, numbers, or entities that can be checked against reliable sources.`
+	var worstClass string
+	worstCount := 0
+	for class, count := range failures {
+		if count > worstCount || (count == worstCount && class < worstClass) {
+			worstClass, worstCount = class, count
+		}
+	}
+	if totalClaims == 0 || float64(worstCount) <= ratio*float64(totalClaims) {
+		return false, ""
+	}
+	return true, fmt.Sprintf("%d of %d claims failed verification: %s", worstCount, totalClaims, worstClass)
+}
+
+// claimErrorClass groups a verification error by the step that failed and the kind of
+// failure, so one provider outage across several claims counts as a single class
+func claimErrorClass(err error) string {
+	step := "verification"
+	var agentErr *AgentError
+	if errors.As(err, &agentErr) {
+		step = strings.TrimSuffix(agentErr.Message, " failed")
+	}
 	
-	userPrompt := fmt.Sprintf(`Analyze the following podcast transcript and extract factual claims that can be verified.
+	var serperErr *clients.SerperError
+	var anthropicErr *clients.AnthropicError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, clients.ErrCircuitOpen):
+		return step + " unavailable (circuit breaker open)"
+	case IsTimeoutError(err), errors.Is(err, context.DeadlineExceeded):
+		return step + " timed out"
+	case IsRateLimitError(err), strings.Contains(strings.ToLower(err.Error()), "rate limit"):
+		return step + " rate limited"
+	case errors.As(err, &serperErr), errors.As(err, &anthropicErr):
+		return step + " API error"
+	case errors.As(err, &netErr):
+		return step + " network error"
+	default:
+		return step + " failed"
+	}
+}
+
+// extractClaimsFrom asks Claude for the verifiable factual claims in one piece of transcript
+func (f *FactCheckerAgent) extractClaimsFrom(ctx context.Context, content string, opts ProcessingOptions) ([]string, error) {
+	data := PromptData{Content: content}
+	systemPrompt, ok := f.prompts.render(f.Name(), "claims_system", data)
+	if !ok {
+		systemPrompt = `You are an expert at identifying specific, verifiable factual claims in text. Focus on concrete statements that make specific assertions about real-world facts, events, dates, numbers, or entities that can be checked against reliable sources.`
+	}
+	
+	userPrompt, ok := f.prompts.render(f.Name(), "claims_user", data)
+	if !ok {
+		userPrompt = fmt.Sprintf(`Analyze the following podcast transcript and extract factual claims that can be verified.
 
 Look for statements that:
 - Make specific factual assertions about events, dates, numbers, or statistics
@@ -175,18 +370,61 @@ Extract 2-3 specific factual claims that can be verified. Format as a simple num
 etc.
 
 FACTUAL CLAIMS:`, content)
+	}
+	
+	if f.strictJSON {
+		systemPrompt = appendJSONSchema(systemPrompt, claimsJSONSchema)
+	}
 	
 	f.LogAPICall(ctx, "anthropic", len(userPrompt), true)
 	
-	response, err := f.anthropicClient.CallClaude(ctx, f.Name(), userPrompt, systemPrompt, false)
+	systemPrompt = appendInstructions(appendClaimCategories(systemPrompt, opts.ClaimCategories), opts.Instructions)
+	reminder := numberedListReminder
+	if f.strictJSON {
+		reminder = jsonReminder
+	}
+	
+	// A response with no parsable claims is asked for again, unless it says there are none
+	var claims []string
+	_, err := f.callWithParseRetry(ctx, userPrompt, reminder, func(userPrompt string) (string, error) {
+		return f.anthropicClient.CallClaude(ctx, f.Name(), userPrompt, systemPrompt, false)
+	}, func(response string) bool {
+		if statesNoClaims(response) {
+			claims = nil
+			return true
+		}
+		claims = f.parseClaimsResponse(ctx, response)
+		return len(claims) > 0
+	})
 	if err != nil {
 		return nil, err
 	}
-	
-	claims := f.parseClaims(response)
 	return claims, nil
 }
 
+// noClaimsPattern matches a statement that the transcript has no claims to verify
+var noClaimsPattern = regexp.MustCompile(`(?i)\b(?:no|zero)\s+(?:\w+\s+){0,2}claims\b`)
+
+// statesNoClaims reports whether a claim extraction response is a one-line statement that
+// there are no claims, rather than a list that failed to parse
+func statesNoClaims(response string) bool {
+	response = strings.TrimSpace(response)
+	return !strings.Contains(response, "\n") && noClaimsPattern.MatchString(response)
+}
+
+// parseClaimsResponse parses the claims in a claim extraction response. In strict JSON mode
+// the response is decoded as JSON first, with the text parser as a fallback.
+func (f *FactCheckerAgent) parseClaimsResponse(ctx context.Context, response string) []string {
+	if f.strictJSON {
+		var parsed claimsJSON
+		if decodeJSONResponse(response, &parsed) {
+			return f.filterClaims(parsed.Claims)
+		}
+		f.logMalformedJSON(ctx, "claims", response)
+	}
+	return f.parseClaims(response)
+}
+
 // parseClaims parses claims from Claude's response
 func (f *FactCheckerAgent) parseClaims(rawResponse string) []string {
 	var claims []string
@@ -212,15 +450,24 @@ func (f *FactCheckerAgent) parseClaims(rawResponse string) []string {
 			cleanedLine = pattern.ReplaceAllString(cleanedLine, "")
 		}
 		
-		// Skip if too short
-		if len(strings.Fields(cleanedLine)) < 4 {
+		claims = append(claims, cleanedLine)
+	}
+	
+	return f.filterClaims(claims)
+}
+
+// filterClaims drops claims too short to verify and keeps at most three, to reduce token
+// usage and processing time
+func (f *FactCheckerAgent) filterClaims(candidates []string) []string {
+	var claims []string
+	for _, claim := range candidates {
+		claim = strings.TrimSpace(claim)
+		if len(strings.Fields(claim)) < 4 {
 			continue
 		}
-		
-		claims = append(claims, cleanedLine)
+		claims = append(claims, claim)
 	}
 	
-	// Limit to 3 claims to reduce token usage and processing time
 	if len(claims) > 3 {
 		claims = claims[:3]
 	}
@@ -228,8 +475,37 @@ func (f *FactCheckerAgent) parseClaims(rawResponse string) []string {
 	return claims
 }
 
-// verifyClaim verifies a single factual claim using Serper web search and Claude analysis
-func (f *FactCheckerAgent) verifyClaim(ctx context.Context, claim string) (FactCheck, error) {
+// logMalformedJSON warns that a strict JSON response could not be decoded and the text
+// parser is used instead
+func (f *FactCheckerAgent) logMalformedJSON(ctx context.Context, step, response string) {
+	f.logger.WithFields(map[string]interface{}{
+		"agent":          f.Name(),
+		"correlation_id": requestctx.CorrelationID(ctx),
+		"step":           step,
+		"response":       f.TruncateForLog(response, 200),
+	}).Warn("Response was not valid JSON, falling back to text parsing")
+}
+
+// verifyClaim verifies a single factual claim using the configured search backend. A
+// non-empty excerpt is the transcript text around the claim, shown to Claude for context.
+func (f *FactCheckerAgent) verifyClaim(ctx context.Context, claim, excerpt string, opts ProcessingOptions) (FactCheck, error) {
+	switch f.searchBackend {
+	case searchBackendNative:
+		return f.verifyClaimWithWebSearch(ctx, claim, excerpt, opts)
+	case searchBackendNone:
+		return FactCheck{
+			Claim:      claim,
+			Verdict:    models.VerdictUnverifiable,
+			Confidence: 0.0,
+			Evidence:   "No search backend configured",
+			Sources:    []string{},
+		}, nil
+	}
+	return f.verifyClaimWithSerper(ctx, claim, excerpt, opts)
+}
+
+// verifyClaimWithSerper verifies a claim using Serper web search and Claude analysis
+func (f *FactCheckerAgent) verifyClaimWithSerper(ctx context.Context, claim, excerpt string, opts ProcessingOptions) (FactCheck, error) {
 	// Step 1: Use Serper to search for the claim
 	f.LogAPICall(ctx, "serper", len(claim), false)
 	searchContext, err := f.serperClient.SearchForClaim(ctx, f.Name(), claim)
@@ -240,13 +516,13 @@ func (f *FactCheckerAgent) verifyClaim(ctx context.Context, claim string) (FactC
 	if len(searchContext.Snippets) == 0 {
 		f.logger.WithFields(map[string]interface{}{
 			"agent": f.Name(),
-			"correlation_id": getCorrelationID(ctx),
+			"correlation_id": requestctx.CorrelationID(ctx),
 			"claim": claim,
 		}).Warn("No search results found for claim")
 		
 		return FactCheck{
 			Claim:      claim,
-			Verdict:    "unverifiable",
+			Verdict:    models.VerdictUnverifiable,
 			Confidence: 0.0,
 			Evidence:   "No search results found",
 			Sources:    []string{},
@@ -255,7 +531,7 @@ func (f *FactCheckerAgent) verifyClaim(ctx context.Context, claim string) (FactC
 	
 	// Step 2: Use Claude to analyze the search results
 	f.LogAPICall(ctx, "anthropic", len(claim), true)
-	analysisResult, err := f.analyzeSearchResults(ctx, claim, searchContext)
+	analysisResult, err := f.analyzeSearchResults(ctx, claim, excerpt, searchContext, opts)
 	if err != nil {
 		return FactCheck{}, NewAgentError(f.Name(), "analysis failed", err)
 	}
@@ -263,14 +539,71 @@ func (f *FactCheckerAgent) verifyClaim(ctx context.Context, claim string) (FactC
 	return analysisResult, nil
 }
 
+// verifyClaimWithWebSearch verifies a claim in a single Claude call using its built-in web
+// search tool. The URLs cited in the answer stand in for search results as sources.
+func (f *FactCheckerAgent) verifyClaimWithWebSearch(ctx context.Context, claim, excerpt string, opts ProcessingOptions) (FactCheck, error) {
+	data := PromptData{Claim: claim}
+	systemPrompt, ok := f.prompts.render(f.Name(), "verify_system", data)
+	if !ok {
+		systemPrompt = defaultVerifySystemPrompt
+	}
+	
+	userPrompt, ok := f.prompts.render(f.Name(), "websearch_user", data)
+	if !ok {
+		userPrompt = fmt.Sprintf(`Search the web to verify this claim:
+
+CLAIM: %s
+
+Based on what you find, provide your assessment:
+
+VERDICT: [%s]
+CONFIDENCE: [0.0-1.0]
+EVIDENCE: [Brief explanation in 1-2 sentences max]
+SOURCES: [List the most relevant source URLs you found]
+
+Guidelines:
+- true: Claim is fully supported by reliable sources
+- false: Claim is contradicted by reliable sources  
+- partially_true: Claim has some truth but lacks important context/nuance
+- unverifiable: Insufficient or unreliable sources to make determination
+
+Be concise and focus on the most relevant evidence.`, claim, models.VerdictList("/"))
+	}
+	userPrompt = appendTranscriptExcerpt(userPrompt, excerpt)
+	
+	if f.strictJSON {
+		systemPrompt = appendJSONSchema(systemPrompt, verificationJSONSchema)
+	}
+	
+	f.LogAPICall(ctx, "anthropic_web_search", len(userPrompt), true)
+	response, err := f.anthropicClient.CallClaude(ctx, f.Name(), userPrompt, appendInstructions(systemPrompt, opts.Instructions), true)
+	if err != nil {
+		return FactCheck{}, NewAgentError(f.Name(), "web search failed", err)
+	}
+	
+	availableSources, lowSourceQuality := f.filterBlockedSources(sourceURLPattern.FindAllString(response, -1))
+	factCheck := f.parseVerificationResult(ctx, claim, response, availableSources)
+	factCheck.LowSourceQuality = lowSourceQuality
+	if f.linkChecker != nil && len(factCheck.Sources) > 0 {
+		factCheck.Sources = f.linkChecker.FilterReachable(ctx, factCheck.Sources)
+	}
+	return factCheck, nil
+}
+
 // analyzeSearchResults uses Claude to analyze search results and determine claim validity
-func (f *FactCheckerAgent) analyzeSearchResults(ctx context.Context, claim string, searchContext *clients.SearchContext) (FactCheck, error) {
+func (f *FactCheckerAgent) analyzeSearchResults(ctx context.Context, claim, excerpt string, searchContext *clients.SearchContext, opts ProcessingOptions) (FactCheck, error) {
 	// Format search results for Claude
 	formattedResults := f.serperClient.FormatSearchResultsForAnalysis(searchContext)
 	
-	systemPrompt := `You are a professional fact-checker analyzing web search results. Evaluate claims objectively based on source quality and evidence strength. Be precise and concise in your assessment.`
+	data := PromptData{Claim: claim, SearchResults: formattedResults}
+	systemPrompt, ok := f.prompts.render(f.Name(), "verify_system", data)
+	if !ok {
+		systemPrompt = defaultVerifySystemPrompt
+	}
 	
-	userPrompt := fmt.Sprintf(`Analyze the following search results to verify this claim:
+	userPrompt, ok := f.prompts.render(f.Name(), "verify_user", data)
+	if !ok {
+		userPrompt = fmt.Sprintf(`Analyze the following search results to verify this claim:
 
 CLAIM: %s
 
@@ -279,7 +612,7 @@ SEARCH RESULTS:
 
 Based on these search results, provide your assessment:
 
-VERDICT: [true/false/partially_true/unverifiable]
+VERDICT: [%s]
 CONFIDENCE: [0.0-1.0]
 EVIDENCE: [Brief explanation in 1-2 sentences max]
 SOURCES: [List the most relevant source URLs from the search results]
@@ -290,49 +623,126 @@ Guidelines:
 - partially_true: Claim has some truth but lacks important context/nuance
 - unverifiable: Insufficient or unreliable sources to make determination
 
-Be concise and focus on the most relevant evidence.`, claim, formattedResults)
+Be concise and focus on the most relevant evidence.`, claim, formattedResults, models.VerdictList("/"))
+	}
+	userPrompt = appendTranscriptExcerpt(userPrompt, excerpt)
 	
-	response, err := f.anthropicClient.CallClaude(ctx, f.Name(), userPrompt, systemPrompt, false)
+	if f.strictJSON {
+		systemPrompt = appendJSONSchema(systemPrompt, verificationJSONSchema)
+	}
+	
+	response, err := f.anthropicClient.CallClaude(ctx, f.Name(), userPrompt, appendInstructions(systemPrompt, opts.Instructions), false)
 	if err != nil {
 		return FactCheck{}, err
 	}
 	
-	return f.parseVerificationResult(claim, response, searchContext.Sources), nil
+	factCheck := f.parseVerificationResult(ctx, claim, response, searchContext.Sources)
+	factCheck.LowSourceQuality = searchContext.LowSourceQuality
+	factCheck.SearchQuery = searchContext.SearchQuery
+	if f.linkChecker != nil && len(factCheck.Sources) > 0 {
+		factCheck.Sources = f.linkChecker.FilterReachable(ctx, factCheck.Sources)
+	}
+	return factCheck, nil
+}
+
+// filterBlockedSources drops URLs on blocked domains. When every URL is blocked they are
+// all kept and reported as low quality, so the claim still has sources to show.
+func (f *FactCheckerAgent) filterBlockedSources(sources []string) ([]string, bool) {
+	allowed := f.blocked.Filter(sources)
+	if len(allowed) == 0 && len(sources) > 0 {
+		return sources, true
+	}
+	return allowed, false
 }
 
-// parseVerificationResult parses the verification result from Claude's response
-func (f *FactCheckerAgent) parseVerificationResult(claim, response string, availableSources []string) FactCheck {
+// parseVerificationResult parses the verification result from Claude's response. In
+// strict JSON mode the response is decoded as JSON first. The minimum confidence and
+// minimum sources policies are applied to whichever verdict is extracted.
+func (f *FactCheckerAgent) parseVerificationResult(ctx context.Context, claim, response string, availableSources []string) FactCheck {
+	if f.strictJSON {
+		if factCheck, ok := f.parseJSONVerification(claim, response, availableSources); ok {
+			return f.applyMinSources(ctx, f.applyMinConfidence(ctx, factCheck))
+		}
+		f.logMalformedJSON(ctx, "verification", response)
+	}
+	
 	verdict := f.extractVerdict(response)
 	confidence := f.extractConfidence(response)
 	evidence := f.extractEvidence(response)
 	sources := f.extractSources(response, availableSources)
 	
-	return FactCheck{
+	return f.applyMinSources(ctx, f.applyMinConfidence(ctx, FactCheck{
 		Claim:      claim,
 		Verdict:    verdict,
 		Confidence: confidence,
 		Evidence:   evidence,
 		Sources:    sources,
+	}))
+}
+
+// applyMinConfidence downgrades a true or false verdict given with less than the minimum
+// confidence to unverifiable, keeping the original verdict, so low-confidence definitive
+// verdicts are never published
+func (f *FactCheckerAgent) applyMinConfidence(ctx context.Context, factCheck FactCheck) FactCheck {
+	if f.minConfidence <= 0 || factCheck.Confidence >= f.minConfidence {
+		return factCheck
+	}
+	if factCheck.Verdict != models.VerdictTrue && factCheck.Verdict != models.VerdictFalse {
+		return factCheck
+	}
+	
+	f.logger.WithFields(map[string]interface{}{
+		"agent":          f.Name(),
+		"correlation_id": requestctx.CorrelationID(ctx),
+		"verdict":        factCheck.Verdict,
+		"confidence":     factCheck.Confidence,
+		"min_confidence": f.minConfidence,
+	}).Info("Downgraded low-confidence verdict to unverifiable")
+	
+	factCheck.OriginalVerdict = factCheck.Verdict
+	factCheck.Verdict = models.VerdictUnverifiable
+	return factCheck
+}
+
+// applyMinSources downgrades a true or false verdict backed by fewer than the minimum
+// number of validated sources to unverifiable, keeping the original verdict and noting
+// why in the evidence
+func (f *FactCheckerAgent) applyMinSources(ctx context.Context, factCheck FactCheck) FactCheck {
+	if f.minSources <= 0 || len(factCheck.Sources) >= f.minSources {
+		return factCheck
 	}
+	if factCheck.Verdict != models.VerdictTrue && factCheck.Verdict != models.VerdictFalse {
+		return factCheck
+	}
+	
+	f.logger.WithFields(map[string]interface{}{
+		"agent":          f.Name(),
+		"correlation_id": requestctx.CorrelationID(ctx),
+		"verdict":        factCheck.Verdict,
+		"sources":        len(factCheck.Sources),
+		"min_sources":    f.minSources,
+	}).Info("Downgraded thinly sourced verdict to unverifiable")
+	
+	factCheck.Evidence += fmt.Sprintf(" (Downgraded from %s to unverifiable: a definitive verdict needs at least %d sources, but only %d were found.)",
+		factCheck.Verdict, f.minSources, len(factCheck.Sources))
+	factCheck.OriginalVerdict = factCheck.Verdict
+	factCheck.Verdict = models.VerdictUnverifiable
+	return factCheck
 }
 
-// extractVerdict parses and validates the verdict from the response
-func (f *FactCheckerAgent) extractVerdict(response string) string {
+// extractVerdict parses and validates the verdict from the response, treating a missing
+// or unknown verdict as unverifiable
+func (f *FactCheckerAgent) extractVerdict(response string) models.Verdict {
 	verdictRegex := regexp.MustCompile(`(?i)VERDICT:\s*(\w+)`)
 	verdictMatch := verdictRegex.FindStringSubmatch(response)
-	verdict := "unverifiable"
-	if len(verdictMatch) > 1 {
-		verdict = strings.ToLower(verdictMatch[1])
+	if len(verdictMatch) < 2 {
+		return models.VerdictUnverifiable
 	}
 	
-	// Ensure valid verdict
-	validVerdicts := map[string]bool{
-		"true": true, "false": true, "partially_true": true, "unverifiable": true,
-	}
-	if !validVerdicts[verdict] {
-		verdict = "unverifiable"
+	verdict, err := models.ParseVerdict(verdictMatch[1])
+	if err != nil {
+		return models.VerdictUnverifiable
 	}
-	
 	return verdict
 }
 
@@ -343,18 +753,22 @@ func (f *FactCheckerAgent) extractConfidence(response string) float64 {
 	confidence := 0.5 // default
 	if len(confidenceMatch) > 1 {
 		if parsed, err := strconv.ParseFloat(confidenceMatch[1], 64); err == nil {
-			confidence = parsed
-			// Clamp to valid range
-			if confidence < 0.0 {
-				confidence = 0.0
-			} else if confidence > 1.0 {
-				confidence = 1.0
-			}
+			confidence = clampConfidence(parsed)
 		}
 	}
 	return confidence
 }
 
+// clampConfidence limits a confidence value to the valid 0.0-1.0 range
+func clampConfidence(confidence float64) float64 {
+	if confidence < 0.0 {
+		return 0.0
+	} else if confidence > 1.0 {
+		return 1.0
+	}
+	return confidence
+}
+
 // extractEvidence parses the evidence text from the response
 func (f *FactCheckerAgent) extractEvidence(response string) string {
 	evidenceRegex := regexp.MustCompile(`(?i)EVIDENCE:\s*(.+?)SOURCES:`)
@@ -373,34 +787,54 @@ func (f *FactCheckerAgent) extractEvidence(response string) string {
 	return evidence
 }
 
-// extractSources parses and validates source URLs from the response
+// extractSources parses and validates source URLs from the response, keeping at most
+// the configured number of distinct sources
 func (f *FactCheckerAgent) extractSources(response string, availableSources []string) []string {
 	sourcesRegex := regexp.MustCompile(`(?i)SOURCES:\s*(.+?)$`)
 	sourcesMatch := sourcesRegex.FindStringSubmatch(response)
-	var sources []string
+	var foundURLs []string
 	
 	if len(sourcesMatch) > 1 {
 		sourcesText := strings.TrimSpace(sourcesMatch[1])
 		if sourcesText != "" && sourcesText != "[]" {
 			// Extract URLs using regex
-			urlRegex := regexp.MustCompile(`https?://[^\s\],]+`)
-			foundURLs := urlRegex.FindAllString(sourcesText, -1)
-			
-			// Validate against available sources
-			for _, url := range foundURLs {
-				for _, availableURL := range availableSources {
-					if url == availableURL {
-						sources = append(sources, url)
-						break
-					}
-				}
+			foundURLs = sourceURLPattern.FindAllString(sourcesText, -1)
+		}
+	}
+	
+	return f.selectSources(foundURLs, availableSources)
+}
+
+// selectSources keeps the cited URLs that appear among the available sources, up to the
+// configured number of distinct sources, falling back to the first available ones
+func (f *FactCheckerAgent) selectSources(foundURLs, availableSources []string) []string {
+	limit := f.maxSources
+	if limit <= 0 {
+		limit = defaultMaxSources
+	}
+	
+	// Validate against available sources, skipping repeats
+	var sources []string
+	seen := make(map[string]bool)
+	for _, url := range foundURLs {
+		if seen[url] || len(sources) == limit {
+			continue
+		}
+		for _, availableURL := range availableSources {
+			if url == availableURL {
+				sources = append(sources, url)
+				seen[url] = true
+				break
 			}
 		}
 	}
 	
-	// If no sources found but we have available sources, use first 2 as fallback
+	// If no sources found but we have available sources, use the first few as fallback
 	if len(sources) == 0 && len(availableSources) > 0 {
-		maxSources := 2
+		maxSources := fallbackSources
+		if limit < maxSources {
+			maxSources = limit
+		}
 		if len(availableSources) < maxSources {
 			maxSources = len(availableSources)
 		}
@@ -411,12 +845,10 @@ func (f *FactCheckerAgent) extractSources(response string, availableSources []st
 }
 
 // countVerdicts counts the number of each verdict type
-func (f *FactCheckerAgent) countVerdicts(factChecks []FactCheck) map[string]int {
-	counts := map[string]int{
-		"true":            0,
-		"false":           0,
-		"partially_true":  0,
-		"unverifiable":    0,
+func (f *FactCheckerAgent) countVerdicts(factChecks []FactCheck) map[models.Verdict]int {
+	counts := make(map[models.Verdict]int, len(models.Verdicts))
+	for _, verdict := range models.Verdicts {
+		counts[verdict] = 0
 	}
 	
 	for _, fc := range factChecks {
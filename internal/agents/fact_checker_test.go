@@ -3,10 +3,13 @@ package agents
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"podcast-analyzer/internal/clients"
 	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/models"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -30,6 +33,23 @@ func (m *MockSerperClient) FormatSearchResultsForAnalysis(context *clients.Searc
 	return args.String(0)
 }
 
+// stubLinkChecker treats every URL outside dead as reachable
+type stubLinkChecker struct {
+	dead    map[string]bool
+	checked []string
+}
+
+func (s *stubLinkChecker) FilterReachable(ctx context.Context, urls []string) []string {
+	s.checked = append(s.checked, urls...)
+	var live []string
+	for _, url := range urls {
+		if !s.dead[url] {
+			live = append(live, url)
+		}
+	}
+	return live
+}
+
 func TestNewFactCheckerAgent(t *testing.T) {
 	cfg := &config.Config{
 		AnthropicAPIKey: "test-key",
@@ -42,6 +62,20 @@ func TestNewFactCheckerAgent(t *testing.T) {
 	assert.Equal(t, "fact_checker", agent.Name())
 	assert.NotNil(t, agent.anthropicClient)
 	assert.NotNil(t, agent.serperClient)
+	assert.Nil(t, agent.linkChecker)
+}
+
+func TestNewFactCheckerAgent_SourceReachability(t *testing.T) {
+	cfg := &config.Config{
+		AnthropicAPIKey:         "test-key",
+		MaxFactCheckSources:     3,
+		CheckSourceReachability: true,
+	}
+
+	agent := NewFactCheckerAgent(cfg)
+
+	assert.Equal(t, 3, agent.maxSources)
+	assert.NotNil(t, agent.linkChecker)
 }
 
 func TestFactCheckerAgent_Process_Success(t *testing.T) {
@@ -102,12 +136,132 @@ func TestFactCheckerAgent_Process_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result.FactChecks, 1)
-	assert.Equal(t, "true", result.FactChecks[0].Verdict)
+	assert.Equal(t, models.VerdictTrue, result.FactChecks[0].Verdict)
 	assert.Equal(t, 0.95, result.FactChecks[0].Confidence)
 	mockAnthropicClient.AssertExpectations(t)
 	mockSerperClient.AssertExpectations(t)
 }
 
+func TestFactCheckerAgent_Process_DegradedWhenSearchFails(t *testing.T) {
+	mockAnthropicClient := &MockAnthropicClient{}
+	mockSerperClient := &MockSerperClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockAnthropicClient,
+		serperClient:    mockSerperClient,
+	}
+
+	mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.AnythingOfType("string"), mock.AnythingOfType("string"), false).
+		Return("1. The moon landing happened in 1969", nil).Once()
+	mockSerperClient.On("SearchForClaim", mock.Anything, "fact_checker", "The moon landing happened in 1969").
+		Return(nil, fmt.Errorf("%w: serper unavailable, retry in 30s", clients.ErrCircuitOpen))
+
+	result, err := agent.Process(context.Background(), "The podcast mentioned that the moon landing happened in 1969.")
+
+	assert.NoError(t, err)
+	assert.Len(t, result.FactChecks, 1)
+	assert.Equal(t, models.VerdictUnverifiable, result.FactChecks[0].Verdict)
+	assert.True(t, result.Degraded)
+	assert.Equal(t, "1 of 1 claims failed verification: web search unavailable (circuit breaker open)", result.DegradedReason)
+}
+
+func TestFactCheckerAgent_checkDegraded(t *testing.T) {
+	tests := []struct {
+		name           string
+		ratio          float64
+		failures       map[string]int
+		totalClaims    int
+		expected       bool
+		expectedReason string
+	}{
+		{
+			name:        "no failures",
+			failures:    map[string]int{},
+			totalClaims: 3,
+		},
+		{
+			name:        "minority of claims failed",
+			failures:    map[string]int{"web search timed out": 1},
+			totalClaims: 3,
+		},
+		{
+			name:        "failures split across classes",
+			failures:    map[string]int{"web search timed out": 1, "analysis rate limited": 1},
+			totalClaims: 3,
+		},
+		{
+			name:           "majority failed the same way",
+			failures:       map[string]int{"web search timed out": 2, "analysis rate limited": 1},
+			totalClaims:    3,
+			expected:       true,
+			expectedReason: "2 of 3 claims failed verification: web search timed out",
+		},
+		{
+			name:        "configured ratio is stricter",
+			ratio:       0.7,
+			failures:    map[string]int{"web search timed out": 2},
+			totalClaims: 3,
+		},
+		{
+			name:           "configured ratio is looser",
+			ratio:          0.3,
+			failures:       map[string]int{"web search timed out": 1},
+			totalClaims:    3,
+			expected:       true,
+			expectedReason: "1 of 3 claims failed verification: web search timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), degradedRatio: tt.ratio}
+			degraded, reason := agent.checkDegraded(tt.failures, tt.totalClaims)
+			assert.Equal(t, tt.expected, degraded)
+			assert.Equal(t, tt.expectedReason, reason)
+		})
+	}
+}
+
+func TestClaimErrorClass(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "search circuit open",
+			err:      NewAgentError("fact_checker", "web search failed", fmt.Errorf("%w: serper unavailable", clients.ErrCircuitOpen)),
+			expected: "web search unavailable (circuit breaker open)",
+		},
+		{
+			name:     "analysis deadline",
+			err:      NewAgentError("fact_checker", "analysis failed", fmt.Errorf("HTTP request failed: %w", context.DeadlineExceeded)),
+			expected: "analysis timed out",
+		},
+		{
+			name:     "analysis rate limit",
+			err:      NewAgentError("fact_checker", "analysis failed", errors.New("rate limit exceeded (retry after 30s)")),
+			expected: "analysis rate limited",
+		},
+		{
+			name:     "search API error",
+			err:      NewAgentError("fact_checker", "web search failed", fmt.Errorf("API error (status 400): %w", &clients.SerperError{Message: "bad query"})),
+			expected: "web search API error",
+		},
+		{
+			name:     "unclassified error",
+			err:      errors.New("something broke"),
+			expected: "verification failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, claimErrorClass(tt.err))
+		})
+	}
+}
+
 func TestFactCheckerAgent_Process_NoClaims(t *testing.T) {
 	mockAnthropicClient := &MockAnthropicClient{}
 	mockSerperClient := &MockSerperClient{}
@@ -157,7 +311,7 @@ func TestFactCheckerAgent_extractClaims_Success(t *testing.T) {
 		false,
 	).Return(response, nil)
 
-	claims, err := agent.extractClaims(ctx, content)
+	claims, err := agent.extractClaims(ctx, content, ProcessingOptions{})
 
 	assert.NoError(t, err)
 	assert.Len(t, claims, 2)
@@ -226,7 +380,8 @@ func TestFactCheckerAgent_verifyClaim_Success(t *testing.T) {
 
 	// Mock search
 	searchContext := &clients.SearchContext{
-		Sources: []string{"https://nasa.gov/earth-shape"},
+		SearchQuery: "earth round",
+		Sources:     []string{"https://nasa.gov/earth-shape"},
 		Snippets: []clients.SearchSnippet{
 			{
 				Title:   "Earth Shape Evidence",
@@ -255,17 +410,49 @@ func TestFactCheckerAgent_verifyClaim_Success(t *testing.T) {
 		false,
 	).Return(verificationResponse, nil)
 
-	factCheck, err := agent.verifyClaim(ctx, claim)
+	factCheck, err := agent.verifyClaim(ctx, claim, "", ProcessingOptions{})
 
 	assert.NoError(t, err)
 	assert.Equal(t, claim, factCheck.Claim)
-	assert.Equal(t, "true", factCheck.Verdict)
+	assert.Equal(t, models.VerdictTrue, factCheck.Verdict)
 	assert.Equal(t, 0.99, factCheck.Confidence)
 	assert.Contains(t, factCheck.Evidence, "Scientific consensus")
+	assert.Equal(t, "earth round", factCheck.SearchQuery)
 	mockSerperClient.AssertExpectations(t)
 	mockAnthropicClient.AssertExpectations(t)
 }
 
+func TestFactCheckerAgent_verifyClaim_DropsUnreachableSources(t *testing.T) {
+	mockSerperClient := &MockSerperClient{}
+	mockAnthropicClient := &MockAnthropicClient{}
+	linkChecker := &stubLinkChecker{dead: map[string]bool{"https://gone.example.com/page": true}}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		serperClient:    mockSerperClient,
+		anthropicClient: mockAnthropicClient,
+		linkChecker:     linkChecker,
+	}
+
+	claim := "The earth is round"
+	searchContext := &clients.SearchContext{
+		Sources: []string{"https://nasa.gov/earth-shape", "https://gone.example.com/page"},
+		Snippets: []clients.SearchSnippet{
+			{Title: "Earth Shape", Snippet: "Earth is round", URL: "https://nasa.gov/earth-shape"},
+			{Title: "Old Page", Snippet: "Earth is round", URL: "https://gone.example.com/page"},
+		},
+	}
+	mockSerperClient.On("SearchForClaim", mock.Anything, "fact_checker", claim).Return(searchContext, nil)
+	mockSerperClient.On("FormatSearchResultsForAnalysis", searchContext).Return("results")
+	mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.AnythingOfType("string"), mock.AnythingOfType("string"), false).
+		Return("VERDICT: true\nCONFIDENCE: 0.9\nEVIDENCE: Confirmed SOURCES: https://nasa.gov/earth-shape, https://gone.example.com/page", nil)
+
+	factCheck, err := agent.verifyClaim(context.Background(), claim, "", ProcessingOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://nasa.gov/earth-shape"}, factCheck.Sources)
+	assert.Equal(t, searchContext.Sources, linkChecker.checked)
+}
+
 func TestFactCheckerAgent_verifyClaim_SearchError(t *testing.T) {
 	mockSerperClient := &MockSerperClient{}
 	agent := &FactCheckerAgent{
@@ -283,7 +470,7 @@ func TestFactCheckerAgent_verifyClaim_SearchError(t *testing.T) {
 		claim,
 	).Return(nil, expectedError)
 
-	factCheck, err := agent.verifyClaim(ctx, claim)
+	factCheck, err := agent.verifyClaim(ctx, claim, "", ProcessingOptions{})
 
 	assert.Error(t, err)
 	assert.Equal(t, FactCheck{}, factCheck)
@@ -299,7 +486,7 @@ func TestFactCheckerAgent_extractVerdict(t *testing.T) {
 	tests := []struct {
 		name     string
 		response string
-		expected string
+		expected models.Verdict
 	}{
 		{
 			name:     "true verdict",
@@ -507,6 +694,48 @@ func TestFactCheckerAgent_extractSources(t *testing.T) {
 	}
 }
 
+func TestFactCheckerAgent_extractSources_Capped(t *testing.T) {
+	availableSources := []string{
+		"https://nasa.gov/article1",
+		"https://wikipedia.org/page1",
+		"https://scientificjournal.com/study",
+	}
+	response := "SOURCES: https://nasa.gov/article1, https://nasa.gov/article1, https://wikipedia.org/page1, https://scientificjournal.com/study"
+
+	tests := []struct {
+		name       string
+		maxSources int
+		response   string
+		expected   []string
+	}{
+		{
+			name:       "repeats are dropped before the cap",
+			maxSources: 2,
+			response:   response,
+			expected:   []string{"https://nasa.gov/article1", "https://wikipedia.org/page1"},
+		},
+		{
+			name:       "unset cap uses the default",
+			maxSources: 0,
+			response:   response,
+			expected:   availableSources,
+		},
+		{
+			name:       "fallback respects a smaller cap",
+			maxSources: 1,
+			response:   "VERDICT: unverifiable",
+			expected:   availableSources[:1],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), maxSources: tt.maxSources}
+			assert.Equal(t, tt.expected, agent.extractSources(tt.response, availableSources))
+		})
+	}
+}
+
 func TestFactCheckerAgent_parseVerificationResult(t *testing.T) {
 	agent := &FactCheckerAgent{
 		BaseAgent: NewBaseAgent("fact_checker"),
@@ -516,15 +745,95 @@ func TestFactCheckerAgent_parseVerificationResult(t *testing.T) {
 	response := "VERDICT: true\nCONFIDENCE: 0.85\nEVIDENCE: Strong evidence supports this SOURCES: https://nasa.gov/article1"
 	availableSources := []string{"https://nasa.gov/article1", "https://other.com/page"}
 
-	result := agent.parseVerificationResult(claim, response, availableSources)
+	result := agent.parseVerificationResult(context.Background(), claim, response, availableSources)
 
 	assert.Equal(t, claim, result.Claim)
-	assert.Equal(t, "true", result.Verdict)
+	assert.Equal(t, models.VerdictTrue, result.Verdict)
 	assert.Equal(t, 0.85, result.Confidence)
 	assert.Equal(t, "Strong evidence supports this", result.Evidence)
 	assert.Equal(t, []string{"https://nasa.gov/article1"}, result.Sources)
 }
 
+func TestFactCheckerAgent_parseVerificationResult_MinConfidence(t *testing.T) {
+	tests := []struct {
+		name             string
+		minConfidence    float64
+		response         string
+		expectedVerdict  models.Verdict
+		expectedOriginal models.Verdict
+	}{
+		{name: "disabled", minConfidence: 0, response: "VERDICT: false\nCONFIDENCE: 0.3\nEVIDENCE: Weak", expectedVerdict: models.VerdictFalse},
+		{name: "false below threshold", minConfidence: 0.6, response: "VERDICT: false\nCONFIDENCE: 0.3\nEVIDENCE: Weak", expectedVerdict: models.VerdictUnverifiable, expectedOriginal: models.VerdictFalse},
+		{name: "true below threshold", minConfidence: 0.6, response: "VERDICT: true\nCONFIDENCE: 0.5\nEVIDENCE: Weak", expectedVerdict: models.VerdictUnverifiable, expectedOriginal: models.VerdictTrue},
+		{name: "at threshold", minConfidence: 0.6, response: "VERDICT: true\nCONFIDENCE: 0.6\nEVIDENCE: Fine", expectedVerdict: models.VerdictTrue},
+		{name: "partially true is kept", minConfidence: 0.6, response: "VERDICT: partially_true\nCONFIDENCE: 0.2\nEVIDENCE: Weak", expectedVerdict: models.VerdictPartiallyTrue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), minConfidence: tt.minConfidence}
+
+			result := agent.parseVerificationResult(context.Background(), "claim", tt.response, nil)
+
+			assert.Equal(t, tt.expectedVerdict, result.Verdict)
+			assert.Equal(t, tt.expectedOriginal, result.OriginalVerdict)
+		})
+	}
+}
+
+func TestFactCheckerAgent_parseVerificationResult_MinConfidenceStrictJSON(t *testing.T) {
+	agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), strictJSON: true, minConfidence: 0.7}
+
+	result := agent.parseVerificationResult(context.Background(), "claim", `{"verdict": "false", "confidence": 0.4, "evidence": "Thin"}`, nil)
+
+	assert.Equal(t, models.VerdictUnverifiable, result.Verdict)
+	assert.Equal(t, models.VerdictFalse, result.OriginalVerdict)
+	assert.Equal(t, 0.4, result.Confidence)
+}
+
+func TestFactCheckerAgent_parseVerificationResult_MinSources(t *testing.T) {
+	availableSources := []string{"https://a.example/1", "https://b.example/2", "https://c.example/3"}
+	tests := []struct {
+		name             string
+		minSources       int
+		response         string
+		expectedVerdict  models.Verdict
+		expectedOriginal models.Verdict
+	}{
+		{name: "disabled", minSources: 0, response: "VERDICT: true\nCONFIDENCE: 0.9\nEVIDENCE: Fine\nSOURCES: https://a.example/1", expectedVerdict: models.VerdictTrue},
+		{name: "true with too few sources", minSources: 2, response: "VERDICT: true\nCONFIDENCE: 0.9\nEVIDENCE: Fine\nSOURCES: https://a.example/1", expectedVerdict: models.VerdictUnverifiable, expectedOriginal: models.VerdictTrue},
+		{name: "unknown sources do not count", minSources: 2, response: "VERDICT: false\nCONFIDENCE: 0.9\nEVIDENCE: Fine\nSOURCES: https://a.example/1, https://made-up.example/x", expectedVerdict: models.VerdictUnverifiable, expectedOriginal: models.VerdictFalse},
+		{name: "enough sources", minSources: 2, response: "VERDICT: false\nCONFIDENCE: 0.9\nEVIDENCE: Fine\nSOURCES: https://a.example/1, https://b.example/2", expectedVerdict: models.VerdictFalse},
+		{name: "partially true is kept", minSources: 2, response: "VERDICT: partially_true\nCONFIDENCE: 0.9\nEVIDENCE: Fine\nSOURCES: https://a.example/1", expectedVerdict: models.VerdictPartiallyTrue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), minSources: tt.minSources}
+
+			result := agent.parseVerificationResult(context.Background(), "claim", tt.response, availableSources)
+
+			assert.Equal(t, tt.expectedVerdict, result.Verdict)
+			assert.Equal(t, tt.expectedOriginal, result.OriginalVerdict)
+			if tt.expectedOriginal != "" {
+				assert.Contains(t, result.Evidence, "needs at least 2 sources, but only 1 were found")
+			} else {
+				assert.NotContains(t, result.Evidence, "Downgraded")
+			}
+		})
+	}
+}
+
+func TestFactCheckerAgent_parseVerificationResult_MinConfidenceBeforeMinSources(t *testing.T) {
+	agent := &FactCheckerAgent{BaseAgent: NewBaseAgent("fact_checker"), minConfidence: 0.7, minSources: 2}
+
+	result := agent.parseVerificationResult(context.Background(), "claim", "VERDICT: true\nCONFIDENCE: 0.4\nEVIDENCE: Thin", nil)
+
+	assert.Equal(t, models.VerdictUnverifiable, result.Verdict)
+	assert.Equal(t, models.VerdictTrue, result.OriginalVerdict)
+	assert.NotContains(t, result.Evidence, "Downgraded")
+}
+
 func TestFactCheckerAgent_countVerdicts(t *testing.T) {
 	agent := &FactCheckerAgent{
 		BaseAgent: NewBaseAgent("fact_checker"),
@@ -541,12 +850,234 @@ func TestFactCheckerAgent_countVerdicts(t *testing.T) {
 
 	result := agent.countVerdicts(factChecks)
 
-	expected := map[string]int{
-		"true":            3,
-		"false":           1,
-		"partially_true":  1,
-		"unverifiable":    1,
+	expected := map[models.Verdict]int{
+		models.VerdictTrue:          3,
+		models.VerdictFalse:         1,
+		models.VerdictPartiallyTrue: 1,
+		models.VerdictUnverifiable:  1,
 	}
 
 	assert.Equal(t, expected, result)
-}
\ No newline at end of file
+}
+// stubFactCheckCache is an in-memory FactCheckCache keyed by exact claim text
+type stubFactCheckCache struct {
+	entries map[string]FactCheck
+	puts    int
+}
+
+func (c *stubFactCheckCache) Get(ctx context.Context, claim string) (FactCheck, bool) {
+	fc, ok := c.entries[claim]
+	return fc, ok
+}
+
+func (c *stubFactCheckCache) Put(ctx context.Context, factCheck FactCheck) {
+	c.entries[factCheck.Claim] = factCheck
+	c.puts++
+}
+
+func TestFactCheckerAgent_Process_CacheHitSkipsVerification(t *testing.T) {
+	mockAnthropicClient := &MockAnthropicClient{}
+	mockSerperClient := &MockSerperClient{}
+	cache := &stubFactCheckCache{entries: map[string]FactCheck{
+		"The moon landing happened in 1969": {
+			Claim:      "The moon landing happened in 1969",
+			Verdict:    "true",
+			Confidence: 0.95,
+			Evidence:   "Widely documented",
+			Sources:    []string{"https://nasa.gov/moon-landing"},
+		},
+	}}
+	agent := (&FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockAnthropicClient,
+		serperClient:    mockSerperClient,
+	}).WithCache(cache)
+
+	mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.AnythingOfType("string"), mock.AnythingOfType("string"), false).
+		Return("1. The moon landing happened in 1969", nil).Once()
+
+	result, err := agent.Process(context.Background(), "The podcast mentioned that the moon landing happened in 1969.")
+
+	assert.NoError(t, err)
+	assert.Len(t, result.FactChecks, 1)
+	assert.True(t, result.FactChecks[0].Cached)
+	assert.Equal(t, models.VerdictTrue, result.FactChecks[0].Verdict)
+	assert.Equal(t, 0, cache.puts)
+	mockAnthropicClient.AssertExpectations(t)
+	mockSerperClient.AssertNotCalled(t, "SearchForClaim", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFactCheckerAgent_Process_CacheHitAppliesMinSources(t *testing.T) {
+	mockAnthropicClient := &MockAnthropicClient{}
+	cache := &stubFactCheckCache{entries: map[string]FactCheck{
+		"The moon landing happened in 1969": {
+			Claim:      "The moon landing happened in 1969",
+			Verdict:    models.VerdictTrue,
+			Confidence: 0.95,
+			Evidence:   "Widely documented",
+			Sources:    []string{"https://nasa.gov/moon-landing"},
+		},
+	}}
+	agent := (&FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockAnthropicClient,
+		minSources:      2,
+	}).WithCache(cache)
+
+	mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.AnythingOfType("string"), mock.AnythingOfType("string"), false).
+		Return("1. The moon landing happened in 1969", nil).Once()
+
+	result, err := agent.Process(context.Background(), "The podcast mentioned that the moon landing happened in 1969.")
+
+	assert.NoError(t, err)
+	assert.Len(t, result.FactChecks, 1)
+	assert.True(t, result.FactChecks[0].Cached)
+	assert.Equal(t, models.VerdictUnverifiable, result.FactChecks[0].Verdict)
+	assert.Equal(t, models.VerdictTrue, result.FactChecks[0].OriginalVerdict)
+}
+
+func TestResolveSearchBackend(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.Config
+		expected string
+	}{
+		{name: "serper by default", cfg: config.Config{AnthropicAPIKey: "key", SerperAPIKey: "key"}, expected: searchBackendSerper},
+		{name: "native when configured", cfg: config.Config{AnthropicAPIKey: "key", SerperAPIKey: "key", FactCheckSearchBackend: searchBackendNative}, expected: searchBackendNative},
+		{name: "native without serper key", cfg: config.Config{AnthropicAPIKey: "key", FactCheckSearchBackend: searchBackendSerper}, expected: searchBackendNative},
+		{name: "serper without anthropic key", cfg: config.Config{SerperAPIKey: "key", FactCheckSearchBackend: searchBackendNative}, expected: searchBackendSerper},
+		{name: "neither key", cfg: config.Config{}, expected: searchBackendNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, resolveSearchBackend(&tt.cfg))
+		})
+	}
+}
+
+func TestFactCheckerAgent_verifyClaim_NativeWebSearch(t *testing.T) {
+	mockAnthropicClient := &MockAnthropicClient{}
+	mockSerperClient := &MockSerperClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockAnthropicClient,
+		serperClient:    mockSerperClient,
+		searchBackend:   searchBackendNative,
+	}
+
+	mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "The moon landing happened in 1969")
+	}), mock.AnythingOfType("string"), true).Return(`VERDICT: true
+CONFIDENCE: 0.95
+EVIDENCE: NASA records confirm Apollo 11 landed on July 20, 1969.
+SOURCES: https://nasa.gov/apollo11, https://nasa.gov/apollo11, https://history.com/moon`, nil).Once()
+
+	factCheck, err := agent.verifyClaim(context.Background(), "The moon landing happened in 1969", "", ProcessingOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictTrue, factCheck.Verdict)
+	assert.Equal(t, 0.95, factCheck.Confidence)
+	assert.Equal(t, []string{"https://nasa.gov/apollo11", "https://history.com/moon"}, factCheck.Sources)
+	mockAnthropicClient.AssertExpectations(t)
+	mockSerperClient.AssertNotCalled(t, "SearchForClaim", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFactCheckerAgent_verifyClaim_BlockedDomains(t *testing.T) {
+	tests := []struct {
+		name       string
+		sources    string
+		expected   []string
+		lowQuality bool
+	}{
+		{
+			name:     "blocked sources dropped",
+			sources:  "https://contentfarm.example/moon, https://nasa.gov/apollo11",
+			expected: []string{"https://nasa.gov/apollo11"},
+		},
+		{
+			name:       "only blocked sources are kept and flagged",
+			sources:    "https://www.contentfarm.example/moon",
+			expected:   []string{"https://www.contentfarm.example/moon"},
+			lowQuality: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAnthropicClient := &MockAnthropicClient{}
+			agent := &FactCheckerAgent{
+				BaseAgent:       NewBaseAgent("fact_checker"),
+				anthropicClient: mockAnthropicClient,
+				searchBackend:   searchBackendNative,
+				blocked:         clients.NewDomainBlocklist([]string{"contentfarm.example"}),
+			}
+
+			mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.AnythingOfType("string"), mock.AnythingOfType("string"), true).
+				Return("VERDICT: true\nCONFIDENCE: 0.9\nEVIDENCE: Confirmed.\nSOURCES: "+tt.sources, nil).Once()
+
+			factCheck, err := agent.verifyClaim(context.Background(), "The moon landing happened in 1969", "", ProcessingOptions{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, factCheck.Sources)
+			assert.Equal(t, tt.lowQuality, factCheck.LowSourceQuality)
+		})
+	}
+}
+
+func TestFactCheckerAgent_verifyClaim_SerperLowSourceQuality(t *testing.T) {
+	mockAnthropicClient := &MockAnthropicClient{}
+	mockSerperClient := &MockSerperClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockAnthropicClient,
+		serperClient:    mockSerperClient,
+	}
+
+	searchContext := &clients.SearchContext{
+		Sources:          []string{"https://contentfarm.example/moon"},
+		Snippets:         []clients.SearchSnippet{{Title: "Farm", Snippet: "Moon landing", URL: "https://contentfarm.example/moon"}},
+		LowSourceQuality: true,
+	}
+	mockSerperClient.On("SearchForClaim", mock.Anything, "fact_checker", mock.Anything).Return(searchContext, nil)
+	mockSerperClient.On("FormatSearchResultsForAnalysis", searchContext).Return("formatted")
+	mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.AnythingOfType("string"), mock.AnythingOfType("string"), false).
+		Return("VERDICT: true\nCONFIDENCE: 0.6\nEVIDENCE: Weakly supported.\nSOURCES: https://contentfarm.example/moon", nil)
+
+	factCheck, err := agent.verifyClaim(context.Background(), "The moon landing happened in 1969", "", ProcessingOptions{})
+
+	assert.NoError(t, err)
+	assert.True(t, factCheck.LowSourceQuality)
+}
+
+func TestFactCheckerAgent_verifyClaim_NativeWebSearchError(t *testing.T) {
+	mockAnthropicClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockAnthropicClient,
+		searchBackend:   searchBackendNative,
+	}
+	mockAnthropicClient.On("CallClaude", mock.Anything, "fact_checker", mock.Anything, mock.Anything, true).
+		Return("", errors.New("API error (status 500)")).Once()
+
+	_, err := agent.verifyClaim(context.Background(), "The moon landing happened in 1969", "", ProcessingOptions{})
+
+	assert.Error(t, err)
+	assert.Equal(t, "web search failed", err.(*AgentError).Message)
+}
+
+func TestFactCheckerAgent_verifyClaim_NoSearchBackend(t *testing.T) {
+	mockAnthropicClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockAnthropicClient,
+		searchBackend:   searchBackendNone,
+	}
+
+	factCheck, err := agent.verifyClaim(context.Background(), "The moon landing happened in 1969", "", ProcessingOptions{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.VerdictUnverifiable, factCheck.Verdict)
+	assert.Equal(t, "No search backend configured", factCheck.Evidence)
+	mockAnthropicClient.AssertNotCalled(t, "CallClaude", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,42 @@
+package agents
+
+import (
+	"context"
+
+	"podcast-analyzer/internal/requestctx"
+)
+
+// Reminders appended to the user prompt when a response could not be parsed
+const (
+	numberedListReminder = "Please format your response as a numbered list, one item per line (1. ..., 2. ..., etc.), with no other text."
+	jsonReminder         = "Please respond with only the JSON object described in the instructions, with no other text."
+	summaryReminder      = "Please respond with only the summary text, with no headings or other commentary."
+)
+
+// callWithParseRetry calls Claude with userPrompt and hands the response to parse. While
+// parse reports nothing usable, Claude is asked again up to parseRetries more times with
+// reminder appended to the prompt, since a misformatted response is often fixed by asking
+// again. Call errors are returned at once; the HTTP client has already retried those. The
+// last response is returned whether or not it parsed, so callers keep their own handling
+// of empty results.
+func (b *BaseAgent) callWithParseRetry(ctx context.Context, userPrompt, reminder string, call func(userPrompt string) (string, error), parse func(response string) bool) (string, error) {
+	prompt := userPrompt
+	for attempt := 0; ; attempt++ {
+		response, err := call(prompt)
+		if err != nil {
+			return "", err
+		}
+		if parse(response) || attempt >= b.parseRetries {
+			return response, nil
+		}
+
+		b.logger.WithFields(map[string]interface{}{
+			"agent":          b.name,
+			"correlation_id": requestctx.CorrelationID(ctx),
+			"attempt":        attempt + 1,
+			"max_retries":    b.parseRetries,
+			"response":       b.TruncateForLog(response, 200),
+		}).Warn("Response could not be parsed, asking again with a format reminder")
+		prompt = userPrompt + "\n\n" + reminder
+	}
+}
@@ -0,0 +1,149 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallWithParseRetry_RetriesWithReminder(t *testing.T) {
+	agent := NewBaseAgent("test_agent")
+	agent.parseRetries = 2
+
+	var prompts []string
+	responses := []string{"garbage", "still garbage", "1. parsed"}
+	response, err := agent.callWithParseRetry(context.Background(), "prompt", "reminder", func(userPrompt string) (string, error) {
+		prompts = append(prompts, userPrompt)
+		return responses[len(prompts)-1], nil
+	}, func(response string) bool {
+		return strings.HasPrefix(response, "1.")
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1. parsed", response)
+	assert.Equal(t, []string{"prompt", "prompt\n\nreminder", "prompt\n\nreminder"}, prompts)
+}
+
+func TestCallWithParseRetry_GivesUpAfterRetries(t *testing.T) {
+	agent := NewBaseAgent("test_agent")
+	agent.parseRetries = 1
+
+	calls := 0
+	response, err := agent.callWithParseRetry(context.Background(), "prompt", "reminder", func(string) (string, error) {
+		calls++
+		return "garbage", nil
+	}, func(string) bool { return false })
+
+	require.NoError(t, err)
+	assert.Equal(t, "garbage", response)
+	assert.Equal(t, 2, calls)
+}
+
+func TestCallWithParseRetry_DoesNotRetryCallErrors(t *testing.T) {
+	agent := NewBaseAgent("test_agent")
+	agent.parseRetries = 3
+
+	calls := 0
+	_, err := agent.callWithParseRetry(context.Background(), "prompt", "reminder", func(string) (string, error) {
+		calls++
+		return "", errors.New("API error")
+	}, func(string) bool { return false })
+
+	assert.EqualError(t, err, "API error")
+	assert.Equal(t, 1, calls)
+}
+
+func TestTakeawayExtractorAgent_Process_RetriesUnparsableResponse(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &TakeawayExtractorAgent{
+		BaseAgent:       NewBaseAgent("takeaway_extractor"),
+		anthropicClient: mockClient,
+	}
+	agent.parseRetries = 1
+
+	content := strings.Repeat("This is a long enough podcast content for testing purposes. ", 10)
+	mockClient.On("CallClaude", mock.Anything, "takeaway_extractor", mock.MatchedBy(func(prompt string) bool {
+		return !strings.Contains(prompt, numberedListReminder)
+	}), mock.Anything, false).Return("Sure!", nil).Once()
+	mockClient.On("CallClaude", mock.Anything, "takeaway_extractor", mock.MatchedBy(func(prompt string) bool {
+		return strings.HasSuffix(prompt, "\n\n"+numberedListReminder)
+	}), mock.Anything, false).Return("1. First takeaway point here with enough words", nil).Once()
+
+	result, err := agent.Process(context.Background(), content)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"First takeaway point here with enough words."}, result.Takeaways)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSummarizerAgent_Process_RetriesEmptySummary(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &SummarizerAgent{
+		BaseAgent:       NewBaseAgent("summarizer"),
+		anthropicClient: mockClient,
+		maxChars:        300,
+	}
+	agent.parseRetries = 1
+
+	content := strings.Repeat("This is a long enough podcast content for testing purposes. ", 10)
+	mockClient.On("CallClaude", mock.Anything, "summarizer", mock.Anything, mock.Anything, false).Return("   ", nil).Once()
+	mockClient.On("CallClaude", mock.Anything, "summarizer", mock.MatchedBy(func(prompt string) bool {
+		return strings.HasSuffix(prompt, summaryReminder)
+	}), mock.Anything, false).Return("A concise summary of the episode.", nil).Once()
+
+	result, err := agent.Process(context.Background(), content)
+
+	require.NoError(t, err)
+	assert.Equal(t, "A concise summary of the episode.", result.Summary)
+	mockClient.AssertExpectations(t)
+}
+
+func TestFactCheckerAgent_extractClaims_NoClaimsResponseNotRetried(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockClient,
+	}
+	agent.parseRetries = 2
+
+	mockClient.On("CallClaude", mock.Anything, "fact_checker", mock.Anything, mock.Anything, false).
+		Return("There are no verifiable factual claims in this transcript.", nil).Once()
+
+	claims, err := agent.extractClaims(context.Background(), "Just opinions here, nothing to check.", ProcessingOptions{})
+
+	require.NoError(t, err)
+	assert.Empty(t, claims)
+	mockClient.AssertExpectations(t)
+}
+
+func TestFactCheckerAgent_extractClaims_RetriesUnparsableResponse(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockClient,
+	}
+	agent.parseRetries = 1
+
+	mockClient.On("CallClaude", mock.Anything, "fact_checker", mock.Anything, mock.Anything, false).Return("Okay.", nil).Once()
+	mockClient.On("CallClaude", mock.Anything, "fact_checker", mock.MatchedBy(func(prompt string) bool {
+		return strings.HasSuffix(prompt, numberedListReminder)
+	}), mock.Anything, false).Return("1. The Apollo 11 mission landed on the Moon in 1969", nil).Once()
+
+	claims, err := agent.extractClaims(context.Background(), "The Apollo 11 mission landed on the Moon in 1969.", ProcessingOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"The Apollo 11 mission landed on the Moon in 1969"}, claims)
+	mockClient.AssertExpectations(t)
+}
+
+func TestStatesNoClaims(t *testing.T) {
+	assert.True(t, statesNoClaims("There are no verifiable factual claims in this transcript."))
+	assert.True(t, statesNoClaims("No claims."))
+	assert.False(t, statesNoClaims("1. The company made no claims about revenue in 2020\n2. The CEO resigned in March 2021"))
+	assert.False(t, statesNoClaims("Okay."))
+}
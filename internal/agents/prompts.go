@@ -0,0 +1,176 @@
+package agents
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/logger"
+)
+
+// promptTemplateExt is the file extension of prompt template overrides
+const promptTemplateExt = ".tmpl"
+
+// promptNames lists the prompts each agent allows to be overridden
+var promptNames = map[string][]string{
+	"summarizer":           {"system", "user"},
+	"takeaway_extractor":   {"system", "user"},
+	"fact_checker":         {"claims_system", "claims_user", "verify_system", "verify_user", "websearch_user"},
+	"takeaway_synthesizer": {"system", "user"},
+}
+
+// PromptData holds the variables available to prompt templates
+type PromptData struct {
+	Content       string // Transcript text, already truncated for the prompt; numbered takeaways for the synthesizer
+	Summary       string // Summary context for takeaway extraction and synthesis
+	MaxChars      int    // Maximum summary length
+	Claim         string // Claim being verified
+	SearchResults string // Formatted search results for claim verification
+	MaxTakeaways  int    // Maximum number of takeaways to extract
+	SummaryStyle  string // Requested summary style, e.g. "prose" or "bullet_points"
+}
+
+// PromptTemplates holds prompt overrides loaded from a directory, keyed by "<agent>.<prompt>"
+type PromptTemplates struct {
+	templates map[string]*template.Template
+}
+
+// promptCache holds loaded templates per directory, shared by all agent instances
+var (
+	promptCacheMu sync.Mutex
+	promptCache   = make(map[string]*PromptTemplates)
+)
+
+// LoadPromptTemplates parses every <agent>.<prompt>.tmpl file in dir. Unknown prompt names
+// and templates that fail to parse or execute are rejected, so mistakes surface at startup.
+func LoadPromptTemplates(dir string) (*PromptTemplates, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("prompt template directory %s is not accessible: %w", dir, err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+promptTemplateExt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt templates in %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	templates := make(map[string]*template.Template)
+	for _, path := range paths {
+		key := strings.TrimSuffix(filepath.Base(path), promptTemplateExt)
+		if !isKnownPrompt(key) {
+			return nil, fmt.Errorf("unknown prompt template %s (expected one of: %s)", filepath.Base(path), strings.Join(knownPromptKeys(), ", "))
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read prompt template %s: %w", path, err)
+		}
+
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid prompt template %s: %w", path, err)
+		}
+
+		// Execute against empty data to catch references to unknown fields
+		if err := tmpl.Execute(io.Discard, PromptData{}); err != nil {
+			return nil, fmt.Errorf("invalid prompt template %s: %w", path, err)
+		}
+
+		templates[key] = tmpl
+	}
+
+	return &PromptTemplates{templates: templates}, nil
+}
+
+// promptTemplatesFor returns the cached prompt overrides for the configured directory,
+// or nil when no directory is configured
+func promptTemplatesFor(cfg *config.Config) *PromptTemplates {
+	if cfg.PromptTemplateDir == "" {
+		return nil
+	}
+
+	promptCacheMu.Lock()
+	defer promptCacheMu.Unlock()
+
+	if prompts, ok := promptCache[cfg.PromptTemplateDir]; ok {
+		return prompts
+	}
+
+	prompts, err := LoadPromptTemplates(cfg.PromptTemplateDir)
+	if err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation":           "load_prompt_templates",
+			"prompt_template_dir": cfg.PromptTemplateDir,
+		})
+		return nil
+	}
+	promptCache[cfg.PromptTemplateDir] = prompts
+	return prompts
+}
+
+// Names returns the keys of all loaded overrides
+func (p *PromptTemplates) Names() []string {
+	if p == nil {
+		return nil
+	}
+	names := make([]string, 0, len(p.templates))
+	for name := range p.templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// render executes the override for an agent prompt. It returns false when there is no override
+// or it fails to execute, in which case the caller uses its built-in prompt.
+func (p *PromptTemplates) render(agent, prompt string, data PromptData) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+	tmpl, ok := p.templates[agent+"."+prompt]
+	if !ok {
+		return "", false
+	}
+
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, data); err != nil {
+		logger.Log.WithFields(map[string]interface{}{
+			"agent":  agent,
+			"prompt": prompt,
+			"error":  err.Error(),
+		}).Warn("Prompt template failed, using built-in prompt")
+		return "", false
+	}
+	return builder.String(), true
+}
+
+// isKnownPrompt reports whether a "<agent>.<prompt>" key names an overridable prompt
+func isKnownPrompt(key string) bool {
+	agent, prompt, ok := strings.Cut(key, ".")
+	if !ok {
+		return false
+	}
+	for _, name := range promptNames[agent] {
+		if name == prompt {
+			return true
+		}
+	}
+	return false
+}
+
+// knownPromptKeys lists every overridable prompt key
+func knownPromptKeys() []string {
+	var keys []string
+	for agent, prompts := range promptNames {
+		for _, prompt := range prompts {
+			keys = append(keys, agent+"."+prompt)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
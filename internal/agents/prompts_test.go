@@ -0,0 +1,111 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"podcast-analyzer/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePromptTemplate(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestLoadPromptTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writePromptTemplate(t, dir, "summarizer.system.tmpl", "Summarize in at most {{.MaxChars}} characters.")
+	writePromptTemplate(t, dir, "fact_checker.verify_user.tmpl", "Claim: {{.Claim}}\n{{.SearchResults}}")
+	writePromptTemplate(t, dir, "README.md", "ignored")
+
+	prompts, err := LoadPromptTemplates(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fact_checker.verify_user", "summarizer.system"}, prompts.Names())
+
+	rendered, ok := prompts.render("summarizer", "system", PromptData{MaxChars: 150})
+	assert.True(t, ok)
+	assert.Equal(t, "Summarize in at most 150 characters.", rendered)
+
+	_, ok = prompts.render("summarizer", "user", PromptData{})
+	assert.False(t, ok)
+}
+
+func TestLoadPromptTemplates_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		filename      string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "unknown prompt name",
+			filename:      "summarizer.sytem.tmpl",
+			content:       "typo",
+			expectedError: "unknown prompt template summarizer.sytem.tmpl",
+		},
+		{
+			name:          "syntax error",
+			filename:      "summarizer.user.tmpl",
+			content:       "{{.Content",
+			expectedError: "invalid prompt template",
+		},
+		{
+			name:          "unknown field",
+			filename:      "takeaway_extractor.user.tmpl",
+			content:       "{{.Transcript}}",
+			expectedError: "invalid prompt template",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writePromptTemplate(t, dir, tt.filename, tt.content)
+
+			prompts, err := LoadPromptTemplates(dir)
+
+			assert.Nil(t, prompts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestLoadPromptTemplates_MissingDirectory(t *testing.T) {
+	_, err := LoadPromptTemplates(filepath.Join(t.TempDir(), "missing"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not accessible")
+}
+
+func TestPromptTemplates_NilFallsBack(t *testing.T) {
+	var prompts *PromptTemplates
+
+	_, ok := prompts.render("summarizer", "system", PromptData{})
+	assert.False(t, ok)
+	assert.Nil(t, prompts.Names())
+}
+
+func TestAgents_UsePromptOverrides(t *testing.T) {
+	dir := t.TempDir()
+	writePromptTemplate(t, dir, "summarizer.user.tmpl", "Custom summary prompt for: {{.Content}}")
+	writePromptTemplate(t, dir, "takeaway_extractor.user.tmpl", "Context: {{.Summary}} / {{.Content}}")
+
+	cfg := &config.Config{
+		AnthropicAPIKey:   "test-key",
+		SummaryMaxChars:   150,
+		PromptTemplateDir: dir,
+	}
+
+	summarizer := NewSummarizerAgent(cfg)
+	assert.Equal(t, "Custom summary prompt for: hello", summarizer.buildUserPrompt("hello", ""))
+	// Prompts without an override keep the built-in text
+	assert.Contains(t, summarizer.buildSystemPrompt(""), "maximum of 150 characters")
+
+	extractor := NewTakeawayExtractorAgent(cfg)
+	assert.Equal(t, "Context: short / transcript", extractor.buildUserPrompt("transcript", "short", defaultMaxTakeaways))
+}
@@ -0,0 +1,80 @@
+package agents
+
+import (
+	"encoding/json"
+	"strings"
+
+	"podcast-analyzer/internal/models"
+)
+
+// JSON schemas the agents ask for in strict JSON mode
+const (
+	takeawaysJSONSchema    = `{"takeaways": ["<takeaway as a complete sentence>", ...]}`
+	claimsJSONSchema       = `{"claims": ["<specific factual claim>", ...]}`
+	verificationJSONSchema = `{"verdict": "<true|false|partially_true|unverifiable>", "confidence": <number from 0.0 to 1.0>, "evidence": "<1-2 sentence explanation>", "sources": ["<source URL>", ...]}`
+)
+
+// takeawaysJSON is the strict JSON response of the takeaway extractor
+type takeawaysJSON struct {
+	Takeaways []string `json:"takeaways"`
+}
+
+// claimsJSON is the strict JSON response of claim extraction
+type claimsJSON struct {
+	Claims []string `json:"claims"`
+}
+
+// verificationJSON is the strict JSON response of claim verification
+type verificationJSON struct {
+	Verdict    string   `json:"verdict"`
+	Confidence *float64 `json:"confidence"`
+	Evidence   string   `json:"evidence"`
+	Sources    []string `json:"sources"`
+}
+
+// appendJSONSchema requires a response to be a single JSON object matching schema. It
+// goes on the system prompt, so it also applies when the user prompt is a template override.
+func appendJSONSchema(systemPrompt, schema string) string {
+	return systemPrompt + "\n\nRespond with only a JSON object matching this schema, with no other text and ignoring any other formatting instructions:\n" + schema
+}
+
+// decodeJSONResponse decodes the JSON object in a response into v, allowing for markdown
+// code fences or text around it. It reports false when no valid object is found.
+func decodeJSONResponse(response string, v interface{}) bool {
+	start := strings.Index(response, "{")
+	end := strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return false
+	}
+	return json.Unmarshal([]byte(response[start:end+1]), v) == nil
+}
+
+// parseJSONVerification builds a fact check from a strict JSON verification response,
+// reporting false when the response is not valid JSON or has no verdict
+func (f *FactCheckerAgent) parseJSONVerification(claim, response string, availableSources []string) (FactCheck, bool) {
+	var parsed verificationJSON
+	if !decodeJSONResponse(response, &parsed) || parsed.Verdict == "" {
+		return FactCheck{}, false
+	}
+
+	verdict, err := models.ParseVerdict(parsed.Verdict)
+	if err != nil {
+		verdict = models.VerdictUnverifiable
+	}
+	confidence := 0.5
+	if parsed.Confidence != nil {
+		confidence = clampConfidence(*parsed.Confidence)
+	}
+	evidence := strings.TrimSpace(parsed.Evidence)
+	if evidence == "" {
+		evidence = "No evidence provided"
+	}
+
+	return FactCheck{
+		Claim:      claim,
+		Verdict:    verdict,
+		Confidence: confidence,
+		Evidence:   evidence,
+		Sources:    f.selectSources(parsed.Sources, availableSources),
+	}, true
+}
@@ -0,0 +1,167 @@
+package agents
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAgents_StrictJSON(t *testing.T) {
+	cfg := &config.Config{AnthropicAPIKey: "test-key", StrictJSONAgents: true}
+
+	assert.True(t, NewTakeawayExtractorAgent(cfg).strictJSON)
+	assert.True(t, NewFactCheckerAgent(cfg).strictJSON)
+	assert.False(t, NewFactCheckerAgent(&config.Config{AnthropicAPIKey: "test-key"}).strictJSON)
+}
+
+func TestDecodeJSONResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		ok       bool
+	}{
+		{name: "bare object", response: `{"claims": ["a"]}`, ok: true},
+		{name: "code fence", response: "```json\n{\"claims\": [\"a\"]}\n```", ok: true},
+		{name: "surrounding text", response: "Here you go:\n{\"claims\": [\"a\"]}\nThanks", ok: true},
+		{name: "truncated", response: `{"claims": ["a"`, ok: false},
+		{name: "no object", response: "1. A claim", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parsed claimsJSON
+			assert.Equal(t, tt.ok, decodeJSONResponse(tt.response, &parsed))
+			if tt.ok {
+				assert.Equal(t, []string{"a"}, parsed.Claims)
+			}
+		})
+	}
+}
+
+func TestTakeawayExtractorAgent_StrictJSON(t *testing.T) {
+	content := strings.Repeat("This is a long enough podcast content for testing purposes. ", 10)
+
+	tests := []struct {
+		name     string
+		response string
+		expected []string
+	}{
+		{
+			name:     "json response",
+			response: "```json\n{\"takeaways\": [\"sleep matters more than most people think\", \"short\", \"Exercise improves focus, per the guest\"]}\n```",
+			expected: []string{"Sleep matters more than most people think.", "Exercise improves focus, per the guest."},
+		},
+		{
+			name:     "malformed json falls back to the text parser",
+			response: "1. Sleep matters more than most people think\n2. Exercise improves focus, per the guest",
+			expected: []string{"Sleep matters more than most people think.", "Exercise improves focus, per the guest."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockAnthropicClient{}
+			agent := &TakeawayExtractorAgent{
+				BaseAgent:       NewBaseAgent("takeaway_extractor"),
+				anthropicClient: mockClient,
+				strictJSON:      true,
+			}
+
+			mockClient.On("CallClaude",
+				mock.Anything,
+				"takeaway_extractor",
+				mock.AnythingOfType("string"),
+				mock.MatchedBy(func(system string) bool { return strings.Contains(system, takeawaysJSONSchema) }),
+				false,
+			).Return(tt.response, nil)
+
+			result, err := agent.Process(context.Background(), content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Takeaways)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFactCheckerAgent_StrictJSON_ExtractClaims(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockClient,
+		strictJSON:      true,
+	}
+
+	mockClient.On("CallClaude",
+		mock.Anything,
+		"fact_checker",
+		mock.AnythingOfType("string"),
+		mock.MatchedBy(func(system string) bool { return strings.Contains(system, claimsJSONSchema) }),
+		false,
+	).Return(`{"claims": ["The moon landing happened in 1969", "Too short", "Water boils at 100 degrees Celsius"]}`, nil)
+
+	claims, err := agent.extractClaims(context.Background(), "transcript", ProcessingOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"The moon landing happened in 1969", "Water boils at 100 degrees Celsius"}, claims)
+	mockClient.AssertExpectations(t)
+}
+
+func TestFactCheckerAgent_StrictJSON_ParseVerification(t *testing.T) {
+	agent := &FactCheckerAgent{
+		BaseAgent:  NewBaseAgent("fact_checker"),
+		strictJSON: true,
+	}
+	availableSources := []string{"https://nasa.gov/article1", "https://other.com/page"}
+
+	tests := []struct {
+		name     string
+		response string
+		expected FactCheck
+	}{
+		{
+			name:     "json response",
+			response: `{"verdict": "partially_true", "confidence": 1.4, "evidence": "Mostly supported", "sources": ["https://other.com/page", "https://unknown.com"]}`,
+			expected: FactCheck{Claim: "claim", Verdict: models.VerdictPartiallyTrue, Confidence: 1.0, Evidence: "Mostly supported", Sources: []string{"https://other.com/page"}},
+		},
+		{
+			name:     "unknown verdict",
+			response: `{"verdict": "maybe", "evidence": "Unclear"}`,
+			expected: FactCheck{Claim: "claim", Verdict: models.VerdictUnverifiable, Confidence: 0.5, Evidence: "Unclear", Sources: availableSources},
+		},
+		{
+			name:     "malformed json falls back to the text parser",
+			response: "VERDICT: false\nCONFIDENCE: 0.9\nEVIDENCE: Contradicted SOURCES: https://nasa.gov/article1",
+			expected: FactCheck{Claim: "claim", Verdict: models.VerdictFalse, Confidence: 0.9, Evidence: "Contradicted", Sources: []string{"https://nasa.gov/article1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, agent.parseVerificationResult(context.Background(), "claim", tt.response, availableSources))
+		})
+	}
+}
+
+func TestFactCheckerAgent_StrictJSON_WebSearchSources(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &FactCheckerAgent{
+		BaseAgent:       NewBaseAgent("fact_checker"),
+		anthropicClient: mockClient,
+		searchBackend:   searchBackendNative,
+		strictJSON:      true,
+	}
+
+	mockClient.On("CallClaude", mock.Anything, "fact_checker", mock.AnythingOfType("string"), mock.AnythingOfType("string"), true).
+		Return(`{"verdict": "true", "confidence": 0.8, "evidence": "Confirmed", "sources": ["https://nasa.gov/apollo"]}`, nil)
+
+	factCheck, err := agent.verifyClaim(context.Background(), "Apollo 11 landed in 1969", "", ProcessingOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, models.VerdictTrue, factCheck.Verdict)
+	assert.Equal(t, []string{"https://nasa.gov/apollo"}, factCheck.Sources)
+}
@@ -9,6 +9,7 @@ import (
 	
 	"podcast-analyzer/internal/clients"
 	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/requestctx"
 )
 
 // SummarizerAgent generates concise summaries of podcast transcripts
@@ -16,19 +17,32 @@ type SummarizerAgent struct {
 	*BaseAgent
 	anthropicClient clients.AnthropicClientInterface
 	maxChars        int
+	prompts         *PromptTemplates
 }
 
 // NewSummarizerAgent creates a new summarizer agent
 func NewSummarizerAgent(cfg *config.Config) *SummarizerAgent {
-	return &SummarizerAgent{
+	agent := &SummarizerAgent{
 		BaseAgent:       NewBaseAgent("summarizer"),
 		anthropicClient: clients.NewAnthropicClient(cfg),
 		maxChars:        cfg.SummaryMaxChars,
+		prompts:         promptTemplatesFor(cfg),
 	}
+	agent.maxInputChars = cfg.AgentMaxInputChars
+	agent.parseRetries = cfg.AgentParseRetries
+	return agent
 }
 
+// summarizerMaxInputChars is the transcript length sent to Claude when no global limit is set
+const summarizerMaxInputChars = 15000
+
 // Process generates a summary of the podcast transcript
 func (s *SummarizerAgent) Process(ctx context.Context, content string) (Result, error) {
+	return s.ProcessWithOptions(ctx, content, ProcessingOptions{})
+}
+
+// ProcessWithOptions generates a summary, applying any job-specific instructions
+func (s *SummarizerAgent) ProcessWithOptions(ctx context.Context, content string, opts ProcessingOptions) (Result, error) {
 	start := time.Now()
 	defer func() {
 		s.LogAPICall(ctx, "anthropic", len(content), true)
@@ -44,24 +58,58 @@ func (s *SummarizerAgent) Process(ctx context.Context, content string) (Result,
 	}
 	
 	// Build prompts
-	systemPrompt := s.buildSystemPrompt()
-	userPrompt := s.buildUserPrompt(content)
+	systemPrompt := appendInstructions(s.buildSystemPrompt(opts.SummaryStyle), opts.Instructions)
+	userPrompt := s.buildUserPrompt(s.TruncateInput(ctx, content, summarizerMaxInputChars), opts.SummaryStyle)
+	reminder := summaryReminder
+	if opts.Headline {
+		systemPrompt = appendHeadlineFormat(systemPrompt)
+		reminder = jsonReminder
+	}
 	
-	// Call Claude API
-	rawSummary, err := s.anthropicClient.CallClaude(ctx, s.Name(), userPrompt, systemPrompt, false)
+	cleanSummary := func(rawSummary string) string {
+		if opts.SummaryStyle == SummaryStyleBulletPoints {
+			return s.cleanBulletSummary(rawSummary)
+		}
+		return s.cleanSummary(rawSummary)
+	}
+
+	// Call Claude API, cleaning the summary and asking again if nothing is left of it
+	var summary, headline string
+	response, err := s.callWithParseRetry(ctx, userPrompt, reminder, func(userPrompt string) (string, error) {
+		return s.anthropicClient.CallClaude(ctx, s.Name(), userPrompt, systemPrompt, false)
+	}, func(rawSummary string) bool {
+		if opts.Headline {
+			var ok bool
+			if headline, rawSummary, ok = parseHeadlineSummary(rawSummary); !ok {
+				return false
+			}
+		}
+		summary = cleanSummary(rawSummary)
+		return summary != ""
+	})
 	if err != nil {
 		s.LogError(ctx, err, time.Since(start))
 		return Result{}, NewAgentError(s.Name(), "failed to generate summary", err)
 	}
+
+	// A headline response that never came back as JSON is used as a plain summary, so a
+	// formatting miss costs the headline rather than the job
+	if opts.Headline && headline == "" {
+		s.logger.WithFields(map[string]interface{}{
+			"agent":          s.Name(),
+			"correlation_id": requestctx.CorrelationID(ctx),
+			"response":       s.TruncateForLog(response, 200),
+		}).Warn("Headline response could not be parsed, using it as the summary without a headline")
+		summary = cleanSummary(response)
+	}
 	
-	// Clean and validate the summary
-	summary := s.cleanSummary(rawSummary)
+	// Validate the summary
 	if err := s.validateSummary(summary); err != nil {
 		s.LogError(ctx, err, time.Since(start))
 		return Result{}, err
 	}
 	
-	result := Result{Summary: summary}
+	result := Result{Summary: summary, Headline: headline}
 	
 	// Log success
 	s.LogSuccess(ctx, &result, time.Since(start))
@@ -69,9 +117,14 @@ func (s *SummarizerAgent) Process(ctx context.Context, content string) (Result,
 	return result, nil
 }
 
-// buildSystemPrompt creates the system prompt for Claude
-func (s *SummarizerAgent) buildSystemPrompt() string {
-	return fmt.Sprintf(`You are an expert at creating concise, professional summaries of podcast content for business audiences.
+// buildSystemPrompt creates the system prompt for Claude, with guidance for the requested style
+func (s *SummarizerAgent) buildSystemPrompt(style SummaryStyle) string {
+	style = style.orDefault()
+	if prompt, ok := s.prompts.render(s.Name(), "system", PromptData{MaxChars: s.maxChars, SummaryStyle: string(style)}); ok {
+		return appendSummaryStyle(prompt, style)
+	}
+
+	return appendSummaryStyle(fmt.Sprintf(`You are an expert at creating concise, professional summaries of podcast content for business audiences.
 
 Your task is to create a summary that:
 - Is a maximum of %d characters
@@ -79,18 +132,17 @@ Your task is to create a summary that:
 - Focuses on factual content rather than opinions
 - Does not include filler words or transcription artifacts
 
-The summary should be useful for someone who wants to post a tweet on X or update their status on Facebook.`, s.maxChars)
+The summary should be useful for someone who wants to post a tweet on X or update their status on Facebook.`, s.maxChars), style)
 }
 
-// buildUserPrompt creates the user prompt with the transcript content
-func (s *SummarizerAgent) buildUserPrompt(content string) string {
-	// Truncate very long transcripts for the prompt
-	maxTranscriptLength := 15000 // Reasonable limit for Claude context
-	if len(content) > maxTranscriptLength {
-		content = s.TruncateContent(content, maxTranscriptLength)
+// buildUserPrompt creates the user prompt with the already truncated transcript content
+func (s *SummarizerAgent) buildUserPrompt(content string, style SummaryStyle) string {
+	style = style.orDefault()
+	if prompt, ok := s.prompts.render(s.Name(), "user", PromptData{Content: content, MaxChars: s.maxChars, SummaryStyle: string(style)}); ok {
+		return prompt
 	}
 	
-	return fmt.Sprintf(`Please create a professional summary of the following podcast transcript.
+	return fmt.Sprintf(`Please create a %s summary of the following podcast transcript.
 
 The summary should be a maximum of %d characters and should include:
 - Main topics and themes discussed
@@ -99,7 +151,7 @@ The summary should be a maximum of %d characters and should include:
 TRANSCRIPT:
 %s
 
-SUMMARY:`, s.maxChars, content)
+SUMMARY:`, summaryStyleGuidance[style].adjective, s.maxChars, content)
 }
 
 // cleanSummary cleans and formats the generated summary
@@ -140,6 +192,24 @@ func (s *SummarizerAgent) cleanSummary(rawSummary string) string {
 	return summary
 }
 
+// cleanBulletSummary cleans a bullet-point summary line by line, keeping one "- " bullet
+// per line instead of collapsing the lines into a paragraph
+func (s *SummarizerAgent) cleanBulletSummary(rawSummary string) string {
+	var bullets []string
+	for _, line := range strings.Split(rawSummary, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.EqualFold(line, "Summary:") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*•"))
+		if line == "" {
+			continue
+		}
+		bullets = append(bullets, "- "+s.cleanSummary(line))
+	}
+	return strings.Join(bullets, "\n")
+}
+
 // validateSummary validates the generated summary
 func (s *SummarizerAgent) validateSummary(summary string) error {
 	if summary == "" {
@@ -66,6 +66,29 @@ func TestSummarizerAgent_Process_Success(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestSummarizerAgent_ProcessWithOptions_AppendsInstructions(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &SummarizerAgent{
+		BaseAgent:       NewBaseAgent("summarizer"),
+		anthropicClient: mockClient,
+		maxChars:        300,
+	}
+
+	ctx := context.Background()
+	mockClient.On("CallClaude", ctx, "summarizer", mock.AnythingOfType("string"),
+		mock.MatchedBy(func(systemPrompt string) bool {
+			return strings.HasPrefix(systemPrompt, agent.buildSystemPrompt("")) &&
+				strings.HasSuffix(systemPrompt, "Summarize for a technical audience.")
+		}), false,
+	).Return("A technical summary of the discussion.", nil)
+
+	_, err := agent.ProcessWithOptions(ctx, "This is a sample podcast transcript with multiple speakers discussing distributed databases.", ProcessingOptions{
+		Instructions: "Summarize for a technical audience.",
+	})
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
 
 func TestSummarizerAgent_Process_ContentTooLong(t *testing.T) {
 	mockClient := new(MockAnthropicClient)
@@ -100,7 +123,7 @@ func TestSummarizerAgent_buildSystemPrompt(t *testing.T) {
 		maxChars:  250,
 	}
 
-	prompt := agent.buildSystemPrompt()
+	prompt := agent.buildSystemPrompt("")
 
 	assert.Contains(t, prompt, "concise")
 	assert.Contains(t, prompt, "250")
@@ -113,7 +136,7 @@ func TestSummarizerAgent_buildUserPrompt(t *testing.T) {
 	}
 
 	content := "Test transcript content here"
-	prompt := agent.buildUserPrompt(content)
+	prompt := agent.buildUserPrompt(content, "")
 
 	assert.Contains(t, prompt, "summary")
 	assert.Contains(t, prompt, content)
@@ -225,4 +248,19 @@ func TestSummarizerAgent_validateSummary(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestSummarizerAgent_ProcessWithOptions_TruncatesToMaxInputChars(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := NewSummarizerAgent(&config.Config{AgentMaxInputChars: 200, SummaryMaxChars: 150})
+	agent.anthropicClient = mockClient
+
+	content := "Opening remarks about the show. " + strings.Repeat("filler ", 100) + "CLOSING-MARKER"
+	mockClient.On("CallClaude", mock.Anything, "summarizer", mock.MatchedBy(func(prompt string) bool {
+		return strings.Contains(prompt, "Opening remarks") && strings.Contains(prompt, "[...content truncated...]") && !strings.Contains(prompt, "CLOSING-MARKER")
+	}), mock.Anything, false).Return("A concise summary of the opening remarks about the show.", nil).Once()
+
+	_, err := agent.ProcessWithOptions(context.Background(), content, ProcessingOptions{})
+
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
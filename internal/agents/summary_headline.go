@@ -0,0 +1,57 @@
+package agents
+
+import (
+	"fmt"
+	"strings"
+)
+
+// headlineMaxChars caps the length of a summary headline
+const headlineMaxChars = 100
+
+// headlineSummaryJSON is the response the summarizer is asked for when a headline is wanted
+type headlineSummaryJSON struct {
+	Headline string `json:"headline"`
+	Summary  string `json:"summary"`
+}
+
+// appendHeadlineFormat asks a summarizer system prompt for a headline as well as the summary,
+// returned together as one JSON object
+func appendHeadlineFormat(systemPrompt string) string {
+	return systemPrompt + fmt.Sprintf(`
+
+Also write a headline: a single line of at most %d characters that states what the episode is about, without ending punctuation.
+Respond with only a JSON object in this form, with no other text:
+{"headline": "<headline>", "summary": "<summary>"}`, headlineMaxChars)
+}
+
+// parseHeadlineSummary extracts the headline and the raw summary from a headline response,
+// reporting false unless both are present. The summary is cleaned by the caller.
+func parseHeadlineSummary(response string) (string, string, bool) {
+	var parsed headlineSummaryJSON
+	if !decodeJSONResponse(response, &parsed) {
+		return "", "", false
+	}
+	headline := cleanHeadline(parsed.Headline)
+	if headline == "" || strings.TrimSpace(parsed.Summary) == "" {
+		return "", "", false
+	}
+	return headline, parsed.Summary, true
+}
+
+// cleanHeadline reduces a headline to one line without surrounding quotes or a trailing
+// period, cutting it at a word boundary when it is longer than headlineMaxChars
+func cleanHeadline(headline string) string {
+	headline = strings.Join(strings.Fields(headline), " ")
+	headline = strings.Trim(headline, `"'“”`)
+	headline = strings.TrimSpace(strings.TrimSuffix(headline, "."))
+
+	runes := []rune(headline)
+	if len(runes) <= headlineMaxChars {
+		return headline
+	}
+	truncated := string(runes[:headlineMaxChars-len("...")])
+	if lastSpace := strings.LastIndex(truncated, " "); lastSpace > 0 {
+		truncated = truncated[:lastSpace]
+	}
+	return strings.TrimRight(truncated, " ,;:-") + "..."
+}
@@ -0,0 +1,102 @@
+package agents
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHeadlineSummary(t *testing.T) {
+	headline, summary, ok := parseHeadlineSummary("```json\n{\"headline\": \"\\\"Why sleep matters.\\\"\", \"summary\": \"The hosts discuss sleep.\"}\n```")
+	require.True(t, ok)
+	assert.Equal(t, "Why sleep matters", headline)
+	assert.Equal(t, "The hosts discuss sleep.", summary)
+
+	for _, response := range []string{
+		"Why sleep matters. The hosts discuss sleep.",
+		`{"headline": "Why sleep matters"}`,
+		`{"headline": "  ", "summary": "The hosts discuss sleep."}`,
+	} {
+		_, _, ok := parseHeadlineSummary(response)
+		assert.False(t, ok, response)
+	}
+}
+
+func TestCleanHeadline(t *testing.T) {
+	assert.Equal(t, "Why sleep matters", cleanHeadline("  Why sleep\n matters. "))
+
+	long := cleanHeadline(strings.Repeat("word ", 40))
+	assert.LessOrEqual(t, len([]rune(long)), headlineMaxChars)
+	assert.True(t, strings.HasSuffix(long, "word..."))
+}
+
+func TestSummarizerAgent_Process_Headline(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &SummarizerAgent{
+		BaseAgent:       NewBaseAgent("summarizer"),
+		anthropicClient: mockClient,
+		maxChars:        300,
+	}
+	agent.parseRetries = 1
+
+	content := strings.Repeat("This is a long enough podcast content for testing purposes. ", 10)
+	mockClient.On("CallClaude", mock.Anything, "summarizer", mock.Anything, mock.MatchedBy(func(systemPrompt string) bool {
+		return strings.Contains(systemPrompt, `{"headline": "<headline>", "summary": "<summary>"}`)
+	}), false).Return("A summary without the requested JSON.", nil).Once()
+	mockClient.On("CallClaude", mock.Anything, "summarizer", mock.MatchedBy(func(prompt string) bool {
+		return strings.HasSuffix(prompt, jsonReminder)
+	}), mock.Anything, false).Return(`{"headline": "Testing podcasts at length", "summary": "the hosts test   podcast content"}`, nil).Once()
+
+	result, err := agent.ProcessWithOptions(context.Background(), content, ProcessingOptions{Headline: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Testing podcasts at length", result.Headline)
+	assert.Equal(t, "The hosts test podcast content.", result.Summary)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSummarizerAgent_Process_HeadlineFallsBackToProse(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &SummarizerAgent{
+		BaseAgent:       NewBaseAgent("summarizer"),
+		anthropicClient: mockClient,
+		maxChars:        300,
+	}
+	agent.parseRetries = 1
+
+	content := strings.Repeat("This is a long enough podcast content for testing purposes. ", 10)
+	mockClient.On("CallClaude", mock.Anything, "summarizer", mock.Anything, mock.Anything, false).
+		Return("The hosts discuss testing podcasts at length.", nil).Twice()
+
+	result, err := agent.ProcessWithOptions(context.Background(), content, ProcessingOptions{Headline: true})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Headline)
+	assert.Equal(t, "The hosts discuss testing podcasts at length.", result.Summary)
+	mockClient.AssertExpectations(t)
+}
+
+func TestSummarizerAgent_Process_NoHeadlineByDefault(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &SummarizerAgent{
+		BaseAgent:       NewBaseAgent("summarizer"),
+		anthropicClient: mockClient,
+		maxChars:        300,
+	}
+
+	content := strings.Repeat("This is a long enough podcast content for testing purposes. ", 10)
+	mockClient.On("CallClaude", mock.Anything, "summarizer", mock.Anything, mock.MatchedBy(func(systemPrompt string) bool {
+		return !strings.Contains(systemPrompt, "headline")
+	}), false).Return("A concise summary of the episode.", nil).Once()
+
+	result, err := agent.Process(context.Background(), content)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Headline)
+	assert.Equal(t, "A concise summary of the episode.", result.Summary)
+	mockClient.AssertExpectations(t)
+}
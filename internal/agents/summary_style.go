@@ -0,0 +1,65 @@
+package agents
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SummaryStyle is the tone and shape requested for a summary
+type SummaryStyle string
+
+const (
+	SummaryStyleProse        SummaryStyle = "prose"
+	SummaryStyleBulletPoints SummaryStyle = "bullet_points"
+	SummaryStyleExecutive    SummaryStyle = "executive"
+	SummaryStyleCasual       SummaryStyle = "casual"
+	SummaryStyleAcademic     SummaryStyle = "academic"
+)
+
+// SummaryStyles lists every supported style, the default first
+var SummaryStyles = []SummaryStyle{SummaryStyleProse, SummaryStyleBulletPoints, SummaryStyleExecutive, SummaryStyleCasual, SummaryStyleAcademic}
+
+// summaryStyleGuidance describes each style for the summarizer, along with the adjective
+// used in the user prompt. Prose has no guidance since the base prompt already asks for it.
+var summaryStyleGuidance = map[SummaryStyle]struct {
+	adjective string
+	guidance  string
+}{
+	SummaryStyleProse:        {adjective: "professional"},
+	SummaryStyleBulletPoints: {adjective: "bullet-point", guidance: "Write the summary as 2-4 short bullet points, one per line, each starting with \"- \". Do not add an introduction."},
+	SummaryStyleExecutive:    {adjective: "executive", guidance: "Write for a busy executive: lead with the single most important conclusion, then its business relevance. Use plain, direct sentences."},
+	SummaryStyleCasual:       {adjective: "casual", guidance: "Write in a relaxed, conversational tone, as if telling a friend what the episode was about. Avoid jargon."},
+	SummaryStyleAcademic:     {adjective: "academic", guidance: "Write in a formal academic register: state the central question, the positions discussed, and any evidence cited, without rhetorical flourishes."},
+}
+
+// ParseSummaryStyle normalizes a requested style, accepting any case and spaces or hyphens
+// for underscores. An empty value selects prose.
+func ParseSummaryStyle(value string) (SummaryStyle, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	normalized = strings.NewReplacer(" ", "_", "-", "_").Replace(normalized)
+	if normalized == "" {
+		return SummaryStyleProse, nil
+	}
+	style := SummaryStyle(normalized)
+	if _, ok := summaryStyleGuidance[style]; !ok {
+		return "", fmt.Errorf("invalid summary style %q", value)
+	}
+	return style, nil
+}
+
+// orDefault returns prose for the zero style
+func (s SummaryStyle) orDefault() SummaryStyle {
+	if s == "" {
+		return SummaryStyleProse
+	}
+	return s
+}
+
+// appendSummaryStyle adds a style's guidance to a summarizer system prompt
+func appendSummaryStyle(systemPrompt string, style SummaryStyle) string {
+	guidance := summaryStyleGuidance[style.orDefault()].guidance
+	if guidance == "" {
+		return systemPrompt
+	}
+	return systemPrompt + "\n\nStyle: " + guidance
+}
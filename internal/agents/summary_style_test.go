@@ -0,0 +1,73 @@
+package agents
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSummaryStyle(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    SummaryStyle
+		expectError bool
+	}{
+		{value: "", expected: SummaryStyleProse},
+		{value: "Casual", expected: SummaryStyleCasual},
+		{value: " bullet points ", expected: SummaryStyleBulletPoints},
+		{value: "bullet-points", expected: SummaryStyleBulletPoints},
+		{value: "poetic", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			style, err := ParseSummaryStyle(tt.value)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, style)
+		})
+	}
+}
+
+func TestSummarizerAgent_SummaryStylePrompts(t *testing.T) {
+	agent := &SummarizerAgent{BaseAgent: NewBaseAgent("summarizer"), maxChars: 150}
+
+	// Prose keeps the built-in prompts unchanged
+	assert.Equal(t, agent.buildSystemPrompt(""), agent.buildSystemPrompt(SummaryStyleProse))
+	assert.NotContains(t, agent.buildSystemPrompt(SummaryStyleProse), "Style:")
+	assert.Contains(t, agent.buildUserPrompt("content", SummaryStyleProse), "create a professional summary")
+
+	for _, style := range SummaryStyles[1:] {
+		system := agent.buildSystemPrompt(style)
+		assert.Contains(t, system, "Style: "+summaryStyleGuidance[style].guidance)
+		assert.Contains(t, agent.buildUserPrompt("content", style), "create a "+summaryStyleGuidance[style].adjective+" summary")
+	}
+}
+
+func TestSummarizerAgent_ProcessWithOptions_BulletPoints(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &SummarizerAgent{
+		BaseAgent:       NewBaseAgent("summarizer"),
+		anthropicClient: mockClient,
+		maxChars:        300,
+	}
+
+	mockClient.On("CallClaude", mock.Anything, "summarizer", mock.AnythingOfType("string"),
+		mock.MatchedBy(func(systemPrompt string) bool { return strings.Contains(systemPrompt, "bullet points") }), false,
+	).Return("Summary:\n- sleep matters more than expected\n* exercise   improves focus\n", nil)
+
+	result, err := agent.ProcessWithOptions(context.Background(), "This is a sample podcast transcript about sleep and exercise habits.", ProcessingOptions{
+		SummaryStyle: SummaryStyleBulletPoints,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "- Sleep matters more than expected.\n- Exercise improves focus.", result.Summary)
+	mockClient.AssertExpectations(t)
+}
@@ -2,26 +2,67 @@ package agents
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
 	
 	"podcast-analyzer/internal/clients"
 	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/requestctx"
 )
 
 // TakeawayExtractorAgent extracts key takeaways and insights from podcast transcripts
 type TakeawayExtractorAgent struct {
 	*BaseAgent
 	anthropicClient clients.AnthropicClientInterface
+	prompts         *PromptTemplates
+	maxTakeaways    int
+	strictJSON      bool // Ask for a JSON response, falling back to the text parser
+	rankImportance  bool // Ask Claude to score each takeaway's importance from 1 to 5
 }
 
+// defaultMaxTakeaways applies when no maximum is configured
+const defaultMaxTakeaways = 10
+
+// takeawayMaxInputChars is the transcript length sent to Claude when no global limit is set
+const takeawayMaxInputChars = 12000
+
 // NewTakeawayExtractorAgent creates a new takeaway extractor agent
 func NewTakeawayExtractorAgent(cfg *config.Config) *TakeawayExtractorAgent {
-	return &TakeawayExtractorAgent{
+	agent := &TakeawayExtractorAgent{
 		BaseAgent:       NewBaseAgent("takeaway_extractor"),
 		anthropicClient: clients.NewAnthropicClient(cfg),
+		prompts:         promptTemplatesFor(cfg),
+		maxTakeaways:    cfg.MaxTakeaways,
+		strictJSON:      cfg.StrictJSONAgents,
+		rankImportance:  cfg.RankTakeaways,
+	}
+	agent.maxInputChars = cfg.AgentMaxInputChars
+	agent.parseRetries = cfg.AgentParseRetries
+	return agent
+}
+
+// takeawayLimit returns the per-job maximum when set, otherwise the configured one
+func (t *TakeawayExtractorAgent) takeawayLimit(opts ProcessingOptions) int {
+	if opts.MaxResults > 0 {
+		return opts.MaxResults
+	}
+	if t.maxTakeaways > 0 {
+		return t.maxTakeaways
 	}
+	return defaultMaxTakeaways
+}
+
+// takeawayTarget describes how many takeaways to ask for, e.g. "4-8" for a maximum of 10,
+// leaving headroom below the hard limit
+func takeawayTarget(limit int) string {
+	low := (limit*2 + 4) / 5 // 40%, rounded up
+	high := (limit*4 + 2) / 5 // 80%, rounded to nearest
+	if high <= low {
+		return fmt.Sprintf("%d", limit)
+	}
+	return fmt.Sprintf("%d-%d", low, high)
 }
 
 // Process extracts key takeaways from the podcast transcript
@@ -47,24 +88,48 @@ func (t *TakeawayExtractorAgent) ProcessWithOptions(ctx context.Context, content
 	
 	// Build prompts
 	systemPrompt := t.buildSystemPrompt()
-	userPrompt := t.buildUserPrompt(content, opts.Summary)
+	switch {
+	case t.strictJSON && t.rankImportance:
+		systemPrompt = appendJSONSchema(systemPrompt, rankedTakeawaysJSONSchema)
+	case t.strictJSON:
+		systemPrompt = appendJSONSchema(systemPrompt, takeawaysJSONSchema)
+	case t.rankImportance:
+		systemPrompt += "\n\n" + takeawayImportanceInstructions
+	}
+	systemPrompt = appendInstructions(systemPrompt, opts.Instructions)
+	limit := t.takeawayLimit(opts)
+	userPrompt := t.buildUserPrompt(t.TruncateInput(ctx, content, takeawayMaxInputChars), opts.Summary, limit)
 	
-	// Call Claude API
-	rawResponse, err := t.anthropicClient.CallClaude(ctx, t.Name(), userPrompt, systemPrompt, false)
+	// Call Claude API, parsing the takeaways and asking again if none could be parsed
+	var takeaways []string
+	var importance []int
+	reminder := numberedListReminder
+	if t.strictJSON {
+		reminder = jsonReminder
+	}
+	_, err := t.callWithParseRetry(ctx, userPrompt, reminder, func(userPrompt string) (string, error) {
+		return t.anthropicClient.CallClaude(ctx, t.Name(), userPrompt, systemPrompt, false)
+	}, func(rawResponse string) bool {
+		if t.rankImportance {
+			takeaways, importance = t.parseRankedResponse(ctx, rawResponse, limit)
+		} else {
+			takeaways = t.parseResponse(ctx, rawResponse, limit)
+		}
+		return len(takeaways) > 0
+	})
 	if err != nil {
 		t.LogError(ctx, err, time.Since(start))
 		return Result{}, NewAgentError(t.Name(), "failed to extract takeaways", err)
 	}
 	
-	// Parse and validate the takeaways
-	takeaways := t.parseTakeaways(rawResponse)
+	// Validate the takeaways
 	if len(takeaways) == 0 {
 		err := NewAgentError(t.Name(), "no takeaways extracted from transcript", nil)
 		t.LogError(ctx, err, time.Since(start))
 		return Result{}, err
 	}
 	
-	result := Result{Takeaways: takeaways}
+	result := Result{Takeaways: takeaways, TakeawayImportance: importance}
 	
 	// Log success with takeaway details
 	t.logTakeaways(ctx, takeaways)
@@ -75,6 +140,10 @@ func (t *TakeawayExtractorAgent) ProcessWithOptions(ctx context.Context, content
 
 // buildSystemPrompt creates the system prompt for Claude
 func (t *TakeawayExtractorAgent) buildSystemPrompt() string {
+	if prompt, ok := t.prompts.render(t.Name(), "system", PromptData{}); ok {
+		return prompt
+	}
+
 	return `You are an expert at identifying key insights and actionable takeaways from podcast discussions.
 
 Your task is to extract the most important, valuable, and memorable points that:
@@ -94,12 +163,10 @@ Focus on substantive content that would be valuable for someone to remember or a
 Return your response as a simple numbered list, with each takeaway as a complete, clear sentence.`
 }
 
-// buildUserPrompt creates the user prompt with transcript and optional summary
-func (t *TakeawayExtractorAgent) buildUserPrompt(content, summary string) string {
-	// Truncate very long transcripts for the prompt
-	maxTranscriptLength := 12000 // Reasonable limit for Claude context
-	if len(content) > maxTranscriptLength {
-		content = t.TruncateContent(content, maxTranscriptLength)
+// buildUserPrompt creates the user prompt with the already truncated transcript and optional summary
+func (t *TakeawayExtractorAgent) buildUserPrompt(content, summary string, maxTakeaways int) string {
+	if prompt, ok := t.prompts.render(t.Name(), "user", PromptData{Content: content, Summary: summary, MaxTakeaways: maxTakeaways}); ok {
+		return prompt
 	}
 	
 	prompt := `Analyze the following podcast transcript and extract the key takeaways and insights.
@@ -120,7 +187,7 @@ Focus on identifying:
 	}
 	
 	prompt += "TRANSCRIPT:\n" + content + "\n\n"
-	prompt += `Please extract 4-8 key takeaways from this podcast. Format your response as a simple numbered list:
+	prompt += "Please extract " + takeawayTarget(maxTakeaways) + ` key takeaways from this podcast. Format your response as a simple numbered list:
 
 1. [First key takeaway]
 2. [Second key takeaway]
@@ -132,30 +199,37 @@ KEY TAKEAWAYS:`
 	return prompt
 }
 
-// parseTakeaways parses takeaways from Claude's response
-func (t *TakeawayExtractorAgent) parseTakeaways(rawResponse string) []string {
-	var takeaways []string
-	
-	// Split response into lines
-	lines := strings.Split(strings.TrimSpace(rawResponse), "\n")
-	
-	for _, line := range lines {
-		processedLine := t.processTakeawayLine(line)
-		if processedLine != "" {
-			takeaways = append(takeaways, processedLine)
-		}
+// parseResponse parses takeaways from Claude's response. In strict JSON mode the response
+// is decoded as JSON first, with the text parser as a fallback.
+func (t *TakeawayExtractorAgent) parseResponse(ctx context.Context, rawResponse string, maxTakeaways int) []string {
+	if !t.strictJSON {
+		return t.parseTakeaways(rawResponse, maxTakeaways)
 	}
 	
-	// Limit to reasonable number of takeaways
-	if len(takeaways) > 10 {
-		t.logger.WithFields(map[string]interface{}{
-			"agent":            t.Name(),
-			"original_count":   len(takeaways),
-			"truncated_count":  10,
-		}).Warn("Truncated takeaways list to maximum count")
-		takeaways = takeaways[:10]
+	var parsed takeawaysJSON
+	if decodeJSONResponse(rawResponse, &parsed) {
+		return t.collectTakeaways(parsed.Takeaways, maxTakeaways)
 	}
 	
+	t.logger.WithFields(map[string]interface{}{
+		"agent":          t.Name(),
+		"correlation_id": requestctx.CorrelationID(ctx),
+		"response":       t.TruncateForLog(rawResponse, 200),
+	}).Warn("Response was not valid JSON, falling back to text parsing")
+	return t.parseTakeaways(rawResponse, maxTakeaways)
+}
+
+// parseTakeaways parses takeaways from Claude's response
+func (t *TakeawayExtractorAgent) parseTakeaways(rawResponse string, maxTakeaways int) []string {
+	// Split response into lines
+	lines := strings.Split(strings.TrimSpace(rawResponse), "\n")
+	return t.collectTakeaways(lines, maxTakeaways)
+}
+
+// collectTakeaways cleans candidate lines, dropping non-takeaways, and keeps at most
+// maxTakeaways of them
+func (t *TakeawayExtractorAgent) collectTakeaways(lines []string, maxTakeaways int) []string {
+	takeaways, _ := t.collectRankedTakeaways(lines, nil, maxTakeaways)
 	return takeaways
 }
 
@@ -245,7 +319,7 @@ func (t *TakeawayExtractorAgent) cleanTakeaway(takeaway string) string {
 
 // logTakeaways logs individual takeaways for visibility
 func (t *TakeawayExtractorAgent) logTakeaways(ctx context.Context, takeaways []string) {
-	correlationID := getCorrelationID(ctx)
+	correlationID := requestctx.CorrelationID(ctx)
 	
 	for i, takeaway := range takeaways {
 		t.logger.WithFields(map[string]interface{}{
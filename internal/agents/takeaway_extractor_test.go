@@ -1,6 +1,7 @@
 package agents
 
 import (
+	"fmt"
 	"context"
 	"strings"
 	"testing"
@@ -307,7 +308,7 @@ func TestTakeawayExtractorAgent_parseTakeaways(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := agent.parseTakeaways(tt.response)
+			result := agent.parseTakeaways(tt.response, defaultMaxTakeaways)
 			assert.Equal(t, tt.expected, result)
 			assert.LessOrEqual(t, len(result), 10) // Should never exceed 10
 		})
@@ -419,9 +420,57 @@ func TestTakeawayExtractorAgent_buildUserPrompt(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			prompt := agent.buildUserPrompt(tt.content, tt.summary)
+			prompt := agent.buildUserPrompt(tt.content, tt.summary, defaultMaxTakeaways)
 			assert.Contains(t, prompt, "extract the key takeaways")
 			assert.Contains(t, prompt, tt.expectedContent)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestTakeawayExtractorAgent_ProcessWithOptions_MaxTakeaways(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &TakeawayExtractorAgent{
+		BaseAgent:       NewBaseAgent("takeaway_extractor"),
+		anthropicClient: mockClient,
+		maxTakeaways:    5,
+	}
+
+	content := strings.Repeat("This is a long enough podcast content for testing purposes. ", 10)
+	var lines []string
+	for i := 1; i <= 6; i++ {
+		lines = append(lines, fmt.Sprintf("%d. Key insight number %d with enough words here", i, i))
+	}
+
+	mockClient.On("CallClaude",
+		mock.Anything,
+		mock.Anything,
+		mock.MatchedBy(func(prompt string) bool { return strings.Contains(prompt, "extract 3 key takeaways") }),
+		mock.AnythingOfType("string"),
+		false,
+	).Return(strings.Join(lines, "\n"), nil).Once()
+	mockClient.On("CallClaude",
+		mock.Anything,
+		mock.Anything,
+		mock.MatchedBy(func(prompt string) bool { return strings.Contains(prompt, "extract 2-4 key takeaways") }),
+		mock.AnythingOfType("string"),
+		false,
+	).Return(strings.Join(lines, "\n"), nil).Once()
+
+	// The per-job maximum takes precedence over the configured one
+	result, err := agent.ProcessWithOptions(context.Background(), content, ProcessingOptions{MaxResults: 3})
+	assert.NoError(t, err)
+	assert.Len(t, result.Takeaways, 3)
+
+	result, err = agent.ProcessWithOptions(context.Background(), content, ProcessingOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Takeaways, 5)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTakeawayTarget(t *testing.T) {
+	assert.Equal(t, "4-8", takeawayTarget(defaultMaxTakeaways))
+	assert.Equal(t, "2-4", takeawayTarget(5))
+	assert.Equal(t, "8-16", takeawayTarget(20))
+	assert.Equal(t, "3", takeawayTarget(3))
+	assert.Equal(t, "1-2", takeawayTarget(2))
+	assert.Equal(t, "1", takeawayTarget(1))
+}
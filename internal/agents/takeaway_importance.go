@@ -0,0 +1,128 @@
+package agents
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"podcast-analyzer/internal/requestctx"
+)
+
+// Bounds of the importance score Claude gives each takeaway when ranking is on
+const (
+	MinTakeawayImportance = 1
+	MaxTakeawayImportance = 5
+)
+
+// rankedTakeawaysJSONSchema replaces takeawaysJSONSchema when takeaways are ranked
+const rankedTakeawaysJSONSchema = `{"takeaways": [{"text": "<takeaway as a complete sentence>", "importance": <integer from 1 to 5>}, ...]}`
+
+// takeawayImportanceInstructions goes on the system prompt when takeaways are ranked, so
+// it also applies when the user prompt is a template override
+const takeawayImportanceInstructions = `Rate each takeaway's importance from 1 (minor detail) to 5 (essential point of the episode), and put the rating in square brackets at the start of the takeaway, after the list number:
+
+1. [5] [Most important takeaway]
+2. [3] [Moderately important takeaway]`
+
+// importancePrefix matches a rating at the start of a takeaway, such as "[4]", "(4)" or "[4/5]"
+var importancePrefix = regexp.MustCompile(`^[\[(]\s*(\d+)\s*(?:/\s*5\s*)?[\])]\s*`)
+
+// rankedTakeawaysJSON is the strict JSON response of the takeaway extractor when ranking
+type rankedTakeawaysJSON struct {
+	Takeaways []struct {
+		Text       string `json:"text"`
+		Importance int    `json:"importance"`
+	} `json:"takeaways"`
+}
+
+// splitImportance removes a leading rating from a takeaway line whose list marker is
+// already gone, returning 0 when the line has none
+func splitImportance(line string) (string, int) {
+	match := importancePrefix.FindStringSubmatch(line)
+	if match == nil {
+		return line, 0
+	}
+	importance, _ := strconv.Atoi(match[1])
+	return line[len(match[0]):], clampImportance(importance)
+}
+
+// clampImportance keeps a rating within the importance scale, leaving 0 for unrated
+func clampImportance(importance int) int {
+	switch {
+	case importance <= 0:
+		return 0
+	case importance > MaxTakeawayImportance:
+		return MaxTakeawayImportance
+	default:
+		return importance
+	}
+}
+
+// parseRankedResponse parses takeaways and their importance from Claude's response. A
+// takeaway without a rating gets 0. In strict JSON mode the response is decoded as JSON
+// first, accepting a plain list too, with the text parser as a fallback.
+func (t *TakeawayExtractorAgent) parseRankedResponse(ctx context.Context, rawResponse string, maxTakeaways int) ([]string, []int) {
+	if t.strictJSON {
+		var ranked rankedTakeawaysJSON
+		if decodeJSONResponse(rawResponse, &ranked) {
+			lines := make([]string, len(ranked.Takeaways))
+			importance := make([]int, len(ranked.Takeaways))
+			for i, takeaway := range ranked.Takeaways {
+				lines[i] = takeaway.Text
+				importance[i] = clampImportance(takeaway.Importance)
+			}
+			return t.collectRankedTakeaways(lines, importance, maxTakeaways)
+		}
+
+		var plain takeawaysJSON
+		if decodeJSONResponse(rawResponse, &plain) {
+			return t.collectRankedTakeaways(plain.Takeaways, nil, maxTakeaways)
+		}
+
+		t.logger.WithFields(map[string]interface{}{
+			"agent":          t.Name(),
+			"correlation_id": requestctx.CorrelationID(ctx),
+			"response":       t.TruncateForLog(rawResponse, 200),
+		}).Warn("Response was not valid JSON, falling back to text parsing")
+	}
+
+	lines := strings.Split(strings.TrimSpace(rawResponse), "\n")
+	importance := make([]int, len(lines))
+	for i, line := range lines {
+		lines[i], importance[i] = splitImportance(t.removeListMarkers(strings.TrimSpace(line)))
+	}
+	return t.collectRankedTakeaways(lines, importance, maxTakeaways)
+}
+
+// collectRankedTakeaways cleans candidate lines like collectTakeaways, keeping each kept
+// line's importance alongside it. A nil importance leaves every takeaway unrated.
+func (t *TakeawayExtractorAgent) collectRankedTakeaways(lines []string, importance []int, maxTakeaways int) ([]string, []int) {
+	var takeaways []string
+	var scores []int
+
+	for i, line := range lines {
+		processedLine := t.processTakeawayLine(line)
+		if processedLine == "" {
+			continue
+		}
+		takeaways = append(takeaways, processedLine)
+		if i < len(importance) {
+			scores = append(scores, importance[i])
+		} else {
+			scores = append(scores, 0)
+		}
+	}
+
+	if len(takeaways) > maxTakeaways {
+		t.logger.WithFields(map[string]interface{}{
+			"agent":           t.Name(),
+			"original_count":  len(takeaways),
+			"truncated_count": maxTakeaways,
+		}).Warn("Truncated takeaways list to maximum count")
+		takeaways = takeaways[:maxTakeaways]
+		scores = scores[:maxTakeaways]
+	}
+
+	return takeaways, scores
+}
@@ -0,0 +1,133 @@
+package agents
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitImportance(t *testing.T) {
+	tests := []struct {
+		line       string
+		text       string
+		importance int
+	}{
+		{"[4] Sleep matters more than most people think", "Sleep matters more than most people think", 4},
+		{"(2) Exercise improves focus", "Exercise improves focus", 2},
+		{"[5/5] Rest is essential", "Rest is essential", 5},
+		{"[9] Ratings above the scale are capped", "Ratings above the scale are capped", 5},
+		{"No rating on this takeaway", "No rating on this takeaway", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			text, importance := splitImportance(tt.line)
+			assert.Equal(t, tt.text, text)
+			assert.Equal(t, tt.importance, importance)
+		})
+	}
+}
+
+func TestTakeawayExtractorAgent_RankImportance(t *testing.T) {
+	content := strings.Repeat("This is a long enough podcast content for testing purposes. ", 10)
+
+	tests := []struct {
+		name       string
+		strictJSON bool
+		response   string
+		expected   []string
+		importance []int
+	}{
+		{
+			name:       "text response",
+			response:   "KEY TAKEAWAYS:\n1. [5] Sleep matters more than most people think\n2. [2] Exercise improves focus, per the guest\n3. Hydration helps with concentration",
+			expected:   []string{"Sleep matters more than most people think.", "Exercise improves focus, per the guest.", "Hydration helps with concentration."},
+			importance: []int{5, 2, 0},
+		},
+		{
+			name:       "json response",
+			strictJSON: true,
+			response:   `{"takeaways": [{"text": "sleep matters more than most people think", "importance": 4}, {"text": "short", "importance": 5}, {"text": "Exercise improves focus, per the guest", "importance": 1}]}`,
+			expected:   []string{"Sleep matters more than most people think.", "Exercise improves focus, per the guest."},
+			importance: []int{4, 1},
+		},
+		{
+			name:       "plain json list is kept unrated",
+			strictJSON: true,
+			response:   `{"takeaways": ["Sleep matters more than most people think"]}`,
+			expected:   []string{"Sleep matters more than most people think."},
+			importance: []int{0},
+		},
+		{
+			name:       "malformed json falls back to the text parser",
+			strictJSON: true,
+			response:   "1. [3] Sleep matters more than most people think",
+			expected:   []string{"Sleep matters more than most people think."},
+			importance: []int{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockAnthropicClient{}
+			agent := &TakeawayExtractorAgent{
+				BaseAgent:       NewBaseAgent("takeaway_extractor"),
+				anthropicClient: mockClient,
+				strictJSON:      tt.strictJSON,
+				rankImportance:  true,
+			}
+
+			instructions := takeawayImportanceInstructions
+			if tt.strictJSON {
+				instructions = rankedTakeawaysJSONSchema
+			}
+			mockClient.On("CallClaude",
+				mock.Anything,
+				"takeaway_extractor",
+				mock.AnythingOfType("string"),
+				mock.MatchedBy(func(system string) bool { return strings.Contains(system, instructions) }),
+				false,
+			).Return(tt.response, nil)
+
+			result, err := agent.Process(context.Background(), content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Takeaways)
+			assert.Equal(t, tt.importance, result.TakeawayImportance)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTakeawayExtractorAgent_RankImportance_KeepsScoresAlignedWhenTruncated(t *testing.T) {
+	agent := &TakeawayExtractorAgent{BaseAgent: NewBaseAgent("takeaway_extractor"), rankImportance: true}
+
+	takeaways, importance := agent.parseRankedResponse(context.Background(),
+		"1. [1] First takeaway with enough words\n2. ok\n3. [4] Second takeaway with enough words\n4. [5] Third takeaway with enough words", 2)
+
+	assert.Equal(t, []string{"First takeaway with enough words.", "Second takeaway with enough words."}, takeaways)
+	assert.Equal(t, []int{1, 4}, importance)
+}
+
+func TestTakeawayExtractorAgent_NotRanked(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &TakeawayExtractorAgent{
+		BaseAgent:       NewBaseAgent("takeaway_extractor"),
+		anthropicClient: mockClient,
+	}
+
+	mockClient.On("CallClaude",
+		mock.Anything,
+		"takeaway_extractor",
+		mock.AnythingOfType("string"),
+		mock.MatchedBy(func(system string) bool { return !strings.Contains(system, takeawayImportanceInstructions) }),
+		false,
+	).Return("1. Sleep matters more than most people think", nil)
+
+	result, err := agent.Process(context.Background(), strings.Repeat("This is a long enough podcast content for testing purposes. ", 10))
+	require.NoError(t, err)
+	assert.Nil(t, result.TakeawayImportance)
+}
@@ -0,0 +1,126 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"podcast-analyzer/internal/clients"
+	"podcast-analyzer/internal/config"
+)
+
+// TakeawaySynthesizerAgent turns extracted takeaways into a single narrative paragraph
+// explaining why they matter together. It reads only the takeaways, not the transcript, so
+// it costs one short call.
+type TakeawaySynthesizerAgent struct {
+	*BaseAgent
+	anthropicClient clients.AnthropicClientInterface
+	prompts         *PromptTemplates
+}
+
+// whitespacePattern matches runs of whitespace, including line breaks
+var whitespacePattern = regexp.MustCompile(`\s+`)
+
+// NewTakeawaySynthesizerAgent creates a new takeaway synthesizer agent
+func NewTakeawaySynthesizerAgent(cfg *config.Config) *TakeawaySynthesizerAgent {
+	return &TakeawaySynthesizerAgent{
+		BaseAgent:       NewBaseAgent("takeaway_synthesizer"),
+		anthropicClient: clients.NewAnthropicClient(cfg),
+		prompts:         promptTemplatesFor(cfg),
+	}
+}
+
+// FormatTakeawayList numbers takeaways one per line, the content the synthesizer expects
+func FormatTakeawayList(takeaways []string) string {
+	var builder strings.Builder
+	for i, takeaway := range takeaways {
+		fmt.Fprintf(&builder, "%d. %s\n", i+1, takeaway)
+	}
+	return builder.String()
+}
+
+// Process synthesizes a numbered takeaway list into one paragraph
+func (a *TakeawaySynthesizerAgent) Process(ctx context.Context, content string) (Result, error) {
+	return a.ProcessWithOptions(ctx, content, ProcessingOptions{})
+}
+
+// ProcessWithOptions synthesizes a numbered takeaway list, using the summary as context
+// when one is given. The paragraph is returned in Result.Summary.
+func (a *TakeawaySynthesizerAgent) ProcessWithOptions(ctx context.Context, content string, opts ProcessingOptions) (Result, error) {
+	start := time.Now()
+	defer func() {
+		a.LogAPICall(ctx, "anthropic", len(content), true)
+	}()
+
+	a.LogStart(ctx, len(content))
+
+	if strings.TrimSpace(content) == "" {
+		err := NewAgentError(a.Name(), "no takeaways to synthesize", nil)
+		a.LogError(ctx, err, time.Since(start))
+		return Result{}, err
+	}
+
+	systemPrompt := appendInstructions(a.buildSystemPrompt(), opts.Instructions)
+	userPrompt := a.buildUserPrompt(content, opts.Summary)
+
+	rawParagraph, err := a.anthropicClient.CallClaude(ctx, a.Name(), userPrompt, systemPrompt, false)
+	if err != nil {
+		a.LogError(ctx, err, time.Since(start))
+		return Result{}, NewAgentError(a.Name(), "failed to synthesize takeaways", err)
+	}
+
+	paragraph := a.cleanParagraph(rawParagraph)
+	if paragraph == "" {
+		err := NewAgentError(a.Name(), "takeaway synthesis is empty", nil)
+		a.LogError(ctx, err, time.Since(start))
+		return Result{}, err
+	}
+
+	result := Result{Summary: paragraph}
+	a.LogSuccess(ctx, &result, time.Since(start))
+	return result, nil
+}
+
+// buildSystemPrompt creates the system prompt for Claude
+func (a *TakeawaySynthesizerAgent) buildSystemPrompt() string {
+	if prompt, ok := a.prompts.render(a.Name(), "system", PromptData{}); ok {
+		return prompt
+	}
+
+	return `You are an expert analyst who explains what a podcast's key points add up to.
+
+Given a list of takeaways from an episode, write a single paragraph of 3-5 sentences that:
+- Connects the takeaways into one narrative instead of repeating them as a list
+- Explains the "so what": why the ideas matter together and what a listener should do or think differently
+- Introduces no facts that are not in the takeaways
+
+Respond with the paragraph only, without a heading, bullet points or line breaks.`
+}
+
+// buildUserPrompt creates the user prompt from the takeaway list and optional summary
+func (a *TakeawaySynthesizerAgent) buildUserPrompt(takeaways, summary string) string {
+	if prompt, ok := a.prompts.render(a.Name(), "user", PromptData{Content: takeaways, Summary: summary}); ok {
+		return prompt
+	}
+
+	var prompt strings.Builder
+	if summary != "" {
+		fmt.Fprintf(&prompt, "EPISODE SUMMARY:\n%s\n\n", summary)
+	}
+	fmt.Fprintf(&prompt, "TAKEAWAYS:\n%s\nSYNTHESIS:", takeaways)
+	return prompt.String()
+}
+
+// cleanParagraph strips a leading label and joins the response into one paragraph
+func (a *TakeawaySynthesizerAgent) cleanParagraph(raw string) string {
+	paragraph := strings.TrimSpace(raw)
+	for _, prefix := range []string{"SYNTHESIS:", "Synthesis:", "Summary:"} {
+		if strings.HasPrefix(paragraph, prefix) {
+			paragraph = strings.TrimSpace(paragraph[len(prefix):])
+			break
+		}
+	}
+	return whitespacePattern.ReplaceAllString(paragraph, " ")
+}
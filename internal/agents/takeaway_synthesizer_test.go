@@ -0,0 +1,99 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"podcast-analyzer/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewTakeawaySynthesizerAgent(t *testing.T) {
+	cfg := &config.Config{
+		AnthropicAPIKey: "test-key",
+	}
+
+	agent := NewTakeawaySynthesizerAgent(cfg)
+
+	assert.NotNil(t, agent)
+	assert.Equal(t, "takeaway_synthesizer", agent.Name())
+	assert.NotNil(t, agent.anthropicClient)
+}
+
+func TestFormatTakeawayList(t *testing.T) {
+	assert.Equal(t, "1. First point\n2. Second point\n", FormatTakeawayList([]string{"First point", "Second point"}))
+	assert.Equal(t, "", FormatTakeawayList(nil))
+}
+
+func TestTakeawaySynthesizerAgent_ProcessWithOptions_Success(t *testing.T) {
+	mockClient := &MockAnthropicClient{}
+	agent := &TakeawaySynthesizerAgent{
+		BaseAgent:       NewBaseAgent("takeaway_synthesizer"),
+		anthropicClient: mockClient,
+	}
+
+	ctx := context.Background()
+	content := FormatTakeawayList([]string{"Remote teams need written decisions", "Async updates cut meeting load"})
+	var userPrompt string
+	mockClient.On("CallClaude",
+		ctx,
+		"takeaway_synthesizer",
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("string"),
+		false,
+	).Run(func(args mock.Arguments) {
+		userPrompt = args.String(2)
+	}).Return("Synthesis: Writing things down is what makes remote work scale.\n\nIt frees time for deep work.", nil)
+
+	result, err := agent.ProcessWithOptions(ctx, content, ProcessingOptions{Summary: "An episode about remote work."})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Writing things down is what makes remote work scale. It frees time for deep work.", result.Summary)
+	assert.Empty(t, result.Takeaways)
+	assert.Contains(t, userPrompt, "EPISODE SUMMARY:\nAn episode about remote work.")
+	assert.Contains(t, userPrompt, "1. Remote teams need written decisions")
+	mockClient.AssertExpectations(t)
+}
+
+func TestTakeawaySynthesizerAgent_ProcessWithOptions_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		response string
+		err      error
+		expected string
+	}{
+		{name: "no takeaways", content: "  ", expected: "no takeaways to synthesize"},
+		{name: "API error", content: "1. A point\n", err: errors.New("API error"), expected: "failed to synthesize takeaways"},
+		{name: "empty response", content: "1. A point\n", response: " \n ", expected: "takeaway synthesis is empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &MockAnthropicClient{}
+			agent := &TakeawaySynthesizerAgent{
+				BaseAgent:       NewBaseAgent("takeaway_synthesizer"),
+				anthropicClient: mockClient,
+			}
+			mockClient.On("CallClaude", mock.Anything, mock.Anything, mock.Anything, mock.Anything, false).Return(tt.response, tt.err).Maybe()
+
+			result, err := agent.Process(context.Background(), tt.content)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+			assert.Empty(t, result.Summary)
+		})
+	}
+}
+
+func TestTakeawaySynthesizerAgent_buildUserPrompt(t *testing.T) {
+	agent := &TakeawaySynthesizerAgent{BaseAgent: NewBaseAgent("takeaway_synthesizer")}
+
+	prompt := agent.buildUserPrompt("1. A point\n", "")
+
+	assert.Equal(t, "TAKEAWAYS:\n1. A point\n\nSYNTHESIS:", prompt)
+	assert.NotContains(t, prompt, "EPISODE SUMMARY")
+}
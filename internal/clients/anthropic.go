@@ -4,14 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 	
 	"podcast-analyzer/internal/config"
 	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/metrics"
+	"podcast-analyzer/internal/requestctx"
 	
 	"github.com/sirupsen/logrus"
 )
@@ -23,10 +27,21 @@ type AnthropicClientInterface interface {
 
 // AnthropicClient handles communication with the Anthropic API
 type AnthropicClient struct {
-	apiKey     string
+	keys       *APIKeyPool // Keys rotated across calls; a rate-limited key is skipped while others are ready
 	model      string
+	fallbackModels []string // Tried in order when the model is overloaded or unavailable
+	maxTokens  int // Output token budget of a call's first attempt
+	tokenCap   int // Largest budget a call cut off at max_tokens is retried with
+	maxRetries int // Retries of a failed request before giving up on its model
 	baseURL    string
+	version    string   // anthropic-version header
+	betas      []string // anthropic-beta flags sent with every call
+	webSearchBetas []string // anthropic-beta flags added to calls using the web search tool
 	httpClient *http.Client
+	timeout    time.Duration // Deadline for a whole call, including retries, when the context has none
+	breaker    *CircuitBreaker
+	queue      *LLMQueue // Shared limit on concurrent calls; nil when unlimited
+	prompts    *PromptLogger
 	logger     *logrus.Logger
 }
 
@@ -59,6 +74,7 @@ type AnthropicResponse struct {
 	Role    string              `json:"role"`
 	Content []AnthropicContent  `json:"content"`
 	Model   string              `json:"model"`
+	StopReason string           `json:"stop_reason"` // Why generation ended, e.g. "end_turn" or "max_tokens"
 	Usage   AnthropicUsage      `json:"usage"`
 }
 
@@ -84,16 +100,73 @@ func (e *AnthropicError) Error() string {
 	return fmt.Sprintf("anthropic API error (%s): %s", e.Type, e.Message)
 }
 
+// anthropicMaxRetries is how many times a transient failure is retried per request
+const anthropicMaxRetries = 3
+
+// statusOverloaded is the status Anthropic returns when a model is temporarily overloaded
+const statusOverloaded = 529
+
+// defaultAnthropicTimeout applies when no timeout is configured
+const defaultAnthropicTimeout = 120 * time.Second
+
+// ErrModelOverloaded marks a call that kept failing because the model was overloaded (529)
+// or unavailable (503), which another model may still be able to serve
+var ErrModelOverloaded = errors.New("model overloaded or unavailable")
+
+// Header values used when none are configured
+const (
+	defaultAnthropicVersion       = "2023-06-01"
+	defaultAnthropicWebSearchBeta = "web-search-2025-03-05"
+)
+
 // NewAnthropicClient creates a new Anthropic API client
 func NewAnthropicClient(cfg *config.Config) *AnthropicClient {
+	timeout := cfg.AnthropicTimeout
+	if timeout <= 0 {
+		timeout = defaultAnthropicTimeout
+	}
+
+	keys := cfg.AnthropicAPIKeys
+	if len(keys) == 0 {
+		keys = []string{cfg.AnthropicAPIKey}
+	}
+
+	maxTokens := cfg.AnthropicMaxTokens
+	if maxTokens <= 0 {
+		maxTokens = defaultAnthropicMaxTokens
+	}
+	maxTokensCap := cfg.AnthropicMaxTokensCap
+	if maxTokensCap < maxTokens {
+		maxTokensCap = maxTokens
+	}
+	version := cfg.AnthropicVersion
+	if version == "" {
+		version = defaultAnthropicVersion
+	}
+	// nil means the config was built without Load; an empty slice disables the beta
+	webSearchBetas := cfg.AnthropicWebSearchBetas
+	if webSearchBetas == nil {
+		webSearchBetas = []string{defaultAnthropicWebSearchBeta}
+	}
+
 	return &AnthropicClient{
-		apiKey:  cfg.AnthropicAPIKey,
-		model:   cfg.ClaudeModel,
-		baseURL: "https://api.anthropic.com/v1/messages",
-		httpClient: &http.Client{
-			Timeout: 120 * time.Second, // 2 minute timeout for AI calls
-		},
-		logger: logger.Log,
+		keys:      getAPIKeyPool("anthropic", keys),
+		model:     cfg.ClaudeModel,
+		fallbackModels: fallbackModelChain(cfg.ClaudeModel, cfg.ClaudeFallbackModels),
+		maxTokens: maxTokens,
+		tokenCap:  maxTokensCap,
+		maxRetries: anthropicMaxRetries,
+		baseURL:   "https://api.anthropic.com/v1/messages",
+		version:   version,
+		betas:     cfg.AnthropicBetas,
+		webSearchBetas: webSearchBetas,
+		// No client-wide timeout so a caller's context deadline can be longer than the default
+		httpClient: &http.Client{Transport: getAPITransport(cfg)},
+		timeout:    timeout,
+		breaker:    getCircuitBreaker("anthropic", cfg),
+		queue:      getLLMQueue(cfg),
+		prompts:    NewPromptLogger(cfg),
+		logger:     logger.Log,
 	}
 }
 
@@ -101,44 +174,105 @@ func NewAnthropicClient(cfg *config.Config) *AnthropicClient {
 func (c *AnthropicClient) CallClaude(ctx context.Context, agentName, prompt, systemPrompt string, useWebSearch bool) (string, error) {
 	start := time.Now()
 	
-	// Prepare the request
+	// Apply the client timeout unless the caller already set a deadline
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	
+	// Prepare the request, with any model pinned on the context
 	request := c.buildAnthropicRequest(prompt, systemPrompt, useWebSearch)
+	request.Model = modelFromContext(ctx, c.model)
 	
 	// Log the API call
-	correlationID := getCorrelationIDFromContext(ctx)
+	correlationID := requestctx.CorrelationID(ctx)
 	c.logger.WithFields(map[string]interface{}{
 		"agent":          agentName,
 		"correlation_id": correlationID,
-		"model":          c.model,
+		"model":          request.Model,
 		"prompt_length":  len(prompt),
 		"has_system":     systemPrompt != "",
 		"use_web_search": useWebSearch,
 	}).Info("Making Anthropic API call")
 	
-	// Marshal the request
-	requestBody, err := json.Marshal(request)
+	// Wait for a slot in the shared queue; batch work yields to interactive calls. The slot
+	// is taken before the breaker is consulted, so a call cancelled while queued never holds
+	// the half-open probe.
+	release, err := c.queue.Acquire(ctx, llmPriorityFromContext(ctx))
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal request: %w", err)
+		return "", fmt.Errorf("waiting for an Anthropic call slot: %w", err)
 	}
+	defer release()
 	
-	// Create HTTP request
-	httpReq, err := c.prepareHTTPRequest(ctx, requestBody, useWebSearch)
-	if err != nil {
-		return "", err
+	// A pinned model must be reproduced exactly, so it never falls back
+	fallbacks := c.fallbackModels
+	if modelPinned(ctx) {
+		fallbacks = nil
 	}
 	
-	// Make the request with retry logic
-	response, err := c.makeRequestWithRetry(ctx, httpReq, agentName, 3)
-	if err != nil {
-		return "", err
+	// Make the request, moving down the fallback chain while the model is overloaded and
+	// retrying with a larger output budget while the response is cut off
+	var responseText string
+	var anthropicResp *AnthropicResponse
+	for {
+		// Fail fast while the provider's circuit breaker is open, checking before every
+		// request since an earlier one may have opened it
+		if err := c.breaker.Allow(); err != nil {
+			c.logger.WithFields(map[string]interface{}{
+				"agent":          agentName,
+				"correlation_id": correlationID,
+				"model":          request.Model,
+				"error":          err.Error(),
+			}).Warn("Anthropic call short-circuited")
+			return "", err
+		}
+		
+		responseText, anthropicResp, err = c.sendRequest(ctx, request, agentName, useWebSearch, len(fallbacks) > 0)
+		if errors.Is(err, ErrModelOverloaded) && len(fallbacks) > 0 {
+			c.logger.WithFields(map[string]interface{}{
+				"agent":          agentName,
+				"correlation_id": correlationID,
+				"model":          request.Model,
+				"fallback_model": fallbacks[0],
+			}).Warn("Anthropic model overloaded, falling back to the next model")
+			metrics.AddCounter("anthropic_model_fallbacks", 1)
+			request.Model, fallbacks = fallbacks[0], fallbacks[1:]
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if anthropicResp.StopReason != stopReasonMaxTokens {
+			break
+		}
+		
+		nextBudget, ok := nextMaxTokens(request.MaxTokens, c.tokenCap)
+		if !ok {
+			// Keep the partial text, but flag it so the analysis is marked truncated
+			recordTruncation(ctx, agentName)
+			c.logger.WithFields(map[string]interface{}{
+				"agent":          agentName,
+				"correlation_id": correlationID,
+				"max_tokens":     request.MaxTokens,
+			}).Warn("Anthropic response truncated at the output token cap")
+			break
+		}
+		c.logger.WithFields(map[string]interface{}{
+			"agent":           agentName,
+			"correlation_id":  correlationID,
+			"max_tokens":      request.MaxTokens,
+			"next_max_tokens": nextBudget,
+		}).Warn("Anthropic response hit max_tokens, retrying with a larger budget")
+		request.MaxTokens = nextBudget
 	}
-	defer response.Body.Close()
 	
-	// Parse the response
-	responseText, anthropicResp, err := c.parseAnthropicResponse(response)
-	if err != nil {
-		return "", err
+	// Record the exact version the request's model resolved to
+	resolvedModel := anthropicResp.Model
+	if resolvedModel == "" {
+		resolvedModel = request.Model
 	}
+	recordModel(ctx, resolvedModel)
 	
 	// Log successful response
 	duration := time.Since(start)
@@ -147,13 +281,49 @@ func (c *AnthropicClient) CallClaude(ctx context.Context, agentName, prompt, sys
 		"correlation_id":  correlationID,
 		"duration_ms":     duration.Milliseconds(),
 		"response_length": len(responseText),
+		"model":           resolvedModel,
 		"input_tokens":    anthropicResp.Usage.InputTokens,
 		"output_tokens":   anthropicResp.Usage.OutputTokens,
+		"stop_reason":     anthropicResp.StopReason,
 	}).Info("Anthropic API response received")
+	c.prompts.LogLLMExchange(ctx, agentName, systemPrompt, prompt, responseText)
 	
 	return responseText, nil
 }
 
+// sendRequest sends one request, retrying transient failures, and parses the response. While
+// a fallback model remains, an overloaded model is not counted against the breaker: the
+// overload is the model's, and counting it would open the breaker on the whole provider
+// before the chain reaches a model that can serve the call.
+func (c *AnthropicClient) sendRequest(ctx context.Context, request AnthropicRequest, agentName string, useWebSearch, hasFallback bool) (string, *AnthropicResponse, error) {
+	// Failures before the request is sent say nothing about the provider
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		c.breaker.releaseProbe()
+		return "", nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	
+	httpReq, err := c.prepareHTTPRequest(ctx, requestBody, useWebSearch)
+	if err != nil {
+		c.breaker.releaseProbe()
+		return "", nil, err
+	}
+	
+	response, err := c.makeRequestWithRetry(ctx, httpReq, agentName, c.maxRetries)
+	if errors.Is(err, ErrModelOverloaded) && hasFallback {
+		c.breaker.releaseProbe()
+		return "", nil, err
+	}
+	if err != nil {
+		c.breaker.RecordResult(ctx, 0, err)
+		return "", nil, err
+	}
+	defer response.Body.Close()
+	c.breaker.RecordResult(ctx, response.StatusCode, nil)
+	
+	return c.parseAnthropicResponse(response)
+}
+
 // makeRequestWithRetry makes an HTTP request with retry logic for retryable errors
 func (c *AnthropicClient) makeRequestWithRetry(ctx context.Context, req *http.Request, agentName string, maxRetries int) (*http.Response, error) {
 	var lastErr error
@@ -203,6 +373,10 @@ func (c *AnthropicClient) makeRequestWithRetry(ctx context.Context, req *http.Re
 		// Check for retryable HTTP status codes
 		if response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests {
 			response.Body.Close()
+			if response.StatusCode == http.StatusTooManyRequests {
+				// Repeated 429s lower the shared limit on concurrent calls
+				c.queue.ReportRateLimited()
+			}
 			
 			if attempt < maxRetries {
 				waitTime := time.Duration(1<<uint(attempt)) * time.Second
@@ -213,6 +387,14 @@ func (c *AnthropicClient) makeRequestWithRetry(ctx context.Context, req *http.Re
 							waitTime = time.Duration(seconds) * time.Second
 						}
 					}
+					
+					// Rest the limited key and retry at once if another key is ready
+					if c.rotateRateLimitedKey(req, waitTime, agentName) {
+						if requestBody != nil {
+							req.Body = io.NopCloser(bytes.NewReader(requestBody))
+						}
+						continue
+					}
 				}
 				
 				c.logger.WithFields(map[string]interface{}{
@@ -236,6 +418,9 @@ func (c *AnthropicClient) makeRequestWithRetry(ctx context.Context, req *http.Re
 			}
 			
 			lastErr = fmt.Errorf("server error after retries (status %d)", response.StatusCode)
+			if response.StatusCode == statusOverloaded || response.StatusCode == http.StatusServiceUnavailable {
+				lastErr = fmt.Errorf("server error after retries (status %d): %w", response.StatusCode, ErrModelOverloaded)
+			}
 			continue
 		}
 		
@@ -246,11 +431,48 @@ func (c *AnthropicClient) makeRequestWithRetry(ctx context.Context, req *http.Re
 	return nil, lastErr
 }
 
+// fallbackModelChain returns the configured fallback models in order, skipping blanks,
+// repeats and the primary model itself
+func fallbackModelChain(primary string, fallbacks []string) []string {
+	var chain []string
+	seen := map[string]bool{primary: true}
+	for _, model := range fallbacks {
+		if model != "" && !seen[model] {
+			seen[model] = true
+			chain = append(chain, model)
+		}
+	}
+	return chain
+}
+
+// rotateRateLimitedKey marks the request's key as rate limited for retryAfter and switches
+// the request to the next key. It reports whether that key is ready for an immediate retry.
+func (c *AnthropicClient) rotateRateLimitedKey(req *http.Request, retryAfter time.Duration, agentName string) bool {
+	if c.keys.Len() < 2 {
+		return false
+	}
+	
+	limited := req.Header.Get("x-api-key")
+	c.keys.MarkRateLimited(limited, retryAfter)
+	next, ready := c.keys.Pick()
+	req.Header.Set("x-api-key", next)
+	if !ready || next == limited {
+		return false
+	}
+	
+	c.logger.WithFields(map[string]interface{}{
+		"agent":       agentName,
+		"limited_key": c.keys.Label(limited),
+		"next_key":    c.keys.Label(next),
+	}).Warn("API key rate limited, retrying with another key")
+	return true
+}
+
 // buildAnthropicRequest constructs the request payload for the Anthropic API
 func (c *AnthropicClient) buildAnthropicRequest(prompt, systemPrompt string, useWebSearch bool) AnthropicRequest {
 	request := AnthropicRequest{
 		Model:       c.model,
-		MaxTokens:   4000,
+		MaxTokens:   c.maxTokens,
 		Temperature: 0.1,
 		Messages: []AnthropicMessage{
 			{
@@ -287,12 +509,17 @@ func (c *AnthropicClient) prepareHTTPRequest(ctx context.Context, requestBody []
 	
 	// Set headers
 	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("x-api-key", c.apiKey)
-	httpReq.Header.Set("anthropic-version", "2023-06-01")
+	key, _ := c.keys.Pick()
+	httpReq.Header.Set("x-api-key", key)
+	httpReq.Header.Set("anthropic-version", c.version)
 	
-	// Add web search beta header if needed
+	// Beta flags go in one comma-separated header; web search needs its own on top
+	betas := c.betas
 	if useWebSearch {
-		httpReq.Header.Set("anthropic-beta", "web-search-2025-03-05")
+		betas = append(append([]string{}, betas...), c.webSearchBetas...)
+	}
+	if len(betas) > 0 {
+		httpReq.Header.Set("anthropic-beta", strings.Join(betas, ","))
 	}
 	
 	return httpReq, nil
@@ -335,7 +562,7 @@ func (c *AnthropicClient) parseAnthropicResponse(response *http.Response) (strin
 		return "", nil, fmt.Errorf("empty response content")
 	}
 	
-	responseText := anthropicResp.Content[0].Text
+	responseText := extractResponseText(anthropicResp.Content)
 	if responseText == "" {
 		return "", nil, fmt.Errorf("empty response text")
 	}
@@ -343,12 +570,14 @@ func (c *AnthropicClient) parseAnthropicResponse(response *http.Response) (strin
 	return responseText, &anthropicResp, nil
 }
 
-// getCorrelationIDFromContext extracts correlation ID from context
-func getCorrelationIDFromContext(ctx context.Context) string {
-	if id := ctx.Value("correlation_id"); id != nil {
-		if correlationID, ok := id.(string); ok {
-			return correlationID
+// extractResponseText concatenates all text blocks, skipping tool-use and thinking blocks
+// that web search responses interleave with the answer
+func extractResponseText(content []AnthropicContent) string {
+	var builder strings.Builder
+	for _, block := range content {
+		if block.Type == "text" {
+			builder.WriteString(block.Text)
 		}
 	}
-	return ""
+	return builder.String()
 }
\ No newline at end of file
@@ -0,0 +1,192 @@
+package clients
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"podcast-analyzer/internal/metrics"
+)
+
+// APIKeyPool spreads a provider's calls across several API keys. Keys are used in turn,
+// skipping any that were recently rate limited so their quota can recover.
+type APIKeyPool struct {
+	provider string
+
+	mu   sync.Mutex
+	keys []*apiKeyState
+	next int // Index of the key to try first on the next pick
+	now  func() time.Time
+}
+
+// apiKeyState tracks the health of one key
+type apiKeyState struct {
+	key          string
+	label        string // Safe identifier for logs and metrics, since the key itself is a secret
+	requests     int64
+	rateLimits   int64
+	limitedUntil time.Time
+}
+
+// APIKeyHealth is a snapshot of one key's health
+type APIKeyHealth struct {
+	Label        string
+	Requests     int64
+	RateLimits   int64
+	LimitedUntil time.Time // Zero unless the key was rate limited
+}
+
+// apiKeyPools holds one pool per provider, shared by all client instances
+var (
+	apiKeyPoolsMu sync.Mutex
+	apiKeyPools   = make(map[string]*APIKeyPool)
+
+	apiKeyHealthCollector sync.Once
+)
+
+// NewAPIKeyPool creates a pool over the given keys, dropping empty and duplicate ones
+func NewAPIKeyPool(provider string, keys []string) *APIKeyPool {
+	p := &APIKeyPool{provider: provider, now: time.Now}
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		p.keys = append(p.keys, &apiKeyState{key: key, label: fmt.Sprintf("key%d", len(p.keys)+1)})
+	}
+	return p
+}
+
+// getAPIKeyPool returns the shared pool for a provider, replacing it when the configured
+// keys have changed
+func getAPIKeyPool(provider string, keys []string) *APIKeyPool {
+	// Registered before taking the pools lock, which the collector takes too
+	apiKeyHealthCollector.Do(func() {
+		metrics.RegisterCollector(publishAPIKeyHealth)
+	})
+
+	apiKeyPoolsMu.Lock()
+	defer apiKeyPoolsMu.Unlock()
+
+	candidate := NewAPIKeyPool(provider, keys)
+	if p, ok := apiKeyPools[provider]; ok && p.sameKeys(candidate) {
+		return p
+	}
+	apiKeyPools[provider] = candidate
+	return candidate
+}
+
+// sameKeys reports whether two pools hold the same keys in the same order
+func (p *APIKeyPool) sameKeys(other *APIKeyPool) bool {
+	if len(p.keys) != len(other.keys) {
+		return false
+	}
+	for i := range p.keys {
+		if p.keys[i].key != other.keys[i].key {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of keys in the pool
+func (p *APIKeyPool) Len() int {
+	return len(p.keys)
+}
+
+// Pick returns the next key in turn that is not rate limited. When every key is rate
+// limited it returns the one whose limit ends first, with ready false. An empty pool
+// returns "".
+func (p *APIKeyPool) Pick() (key string, ready bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.keys) == 0 {
+		return "", false
+	}
+
+	now := p.now()
+	chosen := -1
+	for i := 0; i < len(p.keys); i++ {
+		index := (p.next + i) % len(p.keys)
+		if !now.Before(p.keys[index].limitedUntil) {
+			chosen = index
+			ready = true
+			break
+		}
+		if chosen < 0 || p.keys[index].limitedUntil.Before(p.keys[chosen].limitedUntil) {
+			chosen = index
+		}
+	}
+
+	state := p.keys[chosen]
+	state.requests++
+	p.next = (chosen + 1) % len(p.keys)
+	metrics.AddCounter("api_key_requests_"+p.provider+"_"+state.label, 1)
+	return state.key, ready
+}
+
+// MarkRateLimited records a 429 for a key, keeping it out of rotation for retryAfter
+func (p *APIKeyPool) MarkRateLimited(key string, retryAfter time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, state := range p.keys {
+		if state.key == key {
+			state.rateLimits++
+			state.limitedUntil = p.now().Add(retryAfter)
+			metrics.AddCounter("api_key_rate_limits_"+p.provider+"_"+state.label, 1)
+			return
+		}
+	}
+}
+
+// Label returns the log-safe identifier of a key, or "" when the key is not in the pool
+func (p *APIKeyPool) Label(key string) string {
+	for _, state := range p.keys {
+		if state.key == key {
+			return state.label
+		}
+	}
+	return ""
+}
+
+// Health returns a snapshot of every key's health, in configured order
+func (p *APIKeyPool) Health() []APIKeyHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	health := make([]APIKeyHealth, len(p.keys))
+	for i, state := range p.keys {
+		health[i] = APIKeyHealth{
+			Label:        state.label,
+			Requests:     state.requests,
+			RateLimits:   state.rateLimits,
+			LimitedUntil: state.limitedUntil,
+		}
+	}
+	return health
+}
+
+// publishAPIKeyHealth exposes how long each key stays out of rotation after a 429 as a
+// gauge, 0 when the key is usable
+func publishAPIKeyHealth() {
+	apiKeyPoolsMu.Lock()
+	pools := make([]*APIKeyPool, 0, len(apiKeyPools))
+	for _, p := range apiKeyPools {
+		pools = append(pools, p)
+	}
+	apiKeyPoolsMu.Unlock()
+
+	for _, p := range pools {
+		now := p.now()
+		for _, health := range p.Health() {
+			limited := 0.0
+			if health.LimitedUntil.After(now) {
+				limited = health.LimitedUntil.Sub(now).Seconds()
+			}
+			metrics.SetGauge("api_key_limited_seconds_"+p.provider+"_"+health.Label, limited)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"expvar"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyPool_RoundRobin(t *testing.T) {
+	pool := NewAPIKeyPool("test", []string{"a", "", "b", "a", "c"})
+	assert.Equal(t, 3, pool.Len())
+
+	var picked []string
+	for i := 0; i < 4; i++ {
+		key, ready := pool.Pick()
+		assert.True(t, ready)
+		picked = append(picked, key)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "a"}, picked)
+}
+
+func TestAPIKeyPool_SkipsRateLimitedKeys(t *testing.T) {
+	now := time.Now()
+	pool := NewAPIKeyPool("test", []string{"a", "b"})
+	pool.now = func() time.Time { return now }
+
+	pool.MarkRateLimited("a", 30*time.Second)
+	for i := 0; i < 3; i++ {
+		key, ready := pool.Pick()
+		assert.Equal(t, "b", key)
+		assert.True(t, ready)
+	}
+
+	// With every key limited, the one that recovers first is returned but not ready
+	pool.MarkRateLimited("b", time.Minute)
+	key, ready := pool.Pick()
+	assert.Equal(t, "a", key)
+	assert.False(t, ready)
+
+	// Once its limit ends the key rejoins the rotation
+	now = now.Add(31 * time.Second)
+	key, ready = pool.Pick()
+	assert.Equal(t, "a", key)
+	assert.True(t, ready)
+
+	health := pool.Health()
+	assert.Equal(t, "key1", health[0].Label)
+	assert.Equal(t, int64(1), health[0].RateLimits)
+	assert.Equal(t, int64(2), health[0].Requests)
+	assert.Equal(t, int64(3), health[1].Requests)
+}
+
+func TestAPIKeyPool_Empty(t *testing.T) {
+	pool := NewAPIKeyPool("test", nil)
+	key, ready := pool.Pick()
+	assert.Empty(t, key)
+	assert.False(t, ready)
+	pool.MarkRateLimited("unknown", time.Second)
+	assert.Empty(t, pool.Label("unknown"))
+}
+
+func TestGetAPIKeyPool_Shared(t *testing.T) {
+	first := getAPIKeyPool("shared-test", []string{"a", "b"})
+	assert.Same(t, first, getAPIKeyPool("shared-test", []string{"a", "b"}))
+	assert.NotSame(t, first, getAPIKeyPool("shared-test", []string{"c"}))
+}
+
+func TestPublishAPIKeyHealth(t *testing.T) {
+	now := time.Now()
+	pool := getAPIKeyPool("health-test", []string{"a", "b"})
+	pool.now = func() time.Time { return now }
+	pool.MarkRateLimited("b", 30*time.Second)
+
+	publishAPIKeyHealth()
+
+	assert.Equal(t, float64(0), metrics.Value("api_key_limited_seconds_health-test_key1").(*expvar.Float).Value())
+	assert.Equal(t, float64(30), metrics.Value("api_key_limited_seconds_health-test_key2").(*expvar.Float).Value())
+}
@@ -0,0 +1,230 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/metrics"
+)
+
+// ErrCircuitOpen is returned when a provider's circuit breaker is rejecting calls
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitState represents the state of a circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// circuitStateGauge maps breaker states to numeric metric values
+var circuitStateGauge = map[CircuitState]float64{
+	CircuitClosed:   0,
+	CircuitHalfOpen: 1,
+	CircuitOpen:     2,
+}
+
+// CircuitBreaker short-circuits calls to a provider after repeated failures
+type CircuitBreaker struct {
+	provider     string
+	failureRatio float64
+	minRequests  int
+	window       time.Duration
+	cooldown     time.Duration
+
+	mu            sync.Mutex
+	state         CircuitState
+	requests      int
+	failures      int
+	windowStart   time.Time
+	openedAt      time.Time
+	probeInFlight bool
+	now           func() time.Time
+}
+
+// breakers holds one circuit breaker per provider, shared by all client instances
+var (
+	breakersMu sync.Mutex
+	breakers   = make(map[string]*CircuitBreaker)
+)
+
+// NewCircuitBreaker creates a circuit breaker for a provider
+func NewCircuitBreaker(provider string, failureRatio float64, minRequests int, window, cooldown time.Duration) *CircuitBreaker {
+	b := &CircuitBreaker{
+		provider:     provider,
+		failureRatio: failureRatio,
+		minRequests:  minRequests,
+		window:       window,
+		cooldown:     cooldown,
+		state:        CircuitClosed,
+		now:          time.Now,
+	}
+	b.windowStart = b.now()
+	b.publishState()
+	return b
+}
+
+// getCircuitBreaker returns the shared breaker for a provider, or nil when breakers are disabled
+func getCircuitBreaker(provider string, cfg *config.Config) *CircuitBreaker {
+	if cfg.CircuitBreakerFailureRatio <= 0 {
+		return nil
+	}
+
+	breakersMu.Lock()
+	defer breakersMu.Unlock()
+
+	if b, ok := breakers[provider]; ok {
+		return b
+	}
+	b := NewCircuitBreaker(provider, cfg.CircuitBreakerFailureRatio, cfg.CircuitBreakerMinRequests,
+		cfg.CircuitBreakerWindow, cfg.CircuitBreakerCooldown)
+	breakers[provider] = b
+	return b
+}
+
+// State returns the current breaker state
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+	return b.state
+}
+
+// Allow reports whether a call may proceed, returning ErrCircuitOpen when it may not
+func (b *CircuitBreaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+
+	switch b.state {
+	case CircuitOpen:
+		retryIn := b.cooldown - b.now().Sub(b.openedAt)
+		return fmt.Errorf("%w: %s unavailable, retry in %s", ErrCircuitOpen, b.provider, retryIn.Round(time.Second))
+	case CircuitHalfOpen:
+		// Only one probe at a time while half-open
+		if b.probeInFlight {
+			return fmt.Errorf("%w: %s probe in progress", ErrCircuitOpen, b.provider)
+		}
+		b.probeInFlight = true
+	}
+	return nil
+}
+
+// RecordSuccess records a successful call
+func (b *CircuitBreaker) RecordSuccess() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.transitionLocked(CircuitClosed)
+		return
+	}
+	b.resetWindowIfExpiredLocked()
+	b.requests++
+}
+
+// RecordFailure records a failed call and opens the breaker when the failure ratio is exceeded
+func (b *CircuitBreaker) RecordFailure() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.transitionLocked(CircuitOpen)
+		return
+	}
+	b.resetWindowIfExpiredLocked()
+	b.requests++
+	b.failures++
+
+	if b.requests >= b.minRequests && float64(b.failures)/float64(b.requests) >= b.failureRatio {
+		b.transitionLocked(CircuitOpen)
+	}
+}
+
+// RecordResult classifies a call outcome: transport errors, 5xx, and 429 count as provider failures,
+// caller cancellations are ignored, and anything else counts as success
+func (b *CircuitBreaker) RecordResult(ctx context.Context, statusCode int, err error) {
+	if b == nil {
+		return
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		b.releaseProbe()
+	case err != nil, statusCode >= 500, statusCode == 429:
+		b.RecordFailure()
+	default:
+		b.RecordSuccess()
+	}
+}
+
+// releaseProbe frees the half-open probe slot without recording an outcome
+func (b *CircuitBreaker) releaseProbe() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probeInFlight = false
+}
+
+// advanceLocked moves an open breaker to half-open once the cool-down has elapsed
+func (b *CircuitBreaker) advanceLocked() {
+	if b.state == CircuitOpen && b.now().Sub(b.openedAt) >= b.cooldown {
+		b.transitionLocked(CircuitHalfOpen)
+	}
+}
+
+// resetWindowIfExpiredLocked starts a new counting window when the current one has elapsed
+func (b *CircuitBreaker) resetWindowIfExpiredLocked() {
+	if b.window > 0 && b.now().Sub(b.windowStart) >= b.window {
+		b.requests = 0
+		b.failures = 0
+		b.windowStart = b.now()
+	}
+}
+
+// transitionLocked changes state, resets counters, and publishes the new state
+func (b *CircuitBreaker) transitionLocked(state CircuitState) {
+	previous := b.state
+	b.state = state
+	b.requests = 0
+	b.failures = 0
+	b.windowStart = b.now()
+	b.probeInFlight = false
+	if state == CircuitOpen {
+		b.openedAt = b.now()
+	}
+
+	logger.Log.WithFields(map[string]interface{}{
+		"provider":       b.provider,
+		"previous_state": previous,
+		"state":          state,
+	}).Warn("Circuit breaker state changed")
+	b.publishState()
+}
+
+// publishState exposes the breaker state as a metric
+func (b *CircuitBreaker) publishState() {
+	metrics.SetGauge("circuit_breaker_state_"+b.provider, circuitStateGauge[b.state])
+}
@@ -0,0 +1,243 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCircuitBreaker creates a breaker with a controllable clock
+func newTestCircuitBreaker(provider string) (*CircuitBreaker, *time.Time) {
+	now := time.Now()
+	b := NewCircuitBreaker(provider, 0.5, 4, time.Minute, 30*time.Second)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestCircuitBreaker_StaysClosedBelowMinRequests(t *testing.T) {
+	b, _ := newTestCircuitBreaker("test-min-requests")
+
+	b.RecordFailure()
+	b.RecordFailure()
+	b.RecordFailure()
+
+	assert.Equal(t, CircuitClosed, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+func TestCircuitBreaker_OpensWhenFailureRatioExceeded(t *testing.T) {
+	b, _ := newTestCircuitBreaker("test-opens")
+
+	b.RecordSuccess()
+	b.RecordSuccess()
+	b.RecordFailure()
+	b.RecordFailure()
+
+	assert.Equal(t, CircuitOpen, b.State())
+
+	err := b.Allow()
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrCircuitOpen))
+	assert.Contains(t, err.Error(), "test-opens unavailable")
+}
+
+func TestCircuitBreaker_StaysClosedBelowFailureRatio(t *testing.T) {
+	b, _ := newTestCircuitBreaker("test-below-ratio")
+
+	b.RecordSuccess()
+	b.RecordSuccess()
+	b.RecordSuccess()
+	b.RecordFailure()
+
+	assert.Equal(t, CircuitClosed, b.State())
+}
+
+func TestCircuitBreaker_WindowResetsCounts(t *testing.T) {
+	b, now := newTestCircuitBreaker("test-window")
+
+	b.RecordFailure()
+	b.RecordFailure()
+	b.RecordFailure()
+
+	// Failures from the previous window no longer count
+	*now = now.Add(2 * time.Minute)
+	b.RecordFailure()
+
+	assert.Equal(t, CircuitClosed, b.State())
+}
+
+func TestCircuitBreaker_HalfOpenProbeSuccessCloses(t *testing.T) {
+	b, now := newTestCircuitBreaker("test-half-open-success")
+	for i := 0; i < 4; i++ {
+		b.RecordFailure()
+	}
+	require.Equal(t, CircuitOpen, b.State())
+
+	*now = now.Add(31 * time.Second)
+	assert.Equal(t, CircuitHalfOpen, b.State())
+
+	// Only one probe is admitted while half-open
+	assert.NoError(t, b.Allow())
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+
+	b.RecordSuccess()
+	assert.Equal(t, CircuitClosed, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+func TestCircuitBreaker_HalfOpenProbeFailureReopens(t *testing.T) {
+	b, now := newTestCircuitBreaker("test-half-open-failure")
+	for i := 0; i < 4; i++ {
+		b.RecordFailure()
+	}
+
+	*now = now.Add(31 * time.Second)
+	require.NoError(t, b.Allow())
+
+	b.RecordFailure()
+	assert.Equal(t, CircuitOpen, b.State())
+	assert.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_RecordResult(t *testing.T) {
+	b, _ := newTestCircuitBreaker("test-record-result")
+	ctx := context.Background()
+
+	b.RecordResult(ctx, http.StatusOK, nil)
+	b.RecordResult(ctx, http.StatusBadRequest, nil)
+	assert.Equal(t, CircuitClosed, b.State())
+
+	b.RecordResult(ctx, 0, errors.New("connection refused"))
+	b.RecordResult(ctx, http.StatusServiceUnavailable, nil)
+	assert.Equal(t, CircuitOpen, b.State())
+}
+
+func TestCircuitBreaker_RecordResult_CancelledContextIgnored(t *testing.T) {
+	b, now := newTestCircuitBreaker("test-record-cancelled")
+	for i := 0; i < 4; i++ {
+		b.RecordFailure()
+	}
+	*now = now.Add(31 * time.Second)
+	require.NoError(t, b.Allow())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b.RecordResult(ctx, 0, context.Canceled)
+
+	// Probe slot is released without changing state
+	assert.Equal(t, CircuitHalfOpen, b.State())
+	assert.NoError(t, b.Allow())
+}
+
+func TestCircuitBreaker_NilIsNoop(t *testing.T) {
+	var b *CircuitBreaker
+
+	assert.NoError(t, b.Allow())
+	b.RecordSuccess()
+	b.RecordFailure()
+	b.RecordResult(context.Background(), http.StatusInternalServerError, nil)
+}
+
+func TestCircuitBreaker_PublishesStateMetric(t *testing.T) {
+	b, _ := newTestCircuitBreaker("test-metric")
+	assert.Equal(t, float64(0), metrics.Value("circuit_breaker_state_test-metric").(*expvar.Float).Value())
+
+	for i := 0; i < 4; i++ {
+		b.RecordFailure()
+	}
+	assert.Equal(t, float64(2), metrics.Value("circuit_breaker_state_test-metric").(*expvar.Float).Value())
+}
+
+func TestGetCircuitBreaker(t *testing.T) {
+	disabled := getCircuitBreaker("test-disabled", &config.Config{})
+	assert.Nil(t, disabled)
+
+	cfg := &config.Config{
+		CircuitBreakerFailureRatio: 0.5,
+		CircuitBreakerMinRequests:  5,
+		CircuitBreakerWindow:       time.Minute,
+		CircuitBreakerCooldown:     30 * time.Second,
+	}
+	first := getCircuitBreaker("test-shared", cfg)
+	second := getCircuitBreaker("test-shared", cfg)
+	assert.NotNil(t, first)
+	assert.Same(t, first, second)
+}
+
+func TestAnthropicClient_CallClaude_CircuitOpen(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := setupTestAnthropicClient()
+	client.baseURL = server.URL
+	client.breaker = NewCircuitBreaker("test-anthropic-open", 0.5, 1, time.Minute, time.Minute)
+	client.breaker.RecordFailure()
+
+	_, err := client.CallClaude(context.Background(), "test-agent", "Test prompt", "", false)
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 0, requests)
+}
+
+func TestAnthropicClient_CallClaude_CancelledWhileQueuedKeepsProbeFree(t *testing.T) {
+	client, _ := setupTestAnthropicClient()
+	client.queue = NewLLMQueue(1, 0)
+	breaker, now := newTestCircuitBreaker("test-anthropic-queued-probe")
+	client.breaker = breaker
+	for i := 0; i < 4; i++ {
+		breaker.RecordFailure()
+	}
+	*now = now.Add(31 * time.Second)
+	require.Equal(t, CircuitHalfOpen, client.breaker.State())
+
+	// Hold the only slot so the call waits in the queue
+	release, err := client.queue.Acquire(context.Background(), LLMPriorityInteractive)
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.CallClaude(ctx, "test-agent", "Test prompt", "", false)
+		done <- err
+	}()
+	waitForWaiting(t, client.queue, 1)
+	cancel()
+
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, CircuitHalfOpen, client.breaker.State())
+	assert.NoError(t, client.breaker.Allow())
+}
+
+func TestSerperClient_Search_CircuitOpen(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, _ := setupTestSerperClient()
+	client.baseURL = server.URL
+	client.breaker = NewCircuitBreaker("test-serper-open", 0.5, 1, time.Minute, time.Minute)
+	client.breaker.RecordFailure()
+
+	_, err := client.Search(context.Background(), "test-agent", "test query", 5)
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 0, requests)
+}
@@ -0,0 +1,55 @@
+package clients
+
+import (
+	"net/url"
+	"strings"
+)
+
+// DomainBlocklist matches URLs on domains that must not count as fact-check evidence. A
+// listed domain also blocks its subdomains.
+type DomainBlocklist []string
+
+// NewDomainBlocklist normalizes configured domains, dropping empty entries
+func NewDomainBlocklist(domains []string) DomainBlocklist {
+	var blocklist DomainBlocklist
+	for _, domain := range domains {
+		domain = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "www.")
+		domain = strings.Trim(domain, ".")
+		if domain != "" {
+			blocklist = append(blocklist, domain)
+		}
+	}
+	return blocklist
+}
+
+// Blocks reports whether a URL's host is a blocked domain or one of its subdomains
+func (b DomainBlocklist) Blocks(rawURL string) bool {
+	if len(b) == 0 || rawURL == "" {
+		return false
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	for _, domain := range b {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the URLs that are not blocked, in their original order
+func (b DomainBlocklist) Filter(urls []string) []string {
+	if len(b) == 0 {
+		return urls
+	}
+	allowed := make([]string, 0, len(urls))
+	for _, u := range urls {
+		if !b.Blocks(u) {
+			allowed = append(allowed, u)
+		}
+	}
+	return allowed
+}
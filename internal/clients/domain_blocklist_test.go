@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDomainBlocklist_Blocks(t *testing.T) {
+	blocklist := NewDomainBlocklist([]string{" ContentFarm.example ", "www.spam.example", "", "."})
+	assert.Equal(t, DomainBlocklist{"contentfarm.example", "spam.example"}, blocklist)
+
+	tests := []struct {
+		url     string
+		blocked bool
+	}{
+		{url: "https://contentfarm.example/article", blocked: true},
+		{url: "https://www.contentfarm.example/article", blocked: true},
+		{url: "http://news.CONTENTFARM.example:8080/a", blocked: true},
+		{url: "https://spam.example", blocked: true},
+		{url: "https://notcontentfarm.example/article", blocked: false},
+		{url: "https://contentfarm.example.org/article", blocked: false},
+		{url: "https://nasa.gov/apollo", blocked: false},
+		{url: "", blocked: false},
+		{url: "://bad", blocked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			assert.Equal(t, tt.blocked, blocklist.Blocks(tt.url))
+		})
+	}
+}
+
+func TestDomainBlocklist_Filter(t *testing.T) {
+	urls := []string{"https://nasa.gov/apollo", "https://contentfarm.example/moon", "https://esa.int/moon"}
+
+	assert.Equal(t, []string{"https://nasa.gov/apollo", "https://esa.int/moon"}, NewDomainBlocklist([]string{"contentfarm.example"}).Filter(urls))
+	assert.Equal(t, urls, NewDomainBlocklist(nil).Filter(urls))
+}
@@ -0,0 +1,142 @@
+package clients
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/requestctx"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Reachability check defaults used when nothing is configured
+const (
+	defaultSourceCheckTimeout      = 3 * time.Second
+	defaultSourceCheckConcurrency  = 4
+	defaultSourceCheckBatchTimeout = 5 * time.Second
+)
+
+// LinkCheckerInterface filters source URLs down to the ones that still resolve
+type LinkCheckerInterface interface {
+	FilterReachable(ctx context.Context, urls []string) []string
+}
+
+// LinkChecker sends HEAD requests to source URLs, concurrently and with a short timeout,
+// to drop dead links. Like webhook deliveries it refuses to connect to private addresses.
+type LinkChecker struct {
+	httpClient   *http.Client
+	logger       *logrus.Logger
+	concurrency  int           // URLs checked at once per call
+	batchTimeout time.Duration // Deadline shared by all URLs of one call
+}
+
+// NewLinkChecker creates a link checker using the configured per-URL timeout
+func NewLinkChecker(cfg *config.Config) *LinkChecker {
+	timeout := cfg.SourceCheckTimeout
+	if timeout <= 0 {
+		timeout = defaultSourceCheckTimeout
+	}
+
+	concurrency := cfg.SourceCheckConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultSourceCheckConcurrency
+	}
+	batchTimeout := cfg.SourceCheckBatchTimeout
+	if batchTimeout <= 0 {
+		batchTimeout = defaultSourceCheckBatchTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, Control: refusePrivateAddress}
+	return &LinkChecker{
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+		},
+		logger:       logger.Log,
+		concurrency:  concurrency,
+		batchTimeout: batchTimeout,
+	}
+}
+
+// FilterReachable returns the URLs that answered, in their original order. URLs are
+// checked through a bounded pool under one shared deadline; a URL the deadline cut off is
+// kept, since running out of time says nothing about the link.
+func (c *LinkChecker) FilterReachable(ctx context.Context, urls []string) []string {
+	checkCtx, cancel := context.WithTimeout(ctx, c.batchTimeout)
+	defer cancel()
+
+	reachable := make([]bool, len(urls))
+	var timedOut int32
+	slots := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			select {
+			case slots <- struct{}{}:
+				defer func() { <-slots }()
+				reachable[i] = c.isReachable(checkCtx, url)
+			case <-checkCtx.Done():
+			}
+			if !reachable[i] && checkCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+				reachable[i] = true
+				atomic.AddInt32(&timedOut, 1)
+			}
+		}(i, url)
+	}
+	wg.Wait()
+
+	if timedOut > 0 {
+		c.logger.WithFields(map[string]interface{}{
+			"correlation_id": requestctx.CorrelationID(ctx),
+			"unchecked":      timedOut,
+			"batch_timeout":  c.batchTimeout.String(),
+		}).Warn("Source checks ran out of time, keeping unchecked sources")
+	}
+
+	var live []string
+	for i, url := range urls {
+		if reachable[i] {
+			live = append(live, url)
+		}
+	}
+
+	if dropped := len(urls) - len(live); dropped > 0 {
+		c.logger.WithFields(map[string]interface{}{
+			"correlation_id": requestctx.CorrelationID(ctx),
+			"checked":        len(urls),
+			"dropped":        dropped,
+		}).Info("Dropped unreachable sources")
+	}
+	return live
+}
+
+// isReachable treats connection failures, 404, 410 and server errors as dead. Other 4xx
+// responses (401, 403, 405, 429) usually mean the page exists but rejects HEAD or bots.
+func (c *LinkChecker) isReachable(ctx context.Context, url string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return false
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusGone:
+		return false
+	case resp.StatusCode >= 500:
+		return false
+	}
+	return true
+}
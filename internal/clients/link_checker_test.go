@@ -0,0 +1,141 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupTestLinkChecker returns a checker that may reach the local test server
+func setupTestLinkChecker() *LinkChecker {
+	checker := NewLinkChecker(&config.Config{SourceCheckTimeout: time.Second})
+	checker.httpClient = &http.Client{Timeout: 200 * time.Millisecond}
+	return checker
+}
+
+func TestLinkChecker_FilterReachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/gone":
+			w.WriteHeader(http.StatusGone)
+		case "/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "/slow":
+			time.Sleep(time.Second)
+		}
+	}))
+	defer server.Close()
+
+	urls := []string{
+		server.URL + "/ok",
+		server.URL + "/missing",
+		server.URL + "/gone",
+		server.URL + "/broken",
+		server.URL + "/forbidden",
+		server.URL + "/slow",
+		"http://unreachable.invalid/page",
+	}
+
+	live := setupTestLinkChecker().FilterReachable(context.Background(), urls)
+
+	assert.Equal(t, []string{server.URL + "/ok", server.URL + "/forbidden"}, live)
+}
+
+func TestLinkChecker_FilterReachable_Concurrent(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			previous := atomic.LoadInt32(&peak)
+			if current <= previous || atomic.CompareAndSwapInt32(&peak, previous, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c"}
+	live := setupTestLinkChecker().FilterReachable(context.Background(), urls)
+
+	assert.Equal(t, urls, live)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&peak))
+}
+
+func TestLinkChecker_FilterReachable_BoundedPool(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			previous := atomic.LoadInt32(&peak)
+			if current <= previous || atomic.CompareAndSwapInt32(&peak, previous, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	checker := setupTestLinkChecker()
+	checker.concurrency = 2
+
+	urls := []string{server.URL + "/a", server.URL + "/b", server.URL + "/c", server.URL + "/d", server.URL + "/e"}
+	live := checker.FilterReachable(context.Background(), urls)
+
+	assert.Equal(t, urls, live)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&peak))
+}
+
+func TestLinkChecker_FilterReachable_BatchTimeoutKeepsUncheckedSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/slow":
+			time.Sleep(150 * time.Millisecond)
+		}
+	}))
+	defer server.Close()
+
+	checker := setupTestLinkChecker()
+	checker.batchTimeout = 100 * time.Millisecond
+
+	// The missing page answers in time and is dropped; the slow one is cut off and kept
+	start := time.Now()
+	live := checker.FilterReachable(context.Background(), []string{server.URL + "/missing", server.URL + "/slow"})
+	assert.Equal(t, []string{server.URL + "/slow"}, live)
+
+	// A URL still waiting for a slot when time runs out is kept too
+	checker.concurrency = 1
+	urls := []string{server.URL + "/slow?a", server.URL + "/slow?b"}
+	live = checker.FilterReachable(context.Background(), urls)
+	assert.Equal(t, urls, live)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestLinkChecker_RefusesPrivateAddress(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	live := NewLinkChecker(&config.Config{}).FilterReachable(context.Background(), []string{server.URL})
+
+	assert.Empty(t, live)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+}
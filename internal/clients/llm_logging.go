@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/requestctx"
+
+	"github.com/sirupsen/logrus"
+)
+
+// defaultLogPromptMaxChars caps each logged prompt or response when no cap is configured
+const defaultLogPromptMaxChars = 2000
+
+// redactedPlaceholder replaces secrets in logged prompts and responses
+const redactedPlaceholder = "[REDACTED]"
+
+// minRedactedSecretLength keeps very short configured values from redacting ordinary words
+const minRedactedSecretLength = 8
+
+// secretPatterns match credentials that may turn up in prompt or response text even when
+// they are not ones this service is configured with. Where a pattern has a group, only
+// the text after it is redacted so the key name stays readable.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`sk-ant-[A-Za-z0-9_\-]+`),
+	regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/\-]+=*`),
+	regexp.MustCompile(`(?i)((?:api[_-]?key|access[_-]?token|secret|password)["']?\s*[:=]\s*["']?)[^\s"',;]+`),
+}
+
+// PromptLogger logs the prompts sent to Claude and the responses received, at debug level,
+// truncated and with secrets redacted. It does nothing unless enabled in the configuration.
+type PromptLogger struct {
+	enabled  bool
+	maxChars int
+	secrets  []string // Configured credentials, redacted wherever they appear
+	logger   *logrus.Logger
+}
+
+// NewPromptLogger creates a prompt logger from the configuration
+func NewPromptLogger(cfg *config.Config) *PromptLogger {
+	maxChars := cfg.LogPromptMaxChars
+	if maxChars <= 0 {
+		maxChars = defaultLogPromptMaxChars
+	}
+
+	var secrets []string
+	for _, secret := range append([]string{cfg.AnthropicAPIKey, cfg.SerperAPIKey, cfg.AdminAPIKey}, cfg.AnthropicAPIKeys...) {
+		if len(secret) >= minRedactedSecretLength {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	return &PromptLogger{
+		enabled:  cfg.LogPromptBodies,
+		maxChars: maxChars,
+		secrets:  secrets,
+		logger:   logger.Log,
+	}
+}
+
+// LogLLMExchange logs one prompt/response pair when prompt logging is enabled and the
+// debug level is on
+func (p *PromptLogger) LogLLMExchange(ctx context.Context, agentName, systemPrompt, prompt, response string) {
+	if p == nil || !p.enabled || !p.logger.IsLevelEnabled(logrus.DebugLevel) {
+		return
+	}
+
+	p.logger.WithFields(requestctx.LogFields(ctx)).WithFields(map[string]interface{}{
+		"agent":         agentName,
+		"system_prompt": p.prepare(systemPrompt),
+		"prompt":        p.prepare(prompt),
+		"response":      p.prepare(response),
+	}).Debug("Anthropic prompt and response")
+}
+
+// prepare redacts secrets in text, then cuts it to the configured length. Redacting first
+// means a secret straddling the cut is never partly logged.
+func (p *PromptLogger) prepare(text string) string {
+	return truncateForLog(p.redact(text), p.maxChars)
+}
+
+// redact replaces configured credentials and anything that looks like one
+func (p *PromptLogger) redact(text string) string {
+	for _, secret := range p.secrets {
+		text = strings.ReplaceAll(text, secret, redactedPlaceholder)
+	}
+	for _, pattern := range secretPatterns {
+		if pattern.NumSubexp() > 0 {
+			text = pattern.ReplaceAllString(text, "${1}"+redactedPlaceholder)
+		} else {
+			text = pattern.ReplaceAllString(text, redactedPlaceholder)
+		}
+	}
+	return text
+}
+
+// truncateForLog cuts text to at most maxChars characters, noting how many were dropped
+func truncateForLog(text string, maxChars int) string {
+	length := utf8.RuneCountInString(text)
+	if length <= maxChars {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:maxChars]) + fmt.Sprintf("...[%d more characters]", length-maxChars)
+}
@@ -0,0 +1,104 @@
+package clients
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/requestctx"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPromptLogger returns a prompt logger writing to a test hook at the given level
+func newTestPromptLogger(cfg *config.Config, level logrus.Level) (*PromptLogger, *test.Hook) {
+	log, hook := test.NewNullLogger()
+	log.SetLevel(level)
+	promptLogger := NewPromptLogger(cfg)
+	promptLogger.logger = log
+	return promptLogger, hook
+}
+
+func TestPromptLogger_LogLLMExchange(t *testing.T) {
+	promptLogger, hook := newTestPromptLogger(&config.Config{
+		LogPromptBodies:   true,
+		LogPromptMaxChars: 40,
+		SerperAPIKey:      "serper-secret-value",
+	}, logrus.DebugLevel)
+	jobID := uuid.New()
+	ctx := requestctx.WithJobID(requestctx.WithCorrelationID(context.Background(), "test-correlation-123"), jobID)
+
+	promptLogger.LogLLMExchange(ctx, "summarizer", "Be brief", "Search with serper-secret-value", strings.Repeat("word ", 20))
+
+	entry := hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.DebugLevel, entry.Level)
+	assert.Equal(t, "summarizer", entry.Data["agent"])
+	assert.Equal(t, "test-correlation-123", entry.Data["correlation_id"])
+	assert.Equal(t, jobID.String(), entry.Data["job_id"])
+	assert.Equal(t, "Be brief", entry.Data["system_prompt"])
+	assert.Equal(t, "Search with [REDACTED]", entry.Data["prompt"])
+	assert.Equal(t, strings.Repeat("word ", 8)+"...[60 more characters]", entry.Data["response"])
+}
+
+func TestPromptLogger_DisabledOrNotDebug(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		level   logrus.Level
+	}{
+		{name: "disabled", enabled: false, level: logrus.DebugLevel},
+		{name: "info level", enabled: true, level: logrus.InfoLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			promptLogger, hook := newTestPromptLogger(&config.Config{LogPromptBodies: tt.enabled}, tt.level)
+
+			promptLogger.LogLLMExchange(context.Background(), "summarizer", "", "prompt", "response")
+
+			assert.Empty(t, hook.AllEntries())
+		})
+	}
+
+	// Clients built without a prompt logger log nothing
+	var promptLogger *PromptLogger
+	assert.NotPanics(t, func() {
+		promptLogger.LogLLMExchange(context.Background(), "summarizer", "", "prompt", "response")
+	})
+}
+
+func TestPromptLogger_redact(t *testing.T) {
+	promptLogger := NewPromptLogger(&config.Config{
+		AnthropicAPIKeys: []string{"configured-anthropic-key", "short"},
+	})
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "configured key", text: "key configured-anthropic-key here", expected: "key [REDACTED] here"},
+		{name: "short configured value is left alone", text: "a short answer", expected: "a short answer"},
+		{name: "anthropic key shape", text: "use sk-ant-api03-abcDEF_123 now", expected: "use [REDACTED] now"},
+		{name: "bearer token", text: "Authorization: Bearer abc.def-123", expected: "Authorization: Bearer [REDACTED]"},
+		{name: "key assignment", text: `api_key="xyz789" and password: hunter22`, expected: `api_key="[REDACTED]" and password: [REDACTED]`},
+		{name: "no secrets", text: "The moon landing was in 1969.", expected: "The moon landing was in 1969."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, promptLogger.redact(tt.text))
+		})
+	}
+}
+
+func TestTruncateForLog(t *testing.T) {
+	assert.Equal(t, "short", truncateForLog("short", 10))
+	assert.Equal(t, "héllo...[6 more characters]", truncateForLog("héllo wörld", 5))
+}
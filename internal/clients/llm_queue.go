@@ -0,0 +1,271 @@
+package clients
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/metrics"
+)
+
+// LLMPriority orders Anthropic calls waiting for a free slot
+type LLMPriority int
+
+const (
+	// LLMPriorityInteractive is for latency-sensitive calls and is the default
+	LLMPriorityInteractive LLMPriority = iota
+	// LLMPriorityBatch is for bulk work such as verifying every claim in a transcript
+	LLMPriorityBatch
+)
+
+// String returns the priority name used in metric names
+func (p LLMPriority) String() string {
+	if p == LLMPriorityBatch {
+		return "batch"
+	}
+	return "interactive"
+}
+
+// defaultLLMBatchAdmitEvery applies when no batch admission interval is configured
+const defaultLLMBatchAdmitEvery = 4
+
+// WithLLMPriority returns a context whose Anthropic calls queue at the given priority
+func WithLLMPriority(ctx context.Context, priority LLMPriority) context.Context {
+	return context.WithValue(ctx, llmPriorityContextKey, priority)
+}
+
+// llmPriorityFromContext returns the priority set with WithLLMPriority, defaulting to interactive
+func llmPriorityFromContext(ctx context.Context) LLMPriority {
+	if priority, ok := ctx.Value(llmPriorityContextKey).(LLMPriority); ok {
+		return priority
+	}
+	return LLMPriorityInteractive
+}
+
+// LLMQueue limits concurrent Anthropic calls across the process. Waiting interactive
+// calls are admitted ahead of batch calls, but after batchAdmitEvery interactive
+// admissions in a row a waiting batch call goes next, so batch work is never starved.
+//
+// When rate limit adaptation is on, rateLimitThreshold 429s within rateLimitWindow halve
+// the number of calls admitted at once, and each window without a 429 admits one more,
+// back up to capacity. Calls already in flight are never interrupted.
+type LLMQueue struct {
+	capacity        int
+	batchAdmitEvery int
+
+	rateLimitThreshold int           // 429s within rateLimitWindow that halve the limit; 0 disables adaptation
+	rateLimitWindow    time.Duration // How long a 429 counts, and how long the limit waits to grow by one
+	now                func() time.Time
+
+	mu                sync.Mutex
+	inFlight          int
+	limit             int           // calls admitted at once, capacity unless rate limits lowered it
+	waiting           [2]*list.List // waiters per priority, oldest first
+	interactiveStreak int
+	rateLimits        []time.Time // recent 429s, oldest first
+	lastRateLimit     time.Time
+	lastAdjusted      time.Time
+}
+
+// llmWaiter is a call blocked in the queue; ready is closed once it holds a slot
+type llmWaiter struct {
+	ready   chan struct{}
+	granted bool
+}
+
+// llmQueue is the queue shared by all Anthropic client instances
+var (
+	llmQueueMu sync.Mutex
+	llmQueue   *LLMQueue
+)
+
+// NewLLMQueue creates a queue admitting up to capacity concurrent calls
+func NewLLMQueue(capacity, batchAdmitEvery int) *LLMQueue {
+	if batchAdmitEvery <= 0 {
+		batchAdmitEvery = defaultLLMBatchAdmitEvery
+	}
+	q := &LLMQueue{
+		capacity:        capacity,
+		batchAdmitEvery: batchAdmitEvery,
+		now:             time.Now,
+		limit:           capacity,
+		waiting:         [2]*list.List{list.New(), list.New()},
+	}
+	q.publishLocked()
+	return q
+}
+
+// getLLMQueue returns the shared queue, or nil when LLM concurrency is unlimited
+func getLLMQueue(cfg *config.Config) *LLMQueue {
+	if cfg.MaxConcurrentLLMCalls <= 0 {
+		return nil
+	}
+
+	llmQueueMu.Lock()
+	defer llmQueueMu.Unlock()
+
+	if llmQueue == nil {
+		llmQueue = NewLLMQueue(cfg.MaxConcurrentLLMCalls, cfg.LLMBatchAdmitEvery)
+		if cfg.LLMRateLimitWindow > 0 {
+			llmQueue.rateLimitThreshold = cfg.LLMRateLimitThreshold
+			llmQueue.rateLimitWindow = cfg.LLMRateLimitWindow
+		}
+	}
+	return llmQueue
+}
+
+// Acquire waits for a slot at the given priority and returns the function that frees it.
+// It gives up with the context's error if the context ends first. A nil queue admits
+// every call immediately.
+func (q *LLMQueue) Acquire(ctx context.Context, priority LLMPriority) (func(), error) {
+	if q == nil {
+		return func() {}, nil
+	}
+	if priority != LLMPriorityBatch {
+		priority = LLMPriorityInteractive
+	}
+	start := time.Now()
+
+	q.mu.Lock()
+	q.recoverLimitLocked()
+	if q.inFlight < q.limit && q.waiting[LLMPriorityInteractive].Len() == 0 && q.waiting[LLMPriorityBatch].Len() == 0 {
+		q.inFlight++
+		q.publishLocked()
+		q.mu.Unlock()
+		q.recordAdmission(priority, 0)
+		return q.release, nil
+	}
+	waiter := &llmWaiter{ready: make(chan struct{})}
+	element := q.waiting[priority].PushBack(waiter)
+	q.publishLocked()
+	q.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		q.recordAdmission(priority, time.Since(start))
+		return q.release, nil
+	case <-ctx.Done():
+		q.mu.Lock()
+		if waiter.granted {
+			// The slot arrived as the context ended; hand it on rather than leak it
+			q.inFlight--
+			q.dispatchLocked()
+		} else {
+			q.waiting[priority].Remove(element)
+		}
+		q.publishLocked()
+		q.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// release frees a slot and admits the next waiter, if any
+func (q *LLMQueue) release() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.inFlight--
+	q.recoverLimitLocked()
+	q.dispatchLocked()
+	q.publishLocked()
+}
+
+// ReportRateLimited records a 429 from the provider. Once rateLimitThreshold of them fall
+// within rateLimitWindow, the number of calls admitted at once is halved, down to one.
+func (q *LLMQueue) ReportRateLimited() {
+	if q == nil {
+		return
+	}
+	metrics.AddCounter("llm_queue_rate_limited", 1)
+	if q.rateLimitThreshold <= 0 {
+		return
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now()
+	q.lastRateLimit = now
+	q.rateLimits = append(q.rateLimits, now)
+	for len(q.rateLimits) > 0 && now.Sub(q.rateLimits[0]) >= q.rateLimitWindow {
+		q.rateLimits = q.rateLimits[1:]
+	}
+
+	// The recent 429s are cleared so the next halving needs a fresh run of them
+	if len(q.rateLimits) >= q.rateLimitThreshold && q.limit > 1 {
+		previous := q.limit
+		q.limit = max(1, q.limit/2)
+		q.rateLimits = nil
+		q.lastAdjusted = now
+		logger.Log.WithFields(map[string]interface{}{
+			"previous_limit": previous,
+			"limit":          q.limit,
+			"capacity":       q.capacity,
+		}).Warn("Rate limited by Anthropic, admitting fewer concurrent calls")
+	}
+	q.publishLocked()
+}
+
+// recoverLimitLocked grows a lowered limit by one for each full window since it was last
+// changed or a 429 was seen, whichever is later, and hands the new slots to waiters
+func (q *LLMQueue) recoverLimitLocked() {
+	if q.limit >= q.capacity || q.rateLimitWindow <= 0 {
+		return
+	}
+	since := q.lastAdjusted
+	if q.lastRateLimit.After(since) {
+		since = q.lastRateLimit
+	}
+	steps := int(q.now().Sub(since) / q.rateLimitWindow)
+	if steps <= 0 {
+		return
+	}
+	q.limit = min(q.capacity, q.limit+steps)
+	q.lastAdjusted = since.Add(time.Duration(steps) * q.rateLimitWindow)
+	if q.limit == q.capacity {
+		q.rateLimits = nil
+	}
+	q.dispatchLocked()
+}
+
+// dispatchLocked hands free slots to waiters in priority order
+func (q *LLMQueue) dispatchLocked() {
+	for q.inFlight < q.limit {
+		interactive := q.waiting[LLMPriorityInteractive]
+		batch := q.waiting[LLMPriorityBatch]
+
+		var next *list.List
+		switch {
+		case interactive.Len() > 0 && (batch.Len() == 0 || q.interactiveStreak < q.batchAdmitEvery):
+			next = interactive
+			q.interactiveStreak++
+		case batch.Len() > 0:
+			next = batch
+			q.interactiveStreak = 0
+		default:
+			return
+		}
+
+		waiter := next.Remove(next.Front()).(*llmWaiter)
+		waiter.granted = true
+		q.inFlight++
+		close(waiter.ready)
+	}
+}
+
+// publishLocked exposes the queue depth, in-flight calls and current limit as metrics
+func (q *LLMQueue) publishLocked() {
+	metrics.SetGauge("llm_queue_in_flight", float64(q.inFlight))
+	metrics.SetGauge("llm_queue_limit", float64(q.limit))
+	for _, priority := range []LLMPriority{LLMPriorityInteractive, LLMPriorityBatch} {
+		metrics.SetGauge("llm_queue_waiting_"+priority.String(), float64(q.waiting[priority].Len()))
+	}
+}
+
+// recordAdmission counts admitted calls and their total wait, per priority
+func (q *LLMQueue) recordAdmission(priority LLMPriority, wait time.Duration) {
+	metrics.AddCounter("llm_queue_admitted_"+priority.String(), 1)
+	metrics.AddCounter("llm_queue_wait_ms_total_"+priority.String(), wait.Milliseconds())
+}
@@ -0,0 +1,271 @@
+package clients
+
+import (
+	"context"
+	"expvar"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/metrics"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queueWaiter starts an Acquire in the background and reports its admission on admitted
+func queueWaiter(t *testing.T, q *LLMQueue, priority LLMPriority, name string, admitted chan<- string) {
+	t.Helper()
+	go func() {
+		release, err := q.Acquire(context.Background(), priority)
+		if err != nil {
+			return
+		}
+		admitted <- name
+		release()
+	}()
+}
+
+// waitForWaiting blocks until the queue has n waiters in total
+func waitForWaiting(t *testing.T, q *LLMQueue, n int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		return q.waiting[LLMPriorityInteractive].Len()+q.waiting[LLMPriorityBatch].Len() == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestLLMQueue_NilAdmitsImmediately(t *testing.T) {
+	var q *LLMQueue
+
+	release, err := q.Acquire(context.Background(), LLMPriorityBatch)
+
+	require.NoError(t, err)
+	release()
+	assert.Nil(t, getLLMQueue(&config.Config{}))
+}
+
+func TestLLMQueue_RespectsCapacity(t *testing.T) {
+	q := NewLLMQueue(2, 0)
+
+	first, err := q.Acquire(context.Background(), LLMPriorityInteractive)
+	require.NoError(t, err)
+	second, err := q.Acquire(context.Background(), LLMPriorityBatch)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = q.Acquire(ctx, LLMPriorityInteractive)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// A cancelled waiter leaves the queue
+	waitForWaiting(t, q, 0)
+
+	first()
+	third, err := q.Acquire(context.Background(), LLMPriorityInteractive)
+	require.NoError(t, err)
+	second()
+	third()
+	assert.Equal(t, 0, q.inFlight)
+}
+
+func TestLLMQueue_InteractiveAdmittedFirst(t *testing.T) {
+	q := NewLLMQueue(1, 4)
+	hold, err := q.Acquire(context.Background(), LLMPriorityBatch)
+	require.NoError(t, err)
+
+	admitted := make(chan string, 2)
+	queueWaiter(t, q, LLMPriorityBatch, "batch", admitted)
+	waitForWaiting(t, q, 1)
+	queueWaiter(t, q, LLMPriorityInteractive, "interactive", admitted)
+	waitForWaiting(t, q, 2)
+
+	hold()
+
+	assert.Equal(t, "interactive", <-admitted)
+	assert.Equal(t, "batch", <-admitted)
+}
+
+func TestLLMQueue_BatchNotStarved(t *testing.T) {
+	q := NewLLMQueue(1, 2)
+	hold, err := q.Acquire(context.Background(), LLMPriorityInteractive)
+	require.NoError(t, err)
+
+	// One batch call waits behind a steady stream of interactive calls
+	admitted := make(chan string, 4)
+	queueWaiter(t, q, LLMPriorityBatch, "batch", admitted)
+	waitForWaiting(t, q, 1)
+	for i, name := range []string{"interactive-1", "interactive-2", "interactive-3"} {
+		queueWaiter(t, q, LLMPriorityInteractive, name, admitted)
+		waitForWaiting(t, q, i+2)
+	}
+
+	hold()
+
+	var order []string
+	for i := 0; i < 4; i++ {
+		order = append(order, <-admitted)
+	}
+	assert.Equal(t, []string{"interactive-1", "interactive-2", "batch", "interactive-3"}, order)
+}
+
+func TestLLMQueue_RecordsWaitMetrics(t *testing.T) {
+	q := NewLLMQueue(1, 0)
+	before := int64(0)
+	if admitted, ok := metrics.Value("llm_queue_admitted_batch").(*expvar.Int); ok {
+		before = admitted.Value()
+	}
+
+	release, err := q.Acquire(context.Background(), LLMPriorityBatch)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), metrics.Value("llm_queue_in_flight").(*expvar.Float).Value())
+	release()
+
+	assert.Equal(t, before+1, metrics.Value("llm_queue_admitted_batch").(*expvar.Int).Value())
+	assert.NotNil(t, metrics.Value("llm_queue_wait_ms_total_batch"))
+	assert.Equal(t, float64(0), metrics.Value("llm_queue_in_flight").(*expvar.Float).Value())
+}
+
+// adaptiveQueue returns a queue that halves its limit after three 429s within a minute,
+// with a clock the test moves by hand
+func adaptiveQueue(capacity int) (*LLMQueue, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	q := NewLLMQueue(capacity, 0)
+	q.rateLimitThreshold = 3
+	q.rateLimitWindow = time.Minute
+	q.now = func() time.Time { return now }
+	return q, &now
+}
+
+func TestLLMQueue_RateLimitsHalveLimit(t *testing.T) {
+	q, now := adaptiveQueue(8)
+
+	q.ReportRateLimited()
+	q.ReportRateLimited()
+	assert.Equal(t, 8, q.limit)
+
+	q.ReportRateLimited()
+	assert.Equal(t, 4, q.limit)
+	assert.Equal(t, float64(4), metrics.Value("llm_queue_limit").(*expvar.Float).Value())
+
+	// 429s spread wider than the window do not add up
+	for i := 0; i < 3; i++ {
+		*now = now.Add(40 * time.Second)
+		q.ReportRateLimited()
+	}
+	assert.Equal(t, 4, q.limit)
+
+	for i := 0; i < 9; i++ {
+		q.ReportRateLimited()
+	}
+	assert.Equal(t, 1, q.limit)
+}
+
+func TestLLMQueue_LimitRecoversWithoutRateLimits(t *testing.T) {
+	q, now := adaptiveQueue(8)
+	for i := 0; i < 3; i++ {
+		q.ReportRateLimited()
+	}
+	require.Equal(t, 4, q.limit)
+
+	acquire := func() {
+		release, err := q.Acquire(context.Background(), LLMPriorityInteractive)
+		require.NoError(t, err)
+		release()
+	}
+
+	*now = now.Add(59 * time.Second)
+	acquire()
+	assert.Equal(t, 4, q.limit)
+
+	*now = now.Add(time.Second)
+	acquire()
+	assert.Equal(t, 5, q.limit)
+
+	// A 429 below the threshold holds the limit for another window
+	q.ReportRateLimited()
+	*now = now.Add(30 * time.Second)
+	acquire()
+	assert.Equal(t, 5, q.limit)
+
+	*now = now.Add(10 * time.Minute)
+	acquire()
+	assert.Equal(t, 8, q.limit)
+	assert.Equal(t, float64(8), metrics.Value("llm_queue_limit").(*expvar.Float).Value())
+}
+
+func TestLLMQueue_LoweredLimitHoldsBackCalls(t *testing.T) {
+	q, now := adaptiveQueue(2)
+	for i := 0; i < 3; i++ {
+		q.ReportRateLimited()
+	}
+	require.Equal(t, 1, q.limit)
+
+	release, err := q.Acquire(context.Background(), LLMPriorityInteractive)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = q.Acquire(ctx, LLMPriorityInteractive)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Once the limit recovers, a release admits both waiting calls
+	admitted := make(chan string, 2)
+	queueWaiter(t, q, LLMPriorityInteractive, "first", admitted)
+	queueWaiter(t, q, LLMPriorityBatch, "second", admitted)
+	waitForWaiting(t, q, 2)
+	q.mu.Lock()
+	*now = now.Add(time.Minute)
+	q.mu.Unlock()
+	release()
+
+	assert.ElementsMatch(t, []string{"first", "second"}, []string{<-admitted, <-admitted})
+}
+
+func TestLLMQueue_RecoveredLimitAdmitsWaiters(t *testing.T) {
+	q, now := adaptiveQueue(2)
+	for i := 0; i < 3; i++ {
+		q.ReportRateLimited()
+	}
+	require.Equal(t, 1, q.limit)
+
+	release, err := q.Acquire(context.Background(), LLMPriorityInteractive)
+	require.NoError(t, err)
+	defer release()
+
+	admitted := make(chan string, 2)
+	queueWaiter(t, q, LLMPriorityInteractive, "waiting", admitted)
+	waitForWaiting(t, q, 1)
+
+	// A call arriving after the limit recovers admits the queued call ahead of itself,
+	// while the first slot is still held
+	q.mu.Lock()
+	*now = now.Add(time.Minute)
+	q.mu.Unlock()
+	queueWaiter(t, q, LLMPriorityInteractive, "arriving", admitted)
+
+	select {
+	case name := <-admitted:
+		assert.Equal(t, "waiting", name)
+	case <-time.After(time.Second):
+		t.Fatal("queued call was not admitted when the limit recovered")
+	}
+	assert.Equal(t, "arriving", <-admitted)
+}
+
+func TestLLMQueue_RateLimitAdaptationDisabled(t *testing.T) {
+	q := NewLLMQueue(4, 0)
+	for i := 0; i < 10; i++ {
+		q.ReportRateLimited()
+	}
+	assert.Equal(t, 4, q.limit)
+
+	var nilQueue *LLMQueue
+	assert.NotPanics(t, nilQueue.ReportRateLimited)
+}
+
+func TestLLMPriorityFromContext(t *testing.T) {
+	assert.Equal(t, LLMPriorityInteractive, llmPriorityFromContext(context.Background()))
+	assert.Equal(t, LLMPriorityBatch, llmPriorityFromContext(WithLLMPriority(context.Background(), LLMPriorityBatch)))
+}
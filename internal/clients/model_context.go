@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"context"
+	"sync"
+)
+
+// contextKey is the type of the keys this package stores values under in a context, so they
+// cannot collide with keys set by other packages
+type contextKey string
+
+const (
+	modelContextKey              contextKey = "claude_model"
+	modelRecorderContextKey      contextKey = "model_recorder"
+	truncationRecorderContextKey contextKey = "truncation_recorder"
+	llmPriorityContextKey        contextKey = "llm_priority"
+)
+
+// WithModel returns a context whose Anthropic calls use the given model instead of the
+// configured one, e.g. to re-run an analysis with the exact model version it first used
+func WithModel(ctx context.Context, model string) context.Context {
+	return context.WithValue(ctx, modelContextKey, model)
+}
+
+// modelFromContext returns the model set with WithModel, or fallback when none is set
+func modelFromContext(ctx context.Context, fallback string) string {
+	if model, ok := ctx.Value(modelContextKey).(string); ok && model != "" {
+		return model
+	}
+	return fallback
+}
+
+// modelPinned reports whether a model was set on the context with WithModel
+func modelPinned(ctx context.Context) bool {
+	model, ok := ctx.Value(modelContextKey).(string)
+	return ok && model != ""
+}
+
+// ModelRecorder collects the model versions Anthropic reports for the calls made with a
+// context, since a configured alias may resolve to a different version over time
+type ModelRecorder struct {
+	mu     sync.Mutex
+	models []string
+}
+
+// WithModelRecorder returns a context whose Anthropic calls report their model to recorder
+func WithModelRecorder(ctx context.Context, recorder *ModelRecorder) context.Context {
+	return context.WithValue(ctx, modelRecorderContextKey, recorder)
+}
+
+// Models returns the distinct models recorded, in the order they were first used
+func (r *ModelRecorder) Models() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.models...)
+}
+
+// record adds a model unless it was already seen
+func (r *ModelRecorder) record(model string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, seen := range r.models {
+		if seen == model {
+			return
+		}
+	}
+	r.models = append(r.models, model)
+}
+
+// recordModel reports a call's model to the context's recorder, if it has one
+func recordModel(ctx context.Context, model string) {
+	if recorder, ok := ctx.Value(modelRecorderContextKey).(*ModelRecorder); ok && recorder != nil && model != "" {
+		recorder.record(model)
+	}
+}
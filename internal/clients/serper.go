@@ -12,6 +12,7 @@ import (
 	
 	"podcast-analyzer/internal/config"
 	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/requestctx"
 	
 	"github.com/sirupsen/logrus"
 )
@@ -27,6 +28,8 @@ type SerperClient struct {
 	apiKey     string
 	baseURL    string
 	httpClient *http.Client
+	breaker    *CircuitBreaker
+	blocked    DomainBlocklist // Result domains that must not count as evidence
 	logger     *logrus.Logger
 }
 
@@ -36,9 +39,17 @@ type SerperRequest struct {
 	Num   int    `json:"num"`
 }
 
-// SerperResponse represents a response from the Serper API
+// serperBaseURL is the Serper API root; the endpoint name is appended to it
+const serperBaseURL = "https://google.serper.dev/"
+
+// defaultSerperEndpoint is used when no endpoint is configured
+const defaultSerperEndpoint = "search"
+
+// SerperResponse represents a response from the Serper API. Web and scholar searches
+// return "organic" results while news searches return "news".
 type SerperResponse struct {
 	Organic       []SerperResult    `json:"organic"`
+	News          []SerperResult    `json:"news,omitempty"`
 	AnswerBox     *SerperAnswerBox  `json:"answerBox,omitempty"`
 	KnowledgeGraph *SerperKnowledgeGraph `json:"knowledgeGraph,omitempty"`
 }
@@ -48,14 +59,39 @@ type SerperResult struct {
 	Title   string `json:"title"`
 	Link    string `json:"link"`
 	Snippet string `json:"snippet"`
+
+	// News results
+	Source string `json:"source,omitempty"`
+	Date   string `json:"date,omitempty"`
+
+	// Scholar results
+	PublicationInfo string      `json:"publicationInfo,omitempty"`
+	Year            json.Number `json:"year,omitempty"`
+}
+
+// displayTitle adds the publication details of news and scholar results to the title
+func (r SerperResult) displayTitle() string {
+	var details []string
+	for _, detail := range []string{r.Source, r.PublicationInfo, r.Date, r.Year.String()} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if len(details) == 0 {
+		return r.Title
+	}
+	return fmt.Sprintf("%s (%s)", r.Title, strings.Join(details, ", "))
 }
 
-// SerperAnswerBox represents an answer box result
+// SerperAnswerBox represents an answer box result. Depending on the question it has a
+// direct answer, a snippet, or both.
 type SerperAnswerBox struct {
 	Answer  string `json:"answer"`
 	Title   string `json:"title"`
 	Link    string `json:"link"`
 	Snippet string `json:"snippet"`
+	SnippetHighlighted []string `json:"snippetHighlighted,omitempty"` // The parts of the snippet that answer the query
+	Date    string `json:"date,omitempty"`
 }
 
 // SerperKnowledgeGraph represents a knowledge graph result
@@ -72,6 +108,9 @@ type SearchContext struct {
 	Snippets      []SearchSnippet        `json:"snippets"`
 	Sources       []string               `json:"sources"`
 	TotalResults  int                    `json:"total_results"`
+	BlockedResults   int                 `json:"blocked_results,omitempty"`    // Results dropped for a blocked domain
+	LowSourceQuality bool                `json:"low_source_quality,omitempty"` // Only blocked domains had results, so they were kept
+	DirectAnswer     *DirectAnswer       `json:"direct_answer,omitempty"`      // Structured answer box content, when the search had one
 }
 
 // SearchSnippet represents a formatted search result snippet
@@ -93,13 +132,20 @@ func (e *SerperError) Error() string {
 
 // NewSerperClient creates a new Serper API client
 func NewSerperClient(cfg *config.Config) *SerperClient {
+	endpoint := cfg.SerperEndpoint
+	if endpoint == "" {
+		endpoint = defaultSerperEndpoint
+	}
 	return &SerperClient{
 		apiKey:  cfg.SerperAPIKey,
-		baseURL: "https://google.serper.dev/search",
+		baseURL: serperBaseURL + endpoint,
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: getAPITransport(cfg),
 		},
-		logger: logger.Log,
+		breaker: getCircuitBreaker("serper", cfg),
+		blocked: NewDomainBlocklist(cfg.FactCheckBlockedDomains),
+		logger:  logger.Log,
 	}
 }
 
@@ -110,7 +156,7 @@ func (c *SerperClient) Search(ctx context.Context, agentName, query string, numR
 	}
 	
 	start := time.Now()
-	correlationID := getCorrelationIDFromContext(ctx)
+	correlationID := requestctx.CorrelationID(ctx)
 	
 	c.logger.WithFields(map[string]interface{}{
 		"agent":          agentName,
@@ -140,12 +186,24 @@ func (c *SerperClient) Search(ctx context.Context, agentName, query string, numR
 	httpReq.Header.Set("Content-Type", "application/json")
 	httpReq.Header.Set("X-API-KEY", c.apiKey)
 	
+	// Fail fast while the provider's circuit breaker is open
+	if err := c.breaker.Allow(); err != nil {
+		c.logger.WithFields(map[string]interface{}{
+			"agent":          agentName,
+			"correlation_id": correlationID,
+			"error":          err.Error(),
+		}).Warn("Serper call short-circuited")
+		return nil, err
+	}
+	
 	// Make the request
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
+		c.breaker.RecordResult(ctx, 0, err)
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
 	defer resp.Body.Close()
+	c.breaker.RecordResult(ctx, resp.StatusCode, nil)
 	
 	// Read the response
 	responseBody, err := io.ReadAll(resp.Body)
@@ -174,7 +232,7 @@ func (c *SerperClient) Search(ctx context.Context, agentName, query string, numR
 		"agent":          agentName,
 		"correlation_id": correlationID,
 		"duration_ms":    duration.Milliseconds(),
-		"results_count":  len(serperResp.Organic),
+		"results_count":  len(serperResp.Organic) + len(serperResp.News),
 		"has_answer_box": serperResp.AnswerBox != nil,
 		"has_knowledge_graph": serperResp.KnowledgeGraph != nil,
 	}).Info("Serper search completed")
@@ -206,10 +264,11 @@ func (c *SerperClient) extractSearchContext(results *SerperResponse) *SearchCont
 	context := &SearchContext{
 		Snippets:     []SearchSnippet{},
 		Sources:      []string{},
-		TotalResults: len(results.Organic),
+		TotalResults: len(results.Organic) + len(results.News),
 	}
 	
 	// Add answer box if available (highest priority)
+	context.DirectAnswer = parseAnswerBox(results.AnswerBox)
 	if results.AnswerBox != nil {
 		snippet := results.AnswerBox.Snippet
 		if snippet == "" {
@@ -243,11 +302,14 @@ func (c *SerperClient) extractSearchContext(results *SerperResponse) *SearchCont
 		}
 	}
 	
-	// Add organic search results
-	for _, result := range results.Organic {
+	// Add organic (web and scholar) and news results
+	searchResults := make([]SerperResult, 0, len(results.Organic)+len(results.News))
+	searchResults = append(searchResults, results.Organic...)
+	searchResults = append(searchResults, results.News...)
+	for _, result := range searchResults {
 		if result.Snippet != "" {
 			context.Snippets = append(context.Snippets, SearchSnippet{
-				Title:   result.Title,
+				Title:   result.displayTitle(),
 				Snippet: result.Snippet,
 				URL:     result.Link,
 			})
@@ -258,6 +320,38 @@ func (c *SerperClient) extractSearchContext(results *SerperResponse) *SearchCont
 		}
 	}
 	
+	return c.filterBlockedDomains(context)
+}
+
+// filterBlockedDomains drops snippets and sources on blocked domains. When that would leave
+// no sources at all, the results are kept as they were and flagged as low quality instead.
+func (c *SerperClient) filterBlockedDomains(context *SearchContext) *SearchContext {
+	if len(c.blocked) == 0 {
+		return context
+	}
+	
+	sources := c.blocked.Filter(context.Sources)
+	blocked := len(context.Sources) - len(sources)
+	if blocked == 0 {
+		return context
+	}
+	if len(sources) == 0 {
+		context.LowSourceQuality = true
+		return context
+	}
+	
+	snippets := make([]SearchSnippet, 0, len(context.Snippets))
+	for _, snippet := range context.Snippets {
+		if !c.blocked.Blocks(snippet.URL) {
+			snippets = append(snippets, snippet)
+		}
+	}
+	context.Snippets = snippets
+	context.Sources = sources
+	context.BlockedResults = blocked
+	if context.DirectAnswer != nil && c.blocked.Blocks(context.DirectAnswer.Source) {
+		context.DirectAnswer = nil
+	}
 	return context
 }
 
@@ -278,13 +372,17 @@ func (c *SerperClient) optimizeClaimQuery(claim string) string {
 	return query
 }
 
-// FormatSearchResultsForAnalysis formats search results into readable text for Claude analysis
+// FormatSearchResultsForAnalysis formats search results into readable text for Claude
+// analysis, leading with the structured answer box when there is one
 func (c *SerperClient) FormatSearchResultsForAnalysis(context *SearchContext) string {
 	if len(context.Snippets) == 0 {
 		return "No search results found."
 	}
 	
 	var results []string
+	if context.DirectAnswer != nil {
+		results = append(results, formatDirectAnswer(context.DirectAnswer))
+	}
 	
 	// Limit to top 3 results to avoid overwhelming Claude
 	maxResults := 3
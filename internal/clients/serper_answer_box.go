@@ -0,0 +1,126 @@
+package clients
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Kinds of direct answer an answer box can hold
+const (
+	AnswerKindDate       = "date"       // A date or year, e.g. "July 20, 1969"
+	AnswerKindNumber     = "number"     // A figure, measurement or calculation result, e.g. "8,849 m"
+	AnswerKindDefinition = "definition" // A dictionary-style definition
+	AnswerKindText       = "text"       // Any other short answer
+)
+
+// DirectAnswer is the structured content of a Serper answer box, kept apart from the
+// ordinary snippets because a direct date or figure is strong evidence for or against a
+// claim that states one
+type DirectAnswer struct {
+	Kind        string   `json:"kind"`
+	Answer      string   `json:"answer,omitempty"`  // The answer box's direct answer, when it has one
+	Snippet     string   `json:"snippet,omitempty"` // The supporting passage, when it has one
+	Highlighted []string `json:"highlighted,omitempty"`
+	Date        string   `json:"date,omitempty"` // Publication date of the source page
+	Title       string   `json:"title,omitempty"`
+	Source      string   `json:"source,omitempty"`
+}
+
+var (
+	monthNames = `(?:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sep(?:t(?:ember)?)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)\.?`
+
+	// dateAnswerPattern matches answers that are a date or a year, e.g. "1969",
+	// "July 20, 1969", "20 July 1969", "1969-07-20", "7/20/1969" or "Sunday, July 20, 1969"
+	dateAnswerPattern = regexp.MustCompile(`(?i)^(?:[a-z]+day,?\s+)?(?:` +
+		`\d{4}-\d{1,2}-\d{1,2}|` +
+		`\d{1,2}/\d{1,2}/\d{2,4}|` +
+		monthNames + `\s+\d{1,2}(?:st|nd|rd|th)?,?\s+\d{4}|` +
+		`\d{1,2}(?:st|nd|rd|th)?\s+` + monthNames + `,?\s+\d{4}|` +
+		monthNames + `\s+\d{4}|` +
+		`(?:c\.\s*|circa\s+)?\d{3,4}(?:\s*(?:ad|bc|bce|ce))?` +
+		`)\.?$`)
+
+	// numberAnswerPattern matches answers led by a figure, optionally after a qualifier or
+	// the "=" of a calculation, e.g. "8,849 m", "about 3.2 million", "$1.5 billion" or "= 42"
+	numberAnswerPattern = regexp.MustCompile(`(?i)^(?:=\s*|(?:about|approximately|approx\.|around|roughly|over|under|nearly|almost|more than|less than)\s+|[~≈]\s*)?[-+−]?[$€£¥]?\s*\d[\d,]*(?:\.\d+)?`)
+)
+
+// definitionTitleHints mark answer boxes that define a word rather than answer a question
+var definitionTitleHints = []string{"definition", "meaning", "dictionary"}
+
+// parseAnswerBox turns a Serper answer box into a direct answer, or nil when it has neither
+// an answer nor a snippet
+func parseAnswerBox(box *SerperAnswerBox) *DirectAnswer {
+	if box == nil {
+		return nil
+	}
+	answer := strings.TrimSpace(box.Answer)
+	snippet := strings.TrimSpace(box.Snippet)
+	if answer == "" && snippet == "" {
+		return nil
+	}
+
+	return &DirectAnswer{
+		Kind:        classifyAnswer(answer, box.Title),
+		Answer:      answer,
+		Snippet:     snippet,
+		Highlighted: box.SnippetHighlighted,
+		Date:        box.Date,
+		Title:       box.Title,
+		Source:      box.Link,
+	}
+}
+
+// classifyAnswer works out what kind of answer an answer box gives from its direct answer,
+// falling back to its title for boxes that only have a snippet
+func classifyAnswer(answer, title string) string {
+	if answer != "" {
+		switch {
+		case dateAnswerPattern.MatchString(answer):
+			return AnswerKindDate
+		case numberAnswerPattern.MatchString(answer):
+			return AnswerKindNumber
+		}
+	}
+
+	lowerTitle := strings.ToLower(title)
+	for _, hint := range definitionTitleHints {
+		if strings.Contains(lowerTitle, hint) {
+			return AnswerKindDefinition
+		}
+	}
+	return AnswerKindText
+}
+
+// formatDirectAnswer describes a direct answer for the verification prompt, with guidance
+// on how to weigh it against the claim
+func formatDirectAnswer(answer *DirectAnswer) string {
+	lines := []string{fmt.Sprintf("Direct answer (%s):", answer.Kind)}
+	if answer.Answer != "" {
+		lines = append(lines, "Answer: "+answer.Answer)
+	}
+	if answer.Snippet != "" {
+		lines = append(lines, "Context: "+answer.Snippet)
+	}
+	if len(answer.Highlighted) > 0 {
+		lines = append(lines, "Highlighted: "+strings.Join(answer.Highlighted, "; "))
+	}
+	if answer.Title != "" {
+		lines = append(lines, "Title: "+answer.Title)
+	}
+	if answer.Date != "" {
+		lines = append(lines, "Published: "+answer.Date)
+	}
+	if answer.Source != "" {
+		lines = append(lines, "Source: "+answer.Source)
+	}
+
+	switch answer.Kind {
+	case AnswerKindDate:
+		lines = append(lines, "Note: This is a date answer. Compare it directly with any date or year in the claim; a different year or date contradicts the claim.")
+	case AnswerKindNumber:
+		lines = append(lines, "Note: This is a numeric answer. Compare it directly with any figure in the claim, allowing for rounding and units; a figure that is clearly different contradicts the claim.")
+	}
+	return strings.Join(lines, "\n")
+}
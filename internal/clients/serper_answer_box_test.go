@@ -0,0 +1,96 @@
+package clients
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyAnswer(t *testing.T) {
+	tests := []struct {
+		answer   string
+		title    string
+		expected string
+	}{
+		{"1969", "", AnswerKindDate},
+		{"July 20, 1969", "", AnswerKindDate},
+		{"20 July 1969", "", AnswerKindDate},
+		{"Sunday, July 20, 1969", "", AnswerKindDate},
+		{"1969-07-20", "", AnswerKindDate},
+		{"Sept. 1957", "", AnswerKindDate},
+		{"8,849 m", "", AnswerKindNumber},
+		{"about 3.2 million", "", AnswerKindNumber},
+		{"$1.5 billion", "", AnswerKindNumber},
+		{"= 42", "", AnswerKindNumber},
+		{"12%", "", AnswerKindNumber},
+		{"", "Serendipity Definition & Meaning", AnswerKindDefinition},
+		{"Neil Armstrong", "Who first walked on the moon", AnswerKindText},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer+tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classifyAnswer(tt.answer, tt.title))
+		})
+	}
+}
+
+func TestParseAnswerBox(t *testing.T) {
+	assert.Nil(t, parseAnswerBox(nil))
+	assert.Nil(t, parseAnswerBox(&SerperAnswerBox{Title: "Empty", Link: "https://example.com"}))
+
+	answer := parseAnswerBox(&SerperAnswerBox{
+		Answer:             " 8,849 m ",
+		Snippet:            "Mount Everest is 8,849 metres tall.",
+		SnippetHighlighted: []string{"8,849 metres"},
+		Title:              "Mount Everest height",
+		Link:               "https://example.com/everest",
+	})
+	require.NotNil(t, answer)
+	assert.Equal(t, AnswerKindNumber, answer.Kind)
+	assert.Equal(t, "8,849 m", answer.Answer)
+	assert.Equal(t, "Mount Everest is 8,849 metres tall.", answer.Snippet)
+	assert.Equal(t, []string{"8,849 metres"}, answer.Highlighted)
+	assert.Equal(t, "https://example.com/everest", answer.Source)
+}
+
+func TestSerperClient_extractSearchContext_DirectAnswer(t *testing.T) {
+	client, _ := setupTestSerperClient()
+
+	response := &SerperResponse{
+		AnswerBox: &SerperAnswerBox{
+			Answer:  "July 20, 1969",
+			Snippet: "Apollo 11 landed on the Moon on July 20, 1969.",
+			Title:   "Apollo 11",
+			Link:    "https://nasa.gov/apollo11",
+		},
+		Organic: []SerperResult{{Title: "Apollo program", Link: "https://example.com/apollo", Snippet: "The Apollo program ran from 1961 to 1972."}},
+	}
+
+	result := client.extractSearchContext(response)
+	require.NotNil(t, result.DirectAnswer)
+	assert.Equal(t, AnswerKindDate, result.DirectAnswer.Kind)
+	assert.Equal(t, "July 20, 1969", result.DirectAnswer.Answer)
+	assert.Equal(t, "Apollo 11 landed on the Moon on July 20, 1969.", result.DirectAnswer.Snippet)
+
+	formatted := client.FormatSearchResultsForAnalysis(result)
+	assert.Contains(t, formatted, "Direct answer (date):\nAnswer: July 20, 1969")
+	assert.Contains(t, formatted, "This is a date answer")
+	assert.Contains(t, formatted, "Result 1:")
+	assert.True(t, strings.HasPrefix(formatted, "Direct answer"), "the direct answer leads the search results")
+}
+
+func TestSerperClient_extractSearchContext_BlockedDirectAnswer(t *testing.T) {
+	client, _ := setupTestSerperClient()
+	client.blocked = NewDomainBlocklist([]string{"contentfarm.example"})
+
+	response := &SerperResponse{
+		AnswerBox: &SerperAnswerBox{Answer: "1972", Title: "Moon", Link: "https://contentfarm.example/moon"},
+		Organic:   []SerperResult{{Title: "NASA", Link: "https://nasa.gov/apollo", Snippet: "Apollo 11 landed in 1969"}},
+	}
+
+	result := client.extractSearchContext(response)
+	assert.Nil(t, result.DirectAnswer)
+	assert.NotContains(t, client.FormatSearchResultsForAnalysis(result), "Direct answer")
+}
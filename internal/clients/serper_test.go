@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/requestctx"
 
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
@@ -96,7 +97,7 @@ func TestSerperClient_Search_Success(t *testing.T) {
 	client, _ := setupTestSerperClient()
 	client.baseURL = server.URL + "/search"
 
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-123")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-123")
 	result, err := client.Search(ctx, "test-agent", "test query", 5)
 
 	assert.NoError(t, err)
@@ -324,6 +325,32 @@ func TestSerperClient_extractSearchContext_AnswerBoxWithoutSnippet(t *testing.T)
 	assert.Equal(t, "Direct answer without snippet", result.Snippets[0].Snippet)
 }
 
+func TestSerperClient_extractSearchContext_BlockedDomains(t *testing.T) {
+	client, _ := setupTestSerperClient()
+	client.blocked = NewDomainBlocklist([]string{"contentfarm.example"})
+
+	response := &SerperResponse{
+		Organic: []SerperResult{
+			{Title: "Farm", Link: "https://www.contentfarm.example/moon", Snippet: "Low quality"},
+			{Title: "NASA", Link: "https://nasa.gov/apollo", Snippet: "Apollo 11 landed in 1969"},
+		},
+	}
+
+	result := client.extractSearchContext(response)
+	assert.Equal(t, []string{"https://nasa.gov/apollo"}, result.Sources)
+	assert.Len(t, result.Snippets, 1)
+	assert.Equal(t, "NASA", result.Snippets[0].Title)
+	assert.Equal(t, 1, result.BlockedResults)
+	assert.False(t, result.LowSourceQuality)
+
+	// With only blocked results the search is kept as it was and flagged
+	response.Organic = response.Organic[:1]
+	result = client.extractSearchContext(response)
+	assert.Equal(t, []string{"https://www.contentfarm.example/moon"}, result.Sources)
+	assert.Len(t, result.Snippets, 1)
+	assert.True(t, result.LowSourceQuality)
+}
+
 func TestSerperClient_optimizeClaimQuery(t *testing.T) {
 	client, _ := setupTestSerperClient()
 
@@ -483,4 +510,54 @@ func TestSerperClient_FormatSearchResultsForAnalysis_LimitsToThreeResults(t *tes
 	// Count occurrences to verify exactly 3 results (6 total "Result " strings due to titles)
 	resultCount := strings.Count(result, "Result ")
 	assert.Equal(t, 6, resultCount) // 3 results * 2 occurrences each
-}
\ No newline at end of file
+}
+func TestNewSerperClient_Endpoint(t *testing.T) {
+	for _, endpoint := range []string{"search", "news", "scholar"} {
+		client := NewSerperClient(&config.Config{SerperAPIKey: "test-serper-key", SerperEndpoint: endpoint})
+		assert.Equal(t, "https://google.serper.dev/"+endpoint, client.baseURL)
+	}
+}
+
+func TestSerperClient_SearchForClaim_NewsAndScholar(t *testing.T) {
+	tests := []struct {
+		name          string
+		endpoint      string
+		body          string
+		expectedTitle string
+	}{
+		{
+			name:          "news",
+			endpoint:      "news",
+			body:          `{"news": [{"title": "Rates held", "link": "https://news.example.com/rates", "snippet": "The bank held rates.", "date": "2 days ago", "source": "Example News"}]}`,
+			expectedTitle: "Rates held (Example News, 2 days ago)",
+		},
+		{
+			name:          "scholar",
+			endpoint:      "scholar",
+			body:          `{"organic": [{"title": "Sleep and memory", "link": "https://journal.example.com/sleep", "snippet": "Sleep consolidates memory.", "publicationInfo": "A Author - Journal of Sleep", "year": 2019, "citedBy": 120}]}`,
+			expectedTitle: "Sleep and memory (A Author - Journal of Sleep, 2019)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/"+tt.endpoint, r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			client, _ := setupTestSerperClient()
+			client.baseURL = server.URL + "/" + tt.endpoint
+
+			result, err := client.SearchForClaim(context.Background(), "test-agent", "some claim")
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, result.TotalResults)
+			assert.Len(t, result.Snippets, 1)
+			assert.Equal(t, tt.expectedTitle, result.Snippets[0].Title)
+			assert.Len(t, result.Sources, 1)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"context"
+	"sync"
+)
+
+// stopReasonMaxTokens is the stop_reason Anthropic reports when a response hit the
+// max_tokens budget, so its text ends wherever the budget ran out
+const stopReasonMaxTokens = "max_tokens"
+
+// Defaults applied when the output token budget is not configured
+const defaultAnthropicMaxTokens = 4000
+
+// TruncationRecorder collects the agents whose responses were cut off by the output token
+// budget even after retrying with a larger one
+type TruncationRecorder struct {
+	mu     sync.Mutex
+	agents []string
+}
+
+// WithTruncationRecorder returns a context whose Anthropic calls report truncated responses
+// to recorder
+func WithTruncationRecorder(ctx context.Context, recorder *TruncationRecorder) context.Context {
+	return context.WithValue(ctx, truncationRecorderContextKey, recorder)
+}
+
+// Agents returns the distinct agents with a truncated response, in the order they were
+// first recorded
+func (r *TruncationRecorder) Agents() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.agents...)
+}
+
+// record adds an agent unless it was already seen
+func (r *TruncationRecorder) record(agentName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, seen := range r.agents {
+		if seen == agentName {
+			return
+		}
+	}
+	r.agents = append(r.agents, agentName)
+}
+
+// recordTruncation reports a truncated response to the context's recorder, if it has one
+func recordTruncation(ctx context.Context, agentName string) {
+	if recorder, ok := ctx.Value(truncationRecorderContextKey).(*TruncationRecorder); ok && recorder != nil {
+		recorder.record(agentName)
+	}
+}
+
+// nextMaxTokens doubles a token budget up to limit, reporting false once the budget is
+// already at the limit
+func nextMaxTokens(current, limit int) (int, bool) {
+	if current >= limit {
+		return current, false
+	}
+	next := current * 2
+	if next > limit {
+		next = limit
+	}
+	return next, true
+}
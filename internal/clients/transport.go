@@ -0,0 +1,58 @@
+package clients
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"podcast-analyzer/internal/config"
+)
+
+// Connection pool defaults for outbound API calls. Go's default of two idle connections
+// per host forces new TLS handshakes once several fact checks run at once.
+const (
+	defaultHTTPMaxIdleConns        = 100
+	defaultHTTPMaxIdleConnsPerHost = 16
+	defaultHTTPIdleConnTimeout     = 90 * time.Second
+)
+
+// apiTransport is the connection pool shared by the Anthropic and Serper clients
+var (
+	apiTransportMu sync.Mutex
+	apiTransport   *http.Transport
+)
+
+// NewAPITransport creates a transport tuned for outbound API calls from the configured
+// pool limits, keeping the standard proxy, dial and TLS settings
+func NewAPITransport(cfg *config.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.MaxIdleConns = cfg.HTTPMaxIdleConns
+	if transport.MaxIdleConns <= 0 {
+		transport.MaxIdleConns = defaultHTTPMaxIdleConns
+	}
+	transport.MaxIdleConnsPerHost = cfg.HTTPMaxIdleConnsPerHost
+	if transport.MaxIdleConnsPerHost <= 0 {
+		transport.MaxIdleConnsPerHost = defaultHTTPMaxIdleConnsPerHost
+	}
+	if cfg.HTTPMaxConnsPerHost > 0 {
+		transport.MaxConnsPerHost = cfg.HTTPMaxConnsPerHost
+	}
+	transport.IdleConnTimeout = cfg.HTTPIdleConnTimeout
+	if transport.IdleConnTimeout <= 0 {
+		transport.IdleConnTimeout = defaultHTTPIdleConnTimeout
+	}
+	return transport
+}
+
+// getAPITransport returns the shared transport, creating it on first use so every client
+// instance reuses the same idle connections
+func getAPITransport(cfg *config.Config) *http.Transport {
+	apiTransportMu.Lock()
+	defer apiTransportMu.Unlock()
+
+	if apiTransport == nil {
+		apiTransport = NewAPITransport(cfg)
+	}
+	return apiTransport
+}
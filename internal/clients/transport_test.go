@@ -0,0 +1,41 @@
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPITransport(t *testing.T) {
+	defaults := NewAPITransport(&config.Config{})
+	assert.Equal(t, defaultHTTPMaxIdleConns, defaults.MaxIdleConns)
+	assert.Equal(t, defaultHTTPMaxIdleConnsPerHost, defaults.MaxIdleConnsPerHost)
+	assert.Equal(t, 0, defaults.MaxConnsPerHost)
+	assert.Equal(t, defaultHTTPIdleConnTimeout, defaults.IdleConnTimeout)
+	assert.NotNil(t, defaults.Proxy)
+
+	tuned := NewAPITransport(&config.Config{
+		HTTPMaxIdleConns:        50,
+		HTTPMaxIdleConnsPerHost: 8,
+		HTTPMaxConnsPerHost:     32,
+		HTTPIdleConnTimeout:     30 * time.Second,
+	})
+	assert.Equal(t, 50, tuned.MaxIdleConns)
+	assert.Equal(t, 8, tuned.MaxIdleConnsPerHost)
+	assert.Equal(t, 32, tuned.MaxConnsPerHost)
+	assert.Equal(t, 30*time.Second, tuned.IdleConnTimeout)
+}
+
+func TestAPIClients_ShareTransport(t *testing.T) {
+	cfg := &config.Config{AnthropicAPIKey: "test-key", SerperAPIKey: "test-key"}
+
+	anthropic := NewAnthropicClient(cfg).httpClient
+	serper := NewSerperClient(cfg).httpClient
+	require.NotNil(t, anthropic.Transport)
+	assert.Same(t, anthropic.Transport, serper.Transport)
+	assert.Same(t, getAPITransport(cfg), anthropic.Transport)
+}
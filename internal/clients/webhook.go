@@ -0,0 +1,205 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/requestctx"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WebhookSignatureHeader carries the HMAC-SHA256 of the request body, keyed with the
+// configured webhook secret, as "sha256=<hex>"
+const WebhookSignatureHeader = "X-Webhook-Signature"
+
+// WebhookEventHeader names the event a webhook delivers
+const WebhookEventHeader = "X-Webhook-Event"
+
+// Webhook delivery defaults used when nothing is configured
+const (
+	defaultWebhookMaxAttempts = 3
+	defaultWebhookTimeout     = 10 * time.Second
+	webhookRetryDelay         = 2 * time.Second
+)
+
+// errPrivateAddress is returned when a delivery would connect to a private address
+var errPrivateAddress = errors.New("webhook delivery to a private address refused")
+
+// WebhookSender delivers signed event notifications to caller-supplied URLs
+type WebhookSender interface {
+	Deliver(ctx context.Context, callbackURL, event string, payload interface{}) error
+}
+
+// WebhookClient posts JSON events to callback URLs. Connections to private, loopback and
+// link-local addresses are refused at dial time, so a hostname that resolves to an
+// internal address cannot be used to reach internal services.
+type WebhookClient struct {
+	secret      string
+	maxAttempts int
+	retryDelay  time.Duration
+	httpClient  *http.Client
+	logger      *logrus.Logger
+}
+
+// NewWebhookClient creates a webhook client from the webhook configuration
+func NewWebhookClient(cfg *config.Config) *WebhookClient {
+	maxAttempts := cfg.WebhookMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+	timeout := cfg.WebhookTimeout
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, Control: refusePrivateAddress}
+	return &WebhookClient{
+		secret:      cfg.WebhookSecret,
+		maxAttempts: maxAttempts,
+		retryDelay:  webhookRetryDelay,
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+			// A redirect could point the delivery somewhere the URL check never saw
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger.Log,
+	}
+}
+
+// ValidateWebhookURL checks that a callback URL is absolute http(s) and does not name a
+// private address directly. Hostnames are checked again when the delivery connects.
+func ValidateWebhookURL(rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %v", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("URL scheme must be http or https")
+	}
+	host := parsed.Hostname()
+	if host == "" {
+		return fmt.Errorf("URL must include a host")
+	}
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return fmt.Errorf("URL must not point to a private address")
+	}
+	if ip := net.ParseIP(host); ip != nil && isPrivateIP(ip) {
+		return fmt.Errorf("URL must not point to a private address")
+	}
+	return nil
+}
+
+// isPrivateIP reports whether ip is loopback, private, link-local or otherwise not
+// publicly routable
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
+
+// refusePrivateAddress is a dialer control that rejects connections to private addresses
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isPrivateIP(ip) {
+		return fmt.Errorf("%w: %s", errPrivateAddress, host)
+	}
+	return nil
+}
+
+// Sign returns the signature header value for a webhook body
+func (c *WebhookClient) Sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(c.secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver posts payload to callbackURL, retrying network errors, 429s and 5xx responses
+// up to the configured number of attempts
+func (c *WebhookClient) Deliver(ctx context.Context, callbackURL, event string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+	correlationID := requestctx.CorrelationID(ctx)
+
+	var lastErr error
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(c.retryDelay * time.Duration(1<<(attempt-2))):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		retryable, err := c.post(ctx, callbackURL, event, body)
+		if err == nil {
+			c.logger.WithFields(map[string]interface{}{
+				"correlation_id": correlationID,
+				"event":          event,
+				"attempt":        attempt,
+			}).Info("Webhook delivered")
+			return nil
+		}
+		lastErr = err
+
+		c.logger.WithFields(map[string]interface{}{
+			"correlation_id": correlationID,
+			"event":          event,
+			"attempt":        attempt,
+			"max_attempts":   c.maxAttempts,
+			"error":          err.Error(),
+		}).Warn("Webhook delivery attempt failed")
+
+		if !retryable {
+			break
+		}
+	}
+	return fmt.Errorf("webhook delivery failed: %w", lastErr)
+}
+
+// post makes one delivery attempt and reports whether a failure is worth retrying
+func (c *WebhookClient) post(ctx context.Context, callbackURL, event string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	if c.secret != "" {
+		req.Header.Set(WebhookSignatureHeader, c.Sign(body))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		retryable := ctx.Err() == nil && !errors.Is(err, errPrivateAddress)
+		return retryable, fmt.Errorf("webhook request failed: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("webhook endpoint returned status %d", resp.StatusCode)
+}
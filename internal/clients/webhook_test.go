@@ -0,0 +1,148 @@
+package clients
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestWebhookClient returns a client that may reach the local test server, which the
+// private address guard would otherwise refuse
+func setupTestWebhookClient(secret string) *WebhookClient {
+	client := NewWebhookClient(&config.Config{WebhookSecret: secret, WebhookMaxAttempts: 3})
+	client.retryDelay = time.Millisecond
+	client.httpClient = &http.Client{Timeout: time.Second}
+	return client
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		errorMsg string
+	}{
+		{name: "public https", url: "https://hooks.example.com/uploads"},
+		{name: "public http with port", url: "http://hooks.example.com:8080/uploads"},
+		{name: "unsupported scheme", url: "ftp://hooks.example.com/uploads", errorMsg: "scheme must be http or https"},
+		{name: "relative", url: "/uploads", errorMsg: "scheme must be http or https"},
+		{name: "missing host", url: "https:///uploads", errorMsg: "must include a host"},
+		{name: "localhost", url: "http://localhost:9000/hook", errorMsg: "private address"},
+		{name: "loopback", url: "http://127.0.0.1/hook", errorMsg: "private address"},
+		{name: "private range", url: "http://10.1.2.3/hook", errorMsg: "private address"},
+		{name: "link-local metadata", url: "http://169.254.169.254/latest", errorMsg: "private address"},
+		{name: "ipv6 loopback", url: "http://[::1]/hook", errorMsg: "private address"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWebhookURL(tt.url)
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestWebhookClient_Deliver_Signed(t *testing.T) {
+	var body []byte
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		headers = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := setupTestWebhookClient("test-secret")
+	err := client.Deliver(context.Background(), server.URL, "transcript.uploaded", map[string]string{"filename": "episode.txt"})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"filename": "episode.txt"}`, string(body))
+	assert.Equal(t, "transcript.uploaded", headers.Get(WebhookEventHeader))
+	assert.Equal(t, client.Sign(body), headers.Get(WebhookSignatureHeader))
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, headers.Get(WebhookSignatureHeader))
+}
+
+func TestWebhookClient_Deliver_UnsignedWithoutSecret(t *testing.T) {
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(WebhookSignatureHeader)
+	}))
+	defer server.Close()
+
+	err := setupTestWebhookClient("").Deliver(context.Background(), server.URL, "transcript.uploaded", struct{}{})
+
+	require.NoError(t, err)
+	assert.Empty(t, signature)
+}
+
+func TestWebhookClient_Deliver_RetriesServerErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	err := setupTestWebhookClient("test-secret").Deliver(context.Background(), server.URL, "transcript.uploaded", struct{}{})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestWebhookClient_Deliver_GivesUp(t *testing.T) {
+	tests := []struct {
+		name             string
+		status           int
+		expectedAttempts int32
+	}{
+		{name: "server errors exhaust attempts", status: http.StatusBadGateway, expectedAttempts: 3},
+		{name: "client errors are not retried", status: http.StatusBadRequest, expectedAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&attempts, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := setupTestWebhookClient("test-secret").Deliver(context.Background(), server.URL, "transcript.uploaded", struct{}{})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "webhook delivery failed")
+			assert.Equal(t, tt.expectedAttempts, atomic.LoadInt32(&attempts))
+		})
+	}
+}
+
+func TestWebhookClient_Deliver_RefusesPrivateAddressAtDial(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+	}))
+	defer server.Close()
+
+	// The default client guards the dial, which catches hostnames resolving to private addresses
+	client := NewWebhookClient(&config.Config{})
+	err := client.Deliver(context.Background(), server.URL, "transcript.uploaded", struct{}{})
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errPrivateAddress)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&attempts))
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/requestctx"
+	"podcast-analyzer/internal/services"
+	"podcast-analyzer/internal/utils"
+)
+
+// AdminServiceInterface defines the interface for operator-facing service calls
+type AdminServiceInterface interface {
+	GetQueueStats() (*services.QueueStats, error)
+}
+
+// AdminTranscriptServiceInterface defines the operator-facing transcript maintenance calls
+type AdminTranscriptServiceInterface interface {
+	ReparseAllTranscripts(correlationID string) (*services.ReparseSummary, error)
+}
+
+type AdminHandler struct {
+	adminService      AdminServiceInterface
+	transcriptService AdminTranscriptServiceInterface
+}
+
+func NewAdminHandler(adminService AdminServiceInterface) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+	}
+}
+
+// WithTranscriptService sets the service behind the transcript maintenance endpoints
+func (h *AdminHandler) WithTranscriptService(transcriptService AdminTranscriptServiceInterface) *AdminHandler {
+	h.transcriptService = transcriptService
+	return h
+}
+
+// GetQueueStats returns the analysis job backlog
+func (h *AdminHandler) GetQueueStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	correlationID := utils.GetCorrelationID(r)
+
+	stats, err := h.adminService.GetQueueStats()
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "get_queue_stats",
+		})
+		utils.WriteErrorWithCorrelation(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve queue stats", correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, stats)
+}
+
+// ReparseTranscripts re-derives word counts and metadata for every stored transcript
+func (h *AdminHandler) ReparseTranscripts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	correlationID := utils.GetCorrelationID(r)
+	logger.WithCorrelationID(correlationID).WithField("user", requestctx.User(r.Context())).Info("Reparse of all transcripts requested")
+
+	summary, err := h.transcriptService.ReparseAllTranscripts(correlationID)
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "reparse_all_transcripts",
+		})
+		utils.WriteErrorWithCorrelation(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to reparse transcripts", correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, summary)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"podcast-analyzer/internal/services"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAdminService for testing
+type MockAdminService struct {
+	mock.Mock
+}
+
+func (m *MockAdminService) GetQueueStats() (*services.QueueStats, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.QueueStats), args.Error(1)
+}
+
+// MockAdminTranscriptService for testing
+type MockAdminTranscriptService struct {
+	mock.Mock
+}
+
+func (m *MockAdminTranscriptService) ReparseAllTranscripts(correlationID string) (*services.ReparseSummary, error) {
+	args := m.Called(correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.ReparseSummary), args.Error(1)
+}
+
+func TestAdminHandler_GetQueueStats(t *testing.T) {
+	mockService := &MockAdminService{}
+	handler := NewAdminHandler(mockService)
+
+	oldest := time.Now().Add(-5 * time.Minute)
+	mockService.On("GetQueueStats").Return(&services.QueueStats{
+		Pending:                 3,
+		Processing:              1,
+		OldestPendingAt:         &oldest,
+		OldestPendingAgeSeconds: 300,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/queue", nil)
+	recorder := httptest.NewRecorder()
+	handler.GetQueueStats(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, float64(3), response["pending"])
+	assert.Equal(t, float64(1), response["processing"])
+	assert.Equal(t, float64(300), response["oldest_pending_age_seconds"])
+	assert.NotNil(t, response["oldest_pending_at"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAdminHandler_GetQueueStats_ServiceError(t *testing.T) {
+	mockService := &MockAdminService{}
+	handler := NewAdminHandler(mockService)
+
+	mockService.On("GetQueueStats").Return(nil, errors.New("database unavailable"))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/queue", nil)
+	recorder := httptest.NewRecorder()
+	handler.GetQueueStats(recorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAdminHandler_GetQueueStats_MethodNotAllowed(t *testing.T) {
+	handler := NewAdminHandler(&MockAdminService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/queue", nil)
+	recorder := httptest.NewRecorder()
+	handler.GetQueueStats(recorder, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestAdminHandler_ReparseTranscripts(t *testing.T) {
+	transcriptService := &MockAdminTranscriptService{}
+	handler := NewAdminHandler(&MockAdminService{}).WithTranscriptService(transcriptService)
+
+	failedID := uuid.New()
+	transcriptService.On("ReparseAllTranscripts", mock.AnythingOfType("string")).Return(&services.ReparseSummary{
+		Processed: 3,
+		Updated:   1,
+		Failed:    []services.ReparseFailure{{TranscriptID: failedID, Error: "transcript file not found"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/transcripts/reparse", nil)
+	recorder := httptest.NewRecorder()
+	handler.ReparseTranscripts(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, float64(3), response["processed"])
+	assert.Equal(t, float64(1), response["updated"])
+	failed := response["failed"].([]interface{})
+	require.Len(t, failed, 1)
+	assert.Equal(t, failedID.String(), failed[0].(map[string]interface{})["transcript_id"])
+	transcriptService.AssertExpectations(t)
+}
+
+func TestAdminHandler_ReparseTranscripts_Errors(t *testing.T) {
+	transcriptService := &MockAdminTranscriptService{}
+	handler := NewAdminHandler(&MockAdminService{}).WithTranscriptService(transcriptService)
+	transcriptService.On("ReparseAllTranscripts", mock.AnythingOfType("string")).Return(nil, errors.New("database unavailable"))
+
+	recorder := httptest.NewRecorder()
+	handler.ReparseTranscripts(recorder, httptest.NewRequest(http.MethodPost, "/api/admin/transcripts/reparse", nil))
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ReparseTranscripts(recorder, httptest.NewRequest(http.MethodGet, "/api/admin/transcripts/reparse", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -1,11 +1,19 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
 	"podcast-analyzer/internal/services"
 	"podcast-analyzer/internal/logger"
 	"podcast-analyzer/internal/utils"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,21 +21,49 @@ import (
 // AnalysisServiceInterface defines the interface for analysis service
 type AnalysisServiceInterface interface {
 	CreateAnalysisJob(req *services.AnalysisJobRequest, correlationID string) (*services.AnalysisJobResponse, error)
+	AnalyzeText(req *services.AnalyzeTextRequest, correlationID string) (*services.AnalysisJobResponse, error)
 	GetJobStatus(jobID uuid.UUID, correlationID string) (*services.JobStatusResponse, error)
-	ListAnalysisResults(page, perPage int) ([]*services.AnalysisResultsResponse, int64, error)
-	GetAnalysisResults(analysisID uuid.UUID, correlationID string) (*services.AnalysisResultsResponse, error)
+	WaitForJobStatus(ctx context.Context, jobID uuid.UUID, since string, wait time.Duration, correlationID string) (*services.JobStatusResponse, error)
+	GetJobEvents(jobID uuid.UUID, correlationID string) (*services.JobEventsResponse, error)
+	RetryAnalysisJob(jobID uuid.UUID, correlationID string) (*services.AnalysisJobResponse, error)
+	ListJobs(page, perPage int, status string, dateRange services.DateRange) ([]*services.JobStatusResponse, int64, error)
+	ListAnalysisResults(page, perPage int, dateRange services.DateRange) ([]*services.AnalysisResultsResponse, int64, error)
+	GetAnalysisResults(analysisID uuid.UUID, filter services.FactCheckFilter, correlationID string) (*services.AnalysisResultsResponse, error)
+	GetFactCheck(factCheckID uuid.UUID, correlationID string) (*services.FactCheckDetailResponse, error)
+	CreateAnalysisNote(analysisID uuid.UUID, req *services.CreateAnalysisNoteRequest, correlationID string) (*services.AnalysisNoteResponse, error)
+	ListAnalysisNotes(analysisID uuid.UUID, correlationID string) (*services.AnalysisNotesResponse, error)
+	RerunFactChecks(analysisID uuid.UUID, correlationID string) (*services.FactCheckRerunResponse, error)
 }
 
 type AnalysisHandler struct {
 	analysisService AnalysisServiceInterface
+	pagination      Pagination
+	maxTextBodySize int64 // Largest accepted text analysis request body; zero is unlimited
 }
 
+// textRequestOverhead leaves room in a text analysis request body for the analysis options
+const textRequestOverhead = 64 << 10
+
 func NewAnalysisHandler(analysisService AnalysisServiceInterface) *AnalysisHandler {
 	return &AnalysisHandler{
 		analysisService: analysisService,
 	}
 }
 
+// WithPagination sets the page size bounds of the handler's list endpoints
+func (h *AnalysisHandler) WithPagination(pagination Pagination) *AnalysisHandler {
+	h.pagination = pagination
+	return h
+}
+
+// WithMaxTextSize caps the body of text analysis requests for content of up to maxFileSize
+// bytes. Escaping newlines and quotes can double the content's size in JSON, so the cap is
+// twice that plus room for the options.
+func (h *AnalysisHandler) WithMaxTextSize(maxFileSize int64) *AnalysisHandler {
+	h.maxTextBodySize = 2*maxFileSize + textRequestOverhead
+	return h
+}
+
 // validateAnalysisRequest validates the analysis request and extracts transcript ID
 func (h *AnalysisHandler) validateAnalysisRequest(r *http.Request, correlationID string) (uuid.UUID, error) {
 	// Extract transcript ID from path like /api/analyze/123
@@ -49,8 +85,28 @@ func (h *AnalysisHandler) validateAnalysisRequest(r *http.Request, correlationID
 	return transcriptID, nil
 }
 
+// parseAnalysisOptions decodes the optional JSON body of an analysis request. An empty body
+// keeps the configured defaults.
+func (h *AnalysisHandler) parseAnalysisOptions(r *http.Request) (*services.AnalysisJobRequest, error) {
+	req := &services.AnalysisJobRequest{}
+	if r.Body == nil {
+		return req, nil
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return nil, utils.NewValidationError(typeErr.Field, fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type))
+		}
+		return nil, fmt.Errorf("invalid analysis options: %w", err)
+	}
+	return req, nil
+}
+
 // handleAnalysisServiceError determines error type and status code for analysis service errors
 func (h *AnalysisHandler) handleAnalysisServiceError(err error) (int, string) {
+	if errors.Is(err, services.ErrJobsInProgress) {
+		return http.StatusConflict, "JOB_IN_PROGRESS"
+	}
 	if utils.Contains(err.Error(), "not found") {
 		return http.StatusNotFound, "TRANSCRIPT_NOT_FOUND"
 	}
@@ -104,20 +160,22 @@ func (h *AnalysisHandler) StartAnalysis(w http.ResponseWriter, r *http.Request)
 		if transcriptIDParam == "" {
 			utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid analysis path", correlationID)
 		} else {
-			utils.WriteJSON(w, http.StatusBadRequest, map[string]interface{}{
-				"error": map[string]interface{}{
-					"code":           "INVALID_UUID",
-					"message":        "Invalid UUID format",
-					"correlation_id": correlationID,
-				},
-			})
+			utils.WriteValidationErrors(w, utils.NewValidationError("transcript_id", "Invalid transcript ID format"), correlationID)
 		}
 		return
 	}
 
-	req := &services.AnalysisJobRequest{
-		TranscriptID: transcriptID,
+	req, err := h.parseAnalysisOptions(r)
+	var validationErrs utils.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
 	}
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", err.Error(), correlationID)
+		return
+	}
+	req.TranscriptID = transcriptID
 
 	logger.Log.WithFields(map[string]interface{}{
 		"correlation_id": correlationID,
@@ -126,6 +184,15 @@ func (h *AnalysisHandler) StartAnalysis(w http.ResponseWriter, r *http.Request)
 
 	// Process analysis job through service
 	response, err := h.analysisService.CreateAnalysisJob(req, correlationID)
+	if errors.As(err, &validationErrs) {
+		logger.Log.WithFields(map[string]interface{}{
+			"correlation_id": correlationID,
+			"transcript_id":  transcriptID,
+			"errors":         validationErrs.Error(),
+		}).Warn("Analysis request failed validation")
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
 	if err != nil {
 		statusCode, errorCode := h.handleAnalysisServiceError(err)
 
@@ -150,6 +217,67 @@ func (h *AnalysisHandler) StartAnalysis(w http.ResponseWriter, r *http.Request)
 	utils.WriteJSON(w, http.StatusOK, response)
 }
 
+// AnalyzeText starts an analysis of pasted content without a prior upload
+func (h *AnalysisHandler) AnalyzeText(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	if h.maxTextBodySize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxTextBodySize)
+	}
+
+	req := &services.AnalyzeTextRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.WriteErrorWithCorrelation(w, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", fmt.Sprintf("request body too large: more than %d bytes", maxBytesErr.Limit), correlationID)
+			return
+		}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			utils.WriteValidationErrors(w, utils.NewValidationError(typeErr.Field, fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type)), correlationID)
+			return
+		}
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", fmt.Sprintf("invalid request body: %v", err), correlationID)
+		return
+	}
+
+	logger.Log.WithFields(map[string]interface{}{
+		"correlation_id": correlationID,
+		"content_length": len(req.Content),
+		"client_ip":      utils.GetClientIP(r),
+	}).Info("Text analysis request received")
+
+	response, err := h.analysisService.AnalyzeText(req, correlationID)
+	var validationErrs utils.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "text_analysis_job_creation",
+		})
+		utils.WriteErrorWithCorrelation(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to start text analysis", correlationID)
+		return
+	}
+
+	h.logAnalysisSuccess(response, correlationID)
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
 // GetJobStatus returns job status
 func (h *AnalysisHandler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers
@@ -179,16 +307,31 @@ func (h *AnalysisHandler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
 
 	jobID, err := uuid.Parse(jobIDParam)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"error": map[string]interface{}{
-				"code":    "INVALID_UUID",
-				"message": "Invalid job ID format",
-			},
-		})
+		utils.WriteValidationErrors(w, utils.NewValidationError("job_id", "Invalid job ID format"), correlationID)
 		return
 	}
 
-	response, err := h.analysisService.GetJobStatus(jobID, correlationID)
+	wait, since, longPoll, waitErrs := parseJobStatusWait(r)
+	if len(waitErrs) > 0 {
+		utils.WriteValidationErrors(w, waitErrs, correlationID)
+		return
+	}
+
+	var response *services.JobStatusResponse
+	if longPoll {
+		response, err = h.analysisService.WaitForJobStatus(r.Context(), jobID, since, wait, correlationID)
+	} else {
+		response, err = h.analysisService.GetJobStatus(jobID, correlationID)
+	}
+	var validationErrs utils.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
+	if r.Context().Err() != nil {
+		// The client went away while the request was held; there is no one to answer
+		return
+	}
 	if err != nil {
 		statusCode := http.StatusNotFound
 		errorCode := "JOB_NOT_FOUND"
@@ -218,6 +361,134 @@ func (h *AnalysisHandler) GetJobStatus(w http.ResponseWriter, r *http.Request) {
 	utils.WriteJSON(w, http.StatusOK, response)
 }
 
+// parseJobStatusWait reads the long-poll parameters of a status request: wait, in whole
+// seconds, and the since status the job must move away from. longPoll is false when wait
+// is not given.
+func parseJobStatusWait(r *http.Request) (time.Duration, string, bool, utils.ValidationErrors) {
+	query := r.URL.Query()
+	value := query.Get("wait")
+	if value == "" {
+		return 0, "", false, nil
+	}
+
+	var errs utils.ValidationErrors
+	seconds, convErr := strconv.Atoi(value)
+	if convErr != nil || seconds < 0 {
+		errs.Add("wait", "wait must be a non-negative number of seconds")
+	}
+	since := strings.ToLower(strings.TrimSpace(query.Get("since")))
+	if since == "" {
+		errs.Add("since", "since is required with wait")
+	}
+	if len(errs) > 0 {
+		return 0, "", false, errs
+	}
+	return time.Duration(seconds) * time.Second, since, true, nil
+}
+
+// GetJobEvents returns the timeline of a job's status changes and stages
+func (h *AnalysisHandler) GetJobEvents(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	// Extract job ID from path like /api/jobs/123/events
+	jobIDParam, err := utils.ExtractIDFromPath(strings.TrimSuffix(r.URL.Path, "/events"), "/api/jobs/")
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid job path", correlationID)
+		return
+	}
+
+	jobID, err := uuid.Parse(jobIDParam)
+	if err != nil {
+		utils.WriteValidationErrors(w, utils.NewValidationError("job_id", "Invalid job ID format"), correlationID)
+		return
+	}
+
+	response, err := h.analysisService.GetJobEvents(jobID, correlationID)
+	if err != nil {
+		statusCode := http.StatusNotFound
+		errorCode := "JOB_NOT_FOUND"
+
+		if !utils.Contains(err.Error(), "not found") {
+			statusCode = http.StatusInternalServerError
+			errorCode = "INTERNAL_ERROR"
+		}
+
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":      jobID,
+			"error_code":  errorCode,
+			"status_code": statusCode,
+			"operation":   "get_job_events",
+		})
+
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// RetryJob runs a failed job again under its existing job ID, reusing the stages it finished
+func (h *AnalysisHandler) RetryJob(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	// Extract job ID from path like /api/jobs/123/retry
+	jobIDParam, err := utils.ExtractIDFromPath(strings.TrimSuffix(r.URL.Path, "/retry"), "/api/jobs/")
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid job path", correlationID)
+		return
+	}
+
+	jobID, err := uuid.Parse(jobIDParam)
+	if err != nil {
+		utils.WriteValidationErrors(w, utils.NewValidationError("job_id", "Invalid job ID format"), correlationID)
+		return
+	}
+
+	response, err := h.analysisService.RetryAnalysisJob(jobID, correlationID)
+	if err != nil {
+		statusCode, errorCode := http.StatusInternalServerError, "INTERNAL_ERROR"
+		switch {
+		case errors.Is(err, services.ErrJobNotRetryable):
+			statusCode, errorCode = http.StatusConflict, "JOB_NOT_RETRYABLE"
+		case errors.Is(err, services.ErrJobsInProgress):
+			statusCode, errorCode = http.StatusConflict, "JOB_IN_PROGRESS"
+		case utils.Contains(err.Error(), "not found"):
+			statusCode, errorCode = http.StatusNotFound, "JOB_NOT_FOUND"
+		}
+
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":      jobID,
+			"error_code":  errorCode,
+			"status_code": statusCode,
+			"operation":   "retry_job",
+		})
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusAccepted, response)
+}
+
 // GetAnalysisResults returns complete analysis results
 func (h *AnalysisHandler) GetAnalysisResults(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers
@@ -238,16 +509,17 @@ func (h *AnalysisHandler) GetAnalysisResults(w http.ResponseWriter, r *http.Requ
 
 	analysisID, err := uuid.Parse(analysisIDParam)
 	if err != nil {
-		utils.WriteJSON(w, http.StatusBadRequest, map[string]interface{}{
-			"error": map[string]interface{}{
-				"code":    "INVALID_UUID",
-				"message": "Invalid analysis ID format",
-			},
-		})
+		utils.WriteValidationErrors(w, utils.NewValidationError("analysis_id", "Invalid analysis ID format"), correlationID)
+		return
+	}
+
+	filter, validationErrs := parseFactCheckFilter(r)
+	if len(validationErrs) > 0 {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
 		return
 	}
 
-	response, err := h.analysisService.GetAnalysisResults(analysisID, correlationID)
+	response, err := h.analysisService.GetAnalysisResults(analysisID, filter, correlationID)
 	if err != nil {
 		statusCode := http.StatusNotFound
 		errorCode := "ANALYSIS_NOT_FOUND"
@@ -274,9 +546,340 @@ func (h *AnalysisHandler) GetAnalysisResults(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	utils.WriteJSONWithETag(w, r, http.StatusOK, response)
+}
+
+// ExportAnalysisResults downloads an analysis result as CSV, one row per fact check or,
+// with table=takeaways, one row per takeaway
+func (h *AnalysisHandler) ExportAnalysisResults(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	// Extract analysis ID from path like /api/results/123/export
+	analysisIDParam, err := utils.ExtractIDFromPath(strings.TrimSuffix(r.URL.Path, "/export"), "/api/results/")
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid analysis path", correlationID)
+		return
+	}
+
+	var validationErrs utils.ValidationErrors
+	analysisID, err := uuid.Parse(analysisIDParam)
+	if err != nil {
+		validationErrs.Add("analysis_id", "Invalid analysis ID format")
+	}
+	if format := r.URL.Query().Get("format"); format != "" && format != services.ExportFormatCSV {
+		validationErrs.Add("format", "format must be csv")
+	}
+	table := r.URL.Query().Get("table")
+	if table == "" {
+		table = services.ExportTableFactChecks
+	} else if table != services.ExportTableFactChecks && table != services.ExportTableTakeaways {
+		validationErrs.Add("table", "table must be fact_checks or takeaways")
+	}
+	if len(validationErrs) > 0 {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
+
+	response, err := h.analysisService.GetAnalysisResults(analysisID, services.FactCheckFilter{}, correlationID)
+	if err != nil {
+		statusCode := http.StatusNotFound
+		errorCode := "ANALYSIS_NOT_FOUND"
+
+		if !utils.Contains(err.Error(), "not found") {
+			statusCode = http.StatusInternalServerError
+			errorCode = "INTERNAL_ERROR"
+		}
+
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"analysis_id": analysisID,
+			"error_code":  errorCode,
+			"status_code": statusCode,
+			"operation":   "export_analysis_results",
+		})
+
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": services.ExportFilename(response, table),
+	}))
+	w.WriteHeader(http.StatusOK)
+
+	// Headers are already sent, so a failure part way through can only be logged
+	if err := services.WriteResultsCSV(w, response, table); err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"analysis_id": analysisID,
+			"table":       table,
+			"operation":   "write_results_csv",
+		})
+	}
+}
+
+// analysisIDFromSubPath parses the analysis ID of a /api/results/{id}/{suffix} path, writing
+// the error response and reporting false when it is invalid
+func analysisIDFromSubPath(w http.ResponseWriter, r *http.Request, suffix, correlationID string) (uuid.UUID, bool) {
+	analysisIDParam, err := utils.ExtractIDFromPath(strings.TrimSuffix(r.URL.Path, suffix), "/api/results/")
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid analysis path", correlationID)
+		return uuid.Nil, false
+	}
+
+	analysisID, err := uuid.Parse(analysisIDParam)
+	if err != nil {
+		utils.WriteValidationErrors(w, utils.NewValidationError("analysis_id", "Invalid analysis ID format"), correlationID)
+		return uuid.Nil, false
+	}
+	return analysisID, true
+}
+
+// writeAnalysisNoteError writes the response for a failed note request
+func writeAnalysisNoteError(w http.ResponseWriter, err error, analysisID uuid.UUID, operation, correlationID string) {
+	var validationErrs utils.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
+
+	statusCode := http.StatusNotFound
+	errorCode := "ANALYSIS_NOT_FOUND"
+	if !utils.Contains(err.Error(), "not found") {
+		statusCode = http.StatusInternalServerError
+		errorCode = "INTERNAL_ERROR"
+	}
+
+	logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+		"analysis_id": analysisID,
+		"error_code":  errorCode,
+		"status_code": statusCode,
+		"operation":   operation,
+	})
+
+	utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+}
+
+// CreateAnalysisNote adds a reviewer note to an analysis
+func (h *AnalysisHandler) CreateAnalysisNote(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	analysisID, ok := analysisIDFromSubPath(w, r, "/notes", correlationID)
+	if !ok {
+		return
+	}
+
+	req := &services.CreateAnalysisNoteRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			utils.WriteValidationErrors(w, utils.NewValidationError(typeErr.Field, fmt.Sprintf("%s must be of type %s", typeErr.Field, typeErr.Type)), correlationID)
+			return
+		}
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_REQUEST_BODY", fmt.Sprintf("invalid request body: %v", err), correlationID)
+		return
+	}
+
+	response, err := h.analysisService.CreateAnalysisNote(analysisID, req, correlationID)
+	if err != nil {
+		writeAnalysisNoteError(w, err, analysisID, "create_analysis_note", correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusCreated, response)
+}
+
+// ListAnalysisNotes returns the reviewer notes on an analysis, oldest first
+func (h *AnalysisHandler) ListAnalysisNotes(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	analysisID, ok := analysisIDFromSubPath(w, r, "/notes", correlationID)
+	if !ok {
+		return
+	}
+
+	response, err := h.analysisService.ListAnalysisNotes(analysisID, correlationID)
+	if err != nil {
+		writeAnalysisNoteError(w, err, analysisID, "list_analysis_notes", correlationID)
+		return
+	}
+
 	utils.WriteJSON(w, http.StatusOK, response)
 }
 
+// RerunFactChecks re-runs only the fact checker for a completed analysis, in the
+// background, replacing its fact checks when it finishes
+func (h *AnalysisHandler) RerunFactChecks(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	analysisID, ok := analysisIDFromSubPath(w, r, "/refact-check", correlationID)
+	if !ok {
+		return
+	}
+
+	response, err := h.analysisService.RerunFactChecks(analysisID, correlationID)
+	if err != nil {
+		statusCode, errorCode := http.StatusInternalServerError, "INTERNAL_ERROR"
+		switch {
+		case errors.Is(err, services.ErrFactCheckDisabled):
+			statusCode, errorCode = http.StatusConflict, "FACT_CHECK_DISABLED"
+		case errors.Is(err, services.ErrAnalysisNotCompleted):
+			statusCode, errorCode = http.StatusConflict, "ANALYSIS_NOT_COMPLETED"
+		case errors.Is(err, services.ErrFactCheckRerunInProgress):
+			statusCode, errorCode = http.StatusConflict, "FACT_CHECK_IN_PROGRESS"
+		case utils.Contains(err.Error(), "not found"):
+			statusCode, errorCode = http.StatusNotFound, "ANALYSIS_NOT_FOUND"
+		}
+
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"analysis_id": analysisID,
+			"error_code":  errorCode,
+			"status_code": statusCode,
+			"operation":   "rerun_fact_checks",
+		})
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusAccepted, response)
+}
+
+// GetFactCheck returns a single fact check with its parent analysis and transcript IDs
+func (h *AnalysisHandler) GetFactCheck(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	// Extract fact check ID from path like /api/fact-checks/123
+	factCheckIDParam, err := utils.ExtractIDFromPath(r.URL.Path, "/api/fact-checks/")
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid fact check path", correlationID)
+		return
+	}
+
+	factCheckID, err := uuid.Parse(factCheckIDParam)
+	if err != nil {
+		utils.WriteValidationErrors(w, utils.NewValidationError("fact_check_id", "Invalid fact check ID format"), correlationID)
+		return
+	}
+
+	response, err := h.analysisService.GetFactCheck(factCheckID, correlationID)
+	if err != nil {
+		statusCode := http.StatusNotFound
+		errorCode := "FACT_CHECK_NOT_FOUND"
+
+		if !utils.Contains(err.Error(), "not found") {
+			statusCode = http.StatusInternalServerError
+			errorCode = "INTERNAL_ERROR"
+		}
+
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"fact_check_id": factCheckID,
+			"error_code":    errorCode,
+			"status_code":   statusCode,
+			"operation":     "get_fact_check",
+		})
+
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// ListJobs returns a page of analysis jobs with their statuses, newest first. status narrows
+// the list to one job status and created_after/created_before to a creation window.
+func (h *AnalysisHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+	if r.Method == http.MethodOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	page, perPage := h.pagination.parse(r)
+
+	dateRange, validationErrs := parseDateRange(r)
+	if len(validationErrs) > 0 {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
+	status := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("status")))
+
+	jobs, total, err := h.analysisService.ListJobs(page, perPage, status, dateRange)
+	if err != nil {
+		if errors.As(err, &validationErrs) {
+			utils.WriteValidationErrors(w, validationErrs, correlationID)
+			return
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "list_jobs",
+			"status":    status,
+			"page":      page,
+			"per_page":  perPage,
+		})
+		utils.WriteErrorWithCorrelation(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve jobs", correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, map[string]interface{}{
+		"jobs":     jobs,
+		"total":    total,
+		"page":     page,
+		"per_page": perPage,
+	})
+}
+
 // ListAnalysisResults returns paginated list of analysis results
 func (h *AnalysisHandler) ListAnalysisResults(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers
@@ -288,17 +891,15 @@ func (h *AnalysisHandler) ListAnalysisResults(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	page := utils.GetQueryParamInt(r, "page", 1)
-	perPage := utils.GetQueryParamInt(r, "per_page", 20)
+	page, perPage := h.pagination.parse(r)
 
-	if page < 1 {
-		page = 1
-	}
-	if perPage < 1 || perPage > 100 {
-		perPage = 20
+	dateRange, validationErrs := parseDateRange(r)
+	if len(validationErrs) > 0 {
+		utils.WriteValidationErrors(w, validationErrs, utils.GetCorrelationID(r))
+		return
 	}
 
-	results, total, err := h.analysisService.ListAnalysisResults(page, perPage)
+	results, total, err := h.analysisService.ListAnalysisResults(page, perPage, dateRange)
 	if err != nil {
 		logger.LogErrorWithStack(err, map[string]interface{}{
 			"operation": "list_analysis_results",
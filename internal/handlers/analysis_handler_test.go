@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"context"
+	"podcast-analyzer/internal/models"
 	"podcast-analyzer/internal/services"
+	"podcast-analyzer/internal/utils"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -30,6 +35,14 @@ func (m *MockAnalysisService) CreateAnalysisJob(req *services.AnalysisJobRequest
 	return args.Get(0).(*services.AnalysisJobResponse), args.Error(1)
 }
 
+func (m *MockAnalysisService) AnalyzeText(req *services.AnalyzeTextRequest, correlationID string) (*services.AnalysisJobResponse, error) {
+	args := m.Called(req, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AnalysisJobResponse), args.Error(1)
+}
+
 func (m *MockAnalysisService) GetJobStatus(jobID uuid.UUID, correlationID string) (*services.JobStatusResponse, error) {
 	args := m.Called(jobID, correlationID)
 	if args.Get(0) == nil {
@@ -38,22 +51,86 @@ func (m *MockAnalysisService) GetJobStatus(jobID uuid.UUID, correlationID string
 	return args.Get(0).(*services.JobStatusResponse), args.Error(1)
 }
 
-func (m *MockAnalysisService) ListAnalysisResults(page, perPage int) ([]*services.AnalysisResultsResponse, int64, error) {
-	args := m.Called(page, perPage)
+func (m *MockAnalysisService) WaitForJobStatus(ctx context.Context, jobID uuid.UUID, since string, wait time.Duration, correlationID string) (*services.JobStatusResponse, error) {
+	args := m.Called(ctx, jobID, since, wait, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.JobStatusResponse), args.Error(1)
+}
+
+func (m *MockAnalysisService) GetJobEvents(jobID uuid.UUID, correlationID string) (*services.JobEventsResponse, error) {
+	args := m.Called(jobID, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.JobEventsResponse), args.Error(1)
+}
+
+func (m *MockAnalysisService) RetryAnalysisJob(jobID uuid.UUID, correlationID string) (*services.AnalysisJobResponse, error) {
+	args := m.Called(jobID, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AnalysisJobResponse), args.Error(1)
+}
+
+func (m *MockAnalysisService) ListJobs(page, perPage int, status string, dateRange services.DateRange) ([]*services.JobStatusResponse, int64, error) {
+	args := m.Called(page, perPage, status, dateRange)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*services.JobStatusResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAnalysisService) ListAnalysisResults(page, perPage int, dateRange services.DateRange) ([]*services.AnalysisResultsResponse, int64, error) {
+	args := m.Called(page, perPage, dateRange)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
 	return args.Get(0).([]*services.AnalysisResultsResponse), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockAnalysisService) GetAnalysisResults(analysisID uuid.UUID, correlationID string) (*services.AnalysisResultsResponse, error) {
-	args := m.Called(analysisID, correlationID)
+func (m *MockAnalysisService) GetAnalysisResults(analysisID uuid.UUID, filter services.FactCheckFilter, correlationID string) (*services.AnalysisResultsResponse, error) {
+	args := m.Called(analysisID, filter, correlationID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*services.AnalysisResultsResponse), args.Error(1)
 }
 
+func (m *MockAnalysisService) GetFactCheck(factCheckID uuid.UUID, correlationID string) (*services.FactCheckDetailResponse, error) {
+	args := m.Called(factCheckID, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.FactCheckDetailResponse), args.Error(1)
+}
+
+func (m *MockAnalysisService) CreateAnalysisNote(analysisID uuid.UUID, req *services.CreateAnalysisNoteRequest, correlationID string) (*services.AnalysisNoteResponse, error) {
+	args := m.Called(analysisID, req, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AnalysisNoteResponse), args.Error(1)
+}
+
+func (m *MockAnalysisService) ListAnalysisNotes(analysisID uuid.UUID, correlationID string) (*services.AnalysisNotesResponse, error) {
+	args := m.Called(analysisID, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AnalysisNotesResponse), args.Error(1)
+}
+
+func (m *MockAnalysisService) RerunFactChecks(analysisID uuid.UUID, correlationID string) (*services.FactCheckRerunResponse, error) {
+	args := m.Called(analysisID, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.FactCheckRerunResponse), args.Error(1)
+}
+
 func (m *MockAnalysisService) UpdateJobStatus(jobID uuid.UUID, status string, errorMessage string) error {
 	args := m.Called(jobID, status, errorMessage)
 	return args.Error(0)
@@ -98,12 +175,22 @@ func TestAnalysisHandler_StartAnalysis(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "transcript not found",
 		},
+		{
+			name:         "job already in progress",
+			transcriptID: testTranscriptID.String(),
+			setupMock: func() {
+				mockService.On("CreateAnalysisJob", mock.AnythingOfType("*services.AnalysisJobRequest"), mock.AnythingOfType("string")).Return(
+					nil, fmt.Errorf("%w: 1 pending or processing; set force to start another", services.ErrJobsInProgress))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "already has analysis jobs in progress",
+		},
 		{
 			name:           "invalid UUID",
 			transcriptID:   "invalid-uuid",
 			setupMock:      func() {},
-			expectedStatus: http.StatusBadRequest,
-			expectedError:  "Invalid UUID format",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "Invalid transcript ID format",
 		},
 	}
 
@@ -181,7 +268,7 @@ func TestAnalysisHandler_GetJobStatus(t *testing.T) {
 			name:           "invalid UUID",
 			jobID:          "invalid-uuid",
 			setupMock:      func() {},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "Invalid job ID format",
 		},
 	}
@@ -253,7 +340,7 @@ func TestAnalysisHandler_ListAnalysisResults(t *testing.T) {
 			name:  "successful list",
 			query: "page=1&per_page=10",
 			setupMock: func() {
-				mockService.On("ListAnalysisResults", 1, 10).Return(
+				mockService.On("ListAnalysisResults", 1, 10, services.DateRange{}).Return(
 					testResults, int64(2), nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -262,7 +349,7 @@ func TestAnalysisHandler_ListAnalysisResults(t *testing.T) {
 			name:  "invalid page gets default",
 			query: "page=invalid&per_page=10",
 			setupMock: func() {
-				mockService.On("ListAnalysisResults", 1, 10).Return(
+				mockService.On("ListAnalysisResults", 1, 10, services.DateRange{}).Return(
 					testResults, int64(2), nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -271,11 +358,28 @@ func TestAnalysisHandler_ListAnalysisResults(t *testing.T) {
 			name:  "invalid per_page gets default",
 			query: "page=1&per_page=invalid",
 			setupMock: func() {
-				mockService.On("ListAnalysisResults", 1, 20).Return(
+				mockService.On("ListAnalysisResults", 1, 20, services.DateRange{}).Return(
 					testResults, int64(2), nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:  "date range filter",
+			query: "page=1&per_page=10&created_after=2024-01-01T00:00:00Z",
+			setupMock: func() {
+				after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				mockService.On("ListAnalysisResults", 1, 10, services.DateRange{After: &after}).Return(
+					testResults, int64(2), nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid date range",
+			query:          "created_after=not-a-date",
+			setupMock:      func() {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "created_after must be an RFC3339 timestamp",
+		},
 	}
 
 	for _, tt := range tests {
@@ -296,9 +400,14 @@ func TestAnalysisHandler_ListAnalysisResults(t *testing.T) {
 			err := json.Unmarshal(recorder.Body.Bytes(), &response)
 			require.NoError(t, err)
 
-			results := response["results"].([]interface{})
-			assert.Len(t, results, 2)
-			assert.Equal(t, float64(2), response["total"])
+			if tt.expectedError != "" {
+				errorObj := response["error"].(map[string]interface{})
+				assert.Equal(t, tt.expectedError, errorObj["message"])
+			} else {
+				results := response["results"].([]interface{})
+				assert.Len(t, results, 2)
+				assert.Equal(t, float64(2), response["total"])
+			}
 
 			mockService.AssertExpectations(t)
 		})
@@ -341,7 +450,7 @@ func TestAnalysisHandler_GetAnalysisResults(t *testing.T) {
 			name:       "successful get results",
 			analysisID: testAnalysisID.String(),
 			setupMock: func() {
-				mockService.On("GetAnalysisResults", testAnalysisID, mock.AnythingOfType("string")).Return(
+				mockService.On("GetAnalysisResults", testAnalysisID, services.FactCheckFilter{}, mock.AnythingOfType("string")).Return(
 					testResult, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -350,7 +459,7 @@ func TestAnalysisHandler_GetAnalysisResults(t *testing.T) {
 			name:       "results not found",
 			analysisID: testAnalysisID.String(),
 			setupMock: func() {
-				mockService.On("GetAnalysisResults", testAnalysisID, mock.AnythingOfType("string")).Return(
+				mockService.On("GetAnalysisResults", testAnalysisID, services.FactCheckFilter{}, mock.AnythingOfType("string")).Return(
 					nil, fmt.Errorf("analysis result not found"))
 			},
 			expectedStatus: http.StatusNotFound,
@@ -360,7 +469,7 @@ func TestAnalysisHandler_GetAnalysisResults(t *testing.T) {
 			name:           "invalid UUID",
 			analysisID:     "invalid-uuid",
 			setupMock:      func() {},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "Invalid analysis ID format",
 		},
 	}
@@ -397,4 +506,708 @@ func TestAnalysisHandler_GetAnalysisResults(t *testing.T) {
 			mockService.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestAnalysisHandler_GetAnalysisResults_FactCheckFilter(t *testing.T) {
+	testAnalysisID := uuid.New()
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedFilter *services.FactCheckFilter
+		expectedStatus int
+	}{
+		{
+			name:           "verdict and page",
+			query:          "?fact_check_verdict=FALSE&fact_check_limit=10&fact_check_offset=20",
+			expectedFilter: &services.FactCheckFilter{Verdict: models.VerdictFalse, Limit: 10, Offset: 20},
+			expectedStatus: http.StatusOK,
+		},
+		{name: "unknown verdict", query: "?fact_check_verdict=maybe", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "zero limit", query: "?fact_check_limit=0", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "negative offset", query: "?fact_check_offset=-1", expectedStatus: http.StatusUnprocessableEntity},
+		{name: "non-numeric limit", query: "?fact_check_limit=all", expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAnalysisService{}
+			if tt.expectedFilter != nil {
+				mockService.On("GetAnalysisResults", testAnalysisID, *tt.expectedFilter, mock.AnythingOfType("string")).
+					Return(&services.AnalysisResultsResponse{ID: testAnalysisID}, nil)
+			}
+			handler := NewAnalysisHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/results/"+testAnalysisID.String()+tt.query, nil)
+			recorder := httptest.NewRecorder()
+			handler.GetAnalysisResults(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnalysisHandler_GetAnalysisResults_ConditionalGet(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+
+	testAnalysisID := uuid.New()
+	result := &services.AnalysisResultsResponse{ID: testAnalysisID, Status: "processing"}
+	mockService.On("GetAnalysisResults", testAnalysisID, services.FactCheckFilter{}, mock.AnythingOfType("string")).Return(result, nil)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/results/"+testAnalysisID.String(), nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		recorder := httptest.NewRecorder()
+		handler.GetAnalysisResults(recorder, req)
+		return recorder
+	}
+
+	first := get("")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// Unchanged results are not sent again
+	unchanged := get(etag)
+	assert.Equal(t, http.StatusNotModified, unchanged.Code)
+	assert.Empty(t, unchanged.Body.String())
+
+	// Once the analysis changes, the old tag no longer matches
+	result.Status = "completed"
+	changed := get(etag)
+	assert.Equal(t, http.StatusOK, changed.Code)
+	assert.NotEqual(t, etag, changed.Header().Get("ETag"))
+}
+
+func TestAnalysisHandler_ExportAnalysisResults(t *testing.T) {
+	testAnalysisID := uuid.New()
+	filename := "episode.txt"
+	evidence := "NASA archives"
+	result := &services.AnalysisResultsResponse{
+		ID:                 testAnalysisID,
+		Status:             "completed",
+		TranscriptFilename: &filename,
+		Takeaways:          []string{"Space, still hard"},
+		FactChecks: []services.FactCheckResultResponse{{
+			Claim:      "Apollo 11 landed in 1969",
+			Verdict:    models.VerdictTrue,
+			Confidence: 0.9,
+			Evidence:   &evidence,
+			Sources:    []string{"https://nasa.gov/apollo"},
+		}},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		setupMock      func(*MockAnalysisService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "fact checks by default",
+			path: "/api/results/" + testAnalysisID.String() + "/export?format=csv",
+			setupMock: func(m *MockAnalysisService) {
+				m.On("GetAnalysisResults", testAnalysisID, services.FactCheckFilter{}, mock.AnythingOfType("string")).Return(result, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: "analysis_id,transcript_filename,claim,verdict,confidence,evidence,source\n" +
+				testAnalysisID.String() + ",episode.txt,Apollo 11 landed in 1969,true,0.9,NASA archives,https://nasa.gov/apollo\n",
+		},
+		{
+			name: "takeaways table",
+			path: "/api/results/" + testAnalysisID.String() + "/export?format=csv&table=takeaways",
+			setupMock: func(m *MockAnalysisService) {
+				m.On("GetAnalysisResults", testAnalysisID, services.FactCheckFilter{}, mock.AnythingOfType("string")).Return(result, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: "analysis_id,transcript_filename,position,takeaway,timestamp\n" +
+				testAnalysisID.String() + ",episode.txt,1,\"Space, still hard\",\n",
+		},
+		{
+			name:           "unsupported format",
+			path:           "/api/results/" + testAnalysisID.String() + "/export?format=xlsx",
+			setupMock:      func(m *MockAnalysisService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "unknown table",
+			path:           "/api/results/" + testAnalysisID.String() + "/export?table=sources",
+			setupMock:      func(m *MockAnalysisService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "invalid analysis ID",
+			path:           "/api/results/not-a-uuid/export",
+			setupMock:      func(m *MockAnalysisService) {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "analysis not found",
+			path: "/api/results/" + testAnalysisID.String() + "/export",
+			setupMock: func(m *MockAnalysisService) {
+				m.On("GetAnalysisResults", testAnalysisID, services.FactCheckFilter{}, mock.AnythingOfType("string")).Return(
+					nil, fmt.Errorf("analysis %s not found", testAnalysisID))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAnalysisService{}
+			tt.setupMock(mockService)
+			handler := NewAnalysisHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			recorder := httptest.NewRecorder()
+			handler.ExportAnalysisResults(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				assert.Contains(t, recorder.Header().Get("Content-Disposition"), "attachment")
+				assert.Contains(t, recorder.Header().Get("Content-Disposition"), "analysis-"+testAnalysisID.String())
+				assert.Equal(t, tt.expectedBody, recorder.Body.String())
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnalysisHandler_GetFactCheck(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+
+	testFactCheckID := uuid.New()
+	testResult := &services.FactCheckDetailResponse{
+		FactCheckResultResponse: services.FactCheckResultResponse{
+			ID:         testFactCheckID,
+			Claim:      "Test claim",
+			Verdict:    models.VerdictFalse,
+			Confidence: 0.8,
+			Sources:    []string{"https://example.com/source"},
+			CheckedAt:  time.Now(),
+		},
+		AnalysisID:   uuid.New(),
+		TranscriptID: uuid.New(),
+	}
+
+	tests := []struct {
+		name           string
+		factCheckID    string
+		setupMock      func()
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "successful get fact check",
+			factCheckID: testFactCheckID.String(),
+			setupMock: func() {
+				mockService.On("GetFactCheck", testFactCheckID, "test-correlation-id").Return(testResult, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "fact check not found",
+			factCheckID: testFactCheckID.String(),
+			setupMock: func() {
+				mockService.On("GetFactCheck", testFactCheckID, "test-correlation-id").Return(
+					nil, fmt.Errorf("fact check %s not found", testFactCheckID))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  "not found",
+		},
+		{
+			name:        "database error",
+			factCheckID: testFactCheckID.String(),
+			setupMock: func() {
+				mockService.On("GetFactCheck", testFactCheckID, "test-correlation-id").Return(
+					nil, fmt.Errorf("failed to get fact check: connection refused"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedError:  "failed to get fact check",
+		},
+		{
+			name:           "invalid UUID",
+			factCheckID:    "invalid-uuid",
+			setupMock:      func() {},
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "Invalid fact check ID format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService.ExpectedCalls = nil
+			mockService.Calls = nil
+			tt.setupMock()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/fact-checks/"+tt.factCheckID, nil)
+			req.Header.Set("X-Correlation-ID", "test-correlation-id")
+			recorder := httptest.NewRecorder()
+			handler.GetFactCheck(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+			if tt.expectedError != "" {
+				errorObj := response["error"].(map[string]interface{})
+				assert.Contains(t, errorObj["message"].(string), tt.expectedError)
+			} else {
+				assert.Equal(t, testFactCheckID.String(), response["id"])
+				assert.Equal(t, "false", response["verdict"])
+				assert.Equal(t, testResult.AnalysisID.String(), response["analysis_id"])
+				assert.Equal(t, testResult.TranscriptID.String(), response["transcript_id"])
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAnalysisHandler_StartAnalysis_Options(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+	transcriptID := uuid.New()
+
+	mockService.On("CreateAnalysisJob", mock.MatchedBy(func(req *services.AnalysisJobRequest) bool {
+		return req.TranscriptID == transcriptID && req.StripAds != nil && !*req.StripAds
+	}), mock.AnythingOfType("string")).Return(
+		&services.AnalysisJobResponse{JobID: uuid.New(), TranscriptID: transcriptID, Status: "pending"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/analyze/"+transcriptID.String(), strings.NewReader(`{"strip_ads": false}`))
+	recorder := httptest.NewRecorder()
+	handler.StartAnalysis(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertExpectations(t)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/analyze/"+transcriptID.String(), strings.NewReader(`{"strip_ads": "yes"}`))
+	recorder = httptest.NewRecorder()
+	handler.StartAnalysis(recorder, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"field":"strip_ads"`)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/analyze/"+transcriptID.String(), strings.NewReader(`{"strip_ads": `))
+	recorder = httptest.NewRecorder()
+	handler.StartAnalysis(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "INVALID_REQUEST_BODY")
+}
+
+func TestAnalysisHandler_AnalyzeText(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+	transcriptID := uuid.New()
+
+	mockService.On("AnalyzeText", mock.MatchedBy(func(req *services.AnalyzeTextRequest) bool {
+		return req.Content == "Host: Hello." && req.Filename == "notes.txt" && req.SummaryStyle == "casual" && req.Cleanup != nil && !*req.Cleanup
+	}), mock.AnythingOfType("string")).Return(
+		&services.AnalysisJobResponse{JobID: uuid.New(), TranscriptID: transcriptID, Status: "pending", Ephemeral: true}, nil)
+
+	body := `{"content": "Host: Hello.", "filename": "notes.txt", "summary_style": "casual", "cleanup": false}`
+	req := httptest.NewRequest(http.MethodPost, "/api/analyze/text", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	handler.AnalyzeText(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"ephemeral":true`)
+	mockService.AssertExpectations(t)
+
+	t.Run("body too large", func(t *testing.T) {
+		limited := NewAnalysisHandler(mockService).WithMaxTextSize(16)
+		body := `{"content": "` + strings.Repeat("word ", 20000) + `"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/analyze/text", strings.NewReader(body))
+		recorder := httptest.NewRecorder()
+		limited.AnalyzeText(recorder, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "REQUEST_TOO_LARGE")
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		mockService.On("AnalyzeText", mock.MatchedBy(func(req *services.AnalyzeTextRequest) bool {
+			return req.Content == ""
+		}), mock.AnythingOfType("string")).Return(nil, utils.NewValidationError("content", "content is required"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/analyze/text", strings.NewReader(`{}`))
+		recorder := httptest.NewRecorder()
+		handler.AnalyzeText(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"field":"content"`)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/analyze/text", strings.NewReader(`{"content": 5}`))
+		recorder := httptest.NewRecorder()
+		handler.AnalyzeText(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"field":"content"`)
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/analyze/text", nil)
+		recorder := httptest.NewRecorder()
+		handler.AnalyzeText(recorder, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func TestAnalysisHandler_GetJobEvents(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+	jobID := uuid.New()
+
+	mockService.On("GetJobEvents", jobID, mock.AnythingOfType("string")).Return(&services.JobEventsResponse{
+		JobID: jobID,
+		Events: []services.JobEventResponse{
+			{Status: "pending", Message: "Job queued", CreatedAt: time.Now()},
+			{Status: "processing", CreatedAt: time.Now()},
+		},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+jobID.String()+"/events", nil)
+	recorder := httptest.NewRecorder()
+	handler.GetJobEvents(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response services.JobEventsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, jobID, response.JobID)
+	require.Len(t, response.Events, 2)
+	assert.Equal(t, "Job queued", response.Events[0].Message)
+	mockService.AssertExpectations(t)
+
+	t.Run("unknown job", func(t *testing.T) {
+		unknownID := uuid.New()
+		mockService.On("GetJobEvents", unknownID, mock.AnythingOfType("string")).Return(nil, fmt.Errorf("analysis job not found"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+unknownID.String()+"/events", nil)
+		recorder := httptest.NewRecorder()
+		handler.GetJobEvents(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "JOB_NOT_FOUND")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/not-a-uuid/events", nil)
+		recorder := httptest.NewRecorder()
+		handler.GetJobEvents(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func TestAnalysisHandler_RetryJob(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+	jobID := uuid.New()
+
+	mockService.On("RetryAnalysisJob", jobID, mock.AnythingOfType("string")).Return(&services.AnalysisJobResponse{
+		JobID:  jobID,
+		Status: "pending",
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/jobs/"+jobID.String()+"/retry", nil)
+	recorder := httptest.NewRecorder()
+	handler.RetryJob(recorder, req)
+
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	var response services.AnalysisJobResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, jobID, response.JobID)
+	mockService.AssertExpectations(t)
+
+	t.Run("job not failed", func(t *testing.T) {
+		runningID := uuid.New()
+		mockService.On("RetryAnalysisJob", runningID, mock.AnythingOfType("string")).Return(nil, fmt.Errorf("%w: job is processing", services.ErrJobNotRetryable))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/"+runningID.String()+"/retry", nil)
+		recorder := httptest.NewRecorder()
+		handler.RetryJob(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "JOB_NOT_RETRYABLE")
+	})
+
+	t.Run("transcript has jobs in progress", func(t *testing.T) {
+		busyID := uuid.New()
+		mockService.On("RetryAnalysisJob", busyID, mock.AnythingOfType("string")).Return(nil, fmt.Errorf("%w: 1 pending or processing", services.ErrJobsInProgress))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/"+busyID.String()+"/retry", nil)
+		recorder := httptest.NewRecorder()
+		handler.RetryJob(recorder, req)
+
+		assert.Equal(t, http.StatusConflict, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "JOB_IN_PROGRESS")
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		unknownID := uuid.New()
+		mockService.On("RetryAnalysisJob", unknownID, mock.AnythingOfType("string")).Return(nil, fmt.Errorf("job not found"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/jobs/"+unknownID.String()+"/retry", nil)
+		recorder := httptest.NewRecorder()
+		handler.RetryJob(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+jobID.String()+"/retry", nil)
+		recorder := httptest.NewRecorder()
+		handler.RetryJob(recorder, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func TestAnalysisHandler_CreateAnalysisNote(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+	analysisID := uuid.New()
+
+	mockService.On("CreateAnalysisNote", analysisID, &services.CreateAnalysisNoteRequest{Author: "dana", Body: "Fact check #2 looks wrong"}, mock.AnythingOfType("string")).
+		Return(&services.AnalysisNoteResponse{ID: uuid.New(), AnalysisID: analysisID, Author: "dana", Body: "Fact check #2 looks wrong", CreatedAt: time.Now()}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/results/"+analysisID.String()+"/notes", strings.NewReader(`{"author": "dana", "body": "Fact check #2 looks wrong"}`))
+	recorder := httptest.NewRecorder()
+	handler.CreateAnalysisNote(recorder, req)
+
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	var response services.AnalysisNoteResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, analysisID, response.AnalysisID)
+	assert.Equal(t, "Fact check #2 looks wrong", response.Body)
+	mockService.AssertExpectations(t)
+
+	t.Run("invalid body", func(t *testing.T) {
+		mockService.On("CreateAnalysisNote", analysisID, &services.CreateAnalysisNoteRequest{}, mock.AnythingOfType("string")).
+			Return(nil, utils.NewValidationError("body", "body is required"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/results/"+analysisID.String()+"/notes", strings.NewReader(`{}`))
+		recorder := httptest.NewRecorder()
+		handler.CreateAnalysisNote(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "body is required")
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/results/"+analysisID.String()+"/notes", strings.NewReader(`{"body": `))
+		recorder := httptest.NewRecorder()
+		handler.CreateAnalysisNote(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "INVALID_REQUEST_BODY")
+	})
+
+	t.Run("unknown analysis", func(t *testing.T) {
+		unknownID := uuid.New()
+		mockService.On("CreateAnalysisNote", unknownID, mock.Anything, mock.AnythingOfType("string")).Return(nil, fmt.Errorf("analysis not found"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/results/"+unknownID.String()+"/notes", strings.NewReader(`{"body": "A note"}`))
+		recorder := httptest.NewRecorder()
+		handler.CreateAnalysisNote(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "ANALYSIS_NOT_FOUND")
+	})
+}
+
+func TestAnalysisHandler_ListAnalysisNotes(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+	analysisID := uuid.New()
+
+	mockService.On("ListAnalysisNotes", analysisID, mock.AnythingOfType("string")).Return(&services.AnalysisNotesResponse{
+		AnalysisID: analysisID,
+		Notes:      []services.AnalysisNoteResponse{{ID: uuid.New(), AnalysisID: analysisID, Author: "anonymous", Body: "Looks right", CreatedAt: time.Now()}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/results/"+analysisID.String()+"/notes", nil)
+	recorder := httptest.NewRecorder()
+	handler.ListAnalysisNotes(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response services.AnalysisNotesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Notes, 1)
+	assert.Equal(t, "Looks right", response.Notes[0].Body)
+	mockService.AssertExpectations(t)
+
+	t.Run("invalid id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/results/not-a-uuid/notes", nil)
+		recorder := httptest.NewRecorder()
+		handler.ListAnalysisNotes(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func TestAnalysisHandler_RerunFactChecks(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+	analysisID := uuid.New()
+	jobID := uuid.New()
+
+	mockService.On("RerunFactChecks", analysisID, mock.AnythingOfType("string")).
+		Return(&services.FactCheckRerunResponse{AnalysisID: analysisID, JobID: jobID, Status: "running"}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/results/"+analysisID.String()+"/refact-check", nil)
+	recorder := httptest.NewRecorder()
+	handler.RerunFactChecks(recorder, req)
+
+	assert.Equal(t, http.StatusAccepted, recorder.Code)
+	var response services.FactCheckRerunResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, jobID, response.JobID)
+	mockService.AssertExpectations(t)
+
+	errorTests := []struct {
+		name       string
+		err        error
+		statusCode int
+		errorCode  string
+	}{
+		{"already running", services.ErrFactCheckRerunInProgress, http.StatusConflict, "FACT_CHECK_IN_PROGRESS"},
+		{"not completed", services.ErrAnalysisNotCompleted, http.StatusConflict, "ANALYSIS_NOT_COMPLETED"},
+		{"disabled", services.ErrFactCheckDisabled, http.StatusConflict, "FACT_CHECK_DISABLED"},
+		{"unknown analysis", errors.New("analysis not found"), http.StatusNotFound, "ANALYSIS_NOT_FOUND"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockAnalysisService{}
+			handler := NewAnalysisHandler(mockService)
+			mockService.On("RerunFactChecks", analysisID, mock.AnythingOfType("string")).Return(nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/results/"+analysisID.String()+"/refact-check", nil)
+			recorder := httptest.NewRecorder()
+			handler.RerunFactChecks(recorder, req)
+
+			assert.Equal(t, tt.statusCode, recorder.Code)
+			assert.Contains(t, recorder.Body.String(), tt.errorCode)
+		})
+	}
+
+	t.Run("invalid analysis ID", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/results/not-a-uuid/refact-check", nil)
+		recorder := httptest.NewRecorder()
+		handler.RerunFactChecks(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+
+	t.Run("wrong method", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/results/"+analysisID.String()+"/refact-check", nil)
+		recorder := httptest.NewRecorder()
+		handler.RerunFactChecks(recorder, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+}
+
+func TestAnalysisHandler_GetJobStatus_LongPoll(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+
+	jobID := uuid.New()
+	mockService.On("WaitForJobStatus", mock.Anything, jobID, "processing", 20*time.Second, mock.AnythingOfType("string")).Return(
+		&services.JobStatusResponse{JobID: jobID, Status: "completed"}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+jobID.String()+"/status?wait=20&since=Processing", nil)
+	w := httptest.NewRecorder()
+	handler.GetJobStatus(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "completed", response["status"])
+	mockService.AssertExpectations(t)
+}
+
+func TestAnalysisHandler_GetJobStatus_LongPollValidation(t *testing.T) {
+	jobID := uuid.New()
+
+	for _, query := range []string{"wait=soon&since=pending", "wait=-1&since=pending", "wait=10"} {
+		t.Run(query, func(t *testing.T) {
+			mockService := &MockAnalysisService{}
+			handler := NewAnalysisHandler(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+jobID.String()+"/status?"+query, nil)
+			w := httptest.NewRecorder()
+			handler.GetJobStatus(w, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+			mockService.AssertNotCalled(t, "WaitForJobStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAnalysisHandler_GetJobStatus_LongPollClientGone(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+
+	jobID := uuid.New()
+	mockService.On("WaitForJobStatus", mock.Anything, jobID, "pending", 10*time.Second, mock.AnythingOfType("string")).Return(
+		nil, context.Canceled)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+jobID.String()+"/status?wait=10&since=pending", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	handler.GetJobStatus(w, req)
+
+	assert.Empty(t, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestAnalysisHandler_ListJobs(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+
+	jobID := uuid.New()
+	mockService.On("ListJobs", 2, 10, "processing", services.DateRange{}).Return([]*services.JobStatusResponse{
+		{JobID: jobID, TranscriptID: uuid.New(), Status: "processing", CreatedAt: time.Now()},
+	}, int64(11), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs?status=Processing&page=2&per_page=10", nil)
+	w := httptest.NewRecorder()
+	handler.ListJobs(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, float64(11), response["total"])
+	jobs := response["jobs"].([]interface{})
+	require.Len(t, jobs, 1)
+	assert.Equal(t, jobID.String(), jobs[0].(map[string]interface{})["job_id"])
+	mockService.AssertExpectations(t)
+}
+
+func TestAnalysisHandler_ListJobs_InvalidStatus(t *testing.T) {
+	mockService := &MockAnalysisService{}
+	handler := NewAnalysisHandler(mockService)
+
+	mockService.On("ListJobs", 1, 20, "stuck", services.DateRange{}).Return(
+		nil, int64(0), utils.NewValidationError("status", "status must be one of pending, processing, completed, failed, cancelled")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs?status=stuck", nil)
+	w := httptest.NewRecorder()
+	handler.ListJobs(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/services"
+	"podcast-analyzer/internal/utils"
+)
+
+// DebugServiceInterface defines the service calls behind the debug endpoints
+type DebugServiceInterface interface {
+	RunAgent(ctx context.Context, name string, req *services.DebugAgentRequest, correlationID string) (agents.Result, error)
+}
+
+type DebugHandler struct {
+	debugService DebugServiceInterface
+}
+
+func NewDebugHandler(debugService DebugServiceInterface) *DebugHandler {
+	return &DebugHandler{
+		debugService: debugService,
+	}
+}
+
+// RunAgent runs a single agent against the text in the request body and returns its raw
+// result, for prompt tuning without creating a transcript or job
+func (h *DebugHandler) RunAgent(w http.ResponseWriter, r *http.Request) {
+	correlationID := utils.GetCorrelationID(r)
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	// Extract agent name from path like /api/debug/agents/summarizer
+	name, err := utils.ExtractIDFromPath(r.URL.Path, "/api/debug/agents/")
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid debug agent path", correlationID)
+		return
+	}
+
+	var req services.DebugAgentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_REQUEST", "Invalid request body", correlationID)
+		return
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		utils.WriteValidationErrors(w, utils.NewValidationError("content", "content is required"), correlationID)
+		return
+	}
+
+	result, err := h.debugService.RunAgent(r.Context(), name, &req, correlationID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		errorCode := "AGENT_FAILED"
+		if errors.Is(err, services.ErrAgentNotFound) {
+			statusCode = http.StatusNotFound
+			errorCode = "AGENT_NOT_FOUND"
+		}
+
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"agent":       name,
+			"error_code":  errorCode,
+			"status_code": statusCode,
+			"operation":   "debug_run_agent",
+		})
+
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/services"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockDebugService for testing
+type MockDebugService struct {
+	mock.Mock
+}
+
+func (m *MockDebugService) RunAgent(ctx context.Context, name string, req *services.DebugAgentRequest, correlationID string) (agents.Result, error) {
+	args := m.Called(name, req)
+	return args.Get(0).(agents.Result), args.Error(1)
+}
+
+func TestDebugHandler_RunAgent(t *testing.T) {
+	mockService := &MockDebugService{}
+	handler := NewDebugHandler(mockService)
+
+	expected := &services.DebugAgentRequest{
+		Content: "Some pasted transcript text",
+		Options: services.DebugAgentOptions{Instructions: "Be brief", MaxResults: 3},
+	}
+	mockService.On("RunAgent", "summarizer", expected).Return(agents.Result{Summary: "A short summary"}, nil)
+
+	body := `{"content": "Some pasted transcript text", "options": {"instructions": "Be brief", "max_results": 3}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/debug/agents/summarizer", strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	handler.RunAgent(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "A short summary", response["summary"])
+	mockService.AssertExpectations(t)
+}
+
+func TestDebugHandler_RunAgent_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		serviceErr   error
+		expectedCode int
+		expectedErr  string
+	}{
+		{name: "wrong method", method: http.MethodGet, path: "/api/debug/agents/summarizer", expectedCode: http.StatusMethodNotAllowed, expectedErr: "METHOD_NOT_ALLOWED"},
+		{name: "missing agent name", method: http.MethodPost, path: "/api/debug/agents/", body: `{"content": "text"}`, expectedCode: http.StatusBadRequest, expectedErr: "INVALID_PATH"},
+		{name: "malformed body", method: http.MethodPost, path: "/api/debug/agents/summarizer", body: `{`, expectedCode: http.StatusBadRequest, expectedErr: "INVALID_REQUEST"},
+		{name: "missing content", method: http.MethodPost, path: "/api/debug/agents/summarizer", body: `{"content": "  "}`, expectedCode: http.StatusUnprocessableEntity, expectedErr: "VALIDATION_ERROR"},
+		{name: "unknown agent", method: http.MethodPost, path: "/api/debug/agents/poet", body: `{"content": "text"}`, serviceErr: services.ErrAgentNotFound, expectedCode: http.StatusNotFound, expectedErr: "AGENT_NOT_FOUND"},
+		{name: "agent failure", method: http.MethodPost, path: "/api/debug/agents/summarizer", body: `{"content": "text"}`, serviceErr: errors.New("summarizer: content too short"), expectedCode: http.StatusInternalServerError, expectedErr: "AGENT_FAILED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockDebugService{}
+			if tt.serviceErr != nil {
+				mockService.On("RunAgent", mock.Anything, mock.Anything).Return(agents.Result{}, tt.serviceErr)
+			}
+			handler := NewDebugHandler(mockService)
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			handler.RunAgent(recorder, req)
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			var response struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedErr, response.Error.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
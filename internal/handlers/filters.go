@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/services"
+	"podcast-analyzer/internal/utils"
+)
+
+// Page size bounds used when a handler has no configured pagination
+const (
+	defaultPerPage = 20
+	maxPerPage     = 100
+)
+
+// Pagination holds the page size bounds of list endpoints
+type Pagination struct {
+	DefaultPerPage int // Page size when per_page is absent or out of range
+	MaxPerPage     int // Largest accepted per_page
+}
+
+// parse reads the page and per_page query parameters. A missing or out-of-range per_page
+// falls back to the default page size.
+func (p Pagination) parse(r *http.Request) (page, perPage int) {
+	defaultSize := p.DefaultPerPage
+	if defaultSize <= 0 {
+		defaultSize = defaultPerPage
+	}
+	maxSize := p.MaxPerPage
+	if maxSize <= 0 {
+		maxSize = maxPerPage
+	}
+	if defaultSize > maxSize {
+		defaultSize = maxSize
+	}
+
+	page = utils.GetQueryParamInt(r, "page", 1)
+	perPage = utils.GetQueryParamInt(r, "per_page", defaultSize)
+
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 || perPage > maxSize {
+		perPage = defaultSize
+	}
+	return page, perPage
+}
+
+// parseDateRange reads the created_after and created_before query parameters
+func parseDateRange(r *http.Request) (services.DateRange, utils.ValidationErrors) {
+	var errs utils.ValidationErrors
+	after, err := utils.GetQueryParamTime(r, "created_after")
+	if err != nil {
+		errs.Add("created_after", err.Error())
+	}
+	before, err := utils.GetQueryParamTime(r, "created_before")
+	if err != nil {
+		errs.Add("created_before", err.Error())
+	}
+	if len(errs) > 0 {
+		return services.DateRange{}, errs
+	}
+	if after != nil && before != nil && after.After(*before) {
+		return services.DateRange{}, utils.NewValidationError("created_after", "created_after must not be later than created_before")
+	}
+	return services.DateRange{After: after, Before: before}, nil
+}
+
+// parseFactCheckFilter reads the fact_check_verdict, fact_check_limit and fact_check_offset
+// query parameters. Absent parameters leave the filter returning every fact check.
+func parseFactCheckFilter(r *http.Request) (services.FactCheckFilter, utils.ValidationErrors) {
+	var filter services.FactCheckFilter
+	var errs utils.ValidationErrors
+	query := r.URL.Query()
+
+	if value := query.Get("fact_check_verdict"); value != "" {
+		verdict, err := models.ParseVerdict(value)
+		if err != nil {
+			errs.Add("fact_check_verdict", err.Error())
+		}
+		filter.Verdict = verdict
+	}
+	if value := query.Get("fact_check_limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			errs.Add("fact_check_limit", "fact_check_limit must be a positive integer")
+		}
+		filter.Limit = limit
+	}
+	if value := query.Get("fact_check_offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			errs.Add("fact_check_offset", "fact_check_offset must be a non-negative integer")
+		}
+		filter.Offset = offset
+	}
+
+	if len(errs) > 0 {
+		return services.FactCheckFilter{}, errs
+	}
+	return filter, nil
+}
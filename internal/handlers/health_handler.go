@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/utils"
+)
+
+// defaultHealthCheckTimeout bounds each dependency check when no timeout is configured
+const defaultHealthCheckTimeout = 2 * time.Second
+
+// HealthCheck is one dependency the health endpoint verifies
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// HealthCheckResult is the outcome of one dependency check
+type HealthCheckResult struct {
+	Status     string `json:"status"` // "ok" or "error"
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// HealthResponse is the body of the health endpoint
+type HealthResponse struct {
+	Status  string                       `json:"status"` // "healthy" when every check passed, otherwise "unhealthy"
+	Service string                       `json:"service"`
+	Version string                       `json:"version"`
+	Checks  map[string]HealthCheckResult `json:"checks,omitempty"`
+	Age     float64                      `json:"age"` // Seconds since the checks ran; above 0 when served from the cache
+}
+
+// HealthHandler runs the dependency checks behind GET /health. Results are cached briefly so
+// frequent load balancer probes don't each ping every dependency, and each check has a
+// deadline so a hung dependency can't hold the probe open.
+type HealthHandler struct {
+	checks   []HealthCheck
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	// mu is held while checks run, so concurrent probes wait for one round of checks
+	// rather than starting their own
+	mu        sync.Mutex
+	cached    *HealthResponse
+	checkedAt time.Time
+}
+
+// NewHealthHandler creates a health handler running the given checks. A timeout of 0 uses
+// the default and a cacheTTL of 0 runs the checks on every probe.
+func NewHealthHandler(timeout, cacheTTL time.Duration, checks ...HealthCheck) *HealthHandler {
+	if timeout <= 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+	return &HealthHandler{checks: checks, timeout: timeout, cacheTTL: cacheTTL}
+}
+
+// GetHealth reports whether the service and its dependencies are healthy, with 503 when a
+// check failed
+func (h *HealthHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	response := h.result(r.Context())
+
+	statusCode := http.StatusOK
+	if response.Status != "healthy" {
+		statusCode = http.StatusServiceUnavailable
+	}
+	utils.WriteJSON(w, statusCode, response)
+}
+
+// result returns the cached check results while they are fresh, otherwise runs the checks
+func (h *HealthHandler) result(ctx context.Context) HealthResponse {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cached != nil && time.Since(h.checkedAt) < h.cacheTTL {
+		response := *h.cached
+		response.Age = time.Since(h.checkedAt).Seconds()
+		return response
+	}
+
+	response := h.runChecks(ctx)
+	h.cached = &response
+	h.checkedAt = time.Now()
+	return response
+}
+
+// runChecks runs every check concurrently, each under its own deadline
+func (h *HealthHandler) runChecks(ctx context.Context) HealthResponse {
+	response := HealthResponse{
+		Status:  "healthy",
+		Service: "podcast-analyzer-go",
+		Version: "1.0.0",
+	}
+	if len(h.checks) == 0 {
+		return response
+	}
+
+	results := make([]HealthCheckResult, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func(i int, check HealthCheck) {
+			defer wg.Done()
+			results[i] = h.runCheck(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	response.Checks = make(map[string]HealthCheckResult, len(h.checks))
+	for i, check := range h.checks {
+		response.Checks[check.Name] = results[i]
+		if results[i].Status != "ok" {
+			response.Status = "unhealthy"
+		}
+	}
+	return response
+}
+
+// runCheck runs one check, giving up once the timeout passes even if the check ignores
+// its context
+func (h *HealthHandler) runCheck(ctx context.Context, check HealthCheck) HealthCheckResult {
+	// Detached from the probe's own cancellation, since the result is shared with other probes
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", h.timeout)
+	}
+
+	result := HealthCheckResult{Status: "ok", DurationMS: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+		logger.Log.WithFields(map[string]interface{}{
+			"check":       check.Name,
+			"error":       err.Error(),
+			"duration_ms": result.DurationMS,
+		}).Warn("Health check failed")
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getHealth(t *testing.T, handler *HealthHandler) (int, HealthResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/health", nil)
+	w := httptest.NewRecorder()
+	handler.GetHealth(w, req)
+
+	var response HealthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return w.Code, response
+}
+
+func TestHealthHandler_Healthy(t *testing.T) {
+	handler := NewHealthHandler(time.Second, 0, HealthCheck{
+		Name:  "database",
+		Check: func(ctx context.Context) error { return nil },
+	})
+
+	status, response := getHealth(t, handler)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "healthy", response.Status)
+	assert.Equal(t, "podcast-analyzer-go", response.Service)
+	assert.Equal(t, "ok", response.Checks["database"].Status)
+	assert.Zero(t, response.Age)
+}
+
+func TestHealthHandler_FailingCheck(t *testing.T) {
+	handler := NewHealthHandler(time.Second, 0,
+		HealthCheck{Name: "database", Check: func(ctx context.Context) error { return errors.New("connection refused") }},
+		HealthCheck{Name: "other", Check: func(ctx context.Context) error { return nil }},
+	)
+
+	status, response := getHealth(t, handler)
+
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "unhealthy", response.Status)
+	assert.Equal(t, "error", response.Checks["database"].Status)
+	assert.Equal(t, "connection refused", response.Checks["database"].Error)
+	assert.Equal(t, "ok", response.Checks["other"].Status)
+}
+
+func TestHealthHandler_HungCheckTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	handler := NewHealthHandler(20*time.Millisecond, 0, HealthCheck{
+		Name: "database",
+		// Ignores its context, so only the handler's own deadline can end the wait
+		Check: func(ctx context.Context) error {
+			<-release
+			return nil
+		},
+	})
+
+	start := time.Now()
+	status, response := getHealth(t, handler)
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Contains(t, response.Checks["database"].Error, "timed out")
+}
+
+func TestHealthHandler_CachesResult(t *testing.T) {
+	var calls int32
+	handler := NewHealthHandler(time.Second, time.Minute, HealthCheck{
+		Name: "database",
+		Check: func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, _ := getHealth(t, handler)
+			assert.Equal(t, http.StatusOK, status)
+		}()
+	}
+	wg.Wait()
+
+	_, response := getHealth(t, handler)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Greater(t, response.Age, 0.0)
+}
+
+func TestHealthHandler_NoCacheRunsEveryProbe(t *testing.T) {
+	var calls int32
+	handler := NewHealthHandler(time.Second, 0, HealthCheck{
+		Name:  "database",
+		Check: func(ctx context.Context) error { atomic.AddInt32(&calls, 1); return nil },
+	})
+
+	getHealth(t, handler)
+	getHealth(t, handler)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHealthHandler_MethodNotAllowed(t *testing.T) {
+	handler := NewHealthHandler(time.Second, 0)
+
+	req := httptest.NewRequest(http.MethodPost, "/health", nil)
+	w := httptest.NewRecorder()
+	handler.GetHealth(w, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/services"
+	"podcast-analyzer/internal/utils"
+)
+
+// InfoResponse describes what this server supports, so clients can adapt to it instead of
+// hard-coding assumptions. It never carries secrets.
+type InfoResponse struct {
+	Service           string          `json:"service"`
+	Version           string          `json:"version"`
+	DefaultModel      string          `json:"default_model"`
+	Models            []string        `json:"models"`         // Every model the server may call, default first
+	EnabledAgents     []string        `json:"enabled_agents"` // Pipeline stages run for each job, in order
+	MaxFileSize       int64           `json:"max_file_size"`  // Bytes
+	AllowedExtensions []string        `json:"allowed_extensions"`
+	MaxBatchFiles     int             `json:"max_batch_files"`
+	ExportFormats     []string        `json:"export_formats"`
+	ExportTables      []string        `json:"export_tables"`
+	Features          map[string]bool `json:"features"`
+}
+
+// InfoHandler serves GET /api/info. The response is built once from the configuration,
+// which does not change while the server runs.
+type InfoHandler struct {
+	info InfoResponse
+}
+
+// NewInfoHandler creates an info handler describing the given configuration
+func NewInfoHandler(cfg *config.Config) *InfoHandler {
+	return &InfoHandler{info: buildInfo(cfg)}
+}
+
+// GetInfo returns the server's capabilities and feature flags
+func (h *InfoHandler) GetInfo(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	if r.Method == http.MethodOptions {
+		// Handle preflight request
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, h.info)
+}
+
+// buildInfo collects the client-relevant parts of the configuration
+func buildInfo(cfg *config.Config) InfoResponse {
+	enabledAgents := []string{}
+	if cfg.EnableSummarizer {
+		enabledAgents = append(enabledAgents, "summarizer")
+	}
+	if cfg.EnableTakeaways {
+		enabledAgents = append(enabledAgents, "takeaway_extractor")
+		if cfg.EnableTakeawaysSummary {
+			enabledAgents = append(enabledAgents, "takeaway_synthesizer")
+		}
+	}
+	if cfg.EnableFactCheck {
+		enabledAgents = append(enabledAgents, "fact_checker")
+	}
+
+	return InfoResponse{
+		Service:           "podcast-analyzer-go",
+		Version:           "1.0.0",
+		DefaultModel:      cfg.ClaudeModel,
+		Models:            append([]string{cfg.ClaudeModel}, cfg.ClaudeFallbackModels...),
+		EnabledAgents:     enabledAgents,
+		MaxFileSize:       cfg.MaxFileSize,
+		AllowedExtensions: append([]string{}, cfg.AllowedExts...),
+		MaxBatchFiles:     cfg.MaxBatchFiles,
+		ExportFormats:     []string{services.ExportFormatCSV},
+		ExportTables:      []string{services.ExportTableFactChecks, services.ExportTableTakeaways},
+		Features: map[string]bool{
+			// Results are fetched by polling; there is no streaming or WebSocket API yet
+			"streaming":         false,
+			"websockets":        false,
+			"ad_filter":         cfg.AdFilterEnabled,
+			"takeaways_summary": cfg.EnableTakeaways && cfg.EnableTakeawaysSummary,
+			"summary_headline":  cfg.EnableSummarizer && cfg.EnableSummaryHeadline,
+			"ranked_takeaways":  cfg.EnableTakeaways && cfg.RankTakeaways,
+			"fact_check_rerun":  cfg.EnableFactCheck,
+			"fact_check_cache":  cfg.EnableFactCheck && cfg.FactCheckCacheTTL > 0,
+			"debug_endpoints":   cfg.EnableDebugEndpoints,
+		},
+	}
+}
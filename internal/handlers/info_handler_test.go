@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"podcast-analyzer/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoHandler_GetInfo(t *testing.T) {
+	cfg := &config.Config{
+		AnthropicAPIKey:        "secret-key",
+		ClaudeModel:            "claude-sonnet-4-20250514",
+		ClaudeFallbackModels:   []string{"claude-3-5-haiku-20241022"},
+		MaxFileSize:            10 * 1024 * 1024,
+		AllowedExts:            []string{".txt", ".json"},
+		MaxBatchFiles:          20,
+		EnableSummarizer:       true,
+		EnableTakeaways:        true,
+		EnableTakeawaysSummary: true,
+		EnableSummaryHeadline:  true,
+		EnableFactCheck:        false,
+		RankTakeaways:          true,
+	}
+	handler := NewInfoHandler(cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	w := httptest.NewRecorder()
+	handler.GetInfo(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret-key")
+
+	var info InfoResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, "claude-sonnet-4-20250514", info.DefaultModel)
+	assert.Equal(t, []string{"claude-sonnet-4-20250514", "claude-3-5-haiku-20241022"}, info.Models)
+	assert.Equal(t, []string{"summarizer", "takeaway_extractor", "takeaway_synthesizer"}, info.EnabledAgents)
+	assert.Equal(t, int64(10*1024*1024), info.MaxFileSize)
+	assert.Equal(t, []string{".txt", ".json"}, info.AllowedExtensions)
+	assert.Equal(t, 20, info.MaxBatchFiles)
+	assert.Equal(t, []string{"csv"}, info.ExportFormats)
+	assert.Equal(t, []string{"fact_checks", "takeaways"}, info.ExportTables)
+	assert.False(t, info.Features["streaming"])
+	assert.False(t, info.Features["websockets"])
+	assert.True(t, info.Features["ranked_takeaways"])
+	assert.True(t, info.Features["summary_headline"])
+	assert.False(t, info.Features["fact_check_rerun"])
+}
+
+func TestInfoHandler_NoAgentsEnabled(t *testing.T) {
+	handler := NewInfoHandler(&config.Config{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/info", nil)
+	w := httptest.NewRecorder()
+	handler.GetInfo(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	// An empty list rather than null, so clients can iterate it unconditionally
+	assert.Contains(t, w.Body.String(), `"enabled_agents":[]`)
+}
+
+func TestInfoHandler_MethodNotAllowed(t *testing.T) {
+	handler := NewInfoHandler(&config.Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/info", nil)
+	w := httptest.NewRecorder()
+	handler.GetInfo(w, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestInfoHandler_Preflight(t *testing.T) {
+	handler := NewInfoHandler(&config.Config{})
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/info", nil)
+	w := httptest.NewRecorder()
+	handler.GetInfo(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+}
@@ -1,8 +1,13 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"podcast-analyzer/internal/models"
 	"podcast-analyzer/internal/services"
 	"podcast-analyzer/internal/logger"
@@ -14,21 +19,51 @@ import (
 // TranscriptServiceInterface defines the interface for transcript service
 type TranscriptServiceInterface interface {
 	UploadTranscript(req *services.UploadTranscriptRequest, correlationID string) (*services.UploadTranscriptResponse, error)
-	GetTranscripts(page, perPage int) ([]*models.Transcript, int64, error)
+	UploadTranscripts(files []*multipart.FileHeader, correlationID string) ([]services.BatchUploadResult, error)
+	GetTranscripts(page, perPage int, dateRange services.DateRange) ([]*models.Transcript, int64, error)
+	LoadPreviews(transcripts []*models.Transcript, correlationID string)
 	GetTranscript(id uuid.UUID) (*models.Transcript, error)
 	DeleteTranscript(id uuid.UUID, correlationID string) error
+	ReparseTranscript(id uuid.UUID, correlationID string) (*models.Transcript, error)
+	WriteTranscriptBundle(w io.Writer, transcript *models.Transcript, correlationID string) error
+	GetTranscriptFacets(correlationID string) (*services.TranscriptFacets, error)
+	TranscriptExists(contentHash string, correlationID string) (*services.TranscriptExistsResponse, error)
+	ValidateTranscript(req *services.UploadTranscriptRequest, correlationID string) (*services.TranscriptValidationResponse, error)
 }
 
 type TranscriptHandler struct {
 	transcriptService TranscriptServiceInterface
+	pagination        Pagination
+	maxBatchBodySize  int64 // Largest accepted batch upload body; zero is unlimited
 }
 
+// batchRequestOverhead leaves room in a batch upload body for the multipart headers and
+// boundaries around each file
+const batchRequestOverhead = 64 << 10
+
 func NewTranscriptHandler(transcriptService TranscriptServiceInterface) *TranscriptHandler {
 	return &TranscriptHandler{
 		transcriptService: transcriptService,
 	}
 }
 
+// WithPagination sets the page size bounds of the handler's list endpoints
+func (h *TranscriptHandler) WithPagination(pagination Pagination) *TranscriptHandler {
+	h.pagination = pagination
+	return h
+}
+
+// WithMaxBatchSize caps the body of batch uploads at maxFiles files of up to maxFileSize
+// bytes each, plus room for the multipart framing. A maxFiles of zero uses the service's
+// default limit.
+func (h *TranscriptHandler) WithMaxBatchSize(maxFiles int, maxFileSize int64) *TranscriptHandler {
+	if maxFiles <= 0 {
+		maxFiles = services.DefaultMaxBatchFiles
+	}
+	h.maxBatchBodySize = int64(maxFiles) * (maxFileSize + batchRequestOverhead)
+	return h
+}
+
 // validateUploadRequest validates the upload request and extracts file
 func (h *TranscriptHandler) validateUploadRequest(r *http.Request, correlationID string) (*services.UploadTranscriptRequest, error) {
 	// Parse multipart form
@@ -47,20 +82,41 @@ func (h *TranscriptHandler) validateUploadRequest(r *http.Request, correlationID
 			"correlation_id": correlationID,
 			"error":          err.Error(),
 		}).Error("File upload validation failed")
-		return nil, fmt.Errorf("no file uploaded or invalid file: %w", err)
+		return nil, utils.NewValidationError("file", fmt.Sprintf("no file uploaded or invalid file: %v", err))
 	}
 	defer file.Close()
 
 	return &services.UploadTranscriptRequest{
-		File: fileHeader,
+		File:        fileHeader,
+		CallbackURL: strings.TrimSpace(r.FormValue("callback_url")),
 	}, nil
 }
 
+// uploadRetryAfter is the Retry-After value, in seconds, sent when uploads are saturated
+const uploadRetryAfter = "5"
+
 // handleServiceError determines error type and status code for service errors
 func (h *TranscriptHandler) handleServiceError(err error) (int, string) {
+	if errors.Is(err, services.ErrUploadsSaturated) {
+		return http.StatusServiceUnavailable, "UPLOADS_SATURATED"
+	}
+	if errors.Is(err, services.ErrContentBudgetExceeded) {
+		return http.StatusServiceUnavailable, "MEMORY_BUDGET_EXCEEDED"
+	}
+	var schemaErr *services.TranscriptSchemaError
+	if errors.As(err, &schemaErr) {
+		return http.StatusUnprocessableEntity, "TRANSCRIPT_SCHEMA_ERROR"
+	}
+	var validationErrs utils.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return http.StatusUnprocessableEntity, "VALIDATION_ERROR"
+	}
 	if utils.Contains(err.Error(), "duplicate") {
 		return http.StatusConflict, "DUPLICATE_TRANSCRIPT"
 	}
+	if utils.Contains(err.Error(), "unsupported content type") {
+		return http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE"
+	}
 	return http.StatusBadRequest, "FILE_VALIDATION_ERROR"
 }
 
@@ -80,6 +136,7 @@ func (h *TranscriptHandler) logUploadSuccess(response *services.UploadTranscript
 		"transcript_id":   response.TranscriptID,
 		"filename":        response.Filename,
 		"word_count":      response.WordCount,
+		"char_count":      response.CharCount,
 	}).Info("Upload completed successfully")
 }
 
@@ -103,6 +160,11 @@ func (h *TranscriptHandler) UploadTranscript(w http.ResponseWriter, r *http.Requ
 
 	// Validate upload request
 	req, err := h.validateUploadRequest(r, correlationID)
+	var validationErrs utils.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
 	if err != nil {
 		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "FORM_PARSE_ERROR", err.Error(), correlationID)
 		return
@@ -127,6 +189,13 @@ func (h *TranscriptHandler) UploadTranscript(w http.ResponseWriter, r *http.Requ
 			"operation":   "upload_transcript",
 		})
 
+		if errors.As(err, &validationErrs) {
+			utils.WriteValidationErrors(w, validationErrs, correlationID)
+			return
+		}
+		if statusCode == http.StatusServiceUnavailable {
+			w.Header().Set("Retry-After", uploadRetryAfter)
+		}
 		utils.WriteJSON(w, statusCode, map[string]interface{}{
 			"error": map[string]interface{}{
 				"code":           errorCode,
@@ -141,6 +210,108 @@ func (h *TranscriptHandler) UploadTranscript(w http.ResponseWriter, r *http.Requ
 	utils.WriteJSON(w, http.StatusOK, response)
 }
 
+// UploadTranscriptBatch handles uploads of multiple files in one multipart form,
+// responding with 207 Multi-Status when any file fails
+func (h *TranscriptHandler) UploadTranscriptBatch(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	if r.Method == http.MethodOptions {
+		// Handle preflight request
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	correlationID := utils.GetCorrelationID(r)
+	h.logUploadRequest(r, correlationID)
+
+	if h.maxBatchBodySize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, h.maxBatchBodySize)
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			utils.WriteErrorWithCorrelation(w, http.StatusRequestEntityTooLarge, "REQUEST_TOO_LARGE", fmt.Sprintf("request body too large: more than %d bytes", maxBytesErr.Limit), correlationID)
+			return
+		}
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "FORM_PARSE_ERROR",
+			fmt.Sprintf("failed to parse multipart form: %v", err), correlationID)
+		return
+	}
+
+	results, err := h.transcriptService.UploadTranscripts(r.MultipartForm.File["file"], correlationID)
+	var validationErrs utils.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "BATCH_VALIDATION_ERROR", err.Error(), correlationID)
+		return
+	}
+
+	items := make([]map[string]interface{}, len(results))
+	failed := 0
+	saturated := false
+	for i, result := range results {
+		if result.Err != nil {
+			failed++
+			statusCode, errorCode := h.handleServiceError(result.Err)
+			if statusCode == http.StatusServiceUnavailable {
+				saturated = true
+			}
+			logger.LogErrorWithStackAndCorrelation(result.Err, correlationID, map[string]interface{}{
+				"error_code":  errorCode,
+				"status_code": statusCode,
+				"filename":    result.Filename,
+				"operation":   "upload_transcript_batch_item",
+			})
+			items[i] = map[string]interface{}{
+				"filename": result.Filename,
+				"status":   statusCode,
+				"error": map[string]interface{}{
+					"code":    errorCode,
+					"message": result.Err.Error(),
+				},
+			}
+			if errors.As(result.Err, &validationErrs) {
+				items[i]["errors"] = validationErrs
+			}
+			continue
+		}
+
+		h.logUploadSuccess(result.Response, correlationID)
+		items[i] = map[string]interface{}{
+			"filename":      result.Filename,
+			"status":        http.StatusOK,
+			"transcript_id": result.Response.TranscriptID,
+			"word_count":    result.Response.WordCount,
+			"char_count":    result.Response.CharCount,
+		}
+	}
+
+	statusCode := http.StatusOK
+	if failed > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+	if saturated {
+		// Tell clients when to retry the files that were turned away
+		w.Header().Set("Retry-After", uploadRetryAfter)
+	}
+	utils.WriteJSON(w, statusCode, map[string]interface{}{
+		"results":        items,
+		"succeeded":      len(results) - failed,
+		"failed":         failed,
+		"correlation_id": correlationID,
+	})
+}
+
 // GetTranscripts returns paginated list of transcripts
 func (h *TranscriptHandler) GetTranscripts(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers
@@ -151,17 +322,19 @@ func (h *TranscriptHandler) GetTranscripts(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	page := utils.GetQueryParamInt(r, "page", 1)
-	perPage := utils.GetQueryParamInt(r, "per_page", 20)
+	page, perPage := h.pagination.parse(r)
 
-	if page < 1 {
-		page = 1
+	dateRange, validationErrs := parseDateRange(r)
+	includePreview, err := utils.GetQueryParamBool(r, "include_preview", false)
+	if err != nil {
+		validationErrs.Add("include_preview", err.Error())
 	}
-	if perPage < 1 || perPage > 100 {
-		perPage = 20
+	if len(validationErrs) > 0 {
+		utils.WriteValidationErrors(w, validationErrs, utils.GetCorrelationID(r))
+		return
 	}
 
-	transcripts, total, err := h.transcriptService.GetTranscripts(page, perPage)
+	transcripts, total, err := h.transcriptService.GetTranscripts(page, perPage, dateRange)
 	if err != nil {
 		logger.LogErrorWithStack(err, map[string]interface{}{
 			"operation": "get_transcripts",
@@ -172,6 +345,10 @@ func (h *TranscriptHandler) GetTranscripts(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if includePreview {
+		h.transcriptService.LoadPreviews(transcripts, utils.GetCorrelationID(r))
+	}
+
 	utils.WriteJSON(w, http.StatusOK, map[string]interface{}{
 		"transcripts": transcripts,
 		"total":       total,
@@ -180,6 +357,120 @@ func (h *TranscriptHandler) GetTranscripts(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+// GetTranscriptFacets returns the distinct transcript languages and tags with their counts
+func (h *TranscriptHandler) GetTranscriptFacets(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	if r.Method == http.MethodOptions {
+		// Handle preflight request
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	correlationID := utils.GetCorrelationID(r)
+	facets, err := h.transcriptService.GetTranscriptFacets(correlationID)
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "get_transcript_facets",
+		})
+		utils.WriteErrorWithCorrelation(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to retrieve transcript facets", correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, facets)
+}
+
+// TranscriptExists reports whether a transcript with the content hash in ?hash= is stored
+func (h *TranscriptHandler) TranscriptExists(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	if r.Method == http.MethodOptions {
+		// Handle preflight request
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	correlationID := utils.GetCorrelationID(r)
+	response, err := h.transcriptService.TranscriptExists(r.URL.Query().Get("hash"), correlationID)
+	if err != nil {
+		var validationErrs utils.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			utils.WriteValidationErrors(w, validationErrs, correlationID)
+			return
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "transcript_exists",
+		})
+		utils.WriteErrorWithCorrelation(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Failed to look up transcript", correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
+// ValidateTranscript runs the upload checks on a file without storing it. A file that
+// would be rejected still gets 200, with valid false and its problems in errors.
+func (h *TranscriptHandler) ValidateTranscript(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	if r.Method == http.MethodOptions {
+		// Handle preflight request
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	correlationID := utils.GetCorrelationID(r)
+	req, err := h.validateUploadRequest(r, correlationID)
+	var validationErrs utils.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		utils.WriteValidationErrors(w, validationErrs, correlationID)
+		return
+	}
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "FORM_PARSE_ERROR", err.Error(), correlationID)
+		return
+	}
+
+	response, err := h.transcriptService.ValidateTranscript(req, correlationID)
+	if err != nil {
+		statusCode, errorCode := h.handleServiceError(err)
+		if statusCode != http.StatusServiceUnavailable {
+			statusCode, errorCode = http.StatusInternalServerError, "INTERNAL_ERROR"
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"error_code":  errorCode,
+			"status_code": statusCode,
+			"filename":    req.File.Filename,
+			"operation":   "validate_transcript",
+		})
+		if statusCode == http.StatusServiceUnavailable {
+			w.Header().Set("Retry-After", uploadRetryAfter)
+		}
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, response)
+}
+
 // GetTranscript returns a single transcript
 func (h *TranscriptHandler) GetTranscript(w http.ResponseWriter, r *http.Request) {
 	// Set CORS headers
@@ -199,7 +490,7 @@ func (h *TranscriptHandler) GetTranscript(w http.ResponseWriter, r *http.Request
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.WriteError(w, http.StatusBadRequest, "INVALID_UUID", "Invalid transcript ID format")
+		utils.WriteValidationErrors(w, utils.NewValidationError("transcript_id", "Invalid transcript ID format"), utils.GetCorrelationID(r))
 		return
 	}
 
@@ -224,7 +515,61 @@ func (h *TranscriptHandler) GetTranscript(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	utils.WriteJSON(w, http.StatusOK, transcript)
+	utils.WriteJSONWithETag(w, r, http.StatusOK, transcript)
+}
+
+// GetTranscriptBundle streams a zip of the transcript and all of its analyses
+func (h *TranscriptHandler) GetTranscriptBundle(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+
+	if r.Method != http.MethodGet {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	// Extract ID from path like /api/transcripts/123/bundle
+	idStr, err := utils.ExtractIDFromPath(strings.TrimSuffix(r.URL.Path, "/bundle"), "/api/transcripts/")
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid transcript path", correlationID)
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.WriteValidationErrors(w, utils.NewValidationError("transcript_id", "Invalid transcript ID format"), correlationID)
+		return
+	}
+
+	transcript, err := h.transcriptService.GetTranscript(id)
+	if err != nil {
+		statusCode := http.StatusNotFound
+		errorCode := "TRANSCRIPT_NOT_FOUND"
+
+		if !utils.Contains(err.Error(), "not found") {
+			statusCode = http.StatusInternalServerError
+			errorCode = "INTERNAL_ERROR"
+		}
+
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": services.BundleFilename(transcript),
+	}))
+	w.WriteHeader(http.StatusOK)
+
+	// Headers are already sent, so a failure part way through can only be logged
+	if err := h.transcriptService.WriteTranscriptBundle(w, transcript, correlationID); err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": id,
+			"operation":     "write_transcript_bundle",
+		})
+	}
 }
 
 // DeleteTranscript deletes a transcript
@@ -248,7 +593,7 @@ func (h *TranscriptHandler) DeleteTranscript(w http.ResponseWriter, r *http.Requ
 
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_UUID", "Invalid transcript ID format", correlationID)
+		utils.WriteValidationErrors(w, utils.NewValidationError("transcript_id", "Invalid transcript ID format"), correlationID)
 		return
 	}
 
@@ -275,4 +620,53 @@ func (h *TranscriptHandler) DeleteTranscript(w http.ResponseWriter, r *http.Requ
 	utils.WriteJSON(w, http.StatusOK, map[string]interface{}{
 		"message": "Transcript deleted successfully",
 	})
-}
\ No newline at end of file
+}
+
+// ReparseTranscript re-derives a transcript's word count and metadata from its stored file
+func (h *TranscriptHandler) ReparseTranscript(w http.ResponseWriter, r *http.Request) {
+	// Set CORS headers
+	utils.SetCORSHeaders(w)
+
+	correlationID := utils.GetCorrelationID(r)
+
+	if r.Method != http.MethodPost {
+		utils.WriteError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Method not allowed")
+		return
+	}
+
+	// Extract ID from path like /api/transcripts/123/reparse
+	idStr, err := utils.ExtractIDFromPath(strings.TrimSuffix(r.URL.Path, "/reparse"), "/api/transcripts/")
+	if err != nil {
+		utils.WriteErrorWithCorrelation(w, http.StatusBadRequest, "INVALID_PATH", "Invalid transcript path", correlationID)
+		return
+	}
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		utils.WriteValidationErrors(w, utils.NewValidationError("transcript_id", "Invalid transcript ID format"), correlationID)
+		return
+	}
+
+	transcript, err := h.transcriptService.ReparseTranscript(id, correlationID)
+	if err != nil {
+		statusCode := http.StatusNotFound
+		errorCode := "TRANSCRIPT_NOT_FOUND"
+
+		if !utils.Contains(err.Error(), "not found") {
+			statusCode = http.StatusInternalServerError
+			errorCode = "INTERNAL_ERROR"
+		}
+
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": id,
+			"error_code":    errorCode,
+			"status_code":   statusCode,
+			"operation":     "reparse_transcript",
+		})
+
+		utils.WriteErrorWithCorrelation(w, statusCode, errorCode, err.Error(), correlationID)
+		return
+	}
+
+	utils.WriteJSON(w, http.StatusOK, transcript)
+}
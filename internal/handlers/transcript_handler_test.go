@@ -3,13 +3,18 @@ package handlers
 import (
 	"podcast-analyzer/internal/models"
 	"podcast-analyzer/internal/services"
+	"podcast-analyzer/internal/utils"
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -30,14 +35,26 @@ func (m *MockTranscriptService) UploadTranscript(req *services.UploadTranscriptR
 	return args.Get(0).(*services.UploadTranscriptResponse), args.Error(1)
 }
 
-func (m *MockTranscriptService) GetTranscripts(page, perPage int) ([]*models.Transcript, int64, error) {
-	args := m.Called(page, perPage)
+func (m *MockTranscriptService) UploadTranscripts(files []*multipart.FileHeader, correlationID string) ([]services.BatchUploadResult, error) {
+	args := m.Called(files, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.BatchUploadResult), args.Error(1)
+}
+
+func (m *MockTranscriptService) GetTranscripts(page, perPage int, dateRange services.DateRange) ([]*models.Transcript, int64, error) {
+	args := m.Called(page, perPage, dateRange)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(int64), args.Error(2)
 	}
 	return args.Get(0).([]*models.Transcript), args.Get(1).(int64), args.Error(2)
 }
 
+func (m *MockTranscriptService) LoadPreviews(transcripts []*models.Transcript, correlationID string) {
+	m.Called(transcripts, correlationID)
+}
+
 func (m *MockTranscriptService) GetTranscript(id uuid.UUID) (*models.Transcript, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -51,6 +68,27 @@ func (m *MockTranscriptService) DeleteTranscript(id uuid.UUID, correlationID str
 	return args.Error(0)
 }
 
+func (m *MockTranscriptService) ReparseTranscript(id uuid.UUID, correlationID string) (*models.Transcript, error) {
+	args := m.Called(id, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Transcript), args.Error(1)
+}
+
+func (m *MockTranscriptService) WriteTranscriptBundle(w io.Writer, transcript *models.Transcript, correlationID string) error {
+	args := m.Called(w, transcript, correlationID)
+	return args.Error(0)
+}
+
+func (m *MockTranscriptService) GetTranscriptFacets(correlationID string) (*services.TranscriptFacets, error) {
+	args := m.Called(correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TranscriptFacets), args.Error(1)
+}
+
 func (m *MockTranscriptService) ReadTranscriptContent(transcript *models.Transcript) (string, error) {
 	args := m.Called(transcript)
 	if args.Get(0) == nil {
@@ -115,6 +153,34 @@ func TestTranscriptHandler_UploadTranscript(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "invalid file extension",
 		},
+		{
+			name: "validation errors",
+			setupMock: func() {
+				mockService.On("UploadTranscript", mock.AnythingOfType("*services.UploadTranscriptRequest"), mock.AnythingOfType("string")).Return(
+					nil, utils.ValidationErrors{
+						{Field: "file.extension", Message: "invalid file extension: .pdf"},
+						{Field: "file.size", Message: "file too large"},
+					})
+			},
+			filename:       "test.pdf",
+			content:        "This is invalid content",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  "invalid file extension: .pdf; file too large",
+		},
+		{
+			name: "json schema error",
+			setupMock: func() {
+				mockService.On("UploadTranscript", mock.AnythingOfType("*services.UploadTranscriptRequest"), mock.AnythingOfType("string")).Return(
+					nil, fmt.Errorf("failed to parse transcript: %w", &services.TranscriptSchemaError{
+						Reason:   `missing required "transcript" field`,
+						Expected: []string{`"transcript": "<full transcript text>"`},
+					}))
+			},
+			filename:       "test.json",
+			content:        `{"title": "Episode 1"}`,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedError:  `missing required "transcript" field`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -154,6 +220,78 @@ func TestTranscriptHandler_UploadTranscript(t *testing.T) {
 	}
 }
 
+func TestTranscriptHandler_UploadTranscript_CallbackURL(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	mockService.On("UploadTranscript", mock.MatchedBy(func(req *services.UploadTranscriptRequest) bool {
+		return req.CallbackURL == "https://hooks.example.com/uploads"
+	}), mock.AnythingOfType("string")).Return(&services.UploadTranscriptResponse{Filename: "test.txt"}, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "test.txt")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("This is a test transcript content"))
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteField("callback_url", " https://hooks.example.com/uploads "))
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/transcripts/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	recorder := httptest.NewRecorder()
+	handler.UploadTranscript(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTranscriptHandler_UploadTranscript_Saturated(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	mockService.On("UploadTranscript", mock.AnythingOfType("*services.UploadTranscriptRequest"), mock.AnythingOfType("string")).Return(
+		nil, services.ErrUploadsSaturated)
+
+	body, contentType := createTestFileUpload(t, "file", "test.txt", "This is a test transcript content")
+	req := httptest.NewRequest(http.MethodPost, "/api/transcripts/", body)
+	req.Header.Set("Content-Type", contentType)
+
+	recorder := httptest.NewRecorder()
+	handler.UploadTranscript(recorder, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, uploadRetryAfter, recorder.Header().Get("Retry-After"))
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	errorObj := response["error"].(map[string]interface{})
+	assert.Equal(t, "UPLOADS_SATURATED", errorObj["code"])
+}
+
+func TestTranscriptHandler_UploadTranscript_ContentBudgetExceeded(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	mockService.On("UploadTranscript", mock.AnythingOfType("*services.UploadTranscriptRequest"), mock.AnythingOfType("string")).Return(
+		nil, services.ErrContentBudgetExceeded)
+
+	body, contentType := createTestFileUpload(t, "file", "test.txt", "This is a test transcript content")
+	req := httptest.NewRequest(http.MethodPost, "/api/transcripts/", body)
+	req.Header.Set("Content-Type", contentType)
+
+	recorder := httptest.NewRecorder()
+	handler.UploadTranscript(recorder, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, uploadRetryAfter, recorder.Header().Get("Retry-After"))
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	errorObj := response["error"].(map[string]interface{})
+	assert.Equal(t, "MEMORY_BUDGET_EXCEEDED", errorObj["code"])
+}
+
 func TestTranscriptHandler_UploadTranscript_NoFile(t *testing.T) {
 	mockService := &MockTranscriptService{}
 	handler := NewTranscriptHandler(mockService)
@@ -174,6 +312,121 @@ func TestTranscriptHandler_UploadTranscript_NoFile(t *testing.T) {
 	assert.Contains(t, errorObj["message"].(string), "failed to parse multipart form")
 }
 
+func createTestBatchUpload(t *testing.T, files map[string]string) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for filename, content := range files {
+		part, err := writer.CreateFormFile("file", filename)
+		require.NoError(t, err)
+		_, err = part.Write([]byte(content))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func TestTranscriptHandler_UploadTranscriptBatch(t *testing.T) {
+	tests := []struct {
+		name              string
+		results           []services.BatchUploadResult
+		serviceErr        error
+		expectedStatus    int
+		expectedSucceeded float64
+		expectedFailed    float64
+	}{
+		{
+			name: "all files succeed",
+			results: []services.BatchUploadResult{
+				{Filename: "a.txt", Response: &services.UploadTranscriptResponse{TranscriptID: uuid.New(), WordCount: 10}},
+				{Filename: "b.txt", Response: &services.UploadTranscriptResponse{TranscriptID: uuid.New(), WordCount: 20}},
+			},
+			expectedStatus:    http.StatusOK,
+			expectedSucceeded: 2,
+			expectedFailed:    0,
+		},
+		{
+			name: "partial success",
+			results: []services.BatchUploadResult{
+				{Filename: "a.txt", Response: &services.UploadTranscriptResponse{TranscriptID: uuid.New(), WordCount: 10}},
+				{Filename: "b.txt", Err: fmt.Errorf("duplicate transcript already exists with ID: %s", uuid.New())},
+			},
+			expectedStatus:    http.StatusMultiStatus,
+			expectedSucceeded: 1,
+			expectedFailed:    1,
+		},
+		{
+			name:           "too many files",
+			serviceErr:     fmt.Errorf("too many files: 2. Maximum: 1"),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockTranscriptService{}
+			handler := NewTranscriptHandler(mockService)
+
+			if tt.serviceErr != nil {
+				mockService.On("UploadTranscripts", mock.Anything, mock.AnythingOfType("string")).Return(nil, tt.serviceErr)
+			} else {
+				mockService.On("UploadTranscripts", mock.Anything, mock.AnythingOfType("string")).Return(tt.results, nil)
+			}
+
+			body, contentType := createTestBatchUpload(t, map[string]string{
+				"a.txt": "first transcript",
+				"b.txt": "second transcript",
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/transcripts/batch", body)
+			req.Header.Set("Content-Type", contentType)
+			recorder := httptest.NewRecorder()
+			handler.UploadTranscriptBatch(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+			if tt.serviceErr != nil {
+				errorObj := response["error"].(map[string]interface{})
+				assert.Equal(t, "BATCH_VALIDATION_ERROR", errorObj["code"])
+			} else {
+				assert.Equal(t, tt.expectedSucceeded, response["succeeded"])
+				assert.Equal(t, tt.expectedFailed, response["failed"])
+				items := response["results"].([]interface{})
+				assert.Len(t, items, len(tt.results))
+				if tt.expectedFailed > 0 {
+					failedItem := items[1].(map[string]interface{})
+					assert.Equal(t, float64(http.StatusConflict), failedItem["status"])
+					assert.Equal(t, "DUPLICATE_TRANSCRIPT", failedItem["error"].(map[string]interface{})["code"])
+				}
+			}
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTranscriptHandler_UploadTranscriptBatch_BodyTooLarge(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService).WithMaxBatchSize(1, 1024)
+
+	body, contentType := createTestBatchUpload(t, map[string]string{
+		"a.txt": strings.Repeat("word ", 20<<10),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/transcripts/batch", body)
+	req.Header.Set("Content-Type", contentType)
+	recorder := httptest.NewRecorder()
+	handler.UploadTranscriptBatch(recorder, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "REQUEST_TOO_LARGE", response["error"].(map[string]interface{})["code"])
+	mockService.AssertNotCalled(t, "UploadTranscripts", mock.Anything, mock.Anything)
+}
+
 func TestTranscriptHandler_GetTranscripts(t *testing.T) {
 	mockService := &MockTranscriptService{}
 	handler := NewTranscriptHandler(mockService)
@@ -191,7 +444,7 @@ func TestTranscriptHandler_GetTranscripts(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetTranscripts", 1, 10).Return(testTranscripts, int64(2), nil)
+	mockService.On("GetTranscripts", 1, 10, services.DateRange{}).Return(testTranscripts, int64(2), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/transcripts?page=1&per_page=10", nil)
 	recorder := httptest.NewRecorder()
@@ -212,12 +465,116 @@ func TestTranscriptHandler_GetTranscripts(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestTranscriptHandler_GetTranscripts_DateRange(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mockService.On("GetTranscripts", 2, 5, services.DateRange{After: &after, Before: &before}).
+		Return([]*models.Transcript{}, int64(0), nil)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/api/transcripts?page=2&per_page=5&created_after=2024-01-01T00:00:00Z&created_before=2024-02-01T00:00:00Z", nil)
+	recorder := httptest.NewRecorder()
+	handler.GetTranscripts(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestTranscriptHandler_GetTranscripts_IncludePreview(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	testTranscripts := []*models.Transcript{{ID: uuid.New(), Filename: "test1.txt"}}
+	mockService.On("GetTranscripts", 1, 20, services.DateRange{}).Return(testTranscripts, int64(1), nil)
+	mockService.On("LoadPreviews", testTranscripts, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(0).([]*models.Transcript)[0].Preview = "Welcome to the show"
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transcripts?include_preview=true", nil)
+	recorder := httptest.NewRecorder()
+	handler.GetTranscripts(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	transcript := response["transcripts"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Welcome to the show", transcript["preview"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestTranscriptHandler_GetTranscripts_InvalidIncludePreview(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transcripts?include_preview=maybe", nil)
+	recorder := httptest.NewRecorder()
+	handler.GetTranscripts(recorder, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "include_preview")
+	mockService.AssertNotCalled(t, "GetTranscripts")
+}
+
+func TestTranscriptHandler_GetTranscripts_InvalidDateRange(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{
+			name:          "malformed created_after",
+			query:         "created_after=yesterday",
+			expectedError: "created_after must be an RFC3339 timestamp",
+		},
+		{
+			name:          "malformed created_before",
+			query:         "created_before=2024-01-01",
+			expectedError: "created_before must be an RFC3339 timestamp",
+		},
+		{
+			name:          "after later than before",
+			query:         "created_after=2024-02-01T00:00:00Z&created_before=2024-01-01T00:00:00Z",
+			expectedError: "created_after must not be later than created_before",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/transcripts?"+tt.query, nil)
+			recorder := httptest.NewRecorder()
+			handler.GetTranscripts(recorder, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(recorder.Body.Bytes(), &response)
+			require.NoError(t, err)
+			errorObj := response["error"].(map[string]interface{})
+			assert.Equal(t, "VALIDATION_ERROR", errorObj["code"])
+			assert.Equal(t, tt.expectedError, errorObj["message"])
+			fieldErrors := response["errors"].([]interface{})
+			require.Len(t, fieldErrors, 1)
+			assert.Equal(t, tt.expectedError, fieldErrors[0].(map[string]interface{})["message"])
+		})
+	}
+
+	mockService.AssertNotCalled(t, "GetTranscripts")
+}
+
 func TestTranscriptHandler_GetTranscripts_InvalidPagination(t *testing.T) {
 	mockService := &MockTranscriptService{}
 	handler := NewTranscriptHandler(mockService)
 
 	// Mock service should return empty results for all these tests
-	mockService.On("GetTranscripts", mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return([]*models.Transcript{}, int64(0), nil)
+	mockService.On("GetTranscripts", mock.AnythingOfType("int"), mock.AnythingOfType("int"), services.DateRange{}).Return([]*models.Transcript{}, int64(0), nil)
 
 	tests := []struct {
 		name         string
@@ -277,6 +634,37 @@ func TestTranscriptHandler_GetTranscripts_InvalidPagination(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestTranscriptHandler_GetTranscripts_ConfiguredPagination(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService).WithPagination(Pagination{DefaultPerPage: 50, MaxPerPage: 200})
+
+	mockService.On("GetTranscripts", mock.AnythingOfType("int"), mock.AnythingOfType("int"), services.DateRange{}).Return([]*models.Transcript{}, int64(0), nil)
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedPer int
+	}{
+		{name: "absent per_page uses configured default", query: "", expectedPer: 50},
+		{name: "per_page above the old limit is accepted", query: "per_page=150", expectedPer: 150},
+		{name: "per_page above configured max uses default", query: "per_page=201", expectedPer: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/transcripts?"+tt.query, nil)
+			recorder := httptest.NewRecorder()
+			handler.GetTranscripts(recorder, req)
+
+			assert.Equal(t, http.StatusOK, recorder.Code)
+
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.Equal(t, float64(tt.expectedPer), response["per_page"])
+		})
+	}
+}
+
 func TestTranscriptHandler_GetTranscript(t *testing.T) {
 	mockService := &MockTranscriptService{}
 	handler := NewTranscriptHandler(mockService)
@@ -316,7 +704,7 @@ func TestTranscriptHandler_GetTranscript(t *testing.T) {
 			name:           "invalid UUID",
 			id:             "invalid-uuid",
 			setupMock:      func() {},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "Invalid transcript ID format",
 		},
 	}
@@ -353,6 +741,29 @@ func TestTranscriptHandler_GetTranscript(t *testing.T) {
 	}
 }
 
+func TestTranscriptHandler_GetTranscript_ConditionalGet(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	testID := uuid.New()
+	mockService.On("GetTranscript", testID).Return(&models.Transcript{ID: testID, Filename: "test.txt"}, nil)
+
+	first := httptest.NewRecorder()
+	handler.GetTranscript(first, httptest.NewRequest(http.MethodGet, "/api/transcripts/"+testID.String(), nil))
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transcripts/"+testID.String(), nil)
+	req.Header.Set("If-None-Match", etag)
+	second := httptest.NewRecorder()
+	handler.GetTranscript(second, req)
+
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Equal(t, etag, second.Header().Get("ETag"))
+	assert.Empty(t, second.Body.String())
+}
+
 func TestTranscriptHandler_DeleteTranscript(t *testing.T) {
 	mockService := &MockTranscriptService{}
 	handler := NewTranscriptHandler(mockService)
@@ -387,7 +798,7 @@ func TestTranscriptHandler_DeleteTranscript(t *testing.T) {
 			name:           "invalid UUID",
 			id:             "invalid-uuid",
 			setupMock:      func() {},
-			expectedStatus: http.StatusBadRequest,
+			expectedStatus: http.StatusUnprocessableEntity,
 			expectedError:  "Invalid transcript ID format",
 		},
 	}
@@ -420,4 +831,226 @@ func TestTranscriptHandler_DeleteTranscript(t *testing.T) {
 			mockService.AssertExpectations(t)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestTranscriptHandler_ReparseTranscript(t *testing.T) {
+	testID := uuid.New()
+
+	tests := []struct {
+		name           string
+		method         string
+		id             string
+		serviceResult  *models.Transcript
+		serviceErr     error
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "reparsed", method: http.MethodPost, id: testID.String(), serviceResult: &models.Transcript{ID: testID, WordCount: 42}, expectedStatus: http.StatusOK},
+		{name: "not found", method: http.MethodPost, id: testID.String(), serviceErr: fmt.Errorf("transcript not found"), expectedStatus: http.StatusNotFound, expectedCode: "TRANSCRIPT_NOT_FOUND"},
+		{name: "parse failure", method: http.MethodPost, id: testID.String(), serviceErr: fmt.Errorf("failed to parse transcript: invalid JSON format"), expectedStatus: http.StatusInternalServerError, expectedCode: "INTERNAL_ERROR"},
+		{name: "invalid UUID", method: http.MethodPost, id: "invalid-uuid", expectedStatus: http.StatusUnprocessableEntity, expectedCode: "VALIDATION_ERROR"},
+		{name: "wrong method", method: http.MethodGet, id: testID.String(), expectedStatus: http.StatusMethodNotAllowed, expectedCode: "METHOD_NOT_ALLOWED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockTranscriptService{}
+			if tt.serviceResult != nil || tt.serviceErr != nil {
+				mockService.On("ReparseTranscript", testID, mock.AnythingOfType("string")).Return(tt.serviceResult, tt.serviceErr)
+			}
+			handler := NewTranscriptHandler(mockService)
+
+			req := httptest.NewRequest(tt.method, "/api/transcripts/"+tt.id+"/reparse", nil)
+			recorder := httptest.NewRecorder()
+			handler.ReparseTranscript(recorder, req)
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			if tt.expectedCode != "" {
+				assert.Equal(t, tt.expectedCode, response["error"].(map[string]interface{})["code"])
+			} else {
+				assert.Equal(t, float64(42), response["word_count"])
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTranscriptHandler_GetTranscriptBundle(t *testing.T) {
+	transcriptID := uuid.New()
+	transcript := &models.Transcript{ID: transcriptID, Filename: "episode 12.txt"}
+
+	t.Run("streams zip with download filename", func(t *testing.T) {
+		mockService := &MockTranscriptService{}
+		handler := NewTranscriptHandler(mockService)
+		mockService.On("GetTranscript", transcriptID).Return(transcript, nil)
+		mockService.On("WriteTranscriptBundle", mock.Anything, transcript, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) {
+				args.Get(0).(io.Writer).Write([]byte("PK"))
+			}).Return(nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/transcripts/"+transcriptID.String()+"/bundle", nil)
+		recorder := httptest.NewRecorder()
+		handler.GetTranscriptBundle(recorder, req)
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/zip", recorder.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="episode 12-bundle.zip"`, recorder.Header().Get("Content-Disposition"))
+		assert.Equal(t, "PK", recorder.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("transcript not found", func(t *testing.T) {
+		mockService := &MockTranscriptService{}
+		handler := NewTranscriptHandler(mockService)
+		mockService.On("GetTranscript", transcriptID).Return(nil, fmt.Errorf("transcript not found"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/transcripts/"+transcriptID.String()+"/bundle", nil)
+		recorder := httptest.NewRecorder()
+		handler.GetTranscriptBundle(recorder, req)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "TRANSCRIPT_NOT_FOUND")
+		mockService.AssertNotCalled(t, "WriteTranscriptBundle", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		handler := NewTranscriptHandler(&MockTranscriptService{})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/transcripts/not-a-uuid/bundle", nil)
+		recorder := httptest.NewRecorder()
+		handler.GetTranscriptBundle(recorder, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	})
+}
+
+func TestTranscriptHandler_GetTranscriptFacets(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	mockService.On("GetTranscriptFacets", "test-correlation-id").Return(&services.TranscriptFacets{
+		Languages: []services.FacetCount{{Value: "en", Count: 3}},
+		Tags:      []services.FacetCount{{Value: "tech", Count: 2}, {Value: "news", Count: 1}},
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transcripts/facets", nil)
+	req.Header.Set("X-Correlation-ID", "test-correlation-id")
+	recorder := httptest.NewRecorder()
+	handler.GetTranscriptFacets(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response map[string][]map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "en", response["languages"][0]["value"])
+	assert.Equal(t, float64(3), response["languages"][0]["count"])
+	assert.Len(t, response["tags"], 2)
+
+	mockService.On("GetTranscriptFacets", "test-correlation-id").Return(nil, errors.New("db down")).Once()
+	recorder = httptest.NewRecorder()
+	handler.GetTranscriptFacets(recorder, req)
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.GetTranscriptFacets(recorder, httptest.NewRequest(http.MethodPost, "/api/transcripts/facets", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func (m *MockTranscriptService) TranscriptExists(contentHash string, correlationID string) (*services.TranscriptExistsResponse, error) {
+	args := m.Called(contentHash, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TranscriptExistsResponse), args.Error(1)
+}
+
+func TestTranscriptHandler_TranscriptExists(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	hash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	transcriptID := uuid.New()
+	mockService.On("TranscriptExists", hash, mock.AnythingOfType("string")).Return(&services.TranscriptExistsResponse{
+		Exists:       true,
+		TranscriptID: &transcriptID,
+	}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transcripts/exists?hash="+hash, nil)
+	recorder := httptest.NewRecorder()
+	handler.TranscriptExists(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, true, response["exists"])
+	assert.Equal(t, transcriptID.String(), response["transcript_id"])
+	mockService.AssertExpectations(t)
+}
+
+func TestTranscriptHandler_TranscriptExists_InvalidHash(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	mockService.On("TranscriptExists", "nope", mock.AnythingOfType("string")).Return(
+		nil, utils.NewValidationError("hash", "hash must be a hex-encoded SHA-256 digest (64 characters)")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/transcripts/exists?hash=nope", nil)
+	recorder := httptest.NewRecorder()
+	handler.TranscriptExists(recorder, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func (m *MockTranscriptService) ValidateTranscript(req *services.UploadTranscriptRequest, correlationID string) (*services.TranscriptValidationResponse, error) {
+	args := m.Called(req, correlationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.TranscriptValidationResponse), args.Error(1)
+}
+
+func TestTranscriptHandler_ValidateTranscript(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	mockService.On("ValidateTranscript", mock.MatchedBy(func(req *services.UploadTranscriptRequest) bool {
+		return req.File.Filename == "episode.txt"
+	}), mock.AnythingOfType("string")).Return(&services.TranscriptValidationResponse{
+		Valid:    false,
+		Filename: "episode.txt",
+		Errors:   []utils.FieldError{{Field: "file.size", Message: "file too large"}},
+	}, nil)
+
+	body, contentType := createTestFileUpload(t, "file", "episode.txt", "Hello world")
+	req := httptest.NewRequest(http.MethodPost, "/api/transcripts/validate", body)
+	req.Header.Set("Content-Type", contentType)
+	recorder := httptest.NewRecorder()
+	handler.ValidateTranscript(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, false, response["valid"])
+	assert.Len(t, response["errors"], 1)
+	mockService.AssertExpectations(t)
+}
+
+func TestTranscriptHandler_ValidateTranscript_Saturated(t *testing.T) {
+	mockService := &MockTranscriptService{}
+	handler := NewTranscriptHandler(mockService)
+
+	mockService.On("ValidateTranscript", mock.Anything, mock.AnythingOfType("string")).Return(nil, services.ErrUploadsSaturated)
+
+	body, contentType := createTestFileUpload(t, "file", "episode.txt", "Hello world")
+	req := httptest.NewRequest(http.MethodPost, "/api/transcripts/validate", body)
+	req.Header.Set("Content-Type", contentType)
+	recorder := httptest.NewRecorder()
+	handler.ValidateTranscript(recorder, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, uploadRetryAfter, recorder.Header().Get("Retry-After"))
+}
@@ -9,14 +9,31 @@ import (
 
 var Log *logrus.Logger
 
+const timestampFormat = "2006-01-02T15:04:05.000Z07:00"
+
 func init() {
 	Log = logrus.New()
 	Log.SetFormatter(&logrus.JSONFormatter{
-		TimestampFormat: "2006-01-02T15:04:05.000Z07:00",
+		TimestampFormat: timestampFormat,
 	})
 	Log.SetOutput(os.Stdout)
 }
 
+// SetFormat sets the log output format ("json" or "text")
+func SetFormat(format string) {
+	switch format {
+	case "text":
+		Log.SetFormatter(&logrus.TextFormatter{
+			TimestampFormat: timestampFormat,
+			FullTimestamp:   true,
+		})
+	default:
+		Log.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: timestampFormat,
+		})
+	}
+}
+
 // SetLevel sets the logging level
 func SetLevel(level string) {
 	switch level {
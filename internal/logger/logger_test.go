@@ -67,6 +67,53 @@ func TestSetLevel_Lowercase(t *testing.T) {
 	assert.Equal(t, logrus.InfoLevel, Log.Level)
 }
 
+func TestSetFormat_Text(t *testing.T) {
+	originalFormatter := Log.Formatter
+	defer Log.SetFormatter(originalFormatter)
+
+	SetFormat("text")
+	_, isTextFormatter := Log.Formatter.(*logrus.TextFormatter)
+	assert.True(t, isTextFormatter)
+}
+
+func TestSetFormat_JSON(t *testing.T) {
+	originalFormatter := Log.Formatter
+	defer Log.SetFormatter(originalFormatter)
+
+	SetFormat("text")
+	SetFormat("json")
+	_, isJSONFormatter := Log.Formatter.(*logrus.JSONFormatter)
+	assert.True(t, isJSONFormatter)
+}
+
+func TestSetFormat_Invalid_DefaultsToJSON(t *testing.T) {
+	originalFormatter := Log.Formatter
+	defer Log.SetFormatter(originalFormatter)
+
+	SetFormat("xml")
+	_, isJSONFormatter := Log.Formatter.(*logrus.JSONFormatter)
+	assert.True(t, isJSONFormatter)
+}
+
+func TestSetFormat_TextOutput(t *testing.T) {
+	var buffer bytes.Buffer
+	originalOutput := Log.Out
+	originalFormatter := Log.Formatter
+	Log.SetOutput(&buffer)
+	defer Log.SetOutput(originalOutput)
+	defer Log.SetFormatter(originalFormatter)
+
+	SetFormat("text")
+	Log.WithField("correlation_id", "abc-123").Info("test message")
+
+	logOutput := buffer.String()
+	assert.Contains(t, logOutput, `msg="test message"`)
+	assert.Contains(t, logOutput, "correlation_id=abc-123")
+
+	var logEntry map[string]interface{}
+	assert.Error(t, json.Unmarshal([]byte(logOutput), &logEntry))
+}
+
 func TestWithCorrelationID(t *testing.T) {
 	correlationID := "test-correlation-123"
 	
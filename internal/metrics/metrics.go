@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"expvar"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// registry holds all application metrics, published through expvar
+var registry = expvar.NewMap("podcast_analyzer")
+
+// collectors refresh metrics that are computed on demand rather than updated inline
+var (
+	collectorsMu  sync.Mutex
+	collectors    []func()
+	lastCollected time.Time
+)
+
+// collectInterval is the least time between collector runs. Scrapes in between serve the
+// values from the last run, so frequent or hostile scrapes cannot multiply the queries
+// collectors make.
+const collectInterval = 15 * time.Second
+
+// SetGauge sets a gauge metric to the given value
+func SetGauge(name string, value float64) {
+	if gauge, ok := registry.Get(name).(*expvar.Float); ok {
+		gauge.Set(value)
+		return
+	}
+	gauge := new(expvar.Float)
+	gauge.Set(value)
+	registry.Set(name, gauge)
+}
+
+// AddCounter increments a counter metric by delta
+func AddCounter(name string, delta int64) {
+	registry.Add(name, delta)
+}
+
+// Value returns the current value of a metric, or nil if it has not been recorded
+func Value(name string) expvar.Var {
+	return registry.Get(name)
+}
+
+// RegisterCollector registers a function that refreshes metrics when they are served, at
+// most once per collectInterval
+func RegisterCollector(collect func()) {
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+	collectors = append(collectors, collect)
+	lastCollected = time.Time{}
+}
+
+// Handler returns an HTTP handler exposing all metrics as JSON
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		collectorsMu.Lock()
+		if time.Since(lastCollected) >= collectInterval {
+			for _, collect := range collectors {
+				collect()
+			}
+			lastCollected = time.Now()
+		}
+		collectorsMu.Unlock()
+		expvar.Handler().ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGauge(t *testing.T) {
+	SetGauge("test_gauge", 1.5)
+	assert.Equal(t, 1.5, Value("test_gauge").(*expvar.Float).Value())
+
+	// Setting again overwrites the value
+	SetGauge("test_gauge", 3)
+	assert.Equal(t, float64(3), Value("test_gauge").(*expvar.Float).Value())
+}
+
+func TestAddCounter(t *testing.T) {
+	AddCounter("test_counter", 2)
+	AddCounter("test_counter", 3)
+	assert.Equal(t, int64(5), Value("test_counter").(*expvar.Int).Value())
+}
+
+func TestValue_Missing(t *testing.T) {
+	assert.Nil(t, Value("does_not_exist"))
+}
+
+func TestHandler(t *testing.T) {
+	SetGauge("handler_gauge", 7)
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	appMetrics := response["podcast_analyzer"].(map[string]interface{})
+	assert.Equal(t, float64(7), appMetrics["handler_gauge"])
+}
+
+func TestRegisterCollector(t *testing.T) {
+	calls := 0
+	RegisterCollector(func() {
+		calls++
+		SetGauge("collected_gauge", float64(calls))
+	})
+
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, 1, calls)
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	appMetrics := response["podcast_analyzer"].(map[string]interface{})
+	assert.Equal(t, float64(1), appMetrics["collected_gauge"])
+
+	// A scrape within the collect interval serves the cached value
+	recorder = httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, 1, calls)
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	appMetrics = response["podcast_analyzer"].(map[string]interface{})
+	assert.Equal(t, float64(1), appMetrics["collected_gauge"])
+}
+
+func TestReadRuntimeStats(t *testing.T) {
+	runtime.GC()
+
+	stats := ReadRuntimeStats()
+
+	assert.Positive(t, stats.Goroutines)
+	assert.Positive(t, stats.HeapAllocBytes)
+	assert.GreaterOrEqual(t, stats.HeapSysBytes, stats.HeapAllocBytes)
+	assert.Positive(t, stats.NumGC)
+}
+
+func TestStartRuntimeReporter(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	StartRuntimeReporter(ctx, time.Hour)
+
+	// The first sample is taken immediately
+	require.NotNil(t, Value("runtime_goroutines"))
+	assert.Positive(t, Value("runtime_goroutines").(*expvar.Float).Value())
+	assert.Positive(t, Value("runtime_heap_alloc_bytes").(*expvar.Float).Value())
+}
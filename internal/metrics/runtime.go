@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"context"
+	"runtime"
+	"time"
+
+	"podcast-analyzer/internal/logger"
+)
+
+// RuntimeStats is a snapshot of Go runtime statistics
+type RuntimeStats struct {
+	Goroutines     int
+	HeapAllocBytes uint64
+	HeapSysBytes   uint64
+	NumGC          uint32
+	GCPauseTotal   time.Duration
+	LastGCPause    time.Duration
+}
+
+// ReadRuntimeStats samples the goroutine count and memory statistics
+func ReadRuntimeStats() RuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := RuntimeStats{
+		Goroutines:     runtime.NumGoroutine(),
+		HeapAllocBytes: mem.HeapAlloc,
+		HeapSysBytes:   mem.HeapSys,
+		NumGC:          mem.NumGC,
+		GCPauseTotal:   time.Duration(mem.PauseTotalNs),
+	}
+	if mem.NumGC > 0 {
+		// PauseNs is a circular buffer with the most recent pause at (NumGC+255)%256
+		stats.LastGCPause = time.Duration(mem.PauseNs[(mem.NumGC+255)%256])
+	}
+	return stats
+}
+
+// recordRuntimeStats publishes a snapshot as gauges
+func recordRuntimeStats(stats RuntimeStats) {
+	SetGauge("runtime_goroutines", float64(stats.Goroutines))
+	SetGauge("runtime_heap_alloc_bytes", float64(stats.HeapAllocBytes))
+	SetGauge("runtime_heap_sys_bytes", float64(stats.HeapSysBytes))
+	SetGauge("runtime_gc_count", float64(stats.NumGC))
+	SetGauge("runtime_gc_pause_total_ms", float64(stats.GCPauseTotal.Microseconds())/1000)
+	SetGauge("runtime_gc_last_pause_ms", float64(stats.LastGCPause.Microseconds())/1000)
+}
+
+// StartRuntimeReporter samples runtime statistics every interval until ctx is done, logging
+// each sample and publishing it as gauges. A sample is also taken immediately, so the gauges
+// exist from startup.
+func StartRuntimeReporter(ctx context.Context, interval time.Duration) {
+	report := func() {
+		stats := ReadRuntimeStats()
+		recordRuntimeStats(stats)
+		logger.Log.WithFields(map[string]interface{}{
+			"goroutines":         stats.Goroutines,
+			"heap_alloc_bytes":   stats.HeapAllocBytes,
+			"heap_sys_bytes":     stats.HeapSysBytes,
+			"gc_count":           stats.NumGC,
+			"gc_pause_total_ms":  stats.GCPauseTotal.Milliseconds(),
+			"gc_last_pause_us":   stats.LastGCPause.Microseconds(),
+			"report_interval_ms": interval.Milliseconds(),
+		}).Info("Runtime stats")
+	}
+
+	report()
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report()
+			}
+		}
+	}()
+}
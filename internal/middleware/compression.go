@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultCompressionMinBytes is the smallest response compressed when no threshold is given
+const DefaultCompressionMinBytes = 1024
+
+// incompressibleTypes are content types already compressed, such as export bundles, which
+// gain nothing from gzip
+var incompressibleTypes = []string{
+	"application/zip",
+	"application/gzip",
+	"application/x-gzip",
+	"application/zstd",
+	"image/",
+	"audio/",
+	"video/",
+}
+
+// gzipWriters reuses gzip writers across responses, since each holds sizable buffers
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// CompressionMiddleware gzips responses for clients that accept it. Responses shorter than
+// minBytes, or whose content type is already compressed, are sent unchanged.
+func CompressionMiddleware(minBytes int) func(http.Handler) http.Handler {
+	if minBytes <= 0 {
+		minBytes = DefaultCompressionMinBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if r.Method == http.MethodHead || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Not deferred: after a panic nothing buffered should be sent, so the recovery
+			// middleware can still write its error response
+			cw := &compressingWriter{ResponseWriter: w, minBytes: minBytes, status: http.StatusOK}
+			next.ServeHTTP(cw, r)
+			cw.Close()
+		})
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip with a non-zero q-value
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(params[0]))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// compressingWriter holds back the start of a response until it knows whether it is worth
+// compressing: once minBytes are buffered it switches to gzip, and a response that ends
+// sooner, or is not compressible, is passed through unchanged.
+type compressingWriter struct {
+	http.ResponseWriter
+	minBytes    int
+	status      int
+	buffer      []byte
+	decided     bool
+	gzipWriter  *gzip.Writer
+	wroteHeader bool
+}
+
+// WriteHeader records the status; it is sent once the encoding is decided
+func (cw *compressingWriter) WriteHeader(code int) {
+	if cw.wroteHeader {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = code
+}
+
+// Write buffers the body until the encoding is decided, then writes through
+func (cw *compressingWriter) Write(b []byte) (int, error) {
+	if !cw.decided {
+		if !cw.compressible() {
+			if err := cw.passThrough(); err != nil {
+				return 0, err
+			}
+		} else {
+			cw.buffer = append(cw.buffer, b...)
+			if len(cw.buffer) < cw.minBytes {
+				return len(b), nil
+			}
+			if err := cw.startGzip(); err != nil {
+				return 0, err
+			}
+			return len(b), nil
+		}
+	}
+
+	if cw.gzipWriter != nil {
+		return cw.gzipWriter.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// Flush sends what has been written so far, deciding the encoding first if needed
+func (cw *compressingWriter) Flush() {
+	if !cw.decided {
+		if err := cw.passThrough(); err != nil {
+			return
+		}
+	}
+	if cw.gzipWriter != nil {
+		cw.gzipWriter.Flush()
+	}
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close finishes the response, sending a short buffered body uncompressed
+func (cw *compressingWriter) Close() error {
+	if !cw.decided {
+		return cw.passThrough()
+	}
+	if cw.gzipWriter == nil {
+		return nil
+	}
+	err := cw.gzipWriter.Close()
+	cw.gzipWriter.Reset(nil)
+	gzipWriters.Put(cw.gzipWriter)
+	cw.gzipWriter = nil
+	return err
+}
+
+// Unwrap returns the underlying writer for http.ResponseController
+func (cw *compressingWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// compressible reports whether the response may be gzipped, judging by the headers the
+// handler has set
+func (cw *compressingWriter) compressible() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	if cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+	contentType := strings.ToLower(header.Get("Content-Type"))
+	for _, prefix := range incompressibleTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// passThrough sends the status and any buffered body unchanged
+func (cw *compressingWriter) passThrough() error {
+	cw.decided = true
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if len(cw.buffer) == 0 {
+		return nil
+	}
+	_, err := cw.ResponseWriter.Write(cw.buffer)
+	cw.buffer = nil
+	return err
+}
+
+// startGzip sends the status with gzip headers and compresses the buffered body
+func (cw *compressingWriter) startGzip() error {
+	cw.decided = true
+	header := cw.Header()
+	// The body is no longer what was measured or sniffed, so settle both before encoding
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", http.DetectContentType(cw.buffer))
+	}
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", "gzip")
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	cw.gzipWriter = gzipWriters.Get().(*gzip.Writer)
+	cw.gzipWriter.Reset(cw.ResponseWriter)
+	_, err := cw.gzipWriter.Write(cw.buffer)
+	cw.buffer = nil
+	return err
+}
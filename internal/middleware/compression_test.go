@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionMiddleware(t *testing.T) {
+	large := strings.Repeat(`{"claim": "The same evidence repeated"}`, 100)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		expectGzip     bool
+	}{
+		{name: "large JSON", acceptEncoding: "gzip, deflate", contentType: "application/json", body: large, expectGzip: true},
+		{name: "wildcard encoding", acceptEncoding: "*", contentType: "application/json", body: large, expectGzip: true},
+		{name: "gzip refused", acceptEncoding: "gzip;q=0, br", contentType: "application/json", body: large},
+		{name: "no Accept-Encoding", contentType: "application/json", body: large},
+		{name: "below threshold", acceptEncoding: "gzip", contentType: "application/json", body: `{"ok": true}`},
+		{name: "zip download", acceptEncoding: "gzip", contentType: "application/zip", body: large},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CompressionMiddleware(256)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(http.StatusCreated)
+				// Written in pieces so the threshold is crossed mid-response
+				half := len(tt.body) / 2
+				w.Write([]byte(tt.body[:half]))
+				w.Write([]byte(tt.body[half:]))
+			}))
+
+			req := httptest.NewRequest("GET", "/api/results", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, http.StatusCreated, recorder.Code)
+			assert.Equal(t, tt.contentType, recorder.Header().Get("Content-Type"))
+			assert.Contains(t, recorder.Header().Values("Vary"), "Accept-Encoding")
+
+			body := recorder.Body.String()
+			if tt.expectGzip {
+				assert.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+				assert.Less(t, recorder.Body.Len(), len(tt.body))
+				reader, err := gzip.NewReader(recorder.Body)
+				require.NoError(t, err)
+				decoded, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				body = string(decoded)
+			} else {
+				assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+			}
+			assert.Equal(t, tt.body, body)
+		})
+	}
+}
+
+func TestCompressionMiddleware_SniffsContentTypeBeforeCompressing(t *testing.T) {
+	body := "<html><body>" + strings.Repeat("<p>Transcript line</p>", 100) + "</body></html>"
+	handler := CompressionMiddleware(256)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, "gzip", recorder.Header().Get("Content-Encoding"))
+	assert.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+}
+
+func TestCompressionMiddleware_EmptyResponse(t *testing.T) {
+	handler := CompressionMiddleware(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest("DELETE", "/api/transcripts/1", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+	assert.Zero(t, recorder.Body.Len())
+}
+
+func TestCompressionMiddleware_PanicLeavesResponseToRecovery(t *testing.T) {
+	handler := RecoveryMiddleware()(CompressionMiddleware(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"partial": `))
+		panic("handler failed")
+	})))
+
+	req := httptest.NewRequest("GET", "/api/results", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "INTERNAL_ERROR")
+}
@@ -1,10 +1,12 @@
 package middleware
 
 import (
-	"context"
+	"crypto/subtle"
 	"net/http"
+	"strings"
 	"time"
 	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/requestctx"
 	"podcast-analyzer/internal/utils"
 
 	"github.com/google/uuid"
@@ -17,10 +19,7 @@ func LoggingMiddleware() func(http.Handler) http.Handler {
 			start := time.Now()
 			
 			// Get or generate correlation ID
-			correlationID := r.Header.Get("X-Correlation-ID")
-			if correlationID == "" {
-				correlationID = uuid.New().String()
-			}
+			correlationID := utils.GetCorrelationID(r)
 
 			// Wrap ResponseWriter to capture response data
 			lrw := &loggingResponseWriter{
@@ -47,19 +46,23 @@ func LoggingMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
-// RequestIDMiddleware adds correlation ID to request context and response header
-func RequestIDMiddleware() func(http.Handler) http.Handler {
+// RequestIDMiddleware adds correlation ID to request context and response header. The ID is
+// taken from the first of headers present on the request, checked in order, and generated
+// when none is; the default headers are used when none are given.
+func RequestIDMiddleware(headers ...string) func(http.Handler) http.Handler {
+	if len(headers) == 0 {
+		headers = utils.DefaultCorrelationIDHeaders
+	}
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			correlationID := r.Header.Get("X-Correlation-ID")
+			correlationID := utils.CorrelationIDFromHeaders(r, headers)
 			if correlationID == "" {
 				correlationID = uuid.New().String()
-				w.Header().Set("X-Correlation-ID", correlationID)
 			}
+			w.Header().Set("X-Correlation-ID", correlationID)
 			
 			// Add correlation ID to request context
-			ctx := context.WithValue(r.Context(), "correlation_id", correlationID)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			next.ServeHTTP(w, r.WithContext(requestctx.WithCorrelationID(r.Context(), correlationID)))
 		})
 	}
 }
@@ -75,7 +78,7 @@ func RecoveryMiddleware() func(http.Handler) http.Handler {
 						"method":         r.Method,
 						"path":           r.URL.Path,
 						"client_ip":      utils.GetClientIP(r),
-						"correlation_id": r.Header.Get("X-Correlation-ID"),
+						"correlation_id": utils.GetCorrelationID(r),
 					}).Error("HTTP handler panicked")
 
 					w.Header().Set("Content-Type", "application/json")
@@ -88,6 +91,40 @@ func RecoveryMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
+// ErrorNegotiationMiddleware lets error responses follow the request's Accept header
+func ErrorNegotiationMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(utils.WithErrorNegotiation(w, r), r)
+		})
+	}
+}
+
+// AdminAuthMiddleware requires a matching "Authorization: Bearer <key>" header.
+// All requests are rejected when no admin key is configured.
+func AdminAuthMiddleware(apiKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKey == "" {
+				utils.WriteError(w, http.StatusForbidden, "ADMIN_API_DISABLED", "Admin API is disabled")
+				return
+			}
+
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+				logger.Log.WithFields(map[string]interface{}{
+					"path":      r.URL.Path,
+					"client_ip": utils.GetClientIP(r),
+				}).Warn("Rejected admin request with invalid credentials")
+				utils.WriteError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Invalid or missing admin API key")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(requestctx.WithUser(r.Context(), "admin")))
+		})
+	}
+}
+
 // loggingResponseWriter wraps http.ResponseWriter to capture response data
 type loggingResponseWriter struct {
 	http.ResponseWriter
@@ -15,11 +15,24 @@ type Transcript struct {
 	FilePath         string         `gorm:"size:500;not null" json:"file_path"`
 	ContentHash      string         `gorm:"size:64;not null;unique" json:"content_hash"`
 	WordCount        int            `gorm:"not null" json:"word_count"`
+	CharCount        int            `gorm:"not null;default:0" json:"char_count"`
 	UploadedAt       time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"uploaded_at"`
 	TranscriptMetadata datatypes.JSON `gorm:"type:jsonb" json:"transcript_metadata,omitempty"`
+	Ephemeral        bool           `gorm:"not null;default:false;index" json:"ephemeral"` // Created from pasted text for a single analysis; hidden from listings
+	Language         string         `gorm:"size:35;not null;default:'';index" json:"language,omitempty"` // From the "language" field of a JSON upload, lowercased
+	Preview          string         `gorm:"-" json:"preview,omitempty"` // Excerpt of the content, only set when a listing asks for it
+	AnalysisCount    int            `gorm:"-" json:"analysis_count"`             // Completed analyses, computed on read
+	LastAnalyzedAt   *time.Time     `gorm:"-" json:"last_analyzed_at,omitempty"` // Completion time of the latest analysis, computed on read
 	
 	// Relationships
 	Analyses []AnalysisResult `gorm:"foreignKey:TranscriptID" json:"analyses,omitempty"`
+	Tags     []TranscriptTag  `gorm:"foreignKey:TranscriptID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TranscriptTag labels a transcript for filtering; a transcript has each tag at most once
+type TranscriptTag struct {
+	TranscriptID uuid.UUID `gorm:"type:uuid;primary_key" json:"transcript_id"`
+	Tag          string    `gorm:"size:100;primary_key;index" json:"tag"`
 }
 
 // AnalysisResult represents the results of AI analysis
@@ -27,9 +40,18 @@ type AnalysisResult struct {
 	ID           uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	TranscriptID uuid.UUID      `gorm:"type:uuid;not null;index" json:"transcript_id"`
 	JobID        uuid.UUID      `gorm:"type:uuid;not null;unique;index" json:"job_id"`
-	Status       string         `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, processing, completed, failed
+	Status       string         `gorm:"size:20;not null;default:'pending';index" json:"status"` // pending, processing, completed, failed, cancelled
 	Summary      *string        `gorm:"type:text" json:"summary,omitempty"`
+	Headline     *string        `gorm:"type:text" json:"headline,omitempty"` // One-line version of the summary, when requested
 	Takeaways    datatypes.JSON `gorm:"type:jsonb" json:"takeaways,omitempty"` // Array of key takeaways
+	TakeawaysSummary *string    `gorm:"type:text" json:"takeaways_summary,omitempty"` // One-paragraph synthesis of the takeaways, when requested
+	Instructions *string        `gorm:"type:text" json:"instructions,omitempty"` // Custom instructions the job was run with
+	SummaryStyle string         `gorm:"size:20;not null;default:'prose'" json:"summary_style"` // Summary tone the job was run with
+	AnalysisMetadata datatypes.JSON `gorm:"type:jsonb" json:"analysis_metadata,omitempty"` // Preprocessing details such as ad filtering
+	Model        *string        `gorm:"size:255" json:"model,omitempty"` // Exact Claude model version(s) the API reported, comma-separated if several
+	Truncated    bool           `gorm:"not null;default:false" json:"truncated"` // An agent's output was cut off at the token cap
+	FactCheckStatus string      `gorm:"size:20" json:"fact_check_status,omitempty"` // How the fact-check stage ended; empty for analyses that predate it
+	JobOptions   datatypes.JSON `gorm:"type:jsonb" json:"-"` // Options the job was started with, so a retry runs it the same way
 	CreatedAt    time.Time      `gorm:"default:CURRENT_TIMESTAMP;index" json:"created_at"`
 	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
 	ErrorMessage *string        `gorm:"type:text" json:"error_message,omitempty"`
@@ -37,23 +59,81 @@ type AnalysisResult struct {
 	// Relationships
 	Transcript Transcript  `gorm:"foreignKey:TranscriptID" json:"transcript,omitempty"`
 	FactChecks []FactCheck `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"fact_checks,omitempty"`
+	Notes      []AnalysisNote `gorm:"foreignKey:AnalysisID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
+// How the fact-check stage of an analysis ended, so an analysis without fact checks can
+// be told apart from one whose fact checking never ran
+const (
+	FactCheckStatusCompleted = "completed" // Claims were found and verified
+	FactCheckStatusNoClaims  = "no_claims" // The fact checker ran and found nothing to verify
+	FactCheckStatusSkipped   = "skipped"   // Fact checking is disabled
+	FactCheckStatusDegraded  = "degraded"  // Most claims failed verification for the same reason
+	FactCheckStatusFailed    = "failed"    // The fact checker failed, so the analysis has no fact checks
+)
+
 // FactCheck represents individual fact-check results
 type FactCheck struct {
 	ID         uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	AnalysisID uuid.UUID      `gorm:"type:uuid;not null;index" json:"analysis_id"`
 	Claim      string         `gorm:"type:text;not null" json:"claim"`
-	Verdict    string         `gorm:"size:20;not null" json:"verdict"` // true, false, partially_true, unverifiable
+	Verdict    Verdict        `gorm:"size:20;not null" json:"verdict"`
 	Confidence float64        `gorm:"not null;check:confidence >= 0 AND confidence <= 1" json:"confidence"`
 	Evidence   *string        `gorm:"type:text" json:"evidence,omitempty"`
 	Sources    datatypes.JSON `gorm:"type:jsonb" json:"sources,omitempty"`
 	CheckedAt  time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"checked_at"`
+	Cached     bool           `gorm:"not null;default:false" json:"cached"` // Reused from the fact-check cache
+	SearchQuery string        `gorm:"type:text" json:"search_query,omitempty"` // Query sent to Serper; empty when no Serper search was made
 
 	// Relationships
 	Analysis AnalysisResult `gorm:"foreignKey:AnalysisID" json:"analysis,omitempty"`
 }
 
+// FactCheckCacheEntry caches a verified claim for reuse across transcripts
+type FactCheckCacheEntry struct {
+	ClaimHash  string         `gorm:"size:64;primary_key" json:"claim_hash"` // SHA-256 of the normalized claim
+	Claim      string         `gorm:"type:text;not null" json:"claim"`
+	Verdict    Verdict        `gorm:"size:20;not null" json:"verdict"`
+	Confidence float64        `gorm:"not null" json:"confidence"`
+	Evidence   string         `gorm:"type:text" json:"evidence"`
+	Sources    datatypes.JSON `gorm:"type:jsonb" json:"sources,omitempty"`
+	CheckedAt  time.Time      `gorm:"not null;index" json:"checked_at"`
+}
+
+// TableName overrides the pluralized default
+func (FactCheckCacheEntry) TableName() string {
+	return "fact_check_cache"
+}
+
+// JobEvent records one state change or stage of an analysis job
+type JobEvent struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	JobID     uuid.UUID `gorm:"type:uuid;not null;index" json:"job_id"`
+	Status    string    `gorm:"size:20;not null" json:"status"`
+	Stage     string    `gorm:"size:50" json:"stage,omitempty"` // Agent or step the job entered, empty for plain status changes
+	Message   *string   `gorm:"type:text" json:"message,omitempty"`
+	CreatedAt time.Time `gorm:"not null;index" json:"created_at"`
+}
+
+// AgentRun stores the output of one completed agent stage of a job, so a retried job can
+// reuse it instead of running the stage again
+type AgentRun struct {
+	ID        uuid.UUID      `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	JobID     uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_agent_runs_job_agent" json:"job_id"`
+	Agent     string         `gorm:"size:50;not null;uniqueIndex:idx_agent_runs_job_agent" json:"agent"`
+	Output    datatypes.JSON `gorm:"type:jsonb;not null" json:"output"`
+	CreatedAt time.Time      `gorm:"not null;index" json:"created_at"`
+}
+
+// AnalysisNote is a reviewer's note on an analysis, kept apart from the generated results
+type AnalysisNote struct {
+	ID         uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AnalysisID uuid.UUID `gorm:"type:uuid;not null;index" json:"analysis_id"`
+	Author     string    `gorm:"size:100;not null" json:"author"`
+	Body       string    `gorm:"type:text;not null" json:"body"`
+	CreatedAt  time.Time `gorm:"not null;index" json:"created_at"`
+}
+
 // BeforeCreate will set a UUID rather than numeric ID
 func (t *Transcript) BeforeCreate(tx *gorm.DB) error {
 	if t.ID == uuid.Nil {
@@ -79,7 +159,28 @@ func (f *FactCheck) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+func (e *JobEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+func (r *AgentRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+func (n *AnalysisNote) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
 // AutoMigrate creates or updates database tables
 func AutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(&Transcript{}, &AnalysisResult{}, &FactCheck{})
+	return db.AutoMigrate(&Transcript{}, &AnalysisResult{}, &FactCheck{}, &FactCheckCacheEntry{}, &JobEvent{}, &AgentRun{}, &TranscriptTag{}, &AnalysisNote{})
 }
\ No newline at end of file
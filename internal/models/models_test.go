@@ -407,17 +407,16 @@ func TestModel_Relationships_Structure(t *testing.T) {
 }
 
 func TestFactCheck_VerdictValidation(t *testing.T) {
-	verdicts := []string{"true", "false", "partially_true", "unverifiable"}
-	
-	for _, verdict := range verdicts {
-		t.Run("verdict_"+verdict, func(t *testing.T) {
+	for _, verdict := range Verdicts {
+		t.Run("verdict_"+string(verdict), func(t *testing.T) {
 			factCheck := FactCheck{
-				Claim:      "Test claim for " + verdict,
+				Claim:      "Test claim for " + string(verdict),
 				Verdict:    verdict,
 				Confidence: 0.75,
 			}
 			
 			// Verify the verdict field accepts the value
+			assert.True(t, factCheck.Verdict.Valid())
 			assert.Equal(t, verdict, factCheck.Verdict)
 		})
 	}
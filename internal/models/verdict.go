@@ -0,0 +1,47 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Verdict is the outcome of fact-checking a claim
+type Verdict string
+
+const (
+	VerdictTrue          Verdict = "true"
+	VerdictFalse         Verdict = "false"
+	VerdictPartiallyTrue Verdict = "partially_true"
+	VerdictUnverifiable  Verdict = "unverifiable"
+)
+
+// Verdicts lists every valid verdict in the order they are offered to the fact checker
+var Verdicts = []Verdict{VerdictTrue, VerdictFalse, VerdictPartiallyTrue, VerdictUnverifiable}
+
+// ParseVerdict normalizes a verdict string, accepting any case and surrounding whitespace
+func ParseVerdict(value string) (Verdict, error) {
+	verdict := Verdict(strings.ToLower(strings.TrimSpace(value)))
+	if !verdict.Valid() {
+		return "", fmt.Errorf("invalid verdict %q", value)
+	}
+	return verdict, nil
+}
+
+// Valid reports whether v is one of the known verdicts
+func (v Verdict) Valid() bool {
+	for _, verdict := range Verdicts {
+		if v == verdict {
+			return true
+		}
+	}
+	return false
+}
+
+// VerdictList joins the known verdicts with sep, e.g. for listing them in a prompt
+func VerdictList(sep string) string {
+	names := make([]string, len(Verdicts))
+	for i, verdict := range Verdicts {
+		names[i] = string(verdict)
+	}
+	return strings.Join(names, sep)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Verdict
+	}{
+		{input: "true", expected: VerdictTrue},
+		{input: "FALSE", expected: VerdictFalse},
+		{input: " Partially_True ", expected: VerdictPartiallyTrue},
+		{input: "unverifiable", expected: VerdictUnverifiable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			verdict, err := ParseVerdict(tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, verdict)
+		})
+	}
+}
+
+func TestParseVerdict_Invalid(t *testing.T) {
+	for _, input := range []string{"", "maybe", "partially true"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseVerdict(input)
+
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerdict_Valid(t *testing.T) {
+	assert.True(t, VerdictPartiallyTrue.Valid())
+	assert.False(t, Verdict("misleading").Valid())
+	assert.False(t, Verdict("True").Valid())
+}
+
+func TestVerdictList(t *testing.T) {
+	assert.Equal(t, "true/false/partially_true/unverifiable", VerdictList("/"))
+}
@@ -0,0 +1,80 @@
+// Package requestctx carries request-scoped identifiers through a context.Context under a
+// typed key, so handlers, services, agents and clients read them the same way.
+package requestctx
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// contextKey is unexported so no other package can set or read the value directly
+type contextKey struct{}
+
+// RequestContext identifies the request or background job a context belongs to
+type RequestContext struct {
+	CorrelationID string
+	User          string    // Authenticated caller, e.g. "admin"; empty for anonymous requests
+	JobID         uuid.UUID // Analysis job being processed; uuid.Nil outside a job
+}
+
+// With returns a context carrying rc, replacing any request context already set
+func With(ctx context.Context, rc RequestContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, rc)
+}
+
+// From returns the request context set on ctx, or the zero value when none is set
+func From(ctx context.Context) RequestContext {
+	rc, _ := ctx.Value(contextKey{}).(RequestContext)
+	return rc
+}
+
+// WithCorrelationID returns a context carrying the correlation ID, keeping the other fields
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	rc := From(ctx)
+	rc.CorrelationID = correlationID
+	return With(ctx, rc)
+}
+
+// WithUser returns a context carrying the authenticated caller, keeping the other fields
+func WithUser(ctx context.Context, user string) context.Context {
+	rc := From(ctx)
+	rc.User = user
+	return With(ctx, rc)
+}
+
+// WithJobID returns a context carrying the job ID, keeping the other fields
+func WithJobID(ctx context.Context, jobID uuid.UUID) context.Context {
+	rc := From(ctx)
+	rc.JobID = jobID
+	return With(ctx, rc)
+}
+
+// CorrelationID returns the correlation ID set on ctx, or "" when none is set
+func CorrelationID(ctx context.Context) string {
+	return From(ctx).CorrelationID
+}
+
+// User returns the authenticated caller set on ctx, or "" when none is set
+func User(ctx context.Context) string {
+	return From(ctx).User
+}
+
+// JobID returns the job ID set on ctx, reporting false when none is set
+func JobID(ctx context.Context) (uuid.UUID, bool) {
+	jobID := From(ctx).JobID
+	return jobID, jobID != uuid.Nil
+}
+
+// LogFields returns the identifiers set on ctx as log fields: correlation_id always, and
+// user and job_id when they are set
+func LogFields(ctx context.Context) map[string]interface{} {
+	fields := map[string]interface{}{"correlation_id": CorrelationID(ctx)}
+	if user := User(ctx); user != "" {
+		fields["user"] = user
+	}
+	if jobID, ok := JobID(ctx); ok {
+		fields["job_id"] = jobID.String()
+	}
+	return fields
+}
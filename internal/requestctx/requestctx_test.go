@@ -0,0 +1,60 @@
+package requestctx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestContext_Empty(t *testing.T) {
+	ctx := context.Background()
+
+	assert.Equal(t, RequestContext{}, From(ctx))
+	assert.Equal(t, "", CorrelationID(ctx))
+	assert.Equal(t, "", User(ctx))
+	_, ok := JobID(ctx)
+	assert.False(t, ok)
+}
+
+func TestRequestContext_SettersKeepOtherFields(t *testing.T) {
+	jobID := uuid.New()
+
+	ctx := WithCorrelationID(context.Background(), "test-correlation-123")
+	ctx = WithUser(ctx, "admin")
+	ctx = WithJobID(ctx, jobID)
+
+	assert.Equal(t, RequestContext{CorrelationID: "test-correlation-123", User: "admin", JobID: jobID}, From(ctx))
+	assert.Equal(t, "test-correlation-123", CorrelationID(ctx))
+	assert.Equal(t, "admin", User(ctx))
+	got, ok := JobID(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, jobID, got)
+}
+
+func TestRequestContext_IgnoresUntypedKeys(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "correlation_id", "untyped")
+
+	assert.Equal(t, "", CorrelationID(ctx))
+}
+
+func TestRequestContext_DerivedContextDoesNotChangeParent(t *testing.T) {
+	parent := WithCorrelationID(context.Background(), "parent")
+	child := WithCorrelationID(parent, "child")
+
+	assert.Equal(t, "parent", CorrelationID(parent))
+	assert.Equal(t, "child", CorrelationID(child))
+}
+
+func TestLogFields(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"correlation_id": ""}, LogFields(context.Background()))
+
+	jobID := uuid.New()
+	ctx := WithJobID(WithUser(WithCorrelationID(context.Background(), "test-correlation-123"), "admin"), jobID)
+	assert.Equal(t, map[string]interface{}{
+		"correlation_id": "test-correlation-123",
+		"user":           "admin",
+		"job_id":         jobID.String(),
+	}, LogFields(ctx))
+}
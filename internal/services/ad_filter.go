@@ -0,0 +1,145 @@
+package services
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// adStrongPatterns mark a segment as an ad on their own
+var adStrongPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bbrought to you by\b`),
+	regexp.MustCompile(`(?i)\bsponsored by\b`),
+	regexp.MustCompile(`(?i)\b(today'?s|our|this week'?s|this episode'?s) sponsors?\b`),
+	regexp.MustCompile(`(?i)\b(promo|discount|coupon) code\b`),
+	regexp.MustCompile(`(?i)\bthanks to \S+( \S+)? for sponsoring\b`),
+}
+
+// adWeakPatterns are common in ad reads but also in regular conversation, so a segment
+// needs at least two of them to be treated as an ad
+var adWeakPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\buse (the )?code \w+`),
+	regexp.MustCompile(`(?i)\b\d+ ?(%|percent) off\b`),
+	regexp.MustCompile(`(?i)\bfree trial\b`),
+	regexp.MustCompile(`(?i)\b(visit|go to|head (over )?to|check out) \S+\.(com|io|co|net|org)\b`),
+	regexp.MustCompile(`(?i)\byour first (month|order|box)\b`),
+	regexp.MustCompile(`(?i)\bsign up (today|now)\b`),
+	regexp.MustCompile(`(?i)\blink in the (show notes|description)\b`),
+}
+
+// leadingTimestampPattern matches timestamps such as "[00:12:34]" or "12:34" at the start of a line
+var leadingTimestampPattern = regexp.MustCompile(`^\s*\[?(\d{1,2}:\d{2}(?::\d{2})?)\]?`)
+
+// AdFilterStats records what the ad filter removed from a transcript
+type AdFilterStats struct {
+	SegmentsRemoved   int      `json:"segments_removed"`
+	CharsRemoved      int      `json:"chars_removed"`                // Length of the removed segment text
+	CharsTotal        int      `json:"chars_total"`                  // Length of the content before filtering
+	RemovedTimestamps []string `json:"removed_timestamps,omitempty"` // Start times of removed segments, where known
+}
+
+// isAdSegment reports whether a transcript segment looks like a sponsor read
+func isAdSegment(text string) bool {
+	for _, pattern := range adStrongPatterns {
+		if pattern.MatchString(text) {
+			return true
+		}
+	}
+
+	matches := 0
+	for _, pattern := range adWeakPatterns {
+		if pattern.MatchString(text) {
+			matches++
+		}
+	}
+	return matches >= 2
+}
+
+// stripAdSegments removes likely ad segments from transcript content before analysis.
+// JSON transcripts are filtered segment by segment and plain text line by line; kept
+// segments are left untouched so their timestamps stay valid for chapter generation.
+// Content is returned unchanged if every segment would be removed.
+func stripAdSegments(content string) (string, AdFilterStats) {
+	stats := AdFilterStats{CharsTotal: len(content)}
+
+	var jsonData map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &jsonData); err == nil {
+		if filtered, ok := stripAdsFromJSON(jsonData, &stats); ok {
+			if encoded, err := json.Marshal(filtered); err == nil {
+				return string(encoded), stats
+			}
+		}
+		return content, AdFilterStats{CharsTotal: len(content)}
+	}
+
+	filtered, ok := stripAdLines(content, &stats)
+	if !ok {
+		return content, AdFilterStats{CharsTotal: len(content)}
+	}
+	return filtered, stats
+}
+
+// stripAdsFromJSON filters the segments of a JSON transcript. It returns false when
+// nothing was removed or nothing would be left.
+func stripAdsFromJSON(jsonData map[string]interface{}, stats *AdFilterStats) (map[string]interface{}, bool) {
+	switch transcript := jsonData["transcript"].(type) {
+	case []interface{}:
+		kept := make([]interface{}, 0, len(transcript))
+		for _, item := range transcript {
+			segment, ok := item.(map[string]interface{})
+			text, _ := segment["text"].(string)
+			if !ok || !isAdSegment(text) {
+				kept = append(kept, item)
+				continue
+			}
+			stats.SegmentsRemoved++
+			stats.CharsRemoved += len(text)
+			if timestamp, ok := segment["timestamp"].(string); ok && timestamp != "" {
+				stats.RemovedTimestamps = append(stats.RemovedTimestamps, timestamp)
+			}
+		}
+		if stats.SegmentsRemoved == 0 || len(kept) == 0 {
+			return nil, false
+		}
+		jsonData["transcript"] = kept
+		return jsonData, true
+	case string:
+		filtered, ok := stripAdLines(transcript, stats)
+		if !ok {
+			return nil, false
+		}
+		jsonData["transcript"] = filtered
+		return jsonData, true
+	}
+	return nil, false
+}
+
+// stripAdLines filters plain text line by line. It returns false when nothing was
+// removed or nothing would be left.
+func stripAdLines(text string, stats *AdFilterStats) (string, bool) {
+	lines := strings.Split(text, "\n")
+	kept := make([]string, 0, len(lines))
+	removed, removedChars := 0, 0
+	var timestamps []string
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" || !isAdSegment(line) {
+			kept = append(kept, line)
+			continue
+		}
+		removed++
+		removedChars += len(line)
+		if match := leadingTimestampPattern.FindStringSubmatch(line); match != nil {
+			timestamps = append(timestamps, match[1])
+		}
+	}
+
+	if removed == 0 || strings.TrimSpace(strings.Join(kept, "")) == "" {
+		return "", false
+	}
+
+	stats.SegmentsRemoved += removed
+	stats.CharsRemoved += removedChars
+	stats.RemovedTimestamps = append(stats.RemovedTimestamps, timestamps...)
+	return strings.Join(kept, "\n"), true
+}
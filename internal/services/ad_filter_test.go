@@ -0,0 +1,83 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAdSegment(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected bool
+	}{
+		{"sponsor intro", "This episode is brought to you by Acme Mattresses.", true},
+		{"promo code", "Save big with promo code PODCAST at checkout.", true},
+		{"two weak signals", "Head over to acme.com and use code SLEEP for 20% off.", true},
+		{"single weak signal", "They offered a free trial, which is how I got hooked.", false},
+		{"regular conversation", "The study found sleep quality improved over six weeks.", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isAdSegment(tt.text))
+		})
+	}
+}
+
+func TestStripAdSegments_PlainText(t *testing.T) {
+	content := "[00:00:05] Host: Welcome back to the show.\n" +
+		"[00:01:10] Host: Today's sponsor is Acme. Use promo code SHOW for 10% off.\n" +
+		"[00:02:00] Guest: Thanks for having me."
+
+	filtered, stats := stripAdSegments(content)
+
+	assert.Equal(t, "[00:00:05] Host: Welcome back to the show.\n[00:02:00] Guest: Thanks for having me.", filtered)
+	assert.Equal(t, 1, stats.SegmentsRemoved)
+	assert.Equal(t, len(content), stats.CharsTotal)
+	assert.Equal(t, len("[00:01:10] Host: Today's sponsor is Acme. Use promo code SHOW for 10% off."), stats.CharsRemoved)
+	assert.Equal(t, []string{"00:01:10"}, stats.RemovedTimestamps)
+}
+
+func TestStripAdSegments_JSONKeepsSegmentTimestamps(t *testing.T) {
+	content := `{"title":"Episode 1","transcript":[` +
+		`{"timestamp":"00:00:05","speaker":"Host","text":"Welcome back."},` +
+		`{"timestamp":"00:01:10","speaker":"Host","text":"This episode is sponsored by Acme."},` +
+		`{"timestamp":"00:02:00","speaker":"Guest","text":"Glad to be here."}]}`
+
+	filtered, stats := stripAdSegments(content)
+
+	var parsed struct {
+		Title      string              `json:"title"`
+		Transcript []map[string]string `json:"transcript"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(filtered), &parsed))
+	assert.Equal(t, "Episode 1", parsed.Title)
+	require.Len(t, parsed.Transcript, 2)
+	assert.Equal(t, "00:00:05", parsed.Transcript[0]["timestamp"])
+	assert.Equal(t, "00:02:00", parsed.Transcript[1]["timestamp"])
+	assert.Equal(t, 1, stats.SegmentsRemoved)
+	assert.Equal(t, []string{"00:01:10"}, stats.RemovedTimestamps)
+}
+
+func TestStripAdSegments_NothingRemoved(t *testing.T) {
+	content := "A conversation about history.\nNo sponsors mentioned here at all."
+
+	filtered, stats := stripAdSegments(content)
+
+	assert.Equal(t, content, filtered)
+	assert.Equal(t, 0, stats.SegmentsRemoved)
+	assert.Equal(t, 0, stats.CharsRemoved)
+}
+
+func TestStripAdSegments_KeepsContentThatIsAllAds(t *testing.T) {
+	content := "This episode is brought to you by Acme."
+
+	filtered, stats := stripAdSegments(content)
+
+	assert.Equal(t, content, filtered)
+	assert.Equal(t, 0, stats.SegmentsRemoved)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/metrics"
+	"podcast-analyzer/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AgentRunPruneSummary reports how many stored agent runs a cleanup removed, by reason
+type AgentRunPruneSummary struct {
+	Finished  int64 // Runs of completed or cancelled jobs, which will never be resumed
+	Expired   int64 // Runs older than the retention period
+	OverLimit int64 // Oldest runs beyond the row limit
+}
+
+// Total returns the number of runs removed
+func (s AgentRunPruneSummary) Total() int64 {
+	return s.Finished + s.Expired + s.OverLimit
+}
+
+// PruneAgentRuns applies the agent run retention policy. Stored runs only matter while a
+// job may still be retried, so runs of finished jobs can be dropped straight away; the rest
+// are kept for the retention period and capped at the configured row count, oldest first.
+func (s *AnalysisService) PruneAgentRuns(correlationID string) (*AgentRunPruneSummary, error) {
+	summary := &AgentRunPruneSummary{}
+
+	if s.config.AgentRunPruneFinished {
+		finishedJobs := s.db.Model(&models.AnalysisResult{}).Select("job_id").Where("status IN ?", []string{"completed", "cancelled"})
+		result := s.db.Where("job_id IN (?)", finishedJobs).Delete(&models.AgentRun{})
+		if result.Error != nil {
+			return s.agentRunPruneFailed(summary, "prune_finished_agent_runs", result.Error, correlationID)
+		}
+		summary.Finished = result.RowsAffected
+	}
+
+	if retention := s.config.AgentRunRetention; retention > 0 {
+		result := s.db.Where("created_at < ?", time.Now().Add(-retention)).Delete(&models.AgentRun{})
+		if result.Error != nil {
+			return s.agentRunPruneFailed(summary, "prune_expired_agent_runs", result.Error, correlationID)
+		}
+		summary.Expired = result.RowsAffected
+	}
+
+	if limit := s.config.AgentRunMaxRows; limit > 0 {
+		var oldestKept models.AgentRun
+		err := s.db.Select("created_at").Order("created_at DESC").Offset(limit - 1).Limit(1).Take(&oldestKept).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return s.agentRunPruneFailed(summary, "find_agent_run_limit", err, correlationID)
+		}
+		if err == nil {
+			result := s.db.Where("created_at < ?", oldestKept.CreatedAt).Delete(&models.AgentRun{})
+			if result.Error != nil {
+				return s.agentRunPruneFailed(summary, "prune_agent_runs_over_limit", result.Error, correlationID)
+			}
+			summary.OverLimit = result.RowsAffected
+		}
+	}
+
+	metrics.AddCounter("agent_runs_pruned", summary.Total())
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"finished":   summary.Finished,
+		"expired":    summary.Expired,
+		"over_limit": summary.OverLimit,
+	}).Info("Pruned stored agent runs")
+	return summary, nil
+}
+
+// agentRunPruneFailed logs a failed cleanup step and returns what was pruned before it
+func (s *AnalysisService) agentRunPruneFailed(summary *AgentRunPruneSummary, operation string, err error, correlationID string) (*AgentRunPruneSummary, error) {
+	logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+		"pruned":    summary.Total(),
+		"operation": operation,
+	})
+	return summary, fmt.Errorf("failed to prune agent runs: %w", err)
+}
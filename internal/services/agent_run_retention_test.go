@@ -0,0 +1,76 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createAgentRunForJob stores one agent run for a new job with the given status
+func createAgentRunForJob(t *testing.T, db *gorm.DB, status string, age time.Duration) uuid.UUID {
+	job := &models.AnalysisResult{TranscriptID: uuid.New(), JobID: uuid.New(), Status: status}
+	require.NoError(t, db.Create(job).Error)
+	run := &models.AgentRun{JobID: job.JobID, Agent: "summarizer", Output: []byte(`{"text": "Summary"}`), CreatedAt: time.Now().Add(-age)}
+	require.NoError(t, db.Create(run).Error)
+	return job.JobID
+}
+
+// remainingAgentRunJobs returns the jobs that still have stored agent runs
+func remainingAgentRunJobs(t *testing.T, db *gorm.DB) []uuid.UUID {
+	var jobIDs []uuid.UUID
+	require.NoError(t, db.Model(&models.AgentRun{}).Pluck("job_id", &jobIDs).Error)
+	return jobIDs
+}
+
+func TestAnalysisService_PruneAgentRuns_FinishedAndExpired(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{AgentRunRetention: 24 * time.Hour, AgentRunPruneFinished: true})
+
+	createAgentRunForJob(t, db, "completed", time.Minute)
+	createAgentRunForJob(t, db, "cancelled", time.Minute)
+	createAgentRunForJob(t, db, "failed", 48*time.Hour)
+	failed := createAgentRunForJob(t, db, "failed", time.Minute)
+	processing := createAgentRunForJob(t, db, "processing", time.Minute)
+
+	summary, err := service.PruneAgentRuns("test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, &AgentRunPruneSummary{Finished: 2, Expired: 1}, summary)
+	assert.ElementsMatch(t, []uuid.UUID{failed, processing}, remainingAgentRunJobs(t, db))
+}
+
+func TestAnalysisService_PruneAgentRuns_KeepsNewestWithinLimit(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{AgentRunMaxRows: 2})
+
+	createAgentRunForJob(t, db, "completed", 3*time.Hour)
+	createAgentRunForJob(t, db, "failed", 2*time.Hour)
+	newer := createAgentRunForJob(t, db, "failed", time.Hour)
+	newest := createAgentRunForJob(t, db, "completed", time.Minute)
+
+	summary, err := service.PruneAgentRuns("test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, &AgentRunPruneSummary{OverLimit: 2}, summary)
+	assert.ElementsMatch(t, []uuid.UUID{newer, newest}, remainingAgentRunJobs(t, db))
+}
+
+func TestAnalysisService_PruneAgentRuns_NothingConfigured(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{AgentRunMaxRows: 5})
+
+	createAgentRunForJob(t, db, "completed", 30*24*time.Hour)
+
+	summary, err := service.PruneAgentRuns("test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), summary.Total())
+	assert.Len(t, remainingAgentRunJobs(t, db), 1)
+}
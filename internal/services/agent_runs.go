@@ -0,0 +1,135 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// agentRunText is the stored output of a stage that produces one piece of text
+type agentRunText struct {
+	Text string `json:"text"`
+}
+
+// agentRunSummary is the stored output of the summarizer. It reads stored agentRunText
+// output too, as a summary without a headline.
+type agentRunSummary struct {
+	Text     string `json:"text"`
+	Headline string `json:"headline,omitempty"` // Set when a headline was requested
+}
+
+// agentRunTakeaways is the stored output of the takeaway extractor
+type agentRunTakeaways struct {
+	Takeaways  []string `json:"takeaways"`
+	Importance []int    `json:"importance,omitempty"` // Set when takeaways were ranked
+}
+
+// agentRunOutputs holds the stored stage outputs of one job, keyed by agent name
+type agentRunOutputs map[string]json.RawMessage
+
+// loadAgentRuns returns the stage outputs already stored for a job. Stored output only
+// saves work, so a failed read is logged and the job runs every stage.
+func (s *AnalysisService) loadAgentRuns(jobID uuid.UUID, correlationID string) agentRunOutputs {
+	var runs []models.AgentRun
+	if err := s.db.Where("job_id = ?", jobID).Find(&runs).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":    jobID,
+			"operation": "load_agent_runs",
+		})
+		return agentRunOutputs{}
+	}
+
+	outputs := make(agentRunOutputs, len(runs))
+	for _, run := range runs {
+		outputs[run.Agent] = json.RawMessage(run.Output)
+	}
+	return outputs
+}
+
+// text returns a stored text output, reporting false when there is none or it is empty
+func (o agentRunOutputs) text(agent string) (string, bool) {
+	var output agentRunText
+	if raw, ok := o[agent]; !ok || json.Unmarshal(raw, &output) != nil {
+		return "", false
+	}
+	return output.Text, strings.TrimSpace(output.Text) != ""
+}
+
+// summary returns the stored summarizer output, reporting false when there is none or the
+// summary is empty
+func (o agentRunOutputs) summary() (agentRunSummary, bool) {
+	var output agentRunSummary
+	if raw, ok := o["summarizer"]; !ok || json.Unmarshal(raw, &output) != nil {
+		return agentRunSummary{}, false
+	}
+	return output, strings.TrimSpace(output.Text) != ""
+}
+
+// takeaways returns the stored takeaway extractor output, reporting false when there is
+// none or it has no takeaways
+func (o agentRunOutputs) takeaways() (agentRunTakeaways, bool) {
+	var output agentRunTakeaways
+	if raw, ok := o["takeaway_extractor"]; !ok || json.Unmarshal(raw, &output) != nil {
+		return agentRunTakeaways{}, false
+	}
+	return output, len(output.Takeaways) > 0
+}
+
+// factChecks returns the stored fact checker output, reporting false when there is none or
+// it has no fact checks
+func (o agentRunOutputs) factChecks() (agents.Result, bool) {
+	var output agents.Result
+	if raw, ok := o["fact_checker"]; !ok || json.Unmarshal(raw, &output) != nil {
+		return agents.Result{}, false
+	}
+	return output, len(output.FactChecks) > 0
+}
+
+// saveAgentRun stores a completed stage's output, replacing any earlier output of the same
+// stage. A failed write is logged rather than failing the job, which only loses the
+// ability to skip the stage on a retry.
+func (s *AnalysisService) saveAgentRun(jobID uuid.UUID, agent string, output interface{}, correlationID string) {
+	outputJSON, err := json.Marshal(output)
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":    jobID,
+			"agent":     agent,
+			"operation": "serialize_agent_run",
+		})
+		return
+	}
+
+	run := &models.AgentRun{
+		JobID:     jobID,
+		Agent:     agent,
+		Output:    outputJSON,
+		CreatedAt: time.Now(),
+	}
+	err = s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}, {Name: "agent"}},
+		DoUpdates: clause.AssignmentColumns([]string{"output", "created_at"}),
+	}).Create(run).Error
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":    jobID,
+			"agent":     agent,
+			"operation": "save_agent_run",
+		})
+	}
+}
+
+// resumeAgent logs and records a stage skipped because its output was already stored
+func (s *AnalysisService) resumeAgent(jobID uuid.UUID, agent, correlationID string) {
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"job_id": jobID,
+		"agent":  agent,
+	}).Info("Agent output already stored, skipping")
+	s.recordJobEvent(jobID, "processing", agent, "Resumed: reused stored output")
+}
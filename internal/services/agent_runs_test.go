@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisService_SaveAgentRun_ReplacesEarlierOutput(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{})
+	jobID := uuid.New()
+
+	service.saveAgentRun(jobID, "summarizer", agentRunText{Text: "First"}, "test-correlation-id")
+	service.saveAgentRun(jobID, "summarizer", agentRunText{Text: "Second"}, "test-correlation-id")
+
+	var count int64
+	require.NoError(t, db.Model(&models.AgentRun{}).Where("job_id = ?", jobID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	text, ok := service.loadAgentRuns(jobID, "test-correlation-id").text("summarizer")
+	assert.True(t, ok)
+	assert.Equal(t, "Second", text)
+}
+
+func TestAgentRunOutputs_InvalidOutputIsNotReused(t *testing.T) {
+	outputs := agentRunOutputs{
+		"summarizer":         []byte(`{"text": "  "}`),
+		"takeaway_extractor": []byte(`{"takeaways": []}`),
+		"fact_checker":       []byte(`not json`),
+	}
+
+	_, ok := outputs.text("summarizer")
+	assert.False(t, ok)
+	_, ok = outputs.text("takeaway_synthesizer")
+	assert.False(t, ok)
+	_, ok = outputs.takeaways()
+	assert.False(t, ok)
+	_, ok = outputs.factChecks()
+	assert.False(t, ok)
+}
+
+func TestAnalysisService_runAnalysisAgents_ResumesStoredStages(t *testing.T) {
+	db := setupTestDB(t)
+	// No API keys are configured, so any stage that actually ran would fail
+	service := NewAnalysisService(db, &config.Config{
+		EnableSummarizer: true,
+		EnableTakeaways:  true,
+		EnableFactCheck:  true,
+	})
+	jobID := uuid.New()
+	correlationID := "test-correlation-resume"
+
+	service.saveAgentRun(jobID, "summarizer", agentRunSummary{Text: "Stored summary", Headline: "Stored headline"}, correlationID)
+	service.saveAgentRun(jobID, "takeaway_extractor", agentRunTakeaways{Takeaways: []string{"Stored takeaway"}}, correlationID)
+	service.saveAgentRun(jobID, "takeaway_synthesizer", agentRunText{Text: "Stored synthesis"}, correlationID)
+	service.saveAgentRun(jobID, "fact_checker", agents.Result{FactChecks: []agents.FactCheck{
+		{Claim: "Stored claim", Verdict: models.VerdictTrue, Confidence: 0.9, Evidence: "Stored evidence"},
+	}}, correlationID)
+
+	result, err := service.runAnalysisAgents(context.Background(), "Test content", AnalysisOptions{TakeawaysSummary: true}, jobID, correlationID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Stored summary", result.Summary)
+	assert.Equal(t, "Stored headline", result.Headline)
+	assert.Equal(t, []string{"Stored takeaway"}, result.Takeaways["takeaways"])
+	assert.Equal(t, "Stored synthesis", result.TakeawaysSummary)
+	require.Len(t, result.FactChecks, 1)
+	assert.Equal(t, "Stored claim", result.FactChecks[0].Claim)
+	assert.Equal(t, models.FactCheckStatusCompleted, result.FactCheckStatus)
+	resumed := []string{"summarizer", "takeaway_extractor", "takeaway_synthesizer", "fact_checker"}
+	assert.Equal(t, resumed, result.Metadata["agents_run"])
+	assert.Equal(t, resumed, result.Metadata["resumed_agents"])
+
+	var events []models.JobEvent
+	require.NoError(t, db.Where("job_id = ?", jobID).Order("created_at ASC").Find(&events).Error)
+	require.Len(t, events, 4)
+	for i, stage := range resumed {
+		assert.Equal(t, stage, events[i].Stage)
+		require.NotNil(t, events[i].Message)
+		assert.Contains(t, *events[i].Message, "Resumed")
+	}
+}
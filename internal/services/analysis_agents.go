@@ -3,14 +3,33 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/clients"
 	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/requestctx"
 
 	"github.com/google/uuid"
 )
 
+// ErrAnalysisCancelled is returned when a job's context ends between agent stages
+var ErrAnalysisCancelled = errors.New("analysis cancelled")
+
+// checkAgentContext stops the pipeline before the next stage once the context is done, so
+// a cancelled or timed-out job does not start another paid API call. The returned error
+// wraps both ErrAnalysisCancelled and the context error.
+func checkAgentContext(ctx context.Context, stage string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w before %s: %w", ErrAnalysisCancelled, stage, err)
+	}
+	return nil
+}
+
 // runAnalysisAgents runs the AI analysis agents in sequence
-func (s *AnalysisService) runAnalysisAgents(ctx context.Context, content string, jobID uuid.UUID, correlationID string) (*AnalysisResults, error) {
+func (s *AnalysisService) runAnalysisAgents(ctx context.Context, content string, options AnalysisOptions, jobID uuid.UUID, correlationID string) (*AnalysisResults, error) {
 	log := logger.WithCorrelationID(correlationID)
 	log.WithFields(map[string]interface{}{
 		"job_id":         jobID,
@@ -18,107 +37,319 @@ func (s *AnalysisService) runAnalysisAgents(ctx context.Context, content string,
 		"word_count":     len([]rune(content)) / 6, // rough estimate
 	}).Info("Starting AI agent analysis")
 	
-	// Set correlation ID in context for agent tracing
-	ctx = context.WithValue(ctx, "correlation_id", correlationID)
+	// Identify the request and job in context for agent and client tracing
+	ctx = requestctx.WithJobID(requestctx.WithCorrelationID(ctx, correlationID), jobID)
+	
+	// Record the model versions the calls resolve to, and pin one when the job asks
+	modelRecorder := &clients.ModelRecorder{}
+	ctx = clients.WithModelRecorder(ctx, modelRecorder)
+	if options.Model != "" {
+		ctx = clients.WithModel(ctx, options.Model)
+	}
+	
+	// Record the agents whose output was still cut off after retrying with a larger budget
+	truncations := &clients.TruncationRecorder{}
+	ctx = clients.WithTruncationRecorder(ctx, truncations)
+	
+	// Stages disabled in config are skipped; agentsRun records the ones that ran
+	var agentsRun []string
+	
+	// Stages a previous attempt at this job completed are reused rather than paid for again
+	stored := s.loadAgentRuns(jobID, correlationID)
+	var resumed []string
 	
 	// 1. Run Summarizer Agent
-	summary, err := s.runSummarizerAgent(ctx, content, jobID, correlationID)
-	if err != nil {
-		return nil, err
+	var summary, headline string
+	if s.config.EnableSummarizer {
+		if storedSummary, ok := stored.summary(); ok {
+			summary, headline = storedSummary.Text, storedSummary.Headline
+			s.resumeAgent(jobID, "summarizer", correlationID)
+			resumed = append(resumed, "summarizer")
+		} else {
+			if err := checkAgentContext(ctx, "summarizer"); err != nil {
+				return nil, err
+			}
+			s.recordJobEvent(jobID, "processing", "summarizer", "")
+			summaryResult, err := s.runSummarizerAgent(ctx, content, options, jobID, correlationID)
+			if err != nil {
+				return nil, err
+			}
+			summary, headline = summaryResult.Summary, summaryResult.Headline
+			s.saveAgentRun(jobID, "summarizer", agentRunSummary{Text: summary, Headline: headline}, correlationID)
+		}
+		agentsRun = append(agentsRun, "summarizer")
+	} else {
+		s.skipDisabledAgent(jobID, "summarizer", correlationID)
 	}
 	
-	// 2. Run Takeaway Extractor Agent (with summary context)
-	takeaways, err := s.runTakeawayExtractorAgent(ctx, content, summary, jobID, correlationID)
-	if err != nil {
-		return nil, err
+	// 2. Run Takeaway Extractor Agent (with summary context when the summarizer ran)
+	var takeaways []string
+	var takeawayImportance []int
+	if s.config.EnableTakeaways {
+		if storedTakeaways, ok := stored.takeaways(); ok {
+			takeaways, takeawayImportance = storedTakeaways.Takeaways, storedTakeaways.Importance
+			s.resumeAgent(jobID, "takeaway_extractor", correlationID)
+			resumed = append(resumed, "takeaway_extractor")
+		} else {
+			if err := checkAgentContext(ctx, "takeaway_extractor"); err != nil {
+				return nil, err
+			}
+			s.recordJobEvent(jobID, "processing", "takeaway_extractor", "")
+			takeawayResult, err := s.runTakeawayExtractorAgent(ctx, content, summary, options, jobID, correlationID)
+			if err != nil {
+				return nil, err
+			}
+			takeaways, takeawayImportance = takeawayResult.Takeaways, takeawayResult.TakeawayImportance
+			// A failed extraction also comes back empty, so only non-empty output is kept
+			if len(takeaways) > 0 {
+				s.saveAgentRun(jobID, "takeaway_extractor", agentRunTakeaways{Takeaways: takeaways, Importance: takeawayImportance}, correlationID)
+			}
+		}
+		agentsRun = append(agentsRun, "takeaway_extractor")
+	} else {
+		s.skipDisabledAgent(jobID, "takeaway_extractor", correlationID)
+	}
+	
+	// Synthesize the takeaways into one paragraph when the job asks for it
+	var takeawaysSummary string
+	if options.TakeawaysSummary && len(takeaways) > 0 {
+		if text, ok := stored.text("takeaway_synthesizer"); ok {
+			takeawaysSummary = text
+			s.resumeAgent(jobID, "takeaway_synthesizer", correlationID)
+			resumed = append(resumed, "takeaway_synthesizer")
+		} else {
+			if err := checkAgentContext(ctx, "takeaway_synthesizer"); err != nil {
+				return nil, err
+			}
+			s.recordJobEvent(jobID, "processing", "takeaway_synthesizer", "")
+			takeawaysSummary = s.runTakeawaySynthesizerAgent(ctx, takeaways, summary, options, jobID, correlationID)
+			if takeawaysSummary != "" {
+				s.saveAgentRun(jobID, "takeaway_synthesizer", agentRunText{Text: takeawaysSummary}, correlationID)
+			}
+		}
+		agentsRun = append(agentsRun, "takeaway_synthesizer")
 	}
 	
 	// 3. Run Fact Checker Agent
-	factCheckResults, err := s.runFactCheckerAgent(ctx, content, jobID, correlationID)
-	if err != nil {
+	var factCheckResult agents.Result
+	factCheckStatus := models.FactCheckStatusSkipped
+	if s.config.EnableFactCheck {
+		if storedResult, ok := stored.factChecks(); ok {
+			factCheckResult = storedResult
+			factCheckStatus = models.FactCheckStatusCompleted
+			s.resumeAgent(jobID, "fact_checker", correlationID)
+			resumed = append(resumed, "fact_checker")
+		} else {
+			if err := checkAgentContext(ctx, "fact_checker"); err != nil {
+				return nil, err
+			}
+			s.recordJobEvent(jobID, "processing", "fact_checker", "")
+			var err error
+			factCheckResult, factCheckStatus, err = s.runFactCheckerAgent(ctx, content, options, jobID, correlationID)
+			if err != nil {
+				return nil, err
+			}
+			// Degraded results are worth re-running once the search backend recovers
+			if len(factCheckResult.FactChecks) > 0 && !factCheckResult.Degraded {
+				s.saveAgentRun(jobID, "fact_checker", factCheckResult, correlationID)
+			}
+		}
+		agentsRun = append(agentsRun, "fact_checker")
+	} else {
+		s.skipDisabledAgent(jobID, "fact_checker", correlationID)
+	}
+	
+	// The fact checker degrades gracefully on errors, so confirm it was not cut short
+	if err := checkAgentContext(ctx, "saving results"); err != nil {
 		return nil, err
 	}
 	
 	// Transform results to expected API format
-	return s.transformAnalysisResults(summary, takeaways, factCheckResults, jobID, correlationID)
+	results, err := s.transformAnalysisResults(summary, takeaways, factCheckResult.FactChecks, jobID, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	results.Headline = headline
+	results.TakeawaysSummary = takeawaysSummary
+	results.FactCheckStatus = factCheckStatus
+	annotateTimestamps(results, content, takeaways)
+	annotateImportance(results, takeaways, takeawayImportance)
+	if agentsRun == nil {
+		agentsRun = []string{}
+	}
+	results.Metadata = map[string]interface{}{"agents_run": agentsRun}
+	results.Model = strings.Join(modelRecorder.Models(), ",")
+	if options.Model != "" {
+		results.Metadata["pinned_model"] = options.Model
+	}
+	if len(resumed) > 0 {
+		results.Metadata["resumed_agents"] = resumed
+	}
+	if truncated := truncations.Agents(); len(truncated) > 0 {
+		results.Truncated = true
+		results.Metadata["truncated_agents"] = truncated
+	}
+	if degraded := degradedFactCheckMetadata(factCheckResult); degraded != nil {
+		results.Metadata["fact_check"] = degraded
+	}
+	return results, nil
+}
+
+// degradedFactCheckMetadata returns the analysis metadata entry describing a degraded fact
+// checker run, or nil when the run was not degraded
+func degradedFactCheckMetadata(result agents.Result) map[string]interface{} {
+	if !result.Degraded {
+		return nil
+	}
+	return map[string]interface{}{
+		"degraded": true,
+		"reason":   result.DegradedReason,
+	}
+}
+
+// skipDisabledAgent logs and records a pipeline stage turned off in config
+func (s *AnalysisService) skipDisabledAgent(jobID uuid.UUID, agent, correlationID string) {
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"job_id": jobID,
+		"agent":  agent,
+	}).Info("Agent disabled, skipping")
+	s.recordJobEvent(jobID, "processing", agent, "Skipped: disabled in configuration")
 }
 
 // runSummarizerAgent processes content through the summarizer agent
-func (s *AnalysisService) runSummarizerAgent(ctx context.Context, content string, jobID uuid.UUID, correlationID string) (string, error) {
+func (s *AnalysisService) runSummarizerAgent(ctx context.Context, content string, options AnalysisOptions, jobID uuid.UUID, correlationID string) (agents.Result, error) {
 	log := logger.WithCorrelationID(correlationID)
 	summarizerAgent := agents.NewSummarizerAgent(s.config)
 	
 	log.WithField("job_id", jobID).Info("Agent started: summarizer")
-	summarizerResult, err := summarizerAgent.Process(ctx, content)
+	summarizerResult, err := summarizerAgent.WithTimeout(ctx, s.config.SummarizerTimeout, func(ctx context.Context) (agents.Result, error) {
+		return summarizerAgent.ProcessWithOptions(ctx, content, agents.ProcessingOptions{
+			Instructions: options.Instructions,
+			SummaryStyle: options.SummaryStyle,
+			Headline:     options.Headline,
+		})
+	})
 	if err != nil {
 		log.WithFields(map[string]interface{}{
-			"job_id": jobID,
-			"agent":  "summarizer",
-			"error":  err.Error(),
+			"job_id":    jobID,
+			"agent":     "summarizer",
+			"error":     err.Error(),
+			"timed_out": agents.IsTimeoutError(err),
 		}).Error("Summarizer agent failed")
-		return "", err
+		return agents.Result{}, err
 	}
 	
-	summary := summarizerResult.Summary
 	log.WithFields(map[string]interface{}{
 		"job_id":        jobID,
 		"agent":         "summarizer",
-		"summary_chars": len(summary),
+		"summary_chars": len(summarizerResult.Summary),
+		"has_headline":  summarizerResult.Headline != "",
 	}).Info("Agent completed: summarizer")
 	
-	return summary, nil
+	return summarizerResult, nil
 }
 
 // runTakeawayExtractorAgent processes content through the takeaway extractor agent
-func (s *AnalysisService) runTakeawayExtractorAgent(ctx context.Context, content, summary string, jobID uuid.UUID, correlationID string) ([]string, error) {
+func (s *AnalysisService) runTakeawayExtractorAgent(ctx context.Context, content, summary string, options AnalysisOptions, jobID uuid.UUID, correlationID string) (agents.Result, error) {
 	log := logger.WithCorrelationID(correlationID)
 	takeawayAgent := agents.NewTakeawayExtractorAgent(s.config)
 	
 	log.WithField("job_id", jobID).Info("Agent started: takeaway_extractor")
-	takeawayResult, err := takeawayAgent.ProcessWithOptions(ctx, content, agents.ProcessingOptions{
-		Summary: summary,
+	takeawayResult, err := takeawayAgent.WithTimeout(ctx, s.config.TakeawayExtractorTimeout, func(ctx context.Context) (agents.Result, error) {
+		return takeawayAgent.ProcessWithOptions(ctx, content, agents.ProcessingOptions{
+			Summary:      summary,
+			Instructions: options.Instructions,
+			MaxResults:   options.MaxTakeaways,
+		})
 	})
 	if err != nil {
 		log.WithFields(map[string]interface{}{
-			"job_id": jobID,
-			"agent":  "takeaway_extractor",
-			"error":  err.Error(),
+			"job_id":    jobID,
+			"agent":     "takeaway_extractor",
+			"error":     err.Error(),
+			"timed_out": agents.IsTimeoutError(err),
 		}).Error("Takeaway extractor agent failed, continuing without takeaways")
 		// Return empty takeaways instead of error to continue processing
-		return []string{}, nil
+		return agents.Result{Takeaways: []string{}}, nil
 	}
 	
-	takeaways := takeawayResult.Takeaways
 	log.WithFields(map[string]interface{}{
 		"job_id":          jobID,
 		"agent":           "takeaway_extractor",
-		"takeaways_count": len(takeaways),
+		"takeaways_count": len(takeawayResult.Takeaways),
+		"ranked":          len(takeawayResult.TakeawayImportance) > 0,
 	}).Info("Agent completed: takeaway_extractor")
 	
-	return takeaways, nil
+	return takeawayResult, nil
 }
 
-// runFactCheckerAgent processes content through the fact checker agent
-func (s *AnalysisService) runFactCheckerAgent(ctx context.Context, content string, jobID uuid.UUID, correlationID string) ([]agents.FactCheck, error) {
+// runTakeawaySynthesizerAgent asks for a one-paragraph synthesis of the extracted takeaways.
+// The paragraph is optional, so a failure is logged and leaves it empty.
+func (s *AnalysisService) runTakeawaySynthesizerAgent(ctx context.Context, takeaways []string, summary string, options AnalysisOptions, jobID uuid.UUID, correlationID string) string {
+	log := logger.WithCorrelationID(correlationID)
+	synthesizerAgent := agents.NewTakeawaySynthesizerAgent(s.config)
+	
+	log.WithField("job_id", jobID).Info("Agent started: takeaway_synthesizer")
+	// The synthesis reads only the takeaways, so it shares the takeaway extractor's deadline
+	synthesisResult, err := synthesizerAgent.WithTimeout(ctx, s.config.TakeawayExtractorTimeout, func(ctx context.Context) (agents.Result, error) {
+		return synthesizerAgent.ProcessWithOptions(ctx, agents.FormatTakeawayList(takeaways), agents.ProcessingOptions{
+			Summary:      summary,
+			Instructions: options.Instructions,
+		})
+	})
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"job_id":    jobID,
+			"agent":     "takeaway_synthesizer",
+			"error":     err.Error(),
+			"timed_out": agents.IsTimeoutError(err),
+		}).Warn("Takeaway synthesizer agent failed, continuing without a takeaways summary")
+		return ""
+	}
+	
+	log.WithFields(map[string]interface{}{
+		"job_id":          jobID,
+		"agent":           "takeaway_synthesizer",
+		"paragraph_chars": len(synthesisResult.Summary),
+	}).Info("Agent completed: takeaway_synthesizer")
+	
+	return synthesisResult.Summary
+}
+
+// runFactCheckerAgent processes content through the fact checker agent. The result carries
+// the degraded flag when most claims failed verification for the same reason, and the
+// returned status records how the stage ended.
+func (s *AnalysisService) runFactCheckerAgent(ctx context.Context, content string, options AnalysisOptions, jobID uuid.UUID, correlationID string) (agents.Result, string, error) {
 	log := logger.WithCorrelationID(correlationID)
 	factCheckerAgent := agents.NewFactCheckerAgent(s.config)
+	if s.config.FactCheckCacheTTL > 0 {
+		factCheckerAgent.WithCache(newFactCheckCache(s.db, s.config.FactCheckCacheTTL))
+	}
 	
 	log.WithField("job_id", jobID).Info("Agent started: fact_checker")
-	factCheckResult, err := factCheckerAgent.Process(ctx, content)
+	// Per-claim verification makes many calls, so it queues behind summaries and takeaways
+	ctx = clients.WithLLMPriority(ctx, clients.LLMPriorityBatch)
+	factCheckResult, err := factCheckerAgent.WithTimeout(ctx, s.config.FactCheckerTimeout, func(ctx context.Context) (agents.Result, error) {
+		return factCheckerAgent.ProcessWithOptions(ctx, content, agents.ProcessingOptions{
+			Instructions:    options.Instructions,
+			ClaimCategories: options.ClaimCategories,
+		})
+	})
 	if err != nil {
 		log.WithFields(map[string]interface{}{
-			"job_id": jobID,
-			"agent":  "fact_checker",
-			"error":  err.Error(),
+			"job_id":    jobID,
+			"agent":     "fact_checker",
+			"error":     err.Error(),
+			"timed_out": agents.IsTimeoutError(err),
 		}).Error("Fact checker agent failed, continuing without fact checks")
 		// Return empty fact checks instead of error to continue processing
-		return []agents.FactCheck{}, nil
+		return agents.Result{FactChecks: []agents.FactCheck{}}, models.FactCheckStatusFailed, nil
 	}
 	
 	factCheckResults := factCheckResult.FactChecks
 	
 	// Count verdicts for logging
-	verdictCounts := make(map[string]int)
+	verdictCounts := make(map[models.Verdict]int)
 	for _, fc := range factCheckResults {
 		verdictCounts[fc.Verdict]++
 	}
@@ -127,13 +358,26 @@ func (s *AnalysisService) runFactCheckerAgent(ctx context.Context, content strin
 		"job_id":                   jobID,
 		"agent":                    "fact_checker",
 		"claims_verified":          len(factCheckResults),
-		"claims_true":              verdictCounts["true"],
-		"claims_false":             verdictCounts["false"],
-		"claims_partially_true":    verdictCounts["partially_true"],
-		"claims_unverifiable":      verdictCounts["unverifiable"],
+		"claims_true":              verdictCounts[models.VerdictTrue],
+		"claims_false":             verdictCounts[models.VerdictFalse],
+		"claims_partially_true":    verdictCounts[models.VerdictPartiallyTrue],
+		"claims_unverifiable":      verdictCounts[models.VerdictUnverifiable],
+		"degraded":                 factCheckResult.Degraded,
 	}).Info("Agent completed: fact_checker")
 	
-	return factCheckResults, nil
+	return factCheckResult, factCheckStatusOf(factCheckResult), nil
+}
+
+// factCheckStatusOf returns how a fact checker run that did not fail ended
+func factCheckStatusOf(result agents.Result) string {
+	switch {
+	case result.Degraded:
+		return models.FactCheckStatusDegraded
+	case len(result.FactChecks) == 0:
+		return models.FactCheckStatusNoClaims
+	default:
+		return models.FactCheckStatusCompleted
+	}
 }
 
 // transformAnalysisResults converts agent outputs to the expected API response format
@@ -168,13 +412,21 @@ func (s *AnalysisService) transformAnalysisResults(summary string, takeaways []s
 		sourcesMap := map[string]interface{}{
 			"sources": fc.Sources,
 		}
+		if fc.LowSourceQuality {
+			sourcesMap["low_source_quality"] = true
+		}
+		if fc.OriginalVerdict != "" {
+			sourcesMap["original_verdict"] = fc.OriginalVerdict
+		}
 		
 		factChecksConverted[i] = FactCheckResult{
-			Claim:      fc.Claim,
-			Verdict:    fc.Verdict,
-			Confidence: fc.Confidence,
-			Evidence:   fc.Evidence,
-			Sources:    sourcesMap,
+			Claim:       fc.Claim,
+			Verdict:     fc.Verdict,
+			Confidence:  fc.Confidence,
+			Evidence:    fc.Evidence,
+			Sources:     sourcesMap,
+			Cached:      fc.Cached,
+			SearchQuery: fc.SearchQuery,
 		}
 	}
 	
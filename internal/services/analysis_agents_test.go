@@ -4,15 +4,19 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"podcast-analyzer/internal/agents"
 	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/requestctx"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
@@ -73,7 +77,8 @@ func (m *MockFactCheckerAgent) Process(ctx context.Context, content string) (age
 // Override agent creation methods for testing
 func (m *MockAnalysisService) runSummarizerAgent(ctx context.Context, content string, jobID uuid.UUID, correlationID string) (string, error) {
 	if m.summarizerAgent == nil {
-		return m.AnalysisService.runSummarizerAgent(ctx, content, jobID, correlationID)
+		result, err := m.AnalysisService.runSummarizerAgent(ctx, content, AnalysisOptions{}, jobID, correlationID)
+		return result.Summary, err
 	}
 
 	result, err := m.summarizerAgent.Process(ctx, content)
@@ -85,7 +90,8 @@ func (m *MockAnalysisService) runSummarizerAgent(ctx context.Context, content st
 
 func (m *MockAnalysisService) runTakeawayExtractorAgent(ctx context.Context, content, summary string, jobID uuid.UUID, correlationID string) ([]string, error) {
 	if m.takeawayAgent == nil {
-		return m.AnalysisService.runTakeawayExtractorAgent(ctx, content, summary, jobID, correlationID)
+		result, err := m.AnalysisService.runTakeawayExtractorAgent(ctx, content, summary, AnalysisOptions{}, jobID, correlationID)
+		return result.Takeaways, err
 	}
 
 	result, err := m.takeawayAgent.ProcessWithOptions(ctx, content, agents.ProcessingOptions{Summary: summary})
@@ -98,7 +104,8 @@ func (m *MockAnalysisService) runTakeawayExtractorAgent(ctx context.Context, con
 
 func (m *MockAnalysisService) runFactCheckerAgent(ctx context.Context, content string, jobID uuid.UUID, correlationID string) ([]agents.FactCheck, error) {
 	if m.factCheckerAgent == nil {
-		return m.AnalysisService.runFactCheckerAgent(ctx, content, jobID, correlationID)
+		result, _, err := m.AnalysisService.runFactCheckerAgent(ctx, content, AnalysisOptions{}, jobID, correlationID)
+		return result.FactChecks, err
 	}
 
 	result, err := m.factCheckerAgent.Process(ctx, content)
@@ -146,6 +153,9 @@ func setupMockAnalysisService() (*MockAnalysisService, *test.Hook) {
 		SerperAPIKey:   "test-serper-key",
 		ClaudeModel:    "claude-3-sonnet-20240229",
 		SummaryMaxChars: 300,
+		EnableSummarizer: true,
+		EnableTakeaways:  true,
+		EnableFactCheck:  true,
 	}
 	
 	logger, hook := test.NewNullLogger()
@@ -172,7 +182,7 @@ func setupMockAnalysisService() (*MockAnalysisService, *test.Hook) {
 func TestAnalysisService_runSummarizerAgent_Success(t *testing.T) {
 	service, _ := setupMockAnalysisService()
 
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-123")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-123")
 	content := "This is test podcast content for summarization that talks about technology trends."
 	jobID := uuid.New()
 	correlationID := "test-correlation-123"
@@ -194,7 +204,7 @@ func TestAnalysisService_runSummarizerAgent_Success(t *testing.T) {
 func TestAnalysisService_runSummarizerAgent_Error(t *testing.T) {
 	service, _ := setupMockAnalysisService()
 
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-456")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-456")
 	content := "Test content"
 	jobID := uuid.New()
 	correlationID := "test-correlation-456"
@@ -215,7 +225,7 @@ func TestAnalysisService_runSummarizerAgent_Error(t *testing.T) {
 func TestAnalysisService_runTakeawayExtractorAgent_Success(t *testing.T) {
 	service, _ := setupMockAnalysisService()
 
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-789")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-789")
 	content := "This podcast content has several key insights about business strategy."
 	summary := "Summary of business strategy discussion"
 	jobID := uuid.New()
@@ -265,7 +275,7 @@ func TestAnalysisService_runTakeawayExtractorAgent_Error_GracefulDegradation(t *
 func TestAnalysisService_runFactCheckerAgent_Success(t *testing.T) {
 	service, _ := setupMockAnalysisService()
 
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-fact")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-fact")
 	content := "The moon landing happened in 1969. This is a verifiable historical fact."
 	jobID := uuid.New()
 	correlationID := "test-correlation-fact"
@@ -290,7 +300,7 @@ func TestAnalysisService_runFactCheckerAgent_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedFactChecks, factChecks)
 	assert.Len(t, factChecks, 1)
-	assert.Equal(t, "true", factChecks[0].Verdict)
+	assert.Equal(t, models.VerdictTrue, factChecks[0].Verdict)
 	assert.Equal(t, 0.95, factChecks[0].Confidence)
 	service.factCheckerAgent.AssertExpectations(t)
 }
@@ -357,7 +367,7 @@ func TestAnalysisService_transformAnalysisResults_Success(t *testing.T) {
 	assert.Len(t, result.FactChecks, 1)
 	factCheck := result.FactChecks[0]
 	assert.Equal(t, "AI market will reach $500B by 2024", factCheck.Claim)
-	assert.Equal(t, "partially_true", factCheck.Verdict)
+	assert.Equal(t, models.VerdictPartiallyTrue, factCheck.Verdict)
 	assert.Equal(t, 0.75, factCheck.Confidence)
 	
 	// Verify sources structure
@@ -397,7 +407,7 @@ func TestAnalysisService_transformAnalysisResults_EmptyInputs(t *testing.T) {
 func TestAnalysisService_runAnalysisAgents_FullWorkflow_Success(t *testing.T) {
 	service, _ := setupMockAnalysisService()
 
-	ctx := context.WithValue(context.Background(), "correlation_id", "test-correlation-full")
+	ctx := requestctx.WithCorrelationID(context.Background(), "test-correlation-full")
 	content := "This comprehensive podcast episode discusses the future of renewable energy, including solar power advancements and wind energy efficiency. According to recent studies, solar panel efficiency has increased by 25% in the last five years."
 	jobID := uuid.New()
 	correlationID := "test-correlation-full"
@@ -552,4 +562,98 @@ func TestAnalysisService_transformAnalysisResults_TakeawaysMarshallingEdgeCase(t
 	assert.Equal(t, takeaways[0], takeawaysData[0])
 	assert.Equal(t, takeaways[1], takeawaysData[1])
 	assert.Equal(t, takeaways[2], takeawaysData[2])
-}
\ No newline at end of file
+}
+func TestAnalysisService_runAnalysisAgents_CancelledContextStopsBeforeAgents(t *testing.T) {
+	service, _ := setupMockAnalysisService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// The real pipeline must return before building any agent or calling the API
+	result, err := service.AnalysisService.runAnalysisAgents(ctx, "Test content", AnalysisOptions{}, uuid.New(), "test-correlation-cancel")
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrAnalysisCancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "before summarizer")
+}
+
+func TestAnalysisService_runAnalysisAgents_DisabledAgentsSkipped(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{})
+	jobID := uuid.New()
+
+	// With every stage disabled no agent is built, so no API is called
+	result, err := service.runAnalysisAgents(context.Background(), "Test content", AnalysisOptions{}, jobID, "test-correlation-disabled")
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Summary)
+	assert.Empty(t, result.FactChecks)
+	assert.Equal(t, models.FactCheckStatusSkipped, result.FactCheckStatus)
+	assert.Equal(t, []string{}, result.Metadata["agents_run"])
+
+	var events []models.JobEvent
+	require.NoError(t, db.Where("job_id = ?", jobID).Order("created_at ASC").Find(&events).Error)
+	require.Len(t, events, 3)
+	for i, stage := range []string{"summarizer", "takeaway_extractor", "fact_checker"} {
+		assert.Equal(t, stage, events[i].Stage)
+		require.NotNil(t, events[i].Message)
+		assert.Contains(t, *events[i].Message, "disabled")
+	}
+}
+
+func TestAnalysisService_runAnalysisAgents_TakeawaysSummarySkippedWithoutTakeaways(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{})
+	jobID := uuid.New()
+
+	// The synthesizer needs takeaways, so with extraction disabled it is never built
+	result, err := service.runAnalysisAgents(context.Background(), "Test content", AnalysisOptions{TakeawaysSummary: true}, jobID, "test-correlation-synthesis")
+
+	require.NoError(t, err)
+	assert.Empty(t, result.TakeawaysSummary)
+	assert.Equal(t, []string{}, result.Metadata["agents_run"])
+
+	var count int64
+	require.NoError(t, db.Model(&models.JobEvent{}).Where("job_id = ? AND stage = ?", jobID, "takeaway_synthesizer").Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestFactCheckStatusOf(t *testing.T) {
+	claim := agents.FactCheck{Claim: "A claim", Verdict: models.VerdictTrue}
+
+	assert.Equal(t, models.FactCheckStatusCompleted, factCheckStatusOf(agents.Result{FactChecks: []agents.FactCheck{claim}}))
+	assert.Equal(t, models.FactCheckStatusNoClaims, factCheckStatusOf(agents.Result{FactChecks: []agents.FactCheck{}}))
+	assert.Equal(t, models.FactCheckStatusDegraded, factCheckStatusOf(agents.Result{FactChecks: []agents.FactCheck{claim}, Degraded: true}))
+}
+
+func TestAnalysisService_FactCheckStatus_RoundTrip(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{})
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: "fact-check-status", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	results, err := service.transformAnalysisResults("Summary", nil, nil, analysis.JobID, "test-correlation-id")
+	require.NoError(t, err)
+	results.FactCheckStatus = models.FactCheckStatusNoClaims
+	_, err = service.saveAnalysisResults(context.Background(), analysis.JobID, results, "test-correlation-id")
+	require.NoError(t, err)
+
+	response, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Empty(t, response.FactChecks)
+	assert.Equal(t, models.FactCheckStatusNoClaims, response.FactCheckStatus)
+}
+
+func TestCheckAgentContext(t *testing.T) {
+	assert.NoError(t, checkAgentContext(context.Background(), "summarizer"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	err := checkAgentContext(ctx, "fact_checker")
+	assert.ErrorIs(t, err, ErrAnalysisCancelled)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
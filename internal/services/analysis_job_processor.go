@@ -3,15 +3,32 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"runtime"
 	"time"
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/config"
 	"podcast-analyzer/internal/models"
 	"podcast-analyzer/internal/logger"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
+// ErrJobOrphaned is returned when a job's analysis record was deleted while the job was
+// queued or running, typically because its transcript was deleted
+var ErrJobOrphaned = errors.New("analysis job orphaned")
+
+// isJobOrphaned reports whether a job's analysis record no longer exists
+func (s *AnalysisService) isJobOrphaned(jobID uuid.UUID) bool {
+	var count int64
+	if err := s.db.Model(&models.AnalysisResult{}).Where("job_id = ?", jobID).Count(&count).Error; err != nil {
+		return false
+	}
+	return count == 0
+}
+
 // setupJobPanicRecovery sets up panic recovery for analysis jobs
 func (s *AnalysisService) setupJobPanicRecovery(jobID uuid.UUID, correlationID string) func() {
 	return func() {
@@ -32,17 +49,34 @@ func (s *AnalysisService) setupJobPanicRecovery(jobID uuid.UUID, correlationID s
 	}
 }
 
-// getTranscriptForJob retrieves the transcript for analysis
-func (s *AnalysisService) getTranscriptForJob(transcriptID uuid.UUID, jobID uuid.UUID, correlationID string) (*models.Transcript, string, error) {
+// getTranscriptForJob retrieves the transcript for analysis. Its content is reserved in the
+// shared content budget first, waiting as long as the job's context allows; the returned
+// function gives the reservation back once the job is done with the content.
+func (s *AnalysisService) getTranscriptForJob(ctx context.Context, transcriptID uuid.UUID, jobID uuid.UUID, correlationID string) (*models.Transcript, string, func(), error) {
 	var transcript models.Transcript
 	if err := s.db.Where("id = ?", transcriptID).First(&transcript).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) && s.isJobOrphaned(jobID) {
+			return nil, "", nil, ErrJobOrphaned
+		}
 		errorMsg := fmt.Sprintf("Transcript not found: %s", transcriptID.String())
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"transcript_id": transcriptID,
 			"operation":     "get_transcript",
 		})
 		s.UpdateJobStatus(jobID, "failed", errorMsg)
-		return nil, "", fmt.Errorf("%s: %w", errorMsg, err)
+		return nil, "", nil, fmt.Errorf("%s: %w", errorMsg, err)
+	}
+
+	release, err := getContentBudget(s.config).Acquire(ctx, transcriptContentSize(&transcript, s.config))
+	if err != nil {
+		errorMsg := "Timed out waiting for memory to read the transcript"
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id":              transcriptID,
+			"max_inflight_content_bytes": s.config.MaxInflightContentBytes,
+			"operation":                  "acquire_content_budget",
+		})
+		s.UpdateJobStatus(jobID, "failed", errorMsg)
+		return nil, "", nil, fmt.Errorf("%s: %w", errorMsg, err)
 	}
 
 	// Read transcript content
@@ -54,15 +88,67 @@ func (s *AnalysisService) getTranscriptForJob(transcriptID uuid.UUID, jobID uuid
 			"file_path": transcript.FilePath,
 			"operation": "read_transcript_content",
 		})
+		release()
 		s.UpdateJobStatus(jobID, "failed", errorMsg)
-		return nil, "", fmt.Errorf("%s: %w", errorMsg, err)
+		return nil, "", nil, fmt.Errorf("%s: %w", errorMsg, err)
+	}
+
+	return &transcript, content, release, nil
+}
+
+// transcriptContentSize approximates the bytes a transcript's content takes in memory from
+// its character count, assuming the largest allowed upload when the count is unknown
+func transcriptContentSize(transcript *models.Transcript, cfg *config.Config) int64 {
+	if transcript.CharCount > 0 {
+		return int64(transcript.CharCount)
+	}
+	return cfg.MaxFileSize
+}
+
+// Result persistence retry defaults used when nothing is configured
+const (
+	defaultResultSaveMaxAttempts = 3
+	defaultResultSaveRetryDelay  = 500 * time.Millisecond
+)
+
+// retryResultWrite runs a database write that persists finished analysis results, retrying
+// failures with exponential backoff so a brief database blip does not discard the AI work
+// already done. A missing record is not retried, and the wait between attempts ends early
+// with the context's error when ctx is done.
+func (s *AnalysisService) retryResultWrite(ctx context.Context, operation, correlationID string, write func() error) error {
+	maxAttempts := s.config.ResultSaveMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultResultSaveMaxAttempts
 	}
+	delay := s.config.ResultSaveRetryDelay
+	if delay <= 0 {
+		delay = defaultResultSaveRetryDelay
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := write()
+		if err == nil || errors.Is(err, gorm.ErrRecordNotFound) || attempt >= maxAttempts {
+			return err
+		}
 
-	return &transcript, content, nil
+		logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+			"operation":    operation,
+			"attempt":      attempt,
+			"max_attempts": maxAttempts,
+			"retry_in":     delay.String(),
+			"error":        err.Error(),
+		}).Warn("Saving analysis results failed, retrying")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
 }
 
 // saveAnalysisResults saves the analysis results to the database
-func (s *AnalysisService) saveAnalysisResults(jobID uuid.UUID, results *AnalysisResults, correlationID string) (*models.AnalysisResult, error) {
+func (s *AnalysisService) saveAnalysisResults(ctx context.Context, jobID uuid.UUID, results *AnalysisResults, correlationID string) (*models.AnalysisResult, error) {
 	// Convert takeaways to JSON for database storage
 	takeawaysJSON, err := json.Marshal(results.Takeaways)
 	if err != nil {
@@ -76,7 +162,13 @@ func (s *AnalysisService) saveAnalysisResults(jobID uuid.UUID, results *Analysis
 
 	// Update existing analysis record
 	var analysis models.AnalysisResult
-	if err := s.db.Where("job_id = ?", jobID).First(&analysis).Error; err != nil {
+	err = s.retryResultWrite(ctx, "find_analysis_record", correlationID, func() error {
+		return s.db.Where("job_id = ?", jobID).First(&analysis).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrJobOrphaned
+	}
+	if err != nil {
 		errorMsg := "Failed to find analysis record to update"
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"job_id":    jobID,
@@ -87,11 +179,30 @@ func (s *AnalysisService) saveAnalysisResults(jobID uuid.UUID, results *Analysis
 	}
 
 	analysis.Summary = &results.Summary
+	if results.Headline != "" {
+		analysis.Headline = &results.Headline
+	}
 	analysis.Takeaways = takeawaysJSON
+	if results.TakeawaysSummary != "" {
+		analysis.TakeawaysSummary = &results.TakeawaysSummary
+	}
+	if results.Metadata != nil {
+		metadataJSON, _ := json.Marshal(results.Metadata)
+		analysis.AnalysisMetadata = metadataJSON
+	}
+	if results.Model != "" {
+		analysis.Model = &results.Model
+	}
+	analysis.Truncated = results.Truncated
+	analysis.FactCheckStatus = results.FactCheckStatus
 	now := time.Now()
 	analysis.CompletedAt = &now
 
-	if err := s.db.Save(&analysis).Error; err != nil {
+	// Leave status alone so a concurrent cancellation is not overwritten
+	err = s.retryResultWrite(ctx, "save_analysis_results", correlationID, func() error {
+		return s.db.Model(&analysis).Select("summary", "headline", "takeaways", "takeaways_summary", "analysis_metadata", "model", "truncated", "fact_check_status", "completed_at").Updates(&analysis).Error
+	})
+	if err != nil {
 		errorMsg := "Failed to save analysis results"
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"analysis_id": analysis.ID,
@@ -105,7 +216,7 @@ func (s *AnalysisService) saveAnalysisResults(jobID uuid.UUID, results *Analysis
 }
 
 // saveFactChecks saves fact check results to the database
-func (s *AnalysisService) saveFactChecks(analysisID uuid.UUID, factChecks []FactCheckResult, correlationID string) {
+func (s *AnalysisService) saveFactChecks(ctx context.Context, analysisID uuid.UUID, factChecks []FactCheckResult, correlationID string) {
 	if len(factChecks) == 0 {
 		return
 	}
@@ -115,16 +226,21 @@ func (s *AnalysisService) saveFactChecks(analysisID uuid.UUID, factChecks []Fact
 		sourcesJSON, _ := json.Marshal(fc.Sources)
 		
 		factCheck := &models.FactCheck{
-			ID:         uuid.New(),
-			AnalysisID: analysisID,
-			Claim:      fc.Claim,
-			Verdict:    fc.Verdict,
-			Confidence: fc.Confidence,
-			Evidence:   &fc.Evidence,
-			Sources:    sourcesJSON,
-			CheckedAt:  time.Now(),
+			ID:          uuid.New(),
+			AnalysisID:  analysisID,
+			Claim:       fc.Claim,
+			Verdict:     fc.Verdict,
+			Confidence:  fc.Confidence,
+			Evidence:    &fc.Evidence,
+			Sources:     sourcesJSON,
+			CheckedAt:   time.Now(),
+			Cached:      fc.Cached,
+			SearchQuery: fc.SearchQuery,
 		}
-		if err := s.db.Create(factCheck).Error; err != nil {
+		err := s.retryResultWrite(ctx, "save_fact_check", correlationID, func() error {
+			return s.db.Create(factCheck).Error
+		})
+		if err != nil {
 			logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 				"analysis_id": analysisID,
 				"claim":       fc.Claim,
@@ -135,8 +251,36 @@ func (s *AnalysisService) saveFactChecks(analysisID uuid.UUID, factChecks []Fact
 	}
 }
 
+// startAnalysisJob processes a job in the background
+func (s *AnalysisService) startAnalysisJob(analysis *models.AnalysisResult, options AnalysisOptions, correlationID string) {
+	go s.runAnalysisJob(context.Background(), analysis, options, correlationID)
+}
+
+// runAnalysisJob processes a job, then removes an ephemeral transcript's content when the
+// job asks for it. Content is only removed once the job has completed; a failed or cancelled
+// job keeps it so the job can be retried and the transcript reparsed.
+func (s *AnalysisService) runAnalysisJob(ctx context.Context, analysis *models.AnalysisResult, options AnalysisOptions, correlationID string) {
+	s.processAnalysisJob(ctx, analysis.JobID, analysis.TranscriptID, options, correlationID)
+	if !options.CleanupTranscript {
+		return
+	}
+
+	var status string
+	if err := s.db.Model(&models.AnalysisResult{}).Where("job_id = ?", analysis.JobID).Pluck("status", &status).Error; err != nil || status != "completed" {
+		logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+			"job_id":        analysis.JobID,
+			"transcript_id": analysis.TranscriptID,
+			"status":        status,
+		}).Info("Analysis job did not complete, keeping ephemeral transcript content")
+		return
+	}
+	if err := NewTranscriptService(s.db, s.config).CleanupEphemeralTranscript(analysis.TranscriptID, correlationID); err != nil {
+		logger.WithCorrelationID(correlationID).WithError(err).WithField("transcript_id", analysis.TranscriptID).Warn("Failed to clean up ephemeral transcript")
+	}
+}
+
 // processAnalysisJob processes an analysis job in the background
-func (s *AnalysisService) processAnalysisJob(ctx context.Context, jobID uuid.UUID, transcriptID uuid.UUID, correlationID string) (retErr error) {
+func (s *AnalysisService) processAnalysisJob(ctx context.Context, jobID uuid.UUID, transcriptID uuid.UUID, options AnalysisOptions, correlationID string) (retErr error) {
 	// Setup panic recovery for this job
 	defer s.setupJobPanicRecovery(jobID, correlationID)
 
@@ -146,8 +290,24 @@ func (s *AnalysisService) processAnalysisJob(ctx context.Context, jobID uuid.UUI
 		"transcript_id": transcriptID,
 	}).Info("Processing analysis job")
 
+	// A job whose analysis record is gone has nothing to report to, so it is skipped
+	// rather than failed
+	defer func() {
+		if errors.Is(retErr, ErrJobOrphaned) {
+			log.WithFields(map[string]interface{}{
+				"job_id":        jobID,
+				"transcript_id": transcriptID,
+			}).Warn("Analysis job orphaned, skipping")
+			retErr = nil
+		}
+	}()
+
 	// Update job status to processing
-	if err := s.UpdateJobStatus(jobID, "processing", ""); err != nil {
+	err := s.UpdateJobStatus(jobID, "processing", "")
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrJobOrphaned
+	}
+	if err != nil {
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"job_id":    jobID,
 			"operation": "update_job_status_processing",
@@ -156,12 +316,15 @@ func (s *AnalysisService) processAnalysisJob(ctx context.Context, jobID uuid.UUI
 	}
 
 	// Get transcript and content
-	transcript, content, err := s.getTranscriptForJob(transcriptID, jobID, correlationID)
+	transcript, content, releaseContent, err := s.getTranscriptForJob(ctx, transcriptID, jobID, correlationID)
 	if err != nil {
 		return err
 	}
+	defer releaseContent()
 	_ = transcript // transcript available for future use (metadata, file path, etc.)
 
+	content, adFilterMetadata := s.applyAdFilter(content, options, jobID, correlationID)
+
 	log.WithFields(map[string]interface{}{
 		"job_id":         jobID,
 		"content_length": len(content),
@@ -169,11 +332,26 @@ func (s *AnalysisService) processAnalysisJob(ctx context.Context, jobID uuid.UUI
 
 	// Process with AI agents
 	startTime := time.Now()
-	results, err := s.runAnalysisAgents(ctx, content, jobID, correlationID)
+	results, err := s.runAnalysisAgents(ctx, content, options, jobID, correlationID)
 	duration := time.Since(startTime)
 	
+	if errors.Is(err, ErrAnalysisCancelled) && errors.Is(err, context.Canceled) {
+		log.WithFields(map[string]interface{}{
+			"job_id":   jobID,
+			"duration": duration,
+			"reason":   err.Error(),
+		}).Warn("Analysis cancelled between agents")
+		s.UpdateJobStatus(jobID, "cancelled", "Analysis cancelled")
+		return err
+	}
 	if err != nil {
 		errorMsg := fmt.Sprintf("Analysis processing failed after %v", duration)
+		var timeoutErr *agents.TimeoutError
+		if errors.As(err, &timeoutErr) {
+			errorMsg = fmt.Sprintf("Agent %s timed out after %v", timeoutErr.Agent, timeoutErr.Timeout)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			errorMsg = fmt.Sprintf("Analysis timed out after %v", duration)
+		}
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"job_id":    jobID,
 			"duration":  duration,
@@ -188,17 +366,29 @@ func (s *AnalysisService) processAnalysisJob(ctx context.Context, jobID uuid.UUI
 		"duration": duration,
 	}).Info("AI analysis completed")
 
+	if results.Metadata == nil {
+		results.Metadata = make(map[string]interface{})
+	}
+	results.Metadata["ad_filter"] = adFilterMetadata
+	if len(options.ClaimCategories) > 0 {
+		results.Metadata["claim_categories"] = options.ClaimCategories
+	}
+
 	// Save analysis results
-	analysis, err := s.saveAnalysisResults(jobID, results, correlationID)
+	analysis, err := s.saveAnalysisResults(ctx, jobID, results, correlationID)
 	if err != nil {
 		return err
 	}
 
 	// Save fact checks
-	s.saveFactChecks(analysis.ID, results.FactChecks, correlationID)
+	s.saveFactChecks(ctx, analysis.ID, results.FactChecks, correlationID)
 
 	// Mark job as completed
-	if err := s.UpdateJobStatus(jobID, "completed", ""); err != nil {
+	err = s.UpdateJobStatus(jobID, "completed", "")
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrJobOrphaned
+	}
+	if err != nil {
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"job_id":    jobID,
 			"operation": "update_job_status_completed",
@@ -208,4 +398,28 @@ func (s *AnalysisService) processAnalysisJob(ctx context.Context, jobID uuid.UUI
 
 	log.WithField("job_id", jobID).Info("Analysis complete. Results saved to database.")
 	return nil
-}
\ No newline at end of file
+}
+
+// applyAdFilter strips likely ad segments from the content when the job enables it and
+// returns the metadata describing what was removed
+func (s *AnalysisService) applyAdFilter(content string, options AnalysisOptions, jobID uuid.UUID, correlationID string) (string, map[string]interface{}) {
+	if !options.StripAds {
+		return content, map[string]interface{}{"enabled": false}
+	}
+
+	filtered, stats := stripAdSegments(content)
+
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"job_id":           jobID,
+		"segments_removed": stats.SegmentsRemoved,
+		"chars_removed":    stats.CharsRemoved,
+	}).Info("Ad filter applied")
+
+	return filtered, map[string]interface{}{
+		"enabled":            true,
+		"segments_removed":   stats.SegmentsRemoved,
+		"chars_removed":      stats.CharsRemoved,
+		"chars_total":        stats.CharsTotal,
+		"removed_timestamps": stats.RemovedTimestamps,
+	}
+}
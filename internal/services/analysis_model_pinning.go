@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"gorm.io/gorm"
+)
+
+// resolvePinnedModel returns the exact model version recorded on the analysis a job asks
+// to be pinned to, or "" when the job uses the configured model
+func (s *AnalysisService) resolvePinnedModel(req *AnalysisJobRequest, correlationID string) (string, error) {
+	if req.PinModelFrom == nil {
+		return "", nil
+	}
+
+	var previous models.AnalysisResult
+	if err := s.db.Where("id = ?", *req.PinModelFrom).First(&previous).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", utils.NewValidationError("pin_model_from", fmt.Sprintf("analysis %s not found", *req.PinModelFrom))
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"analysis_id": *req.PinModelFrom,
+			"operation":   "find_analysis_for_model_pin",
+		})
+		return "", fmt.Errorf("failed to find analysis to pin model from: %w", err)
+	}
+
+	if previous.TranscriptID != req.TranscriptID {
+		return "", utils.NewValidationError("pin_model_from", "analysis belongs to a different transcript")
+	}
+	if previous.Model == nil || *previous.Model == "" {
+		return "", utils.NewValidationError("pin_model_from", "analysis has no recorded model version")
+	}
+	if strings.Contains(*previous.Model, ",") {
+		return "", utils.NewValidationError("pin_model_from", "analysis used more than one model version")
+	}
+	return *previous.Model, nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisService_ResolvePinnedModel(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: "pinhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+
+	newAnalysis := func(model *string, transcriptID uuid.UUID) uuid.UUID {
+		analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcriptID, JobID: uuid.New(), Status: "completed", Model: model}
+		require.NoError(t, db.Create(analysis).Error)
+		return analysis.ID
+	}
+	pinned := "claude-3-5-sonnet-20241022"
+	several := "claude-3-5-sonnet-20241022,claude-3-5-sonnet-20250101"
+	recorded := newAnalysis(&pinned, transcript.ID)
+	unrecorded := newAnalysis(nil, transcript.ID)
+	mixed := newAnalysis(&several, transcript.ID)
+	otherTranscript := newAnalysis(&pinned, uuid.New())
+	missing := uuid.New()
+
+	tests := []struct {
+		name         string
+		pinModelFrom *uuid.UUID
+		expected     string
+		expectError  bool
+	}{
+		{name: "not pinned", pinModelFrom: nil, expected: ""},
+		{name: "recorded model", pinModelFrom: &recorded, expected: pinned},
+		{name: "no recorded model", pinModelFrom: &unrecorded, expectError: true},
+		{name: "several models", pinModelFrom: &mixed, expectError: true},
+		{name: "different transcript", pinModelFrom: &otherTranscript, expectError: true},
+		{name: "unknown analysis", pinModelFrom: &missing, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model, err := service.resolvePinnedModel(&AnalysisJobRequest{TranscriptID: transcript.ID, PinModelFrom: tt.pinModelFrom}, "test-correlation-id")
+			if tt.expectError {
+				var validationErrs utils.ValidationErrors
+				assert.ErrorAs(t, err, &validationErrs)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, model)
+		})
+	}
+}
+
+func TestAnalysisService_SaveAnalysisResults_RecordsModel(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: "modelhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	_, err := service.saveAnalysisResults(context.Background(), analysis.JobID, &AnalysisResults{Summary: "Summary", Model: "claude-3-5-sonnet-20241022"}, "test-correlation-id")
+	require.NoError(t, err)
+
+	response, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	require.NotNil(t, response.Model)
+	assert.Equal(t, "claude-3-5-sonnet-20241022", *response.Model)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Length caps for analysis notes
+const (
+	maxNoteBodyChars   = 5000
+	maxNoteAuthorChars = 100
+)
+
+// defaultNoteAuthor is recorded when a note is added without an author
+const defaultNoteAuthor = "anonymous"
+
+// CreateAnalysisNoteRequest adds a reviewer note to an analysis
+type CreateAnalysisNoteRequest struct {
+	Author string `json:"author"`
+	Body   string `json:"body"`
+}
+
+// AnalysisNoteResponse is one reviewer note on an analysis
+type AnalysisNoteResponse struct {
+	ID         uuid.UUID `json:"id"`
+	AnalysisID uuid.UUID `json:"analysis_id"`
+	Author     string    `json:"author"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AnalysisNotesResponse lists an analysis's notes, oldest first
+type AnalysisNotesResponse struct {
+	AnalysisID uuid.UUID              `json:"analysis_id"`
+	Notes      []AnalysisNoteResponse `json:"notes"`
+}
+
+// validate trims the note and checks its length, defaulting a missing author
+func (req *CreateAnalysisNoteRequest) validate() error {
+	req.Author = strings.TrimSpace(req.Author)
+	req.Body = strings.TrimSpace(req.Body)
+
+	var errs utils.ValidationErrors
+	if req.Body == "" {
+		errs.Add("body", "body is required")
+	} else if length := utf8.RuneCountInString(req.Body); length > maxNoteBodyChars {
+		errs.Add("body", fmt.Sprintf("body too long: %d characters. Maximum: %d", length, maxNoteBodyChars))
+	}
+	if length := utf8.RuneCountInString(req.Author); length > maxNoteAuthorChars {
+		errs.Add("author", fmt.Sprintf("author too long: %d characters. Maximum: %d", length, maxNoteAuthorChars))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	if req.Author == "" {
+		req.Author = defaultNoteAuthor
+	}
+	return nil
+}
+
+// CreateAnalysisNote adds a reviewer note to an analysis
+func (s *AnalysisService) CreateAnalysisNote(analysisID uuid.UUID, req *CreateAnalysisNoteRequest, correlationID string) (*AnalysisNoteResponse, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	if err := s.findAnalysisForNotes(analysisID, correlationID); err != nil {
+		return nil, err
+	}
+
+	note := &models.AnalysisNote{
+		AnalysisID: analysisID,
+		Author:     req.Author,
+		Body:       req.Body,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.db.Create(note).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"analysis_id": analysisID,
+			"operation":   "create_analysis_note",
+		})
+		return nil, fmt.Errorf("failed to save note: %w", err)
+	}
+
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"analysis_id": analysisID,
+		"note_id":     note.ID,
+		"author":      note.Author,
+	}).Info("Analysis note added")
+
+	response := noteResponse(*note)
+	return &response, nil
+}
+
+// ListAnalysisNotes returns an analysis's notes, oldest first
+func (s *AnalysisService) ListAnalysisNotes(analysisID uuid.UUID, correlationID string) (*AnalysisNotesResponse, error) {
+	if err := s.findAnalysisForNotes(analysisID, correlationID); err != nil {
+		return nil, err
+	}
+
+	var notes []models.AnalysisNote
+	if err := s.db.Where("analysis_id = ?", analysisID).Order("created_at ASC").Find(&notes).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"analysis_id": analysisID,
+			"operation":   "list_analysis_notes",
+		})
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+
+	response := &AnalysisNotesResponse{
+		AnalysisID: analysisID,
+		Notes:      make([]AnalysisNoteResponse, len(notes)),
+	}
+	for i, note := range notes {
+		response.Notes[i] = noteResponse(note)
+	}
+	return response, nil
+}
+
+// findAnalysisForNotes checks that the analysis a note belongs to exists
+func (s *AnalysisService) findAnalysisForNotes(analysisID uuid.UUID, correlationID string) error {
+	var analysis models.AnalysisResult
+	if err := s.db.Select("id").Where("id = ?", analysisID).First(&analysis).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("analysis not found")
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"analysis_id": analysisID,
+			"operation":   "find_analysis_for_notes",
+		})
+		return fmt.Errorf("failed to find analysis: %w", err)
+	}
+	return nil
+}
+
+// noteResponse converts a stored note to its API form
+func noteResponse(note models.AnalysisNote) AnalysisNoteResponse {
+	return AnalysisNoteResponse{
+		ID:         note.ID,
+		AnalysisID: note.AnalysisID,
+		Author:     note.Author,
+		Body:       note.Body,
+		CreatedAt:  note.CreatedAt,
+	}
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisService_AnalysisNotes(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{})
+	analysis := &models.AnalysisResult{TranscriptID: uuid.New(), Status: "completed"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	first, err := service.CreateAnalysisNote(analysis.ID, &CreateAnalysisNoteRequest{Author: " dana ", Body: " Fact check #2 looks wrong "}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, "dana", first.Author)
+	assert.Equal(t, "Fact check #2 looks wrong", first.Body)
+
+	second, err := service.CreateAnalysisNote(analysis.ID, &CreateAnalysisNoteRequest{Body: "Agreed"}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, "anonymous", second.Author)
+
+	notes, err := service.ListAnalysisNotes(analysis.ID, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, analysis.ID, notes.AnalysisID)
+	require.Len(t, notes.Notes, 2)
+	assert.Equal(t, first.ID, notes.Notes[0].ID)
+	assert.Equal(t, second.ID, notes.Notes[1].ID)
+}
+
+func TestAnalysisService_ListAnalysisNotes_Empty(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{})
+	analysis := &models.AnalysisResult{TranscriptID: uuid.New(), Status: "completed"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	notes, err := service.ListAnalysisNotes(analysis.ID, "test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, []AnalysisNoteResponse{}, notes.Notes)
+}
+
+func TestAnalysisService_CreateAnalysisNote_Validation(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, &config.Config{})
+	analysis := &models.AnalysisResult{TranscriptID: uuid.New(), Status: "completed"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	tests := []struct {
+		name  string
+		req   CreateAnalysisNoteRequest
+		field string
+	}{
+		{name: "empty body", req: CreateAnalysisNoteRequest{Body: "   "}, field: "body"},
+		{name: "body too long", req: CreateAnalysisNoteRequest{Body: strings.Repeat("é", maxNoteBodyChars+1)}, field: "body"},
+		{name: "author too long", req: CreateAnalysisNoteRequest{Author: strings.Repeat("a", maxNoteAuthorChars+1), Body: "A note"}, field: "author"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateAnalysisNote(analysis.ID, &tt.req, "test-correlation-id")
+
+			var validationErrs utils.ValidationErrors
+			require.ErrorAs(t, err, &validationErrs)
+			require.Len(t, validationErrs, 1)
+			assert.Equal(t, tt.field, validationErrs[0].Field)
+		})
+	}
+
+	// A body at the limit counts characters, not bytes
+	_, err := service.CreateAnalysisNote(analysis.ID, &CreateAnalysisNoteRequest{Body: strings.Repeat("é", maxNoteBodyChars)}, "test-correlation-id")
+	assert.NoError(t, err)
+}
+
+func TestAnalysisService_AnalysisNotes_UnknownAnalysis(t *testing.T) {
+	service := NewAnalysisService(setupTestDB(t), &config.Config{})
+
+	_, err := service.CreateAnalysisNote(uuid.New(), &CreateAnalysisNoteRequest{Body: "A note"}, "test-correlation-id")
+	assert.EqualError(t, err, "analysis not found")
+
+	_, err = service.ListAnalysisNotes(uuid.New(), "test-correlation-id")
+	assert.EqualError(t, err, "analysis not found")
+}
@@ -1,16 +1,23 @@
 package services
 
 import (
-	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
+	"podcast-analyzer/internal/agents"
 	"podcast-analyzer/internal/config"
 	"podcast-analyzer/internal/models"
 	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/metrics"
+	"podcast-analyzer/internal/utils"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 
@@ -30,6 +37,50 @@ func NewAnalysisService(db *gorm.DB, cfg *config.Config) *AnalysisService {
 // AnalysisJobRequest represents the request to start analysis
 type AnalysisJobRequest struct {
 	TranscriptID uuid.UUID `json:"transcript_id" binding:"required"`
+	StripAds     *bool     `json:"strip_ads,omitempty"` // Overrides the configured ad filter default when set
+	Instructions string    `json:"instructions,omitempty"` // Free-text guidance appended to the agents' system prompts
+	MaxTakeaways int       `json:"max_takeaways,omitempty"` // Overrides the configured maximum number of takeaways when set
+	PinModelFrom *uuid.UUID `json:"pin_model_from,omitempty"` // Re-runs with the exact model version recorded on this earlier analysis of the transcript
+	SummaryStyle string    `json:"summary_style,omitempty"` // Summary tone: prose (default), bullet_points, executive, casual or academic
+	TakeawaysSummary *bool `json:"takeaways_summary,omitempty"` // Overrides the configured takeaways synthesis default when set
+	Headline     *bool     `json:"headline,omitempty"` // Overrides the configured summary headline default when set
+	ClaimCategories []string `json:"claim_categories,omitempty"` // Only fact-check these kinds of claim, e.g. statistics and dates
+	Force        bool      `json:"force,omitempty"` // Start the job even if the transcript already has jobs in progress
+
+	cleanupTranscript bool // Remove the ephemeral transcript's content once the job finishes
+}
+
+// AnalysisOptions holds the per-job settings resolved when the job is created
+type AnalysisOptions struct {
+	StripAds     bool
+	Instructions string
+	MaxTakeaways int // Zero uses the configured maximum
+	Model        string // Exact model version to call; empty uses the configured model
+	SummaryStyle agents.SummaryStyle
+	TakeawaysSummary bool // Synthesize the takeaways into one paragraph
+	Headline     bool   // Have the summarizer write a one-line headline with the summary
+	ClaimCategories []agents.ClaimCategory // Kinds of claim the fact checker extracts; empty means any
+	CleanupTranscript bool // Remove the ephemeral transcript's content once the job finishes
+}
+
+// maxInstructionsChars caps the length of custom analysis instructions
+const maxInstructionsChars = 1000
+
+// sanitizeInstructions trims custom instructions, drops control characters other than
+// newlines and tabs, and enforces the length cap
+func sanitizeInstructions(instructions string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\t' {
+			return -1
+		}
+		return r
+	}, instructions)
+	cleaned = strings.TrimSpace(cleaned)
+
+	if length := utf8.RuneCountInString(cleaned); length > maxInstructionsChars {
+		return "", utils.NewValidationError("instructions", fmt.Sprintf("instructions too long: %d characters. Maximum: %d", length, maxInstructionsChars))
+	}
+	return cleaned, nil
 }
 
 // AnalysisJobResponse represents the job creation response
@@ -38,13 +89,14 @@ type AnalysisJobResponse struct {
 	TranscriptID uuid.UUID `json:"transcript_id"`
 	Status       string    `json:"status"`
 	Message      string    `json:"message"`
+	Ephemeral    bool      `json:"ephemeral,omitempty"` // The transcript was created from pasted text for this job
 }
 
 // JobStatusResponse represents the job status polling response
 type JobStatusResponse struct {
 	JobID        uuid.UUID  `json:"job_id"`
 	TranscriptID uuid.UUID  `json:"transcript_id"`
-	Status       string     `json:"status"` // pending, processing, completed, failed
+	Status       string     `json:"status"` // pending, processing, completed, failed, cancelled
 	CreatedAt    time.Time  `json:"created_at"`
 	CompletedAt  *time.Time `json:"completed_at,omitempty"`
 	ErrorMessage *string    `json:"error_message,omitempty"`
@@ -57,45 +109,169 @@ type AnalysisResultsResponse struct {
 	TranscriptID       uuid.UUID                `json:"transcript_id"`
 	Status             string                   `json:"status"`
 	Summary            *string                  `json:"summary,omitempty"`
+	Headline           *string                  `json:"headline,omitempty"` // One-line version of the summary, when requested
 	Takeaways          []string                 `json:"takeaways,omitempty"`
+	TakeawayTimestamps map[int]string           `json:"takeaway_timestamps,omitempty"` // Takeaway index to HH:MM:SS, for transcripts with timestamp markers
+	RankedTakeaways    []RankedTakeaway         `json:"ranked_takeaways,omitempty"`    // The takeaways with their importance, when ranking was on
+	TakeawaysSummary   *string                  `json:"takeaways_summary,omitempty"`   // One-paragraph synthesis of the takeaways, when requested
 	FactChecks         []FactCheckResultResponse `json:"fact_checks"`
+	FactChecksTotal    *int64                   `json:"fact_checks_total,omitempty"` // Fact checks matching a filter before paging; only set when filtered
+	FactCheckStatus    string                   `json:"fact_check_status,omitempty"` // completed, no_claims, skipped, degraded or failed; why fact_checks may be empty
 	CreatedAt          time.Time                `json:"created_at"`
 	CompletedAt        *time.Time               `json:"completed_at,omitempty"`
 	TranscriptFilename *string                  `json:"transcript_filename,omitempty"`
 	TranscriptTitle    *string                  `json:"transcript_title,omitempty"`
+	Instructions       *string                  `json:"instructions,omitempty"`
+	SummaryStyle       string                   `json:"summary_style,omitempty"`
+	Model              *string                  `json:"model,omitempty"` // Exact model version(s) used, for reproducing the analysis
+	Truncated          bool                     `json:"truncated"`       // An agent's output was cut off at the token cap; metadata.truncated_agents names which
+	Metadata           map[string]interface{}   `json:"metadata,omitempty"`
 }
 
 // FactCheckResultResponse represents individual fact-check results
 type FactCheckResultResponse struct {
 	ID         uuid.UUID `json:"id"`
 	Claim      string    `json:"claim"`
-	Verdict    string    `json:"verdict"`
+	Verdict    models.Verdict `json:"verdict"`
 	Confidence float64   `json:"confidence"`
 	Evidence   *string   `json:"evidence,omitempty"`
 	Sources    []string  `json:"sources,omitempty"`
 	CheckedAt  time.Time `json:"checked_at"`
+	Cached     bool      `json:"cached"`
+	Timestamp  string    `json:"timestamp,omitempty"` // Where the claim appears, for transcripts with timestamp markers
+	LowSourceQuality bool `json:"low_source_quality,omitempty"` // Only blocked domains were found, so they were used anyway
+	OriginalVerdict models.Verdict `json:"original_verdict,omitempty"` // Verdict before a low confidence downgraded it to unverifiable
+	SearchQuery string `json:"search_query,omitempty"` // Query sent to Serper for this claim, for debugging its verdict
+}
+
+// FactCheckDetailResponse is a single fact check with the analysis and transcript it belongs to
+type FactCheckDetailResponse struct {
+	FactCheckResultResponse
+	AnalysisID   uuid.UUID `json:"analysis_id"`
+	TranscriptID uuid.UUID `json:"transcript_id"`
+}
+
+// newFactCheckResultResponse converts a stored fact check to its response format
+func newFactCheckResultResponse(fc models.FactCheck) FactCheckResultResponse {
+	stored := decodeStoredSources(fc.Sources)
+
+	return FactCheckResultResponse{
+		ID:               fc.ID,
+		Claim:            fc.Claim,
+		Verdict:          fc.Verdict,
+		Confidence:       fc.Confidence,
+		Evidence:         fc.Evidence,
+		Sources:          stored.Sources,
+		CheckedAt:        fc.CheckedAt,
+		Cached:           fc.Cached,
+		Timestamp:        stored.Timestamp,
+		LowSourceQuality: stored.LowSourceQuality,
+		OriginalVerdict:  stored.OriginalVerdict,
+		SearchQuery:      fc.SearchQuery,
+	}
+}
+
+// storedTakeaways is the {"takeaways": [...], ...} object the analysis pipeline writes to
+// an analysis's takeaways column
+type storedTakeaways struct {
+	Takeaways  []string         `json:"takeaways"`
+	Timestamps map[int]string   `json:"timestamps"`
+	Ranked     []RankedTakeaway `json:"ranked"`
+}
+
+// decodeStoredTakeaways reads a takeaways column, which holds either a plain list or a
+// storedTakeaways object
+func decodeStoredTakeaways(raw []byte) storedTakeaways {
+	var stored storedTakeaways
+	if len(raw) == 0 {
+		return stored
+	}
+
+	if err := json.Unmarshal(raw, &stored.Takeaways); err == nil {
+		return stored
+	}
+
+	json.Unmarshal(raw, &stored)
+	return stored
+}
+
+// storedSources is the {"sources": [...], ...} object the analysis pipeline writes to a
+// fact check's sources column
+type storedSources struct {
+	Sources          []string `json:"sources"`
+	Timestamp        string   `json:"timestamp"`
+	LowSourceQuality bool     `json:"low_source_quality"`
+	OriginalVerdict  models.Verdict `json:"original_verdict"`
+}
+
+// decodeStoredSources reads a fact check's sources column, which holds either a plain list
+// or a storedSources object
+func decodeStoredSources(raw []byte) storedSources {
+	var stored storedSources
+	if len(raw) == 0 {
+		return stored
+	}
+
+	if err := json.Unmarshal(raw, &stored.Sources); err == nil {
+		return stored
+	}
+
+	json.Unmarshal(raw, &stored)
+	return stored
+}
+
+// QueueStats summarizes the analysis job backlog
+type QueueStats struct {
+	Pending                 int64      `json:"pending"`
+	Processing              int64      `json:"processing"`
+	OldestPendingAt         *time.Time `json:"oldest_pending_at,omitempty"`
+	OldestPendingAgeSeconds float64    `json:"oldest_pending_age_seconds"`
 }
 
 // AnalysisResults represents the results from AI agents
 type AnalysisResults struct {
 	Summary    string                 `json:"summary"`
+	Headline   string                 `json:"headline,omitempty"` // One-line version of the summary, when requested
 	Takeaways  map[string]interface{} `json:"takeaways"`
+	TakeawaysSummary string           `json:"takeaways_summary,omitempty"` // One-paragraph synthesis of the takeaways, when requested
 	FactChecks []FactCheckResult      `json:"fact_checks"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Model      string                 `json:"model,omitempty"` // Model versions the agents' calls resolved to
+	Truncated  bool                   `json:"truncated,omitempty"` // An agent's output was cut off at the token cap
+	FactCheckStatus string            `json:"fact_check_status,omitempty"` // How the fact-check stage ended
 }
 
 // FactCheckResult represents individual fact-check results
 type FactCheckResult struct {
 	Claim      string                 `json:"claim"`
-	Verdict    string                 `json:"verdict"`
+	Verdict    models.Verdict         `json:"verdict"`
 	Confidence float64                `json:"confidence"`
 	Evidence   string                 `json:"evidence"`
 	Sources    map[string]interface{} `json:"sources"`
+	Cached     bool                   `json:"cached"`
+	SearchQuery string                `json:"search_query,omitempty"`
 }
 
 // CreateAnalysisJob creates a new analysis job
 func (s *AnalysisService) CreateAnalysisJob(req *AnalysisJobRequest, correlationID string) (*AnalysisJobResponse, error) {
 	log := logger.WithCorrelationID(correlationID)
 
+	instructions, err := sanitizeInstructions(req.Instructions)
+	if err != nil {
+		return nil, err
+	}
+	if req.MaxTakeaways < 0 {
+		return nil, utils.NewValidationError("max_takeaways", fmt.Sprintf("invalid max_takeaways: %d. Must be positive", req.MaxTakeaways))
+	}
+	summaryStyle, err := agents.ParseSummaryStyle(req.SummaryStyle)
+	if err != nil {
+		return nil, utils.NewValidationError("summary_style", fmt.Sprintf("%s. Must be one of: %s", err, joinSummaryStyles()))
+	}
+	claimCategories, err := agents.ParseClaimCategories(req.ClaimCategories)
+	if err != nil {
+		return nil, utils.NewValidationError("claim_categories", fmt.Sprintf("%s. Must be any of: %s", err, joinClaimCategories()))
+	}
+
 	// Verify transcript exists
 	var transcript models.Transcript
 	if err := s.db.Where("id = ?", req.TranscriptID).First(&transcript).Error; err != nil {
@@ -110,27 +286,41 @@ func (s *AnalysisService) CreateAnalysisJob(req *AnalysisJobRequest, correlation
 		return nil, fmt.Errorf("failed to find transcript: %w", err)
 	}
 
+	pinnedModel, err := s.resolvePinnedModel(req, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	options := AnalysisOptions{StripAds: s.config.AdFilterEnabled, Instructions: instructions, MaxTakeaways: req.MaxTakeaways, Model: pinnedModel, SummaryStyle: summaryStyle, TakeawaysSummary: s.config.EnableTakeawaysSummary, Headline: s.config.EnableSummaryHeadline, ClaimCategories: claimCategories, CleanupTranscript: req.cleanupTranscript}
+	if req.StripAds != nil {
+		options.StripAds = *req.StripAds
+	}
+	if req.TakeawaysSummary != nil {
+		options.TakeawaysSummary = *req.TakeawaysSummary
+	}
+	if req.Headline != nil {
+		options.Headline = *req.Headline
+	}
+
 	// Create analysis record
 	analysis := &models.AnalysisResult{
 		TranscriptID: req.TranscriptID,
 		JobID:        uuid.New(),
 		Status:       "pending",
+		SummaryStyle: string(summaryStyle),
+		JobOptions:   encodeJobOptions(options),
+	}
+	if instructions != "" {
+		analysis.Instructions = &instructions
 	}
 
-	if err := s.db.Create(analysis).Error; err != nil {
-		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
-			"transcript_id": req.TranscriptID,
-			"job_id":        analysis.JobID,
-			"operation":     "create_analysis_job",
-		})
-		return nil, fmt.Errorf("failed to create analysis job: %w", err)
+	if err := s.createJobRecord(analysis, req.Force, correlationID); err != nil {
+		return nil, err
 	}
+	s.recordJobEvent(analysis.JobID, analysis.Status, "", "Job queued")
 
 	// Launch background processing directly
-	go func() {
-		ctx := context.Background()
-		s.processAnalysisJob(ctx, analysis.JobID, analysis.TranscriptID, correlationID)
-	}()
+	s.startAnalysisJob(analysis, options, correlationID)
 
 	log.WithFields(map[string]interface{}{
 		"job_id":        analysis.JobID,
@@ -146,14 +336,98 @@ func (s *AnalysisService) CreateAnalysisJob(req *AnalysisJobRequest, correlation
 	}, nil
 }
 
+// ErrJobsInProgress is returned when a transcript already has as many jobs in progress as
+// are allowed at once
+var ErrJobsInProgress = errors.New("transcript already has analysis jobs in progress")
+
+// createJobRecord inserts a new job's analysis record. Unless forced, the jobs-in-progress
+// limit is checked in the same transaction, with the transcript row locked, so concurrent
+// requests for one transcript cannot all pass the check before any of them is inserted.
+func (s *AnalysisService) createJobRecord(analysis *models.AnalysisResult, force bool, correlationID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if !force {
+			if err := s.checkJobsInProgress(tx, analysis.TranscriptID, correlationID); err != nil {
+				if errors.Is(err, ErrJobsInProgress) {
+					return fmt.Errorf("%w; set force to start another", err)
+				}
+				return err
+			}
+		}
+
+		if err := tx.Create(analysis).Error; err != nil {
+			logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+				"transcript_id": analysis.TranscriptID,
+				"job_id":        analysis.JobID,
+				"operation":     "create_analysis_job",
+			})
+			return fmt.Errorf("failed to create analysis job: %w", err)
+		}
+		return nil
+	})
+}
+
+// checkJobsInProgress rejects a new or retried job when the transcript already has the
+// configured maximum of pending or processing jobs, which usually means the analysis was
+// requested twice. The transcript row is locked first, so concurrent requests for one
+// transcript are checked one at a time.
+func (s *AnalysisService) checkJobsInProgress(tx *gorm.DB, transcriptID uuid.UUID, correlationID string) error {
+	limit := s.config.MaxConcurrentJobsPerTranscript
+	if limit <= 0 {
+		return nil
+	}
+
+	var transcript models.Transcript
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", transcriptID).First(&transcript).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": transcriptID,
+			"operation":     "lock_transcript_for_analysis",
+		})
+		return fmt.Errorf("failed to lock transcript: %w", err)
+	}
+
+	var active int64
+	if err := tx.Model(&models.AnalysisResult{}).
+		Where("transcript_id = ? AND status IN ?", transcriptID, []string{"pending", "processing"}).
+		Count(&active).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": transcriptID,
+			"operation":     "count_jobs_in_progress",
+		})
+		return fmt.Errorf("failed to check jobs in progress: %w", err)
+	}
+	if active >= int64(limit) {
+		logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+			"transcript_id": transcriptID,
+			"active_jobs":   active,
+			"limit":         limit,
+		}).Warn("Rejected analysis job, transcript already has jobs in progress")
+		return fmt.Errorf("%w: %d pending or processing", ErrJobsInProgress, active)
+	}
+	return nil
+}
+
 // GetJobStatus returns the status of an analysis job
 func (s *AnalysisService) GetJobStatus(jobID uuid.UUID, correlationID string) (*JobStatusResponse, error) {
-	log := logger.WithCorrelationID(correlationID)
+	status, err := s.findJobStatus(jobID, correlationID)
+	if err != nil {
+		return nil, err
+	}
 
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"job_id": jobID,
+		"status": status.Status,
+	}).Info("Retrieved job status")
+
+	return status, nil
+}
+
+// findJobStatus reads a job's status without logging successful reads, so it can be
+// polled
+func (s *AnalysisService) findJobStatus(jobID uuid.UUID, correlationID string) (*JobStatusResponse, error) {
 	var analysis models.AnalysisResult
 	if err := s.db.Where("job_id = ?", jobID).First(&analysis).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			log.WithField("job_id", jobID).Error("Analysis job not found")
+			logger.WithCorrelationID(correlationID).WithField("job_id", jobID).Error("Analysis job not found")
 			return nil, fmt.Errorf("analysis job %s not found", jobID)
 		}
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
@@ -163,12 +437,6 @@ func (s *AnalysisService) GetJobStatus(jobID uuid.UUID, correlationID string) (*
 		return nil, fmt.Errorf("failed to get job status: %w", err)
 	}
 
-	log.WithFields(map[string]interface{}{
-		"job_id":      jobID,
-		"status":      analysis.Status,
-		"analysis_id": analysis.ID,
-	}).Info("Retrieved job status")
-
 	return &JobStatusResponse{
 		JobID:        analysis.JobID,
 		TranscriptID: analysis.TranscriptID,
@@ -179,8 +447,63 @@ func (s *AnalysisService) GetJobStatus(jobID uuid.UUID, correlationID string) (*
 	}, nil
 }
 
-// GetAnalysisResults returns complete analysis results
-func (s *AnalysisService) GetAnalysisResults(analysisID uuid.UUID, correlationID string) (*AnalysisResultsResponse, error) {
+// ListJobs returns a page of analysis jobs across all transcripts, newest first, optionally
+// only those in one status
+func (s *AnalysisService) ListJobs(page, perPage int, status string, dateRange DateRange) ([]*JobStatusResponse, int64, error) {
+	if _, known := jobStatusTransitions[status]; status != "" && !known {
+		return nil, 0, utils.NewValidationError("status", "status must be one of pending, processing, completed, failed, cancelled")
+	}
+
+	// Built fresh for each query, since a gorm chain should not be reused after Count
+	jobsQuery := func() *gorm.DB {
+		query := dateRange.apply(s.db.Model(&models.AnalysisResult{}), "created_at")
+		if status != "" {
+			query = query.Where("status = ?", status)
+		}
+		return query
+	}
+
+	var total int64
+	if err := jobsQuery().Count(&total).Error; err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation": "count_jobs",
+			"status":    status,
+		})
+		return nil, 0, fmt.Errorf("failed to count jobs: %w", err)
+	}
+
+	var analyses []models.AnalysisResult
+	if err := jobsQuery().Select("job_id", "transcript_id", "status", "created_at", "completed_at", "error_message").
+		Order("created_at DESC").
+		Offset((page - 1) * perPage).
+		Limit(perPage).
+		Find(&analyses).Error; err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation": "list_jobs",
+			"status":    status,
+			"page":      page,
+			"per_page":  perPage,
+		})
+		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	jobs := make([]*JobStatusResponse, len(analyses))
+	for i, analysis := range analyses {
+		jobs[i] = &JobStatusResponse{
+			JobID:        analysis.JobID,
+			TranscriptID: analysis.TranscriptID,
+			Status:       analysis.Status,
+			CreatedAt:    analysis.CreatedAt,
+			CompletedAt:  analysis.CompletedAt,
+			ErrorMessage: analysis.ErrorMessage,
+		}
+	}
+	return jobs, total, nil
+}
+
+// GetAnalysisResults returns complete analysis results. The embedded fact checks are
+// narrowed and paged by filter in the query; the zero filter returns all of them.
+func (s *AnalysisService) GetAnalysisResults(analysisID uuid.UUID, filter FactCheckFilter, correlationID string) (*AnalysisResultsResponse, error) {
 	log := logger.WithCorrelationID(correlationID)
 
 	// Join with transcript to get filename and metadata
@@ -209,8 +532,23 @@ func (s *AnalysisService) GetAnalysisResults(analysisID uuid.UUID, correlationID
 	}
 
 	// Load fact checks
+	factCheckQuery := filter.apply(s.db.Model(&models.FactCheck{}).Where("analysis_id = ?", analysisID)).Session(&gorm.Session{})
+
+	var factChecksTotal *int64
+	if !filter.IsZero() {
+		var total int64
+		if err := factCheckQuery.Count(&total).Error; err != nil {
+			logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+				"analysis_id": analysisID,
+				"operation":   "count_fact_checks",
+			})
+			return nil, fmt.Errorf("failed to count fact checks: %w", err)
+		}
+		factChecksTotal = &total
+	}
+
 	var factChecks []models.FactCheck
-	if err := s.db.Where("analysis_id = ?", analysisID).Find(&factChecks).Error; err != nil {
+	if err := filter.page(factCheckQuery).Find(&factChecks).Error; err != nil {
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"analysis_id": analysisID,
 			"operation":   "load_fact_checks",
@@ -221,26 +559,15 @@ func (s *AnalysisService) GetAnalysisResults(analysisID uuid.UUID, correlationID
 	// Convert fact checks to response format
 	factCheckResponses := make([]FactCheckResultResponse, len(factChecks))
 	for i, fc := range factChecks {
-		var sources []string
-		if fc.Sources != nil {
-			json.Unmarshal(fc.Sources, &sources)
-		}
-		
-		factCheckResponses[i] = FactCheckResultResponse{
-			ID:         fc.ID,
-			Claim:      fc.Claim,
-			Verdict:    fc.Verdict,
-			Confidence: fc.Confidence,
-			Evidence:   fc.Evidence,
-			Sources:    sources,
-			CheckedAt:  fc.CheckedAt,
-		}
+		factCheckResponses[i] = newFactCheckResultResponse(fc)
 	}
 
 	// Convert takeaways from JSON
-	var takeaways []string
-	if analysis.Takeaways != nil {
-		json.Unmarshal(analysis.Takeaways, &takeaways)
+	takeaways := decodeStoredTakeaways(analysis.Takeaways)
+
+	var analysisMetadata map[string]interface{}
+	if analysis.AnalysisMetadata != nil {
+		json.Unmarshal(analysis.AnalysisMetadata, &analysisMetadata)
 	}
 
 	// Extract title from transcript metadata if available
@@ -266,17 +593,62 @@ func (s *AnalysisService) GetAnalysisResults(analysisID uuid.UUID, correlationID
 		TranscriptID:       analysis.TranscriptID,
 		Status:             analysis.Status,
 		Summary:            analysis.Summary,
-		Takeaways:          takeaways,
+		Headline:           analysis.Headline,
+		Takeaways:          takeaways.Takeaways,
+		TakeawayTimestamps: takeaways.Timestamps,
+		RankedTakeaways:    takeaways.Ranked,
+		TakeawaysSummary:   analysis.TakeawaysSummary,
 		FactChecks:         factCheckResponses,
+		FactChecksTotal:    factChecksTotal,
 		CreatedAt:          analysis.CreatedAt,
 		CompletedAt:        analysis.CompletedAt,
 		TranscriptFilename: &transcript.Filename,
 		TranscriptTitle:    transcriptTitle,
+		Instructions:       analysis.Instructions,
+		SummaryStyle:       analysis.SummaryStyle,
+		Model:              analysis.Model,
+		Truncated:          analysis.Truncated,
+		FactCheckStatus:    analysis.FactCheckStatus,
+		Metadata:           analysisMetadata,
+	}, nil
+}
+
+// GetFactCheck returns a single fact check by ID, for linking to one claim directly
+func (s *AnalysisService) GetFactCheck(factCheckID uuid.UUID, correlationID string) (*FactCheckDetailResponse, error) {
+	log := logger.WithCorrelationID(correlationID)
+
+	var factCheck models.FactCheck
+	if err := s.db.Where("id = ?", factCheckID).First(&factCheck).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.WithField("fact_check_id", factCheckID).Error("Fact check not found")
+			return nil, fmt.Errorf("fact check %s not found", factCheckID)
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"fact_check_id": factCheckID,
+			"operation":     "get_fact_check",
+		})
+		return nil, fmt.Errorf("failed to get fact check: %w", err)
+	}
+
+	var analysis models.AnalysisResult
+	if err := s.db.Select("id", "transcript_id").Where("id = ?", factCheck.AnalysisID).First(&analysis).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"fact_check_id": factCheckID,
+			"analysis_id":   factCheck.AnalysisID,
+			"operation":     "get_analysis_for_fact_check",
+		})
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
+	}
+
+	return &FactCheckDetailResponse{
+		FactCheckResultResponse: newFactCheckResultResponse(factCheck),
+		AnalysisID:              analysis.ID,
+		TranscriptID:            analysis.TranscriptID,
 	}, nil
 }
 
-// ListAnalysisResults returns paginated list of analysis results
-func (s *AnalysisService) ListAnalysisResults(page, perPage int) ([]*AnalysisResultsResponse, int64, error) {
+// ListAnalysisResults returns paginated list of analysis results created within the given date range
+func (s *AnalysisService) ListAnalysisResults(page, perPage int, dateRange DateRange) ([]*AnalysisResultsResponse, int64, error) {
 	var results []struct {
 		models.AnalysisResult
 		TranscriptFilename string `json:"transcript_filename"`
@@ -286,7 +658,7 @@ func (s *AnalysisService) ListAnalysisResults(page, perPage int) ([]*AnalysisRes
 	offset := (page - 1) * perPage
 
 	// Count total
-	if err := s.db.Model(&models.AnalysisResult{}).Count(&total).Error; err != nil {
+	if err := dateRange.apply(s.db.Model(&models.AnalysisResult{}), "created_at").Count(&total).Error; err != nil {
 		logger.LogErrorWithStack(err, map[string]interface{}{
 			"operation": "count_analysis_results",
 			"page":      page,
@@ -296,8 +668,7 @@ func (s *AnalysisService) ListAnalysisResults(page, perPage int) ([]*AnalysisRes
 	}
 
 	// Get results with transcript filename
-	if err := s.db.
-		Table("analysis_results").
+	if err := dateRange.apply(s.db.Table("analysis_results"), "analysis_results.created_at").
 		Select("analysis_results.*, transcripts.filename as transcript_filename").
 		Joins("JOIN transcripts ON analysis_results.transcript_id = transcripts.id").
 		Order("analysis_results.created_at DESC").
@@ -313,36 +684,32 @@ func (s *AnalysisService) ListAnalysisResults(page, perPage int) ([]*AnalysisRes
 		return nil, 0, fmt.Errorf("failed to get analysis results: %w", err)
 	}
 
+	// Load the fact checks for the whole page in one query
+	analysisIDs := make([]uuid.UUID, len(results))
+	for i, result := range results {
+		analysisIDs[i] = result.ID
+	}
+	factChecksByAnalysis, err := s.loadFactChecksByAnalysis(analysisIDs)
+	if err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation": "get_analysis_results_list_fact_checks",
+			"page":      page,
+			"per_page":  perPage,
+		})
+		return nil, 0, fmt.Errorf("failed to get fact checks: %w", err)
+	}
+
 	// Convert to response format
 	responses := make([]*AnalysisResultsResponse, len(results))
 	for i, result := range results {
-		// Load fact checks for this analysis
-		var factChecks []models.FactCheck
-		s.db.Where("analysis_id = ?", result.ID).Find(&factChecks)
-
+		factChecks := factChecksByAnalysis[result.ID]
 		factCheckResponses := make([]FactCheckResultResponse, len(factChecks))
 		for j, fc := range factChecks {
-			var sources []string
-			if fc.Sources != nil {
-				json.Unmarshal(fc.Sources, &sources)
-			}
-			
-			factCheckResponses[j] = FactCheckResultResponse{
-				ID:         fc.ID,
-				Claim:      fc.Claim,
-				Verdict:    fc.Verdict,
-				Confidence: fc.Confidence,
-				Evidence:   fc.Evidence,
-				Sources:    sources,
-				CheckedAt:  fc.CheckedAt,
-			}
+			factCheckResponses[j] = newFactCheckResultResponse(fc)
 		}
 
 		// Convert takeaways from JSON
-		var takeaways []string
-		if result.Takeaways != nil {
-			json.Unmarshal(result.Takeaways, &takeaways)
-		}
+		takeaways := decodeStoredTakeaways(result.Takeaways)
 
 		responses[i] = &AnalysisResultsResponse{
 			ID:                 result.ID,
@@ -350,46 +717,129 @@ func (s *AnalysisService) ListAnalysisResults(page, perPage int) ([]*AnalysisRes
 			TranscriptID:       result.TranscriptID,
 			Status:             result.Status,
 			Summary:            result.Summary,
-			Takeaways:          takeaways,
+			Headline:           result.Headline,
+			Takeaways:          takeaways.Takeaways,
+			TakeawayTimestamps: takeaways.Timestamps,
+			RankedTakeaways:    takeaways.Ranked,
+			TakeawaysSummary:   result.TakeawaysSummary,
 			FactChecks:         factCheckResponses,
 			CreatedAt:          result.CreatedAt,
 			CompletedAt:        result.CompletedAt,
 			TranscriptFilename: &result.TranscriptFilename,
+			SummaryStyle:       result.SummaryStyle,
+			Model:              result.Model,
+			Truncated:          result.Truncated,
+			FactCheckStatus:    result.FactCheckStatus,
 		}
 	}
 
 	return responses, total, nil
 }
 
-// UpdateJobStatus updates the status of an analysis job (matches Python def update_job_status)
+// loadFactChecksByAnalysis fetches the fact checks of several analyses in a single query,
+// grouped by analysis ID
+func (s *AnalysisService) loadFactChecksByAnalysis(analysisIDs []uuid.UUID) (map[uuid.UUID][]models.FactCheck, error) {
+	grouped := make(map[uuid.UUID][]models.FactCheck, len(analysisIDs))
+	if len(analysisIDs) == 0 {
+		return grouped, nil
+	}
+
+	var factChecks []models.FactCheck
+	if err := s.db.Where("analysis_id IN ?", analysisIDs).Find(&factChecks).Error; err != nil {
+		return nil, err
+	}
+	for _, fc := range factChecks {
+		grouped[fc.AnalysisID] = append(grouped[fc.AnalysisID], fc)
+	}
+	return grouped, nil
+}
+
+// ErrInvalidStatusTransition is returned when a job status change is not allowed
+var ErrInvalidStatusTransition = errors.New("invalid job status transition")
+
+// jobStatusTransitions lists the statuses each job status may move to; terminal statuses have none
+var jobStatusTransitions = map[string][]string{
+	"pending":    {"processing", "failed", "cancelled"},
+	"processing": {"completed", "failed", "cancelled"},
+	"completed":  {},
+	"failed":     {},
+	"cancelled":  {},
+}
+
+// isTerminalJobStatus reports whether a job in this status can no longer change
+func isTerminalJobStatus(status string) bool {
+	return status == "completed" || status == "failed" || status == "cancelled"
+}
+
+// canTransitionJobStatus reports whether a job may move from one status to another
+func canTransitionJobStatus(from, to string) bool {
+	for _, allowed := range jobStatusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateJobStatus moves a job to a new status, enforcing the job state machine.
+// Repeating the current status is a no-op; illegal transitions are rejected with ErrInvalidStatusTransition.
 func (s *AnalysisService) UpdateJobStatus(jobID uuid.UUID, status string, errorMessage string) error {
 	var analysis models.AnalysisResult
 	if err := s.db.Where("job_id = ?", jobID).First(&analysis).Error; err != nil {
-		logger.LogErrorWithStack(err, map[string]interface{}{
-			"job_id":    jobID,
-			"operation": "find_job_for_status_update",
-		})
+		// A missing job is left to the caller, which may expect it (e.g. an orphaned job)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.LogErrorWithStack(err, map[string]interface{}{
+				"job_id":    jobID,
+				"operation": "find_job_for_status_update",
+			})
+		}
 		return err
 	}
 
-	analysis.Status = status
+	if analysis.Status == status {
+		return nil
+	}
+
+	if !canTransitionJobStatus(analysis.Status, status) {
+		logger.Log.WithFields(map[string]interface{}{
+			"job_id":         jobID,
+			"current_status": analysis.Status,
+			"status":         status,
+		}).Warn("Rejected illegal job status transition")
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, analysis.Status, status)
+	}
+
+	updates := map[string]interface{}{"status": status}
 	if errorMessage != "" {
-		analysis.ErrorMessage = &errorMessage
+		updates["error_message"] = errorMessage
 	}
-	if status == "completed" || status == "failed" {
-		now := time.Now()
-		analysis.CompletedAt = &now
+	if isTerminalJobStatus(status) {
+		updates["completed_at"] = time.Now()
 	}
 
-	if err := s.db.Save(&analysis).Error; err != nil {
-		logger.LogErrorWithStack(err, map[string]interface{}{
+	// Only apply the update if no one else changed the status since it was read
+	result := s.db.Model(&models.AnalysisResult{}).
+		Where("id = ? AND status = ?", analysis.ID, analysis.Status).
+		Updates(updates)
+	if result.Error != nil {
+		logger.LogErrorWithStack(result.Error, map[string]interface{}{
 			"job_id":      jobID,
 			"analysis_id": analysis.ID,
 			"status":      status,
 			"operation":   "save_job_status_update",
 		})
-		return err
+		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		logger.Log.WithFields(map[string]interface{}{
+			"job_id":          jobID,
+			"expected_status": analysis.Status,
+			"status":          status,
+		}).Warn("Job status changed concurrently, update skipped")
+		return fmt.Errorf("%w: %s changed concurrently", ErrInvalidStatusTransition, analysis.Status)
+	}
+
+	s.recordJobEvent(jobID, status, "", errorMessage)
 
 	logger.Log.WithFields(map[string]interface{}{
 		"job_id": jobID,
@@ -400,3 +850,58 @@ func (s *AnalysisService) UpdateJobStatus(jobID uuid.UUID, status string, errorM
 	return nil
 }
 
+// GetQueueStats counts pending and processing jobs, finds the oldest pending job,
+// and publishes the numbers as queue gauges
+func (s *AnalysisService) GetQueueStats() (*QueueStats, error) {
+	stats := &QueueStats{}
+
+	if err := s.db.Model(&models.AnalysisResult{}).Where("status = ?", "pending").Count(&stats.Pending).Error; err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation": "count_pending_jobs",
+		})
+		return nil, fmt.Errorf("failed to count pending jobs: %w", err)
+	}
+
+	if err := s.db.Model(&models.AnalysisResult{}).Where("status = ?", "processing").Count(&stats.Processing).Error; err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation": "count_processing_jobs",
+		})
+		return nil, fmt.Errorf("failed to count processing jobs: %w", err)
+	}
+
+	if stats.Pending > 0 {
+		var oldest models.AnalysisResult
+		if err := s.db.Where("status = ?", "pending").Order("created_at ASC").First(&oldest).Error; err != nil {
+			logger.LogErrorWithStack(err, map[string]interface{}{
+				"operation": "find_oldest_pending_job",
+			})
+			return nil, fmt.Errorf("failed to find oldest pending job: %w", err)
+		}
+		stats.OldestPendingAt = &oldest.CreatedAt
+		stats.OldestPendingAgeSeconds = time.Since(oldest.CreatedAt).Seconds()
+	}
+
+	metrics.SetGauge("analysis_queue_pending", float64(stats.Pending))
+	metrics.SetGauge("analysis_queue_processing", float64(stats.Processing))
+	metrics.SetGauge("analysis_queue_oldest_pending_age_seconds", stats.OldestPendingAgeSeconds)
+
+	return stats, nil
+}
+
+// joinSummaryStyles lists the supported summary styles for validation messages
+func joinSummaryStyles() string {
+	styles := make([]string, len(agents.SummaryStyles))
+	for i, style := range agents.SummaryStyles {
+		styles[i] = string(style)
+	}
+	return strings.Join(styles, ", ")
+}
+
+// joinClaimCategories lists the supported claim categories for validation messages
+func joinClaimCategories() string {
+	categories := make([]string, len(agents.ClaimCategories))
+	for i, category := range agents.ClaimCategories {
+		categories[i] = string(category)
+	}
+	return strings.Join(categories, ", ")
+}
@@ -1,8 +1,16 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
 	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/metrics"
 	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -67,6 +75,97 @@ func TestAnalysisService_CreateAnalysisJob(t *testing.T) {
 	// Note: Processing now happens in background goroutine
 }
 
+func TestAnalysisService_CreateAnalysisJob_Instructions(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "test.txt", ContentHash: "instructionshash", FilePath: "/tmp/missing.txt", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+
+	resp, err := service.CreateAnalysisJob(&AnalysisJobRequest{
+		TranscriptID: transcript.ID,
+		Instructions: "  Focus on financial claims.\x00\x07  ",
+	}, "test-correlation-id")
+	require.NoError(t, err)
+
+	var analysis models.AnalysisResult
+	require.NoError(t, db.Where("job_id = ?", resp.JobID).First(&analysis).Error)
+	require.NotNil(t, analysis.Instructions)
+	assert.Equal(t, "Focus on financial claims.", *analysis.Instructions)
+
+	_, err = service.CreateAnalysisJob(&AnalysisJobRequest{
+		TranscriptID: transcript.ID,
+		Instructions: strings.Repeat("a", maxInstructionsChars+1),
+	}, "test-correlation-id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "instructions too long")
+
+	_, err = service.CreateAnalysisJob(&AnalysisJobRequest{
+		TranscriptID: transcript.ID,
+		MaxTakeaways: -1,
+	}, "test-correlation-id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid max_takeaways")
+}
+
+func TestAnalysisService_CreateAnalysisJob_SummaryStyle(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "test.txt", ContentHash: "stylehash", FilePath: "/tmp/missing.txt", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+
+	tests := []struct {
+		name     string
+		style    string
+		expected string
+	}{
+		{name: "default", style: "", expected: "prose"},
+		{name: "normalized", style: "Bullet Points", expected: "bullet_points"},
+		{name: "executive", style: "executive", expected: "executive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.CreateAnalysisJob(&AnalysisJobRequest{TranscriptID: transcript.ID, SummaryStyle: tt.style}, "test-correlation-id")
+			require.NoError(t, err)
+
+			var analysis models.AnalysisResult
+			require.NoError(t, db.Where("job_id = ?", resp.JobID).First(&analysis).Error)
+			assert.Equal(t, tt.expected, analysis.SummaryStyle)
+		})
+	}
+
+	_, err := service.CreateAnalysisJob(&AnalysisJobRequest{TranscriptID: transcript.ID, SummaryStyle: "poetic"}, "test-correlation-id")
+	var validationErrs utils.ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	assert.Equal(t, "summary_style", validationErrs[0].Field)
+}
+
+func TestAnalysisService_CreateAnalysisJob_ClaimCategories(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "test.txt", ContentHash: "categoryhash", FilePath: "/tmp/missing.txt", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+
+	_, err := service.CreateAnalysisJob(&AnalysisJobRequest{TranscriptID: transcript.ID, ClaimCategories: []string{"statistics", "rumours"}}, "test-correlation-id")
+	var validationErrs utils.ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	assert.Equal(t, "claim_categories", validationErrs[0].Field)
+	assert.Contains(t, validationErrs[0].Message, "statistics, dates, scientific")
+}
+
+func TestSanitizeInstructions(t *testing.T) {
+	cleaned, err := sanitizeInstructions("\tLine one\nLine two\r\x1b[31m ")
+	require.NoError(t, err)
+	assert.Equal(t, "Line one\nLine two[31m", cleaned)
+
+	cleaned, err = sanitizeInstructions("")
+	require.NoError(t, err)
+	assert.Empty(t, cleaned)
+}
+
 func TestAnalysisService_CreateAnalysisJob_TranscriptNotFound(t *testing.T) {
 	db := setupAnalysisTestDB(t)
 	cfg := setupAnalysisTestConfig(t)
@@ -127,6 +226,86 @@ func TestAnalysisService_CreateAnalysisJob_DuplicatePrevention(t *testing.T) {
 	// Note: Processing now happens in background goroutine
 }
 
+func TestAnalysisService_CreateAnalysisJob_JobsInProgress(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	cfg.MaxConcurrentJobsPerTranscript = 1
+	service := NewAnalysisService(db, cfg)
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "test.txt", ContentHash: "testhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	running := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing", CreatedAt: time.Now()}
+	require.NoError(t, db.Create(running).Error)
+
+	resp, err := service.CreateAnalysisJob(&AnalysisJobRequest{TranscriptID: transcript.ID}, "test-correlation-id")
+	assert.ErrorIs(t, err, ErrJobsInProgress)
+	assert.Nil(t, resp)
+
+	var count int64
+	require.NoError(t, db.Model(&models.AnalysisResult{}).Where("transcript_id = ?", transcript.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+
+	// Forcing bypasses the guard
+	resp, err = service.CreateAnalysisJob(&AnalysisJobRequest{TranscriptID: transcript.ID, Force: true}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.NotNil(t, resp)
+
+	// Finished jobs do not count toward the limit
+	require.NoError(t, db.Model(&models.AnalysisResult{}).Where("transcript_id = ?", transcript.ID).Update("status", "completed").Error)
+	resp, err = service.CreateAnalysisJob(&AnalysisJobRequest{TranscriptID: transcript.ID}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.NotNil(t, resp)
+}
+
+func TestAnalysisService_createJobRecord_ConcurrentRequests(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	// An in-memory database exists per connection, so every request shares one
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	// Hold each request between counting jobs and inserting its own, so unsynchronized
+	// requests would all pass the check
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:pause_after_count", func(tx *gorm.DB) {
+		if tx.Statement.Table == "analysis_results" {
+			time.Sleep(20 * time.Millisecond)
+		}
+	}))
+	cfg := setupAnalysisTestConfig(t)
+	cfg.MaxConcurrentJobsPerTranscript = 1
+	service := NewAnalysisService(db, cfg)
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "test.txt", ContentHash: "testhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+
+	const requests = 8
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "pending", CreatedAt: time.Now()}
+			errs <- service.createJobRecord(analysis, false, "test-correlation-id")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	created := 0
+	for err := range errs {
+		if err == nil {
+			created++
+			continue
+		}
+		assert.ErrorIs(t, err, ErrJobsInProgress)
+	}
+	assert.Equal(t, 1, created)
+
+	var count int64
+	require.NoError(t, db.Model(&models.AnalysisResult{}).Where("transcript_id = ?", transcript.ID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
 func TestAnalysisService_CreateAnalysisJob_KafkaError(t *testing.T) {
 	db := setupAnalysisTestDB(t)
 	cfg := setupAnalysisTestConfig(t)
@@ -193,6 +372,45 @@ func TestAnalysisService_GetJobStatus(t *testing.T) {
 	assert.Nil(t, status)
 }
 
+func TestAnalysisService_ListJobs(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	service := NewAnalysisService(db, cfg)
+
+	now := time.Now()
+	statuses := []string{"completed", "processing", "processing", "failed"}
+	jobIDs := make([]uuid.UUID, len(statuses))
+	for i, status := range statuses {
+		jobIDs[i] = uuid.New()
+		require.NoError(t, db.Create(&models.AnalysisResult{
+			ID:           uuid.New(),
+			TranscriptID: uuid.New(),
+			JobID:        jobIDs[i],
+			Status:       status,
+			CreatedAt:    now.Add(time.Duration(i) * time.Minute),
+		}).Error)
+	}
+
+	// Every job, newest first
+	jobs, total, err := service.ListJobs(1, 10, "", DateRange{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	require.Len(t, jobs, 4)
+	assert.Equal(t, jobIDs[3], jobs[0].JobID)
+	assert.Equal(t, "failed", jobs[0].Status)
+
+	// Only processing jobs, paged
+	jobs, total, err = service.ListJobs(2, 1, "processing", DateRange{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, jobIDs[1], jobs[0].JobID)
+
+	_, _, err = service.ListJobs(1, 10, "stuck", DateRange{})
+	var validationErrs utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrs)
+}
+
 func TestAnalysisService_ListAnalysisResults(t *testing.T) {
 	db := setupAnalysisTestDB(t)
 	cfg := setupAnalysisTestConfig(t)
@@ -261,7 +479,7 @@ func TestAnalysisService_ListAnalysisResults(t *testing.T) {
 	}
 
 	// Test getting all results
-	results, total, err := service.ListAnalysisResults(1, 10)
+	results, total, err := service.ListAnalysisResults(1, 10, DateRange{})
 	assert.NoError(t, err)
 	assert.Equal(t, int64(3), total)
 	assert.Len(t, results, 3)
@@ -272,11 +490,55 @@ func TestAnalysisService_ListAnalysisResults(t *testing.T) {
 	assert.Equal(t, analyses[0].ID, results[2].ID)
 
 	// Test pagination
-	results, total, err = service.ListAnalysisResults(1, 1)
+	results, total, err = service.ListAnalysisResults(1, 1, DateRange{})
 	assert.NoError(t, err)
 	assert.Equal(t, int64(3), total)
 	assert.Len(t, results, 1)
 	assert.Equal(t, analyses[2].ID, results[0].ID)
+
+	// Test date range filter
+	before := time.Now().Add(-30 * time.Minute)
+	results, total, err = service.ListAnalysisResults(1, 10, DateRange{Before: &before})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, results, 2)
+	assert.Equal(t, analyses[1].ID, results[0].ID)
+}
+
+func TestAnalysisService_ListAnalysisResults_LoadsFactChecksInOneQuery(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "list.txt", ContentHash: "listhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+
+	var analyses []*models.AnalysisResult
+	for i := 0; i < 3; i++ {
+		analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "completed", CreatedAt: time.Now().Add(time.Duration(-i) * time.Hour)}
+		require.NoError(t, db.Create(analysis).Error)
+		analyses = append(analyses, analysis)
+	}
+	for i, claims := range []int{2, 0, 1} {
+		for j := 0; j < claims; j++ {
+			require.NoError(t, db.Create(&models.FactCheck{ID: uuid.New(), AnalysisID: analyses[i].ID, Claim: "Claim", Verdict: models.VerdictTrue, CheckedAt: time.Now()}).Error)
+		}
+	}
+
+	factCheckQueries := 0
+	require.NoError(t, db.Callback().Query().Before("gorm:query").Register("test:count_fact_check_queries", func(tx *gorm.DB) {
+		if tx.Statement.Table == "fact_checks" {
+			factCheckQueries++
+		}
+	}))
+
+	results, _, err := service.ListAnalysisResults(1, 10, DateRange{})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, 1, factCheckQueries)
+	assert.Len(t, results[0].FactChecks, 2)
+	assert.Len(t, results[1].FactChecks, 0)
+	assert.NotNil(t, results[1].FactChecks)
+	assert.Len(t, results[2].FactChecks, 1)
 }
 
 func TestAnalysisService_GetAnalysisResults(t *testing.T) {
@@ -335,7 +597,7 @@ func TestAnalysisService_GetAnalysisResults(t *testing.T) {
 	}
 
 	// Test getting existing analysis results
-	results, err := service.GetAnalysisResults(testAnalysis.ID, "test-correlation-id")
+	results, err := service.GetAnalysisResults(testAnalysis.ID, FactCheckFilter{}, "test-correlation-id")
 	assert.NoError(t, err)
 	assert.NotNil(t, results)
 	assert.Equal(t, testAnalysis.ID, results.ID)
@@ -345,8 +607,522 @@ func TestAnalysisService_GetAnalysisResults(t *testing.T) {
 
 	// Test getting non-existent analysis results
 	nonExistentID := uuid.New()
-	results, err = service.GetAnalysisResults(nonExistentID, "test-correlation-id")
+	results, err = service.GetAnalysisResults(nonExistentID, FactCheckFilter{}, "test-correlation-id")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 	assert.Nil(t, results)
-}
\ No newline at end of file
+}
+
+func TestDecodeStoredSources(t *testing.T) {
+	assert.Equal(t, storedSources{}, decodeStoredSources(nil))
+	assert.Equal(t, storedSources{Sources: []string{"https://a.example"}}, decodeStoredSources([]byte(`["https://a.example"]`)))
+	assert.Equal(t,
+		storedSources{Sources: []string{"https://farm.example"}, Timestamp: "00:01:00", LowSourceQuality: true},
+		decodeStoredSources([]byte(`{"sources":["https://farm.example"],"timestamp":"00:01:00","low_source_quality":true}`)))
+	assert.Equal(t,
+		storedSources{Sources: []string{"https://a.example"}, OriginalVerdict: models.VerdictFalse},
+		decodeStoredSources([]byte(`{"sources":["https://a.example"],"original_verdict":"false"}`)))
+}
+
+func TestAnalysisService_GetFactCheck(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "test.txt", ContentHash: "testhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "completed"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	evidence := "NASA imagery"
+	factCheck := &models.FactCheck{
+		ID:          uuid.New(),
+		AnalysisID:  analysis.ID,
+		Claim:       "The earth is round",
+		Verdict:     models.VerdictTrue,
+		Confidence:  0.95,
+		Evidence:    &evidence,
+		Sources:     []byte(`["https://nasa.gov/earth"]`),
+		CheckedAt:   time.Now(),
+		SearchQuery: "earth round shape",
+	}
+	require.NoError(t, db.Create(factCheck).Error)
+
+	result, err := service.GetFactCheck(factCheck.ID, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, factCheck.ID, result.ID)
+	assert.Equal(t, analysis.ID, result.AnalysisID)
+	assert.Equal(t, transcript.ID, result.TranscriptID)
+	assert.Equal(t, models.VerdictTrue, result.Verdict)
+	assert.Equal(t, "NASA imagery", *result.Evidence)
+	assert.Equal(t, []string{"https://nasa.gov/earth"}, result.Sources)
+	assert.Equal(t, "earth round shape", result.SearchQuery)
+
+	result, err = service.GetFactCheck(uuid.New(), "test-correlation-id")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	assert.Nil(t, result)
+}
+
+func TestAnalysisService_SaveAnalysisResults_StoresAdFilterMetadata(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "ads.txt", ContentHash: "adshash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	content, adFilter := service.applyAdFilter("Welcome.\nThis episode is brought to you by Acme.\nBack to the topic.", AnalysisOptions{StripAds: true}, analysis.JobID, "test-correlation-id")
+	assert.Equal(t, "Welcome.\nBack to the topic.", content)
+
+	_, err := service.saveAnalysisResults(context.Background(), analysis.JobID, &AnalysisResults{
+		Summary:  "Summary",
+		Metadata: map[string]interface{}{"ad_filter": adFilter},
+	}, "test-correlation-id")
+	require.NoError(t, err)
+
+	results, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	stored := results.Metadata["ad_filter"].(map[string]interface{})
+	assert.Equal(t, true, stored["enabled"])
+	assert.Equal(t, float64(1), stored["segments_removed"])
+
+	_, disabled := service.applyAdFilter("Brought to you by Acme.", AnalysisOptions{}, analysis.JobID, "test-correlation-id")
+	assert.Equal(t, map[string]interface{}{"enabled": false}, disabled)
+}
+
+func TestAnalysisService_SaveAnalysisResults_StoresTakeawaysSummary(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "synthesis.txt", ContentHash: "synthesishash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	_, err := service.saveAnalysisResults(context.Background(), analysis.JobID, &AnalysisResults{
+		Summary:          "Summary",
+		Takeaways:        map[string]interface{}{"takeaways": []string{"First", "Second"}},
+		TakeawaysSummary: "Together the takeaways show why the topic matters.",
+	}, "test-correlation-id")
+	require.NoError(t, err)
+
+	results, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	require.NotNil(t, results.TakeawaysSummary)
+	assert.Equal(t, "Together the takeaways show why the topic matters.", *results.TakeawaysSummary)
+
+	listed, _, err := service.ListAnalysisResults(1, 10, DateRange{})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.NotNil(t, listed[0].TakeawaysSummary)
+	assert.Equal(t, *results.TakeawaysSummary, *listed[0].TakeawaysSummary)
+}
+
+func TestAnalysisService_SaveAnalysisResults_StoresHeadline(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "headline.txt", ContentHash: "headlinehash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	_, err := service.saveAnalysisResults(context.Background(), analysis.JobID, &AnalysisResults{
+		Summary:   "A paragraph summary of the episode.",
+		Headline:  "Why sleep matters",
+		Takeaways: map[string]interface{}{"takeaways": []string{"First"}},
+	}, "test-correlation-id")
+	require.NoError(t, err)
+
+	results, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	require.NotNil(t, results.Headline)
+	assert.Equal(t, "Why sleep matters", *results.Headline)
+	assert.Equal(t, "A paragraph summary of the episode.", *results.Summary)
+
+	listed, _, err := service.ListAnalysisResults(1, 10, DateRange{})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.NotNil(t, listed[0].Headline)
+	assert.Equal(t, "Why sleep matters", *listed[0].Headline)
+}
+
+func TestAnalysisService_SaveAnalysisResults_StoresTruncated(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "long.txt", ContentHash: "longhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	_, err := service.saveAnalysisResults(context.Background(), analysis.JobID, &AnalysisResults{
+		Summary:   "Summary cut off mid",
+		Truncated: true,
+		Metadata:  map[string]interface{}{"truncated_agents": []string{"summarizer"}},
+	}, "test-correlation-id")
+	require.NoError(t, err)
+
+	results, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.True(t, results.Truncated)
+	assert.Equal(t, []interface{}{"summarizer"}, results.Metadata["truncated_agents"])
+
+	listed, _, err := service.ListAnalysisResults(1, 10, DateRange{})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.True(t, listed[0].Truncated)
+}
+
+// failWrites makes the first n creates or updates matching match fail as a locked database
+// would, returning the number of matching writes attempted
+func failWrites(t *testing.T, db *gorm.DB, n int, match func(stmt *gorm.Statement) bool) *int {
+	t.Helper()
+	attempts := 0
+	fail := func(tx *gorm.DB) {
+		if !match(tx.Statement) {
+			return
+		}
+		attempts++
+		if attempts <= n {
+			tx.AddError(errors.New("database is locked"))
+		}
+	}
+	require.NoError(t, db.Callback().Update().Before("gorm:update").Register("test:fail_writes", fail))
+	require.NoError(t, db.Callback().Create().Before("gorm:create").Register("test:fail_writes", fail))
+	return &attempts
+}
+
+// isResultsUpdate matches the update persisting a finished analysis
+func isResultsUpdate(stmt *gorm.Statement) bool {
+	for _, column := range stmt.Selects {
+		if column == "summary" {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAnalysisService_SaveAnalysisResults_RetriesTransientFailures(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	cfg.ResultSaveRetryDelay = time.Millisecond
+	service := NewAnalysisService(db, cfg)
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "retry.txt", ContentHash: "retryhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	attempts := failWrites(t, db, 2, isResultsUpdate)
+
+	saved, err := service.saveAnalysisResults(context.Background(), analysis.JobID, &AnalysisResults{Summary: "Summary"}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, 3, *attempts)
+
+	var stored models.AnalysisResult
+	require.NoError(t, db.First(&stored, "id = ?", saved.ID).Error)
+	assert.Equal(t, "Summary", *stored.Summary)
+	assert.Equal(t, "processing", stored.Status)
+}
+
+func TestAnalysisService_SaveAnalysisResults_FailsJobAfterRetriesExhausted(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	cfg.ResultSaveMaxAttempts = 3
+	cfg.ResultSaveRetryDelay = time.Millisecond
+	service := NewAnalysisService(db, cfg)
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "retry.txt", ContentHash: "retryhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	attempts := failWrites(t, db, 100, isResultsUpdate)
+
+	_, err := service.saveAnalysisResults(context.Background(), analysis.JobID, &AnalysisResults{Summary: "Summary"}, "test-correlation-id")
+	require.Error(t, err)
+	assert.Equal(t, 3, *attempts)
+
+	var stored models.AnalysisResult
+	require.NoError(t, db.First(&stored, "id = ?", analysis.ID).Error)
+	assert.Equal(t, "failed", stored.Status)
+	assert.Nil(t, stored.Summary)
+}
+
+func TestAnalysisService_SaveAnalysisResults_MissingRecordNotRetried(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	cfg.ResultSaveRetryDelay = time.Hour
+	service := NewAnalysisService(db, cfg)
+
+	_, err := service.saveAnalysisResults(context.Background(), uuid.New(), &AnalysisResults{Summary: "Summary"}, "test-correlation-id")
+	assert.ErrorIs(t, err, ErrJobOrphaned)
+}
+
+func TestAnalysisService_retryResultWrite_StopsWhenContextEnds(t *testing.T) {
+	cfg := setupAnalysisTestConfig(t)
+	cfg.ResultSaveMaxAttempts = 3
+	cfg.ResultSaveRetryDelay = time.Hour
+	service := NewAnalysisService(setupAnalysisTestDB(t), cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- service.retryResultWrite(ctx, "save_analysis_results", "test-correlation-id", func() error {
+			attempts++
+			return errors.New("connection reset")
+		})
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, 1, attempts)
+	case <-time.After(5 * time.Second):
+		t.Fatal("retry kept waiting after the context ended")
+	}
+}
+
+func TestAnalysisService_ProcessAnalysisJob_Orphaned(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	// The analysis record and transcript were both deleted before the job ran
+	err := service.processAnalysisJob(context.Background(), uuid.New(), uuid.New(), AnalysisOptions{}, "test-correlation-id")
+	assert.NoError(t, err)
+
+	var count int64
+	require.NoError(t, db.Model(&models.AnalysisResult{}).Count(&count).Error)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestAnalysisService_ProcessAnalysisJob_MissingTranscriptFailsJob(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	// Only the transcript is missing, so the job still has a record to fail
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: uuid.New(), JobID: uuid.New(), Status: "pending"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	err := service.processAnalysisJob(context.Background(), analysis.JobID, analysis.TranscriptID, AnalysisOptions{}, "test-correlation-id")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrJobOrphaned)
+
+	var stored models.AnalysisResult
+	require.NoError(t, db.First(&stored, "id = ?", analysis.ID).Error)
+	assert.Equal(t, "failed", stored.Status)
+}
+
+func TestAnalysisService_SaveFactChecks_RetriesTransientFailures(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	cfg.ResultSaveRetryDelay = time.Millisecond
+	service := NewAnalysisService(db, cfg)
+
+	analysisID := uuid.New()
+	attempts := failWrites(t, db, 1, func(stmt *gorm.Statement) bool {
+		return stmt.Table == "fact_checks"
+	})
+
+	service.saveFactChecks(context.Background(), analysisID, []FactCheckResult{
+		{Claim: "The moon landing was in 1969", Verdict: models.VerdictTrue, Confidence: 0.9},
+	}, "test-correlation-id")
+
+	assert.Equal(t, 2, *attempts)
+	var count int64
+	require.NoError(t, db.Model(&models.FactCheck{}).Where("analysis_id = ?", analysisID).Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestAnalysisService_SaveFactChecks_StoresSearchQuery(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	analysisID := uuid.New()
+	service.saveFactChecks(context.Background(), analysisID, []FactCheckResult{
+		{Claim: "The moon landing was in 1969", Verdict: models.VerdictTrue, Confidence: 0.9, SearchQuery: "moon landing 1969"},
+		{Claim: "Water boils at 100C", Verdict: models.VerdictTrue, Confidence: 0.9, Cached: true},
+	}, "test-correlation-id")
+
+	var stored []models.FactCheck
+	require.NoError(t, db.Where("analysis_id = ?", analysisID).Order("claim").Find(&stored).Error)
+	require.Len(t, stored, 2)
+	assert.Equal(t, "moon landing 1969", stored[0].SearchQuery)
+	assert.Empty(t, stored[1].SearchQuery)
+}
+
+func TestAnalysisService_UpdateJobStatus_Transitions(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		expectError bool
+	}{
+		{name: "pending to processing", from: "pending", to: "processing"},
+		{name: "pending to failed", from: "pending", to: "failed"},
+		{name: "processing to completed", from: "processing", to: "completed"},
+		{name: "processing to cancelled", from: "processing", to: "cancelled"},
+		{name: "same status is a no-op", from: "processing", to: "processing"},
+		{name: "completed is terminal", from: "completed", to: "processing", expectError: true},
+		{name: "cancelled cannot complete", from: "cancelled", to: "completed", expectError: true},
+		{name: "failed cannot be cancelled", from: "failed", to: "cancelled", expectError: true},
+		{name: "pending cannot skip to completed", from: "pending", to: "completed", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupAnalysisTestDB(t)
+			cfg := setupAnalysisTestConfig(t)
+			service := NewAnalysisService(db, cfg)
+
+			job := &models.AnalysisResult{
+				TranscriptID: uuid.New(),
+				JobID:        uuid.New(),
+				Status:       tt.from,
+				CreatedAt:    time.Now(),
+			}
+			require.NoError(t, db.Create(job).Error)
+
+			err := service.UpdateJobStatus(job.JobID, tt.to, "")
+
+			var stored models.AnalysisResult
+			require.NoError(t, db.Where("job_id = ?", job.JobID).First(&stored).Error)
+			if tt.expectError {
+				assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+				assert.Equal(t, tt.from, stored.Status)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.to, stored.Status)
+			}
+		})
+	}
+}
+
+func TestAnalysisService_UpdateJobStatus_SetsCompletionFields(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	service := NewAnalysisService(db, cfg)
+
+	job := &models.AnalysisResult{
+		TranscriptID: uuid.New(),
+		JobID:        uuid.New(),
+		Status:       "processing",
+		CreatedAt:    time.Now(),
+	}
+	require.NoError(t, db.Create(job).Error)
+
+	require.NoError(t, service.UpdateJobStatus(job.JobID, "failed", "agent timed out"))
+
+	var stored models.AnalysisResult
+	require.NoError(t, db.Where("job_id = ?", job.JobID).First(&stored).Error)
+	assert.Equal(t, "failed", stored.Status)
+	require.NotNil(t, stored.ErrorMessage)
+	assert.Equal(t, "agent timed out", *stored.ErrorMessage)
+	assert.NotNil(t, stored.CompletedAt)
+}
+
+func TestAnalysisService_GetQueueStats(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	service := NewAnalysisService(db, cfg)
+
+	// Empty queue
+	stats, err := service.GetQueueStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), stats.Pending)
+	assert.Equal(t, int64(0), stats.Processing)
+	assert.Nil(t, stats.OldestPendingAt)
+
+	oldest := time.Now().Add(-10 * time.Minute)
+	jobs := []*models.AnalysisResult{
+		{TranscriptID: uuid.New(), JobID: uuid.New(), Status: "pending", CreatedAt: oldest},
+		{TranscriptID: uuid.New(), JobID: uuid.New(), Status: "pending", CreatedAt: time.Now().Add(-1 * time.Minute)},
+		{TranscriptID: uuid.New(), JobID: uuid.New(), Status: "processing", CreatedAt: time.Now()},
+		{TranscriptID: uuid.New(), JobID: uuid.New(), Status: "completed", CreatedAt: time.Now().Add(-time.Hour)},
+	}
+	for _, job := range jobs {
+		require.NoError(t, db.Create(job).Error)
+	}
+
+	stats, err = service.GetQueueStats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stats.Pending)
+	assert.Equal(t, int64(1), stats.Processing)
+	require.NotNil(t, stats.OldestPendingAt)
+	assert.WithinDuration(t, oldest, *stats.OldestPendingAt, time.Second)
+	assert.InDelta(t, 600, stats.OldestPendingAgeSeconds, 5)
+
+	// Gauges mirror the returned stats
+	assert.Equal(t, float64(2), metrics.Value("analysis_queue_pending").(*expvar.Float).Value())
+	assert.Equal(t, float64(1), metrics.Value("analysis_queue_processing").(*expvar.Float).Value())
+}
+
+func TestAnalysisService_RunAgent_UnknownAgent(t *testing.T) {
+	service := NewAnalysisService(setupAnalysisTestDB(t), setupAnalysisTestConfig(t))
+
+	_, err := service.RunAgent(context.Background(), "poet", &DebugAgentRequest{Content: "Some text"}, "test-correlation-id")
+
+	assert.ErrorIs(t, err, ErrAgentNotFound)
+}
+
+func TestAnalysisService_GetAnalysisResults_FactCheckFilter(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: "filterhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "completed"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	checkedAt := time.Now()
+	verdicts := []models.Verdict{models.VerdictTrue, models.VerdictFalse, models.VerdictTrue, models.VerdictTrue}
+	for i, verdict := range verdicts {
+		require.NoError(t, db.Create(&models.FactCheck{
+			ID:         uuid.New(),
+			AnalysisID: analysis.ID,
+			Claim:      fmt.Sprintf("Claim %d", i+1),
+			Verdict:    verdict,
+			Confidence: 0.8,
+			CheckedAt:  checkedAt.Add(time.Duration(i) * time.Second),
+		}).Error)
+	}
+
+	tests := []struct {
+		name           string
+		filter         FactCheckFilter
+		expectedClaims []string
+		expectedTotal  *int64
+	}{
+		{name: "all by default", filter: FactCheckFilter{}, expectedClaims: []string{"Claim 1", "Claim 2", "Claim 3", "Claim 4"}},
+		{name: "verdict", filter: FactCheckFilter{Verdict: models.VerdictTrue}, expectedClaims: []string{"Claim 1", "Claim 3", "Claim 4"}, expectedTotal: int64Ptr(3)},
+		{name: "limit", filter: FactCheckFilter{Limit: 2}, expectedClaims: []string{"Claim 1", "Claim 2"}, expectedTotal: int64Ptr(4)},
+		{name: "verdict page", filter: FactCheckFilter{Verdict: models.VerdictTrue, Limit: 1, Offset: 1}, expectedClaims: []string{"Claim 3"}, expectedTotal: int64Ptr(3)},
+		{name: "offset past the end", filter: FactCheckFilter{Offset: 10}, expectedClaims: []string{}, expectedTotal: int64Ptr(4)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := service.GetAnalysisResults(analysis.ID, tt.filter, "test-correlation-id")
+			require.NoError(t, err)
+
+			claims := []string{}
+			for _, factCheck := range results.FactChecks {
+				claims = append(claims, factCheck.Claim)
+			}
+			if tt.filter.IsZero() {
+				assert.ElementsMatch(t, tt.expectedClaims, claims)
+			} else {
+				assert.Equal(t, tt.expectedClaims, claims)
+			}
+			assert.Equal(t, tt.expectedTotal, results.FactChecksTotal)
+		})
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
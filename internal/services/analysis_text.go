@@ -0,0 +1,47 @@
+package services
+
+import (
+	"podcast-analyzer/internal/logger"
+)
+
+// AnalyzeTextRequest is raw content to analyze without uploading a transcript first. It
+// accepts the same analysis options as a transcript analysis.
+type AnalyzeTextRequest struct {
+	AnalysisJobRequest
+	Content  string `json:"content"`
+	Filename string `json:"filename,omitempty"` // Label for the ephemeral transcript; defaults to pasted-text.txt
+	Cleanup  *bool  `json:"cleanup,omitempty"`  // Overrides the configured cleanup default when set
+}
+
+// AnalyzeText stores the content as an ephemeral transcript and starts the normal analysis
+// pipeline on it. Unless cleanup is turned off, the transcript's content is removed once the
+// job completes; a job that fails or is cancelled keeps it, so it can be retried.
+func (s *AnalysisService) AnalyzeText(req *AnalyzeTextRequest, correlationID string) (*AnalysisJobResponse, error) {
+	transcriptService := NewTranscriptService(s.db, s.config)
+	transcript, err := transcriptService.CreateEphemeralTranscript(req.Content, req.Filename, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	jobReq := req.AnalysisJobRequest
+	jobReq.TranscriptID = transcript.ID
+	jobReq.cleanupTranscript = s.config.EphemeralTranscriptCleanup
+	if req.Cleanup != nil {
+		jobReq.cleanupTranscript = *req.Cleanup
+	}
+
+	response, err := s.CreateAnalysisJob(&jobReq, correlationID)
+	if err != nil {
+		// The transcript exists only for this job, so it goes with it
+		if deleteErr := transcriptService.DeleteTranscript(transcript.ID, correlationID); deleteErr != nil {
+			logger.LogErrorWithStackAndCorrelation(deleteErr, correlationID, map[string]interface{}{
+				"transcript_id": transcript.ID,
+				"operation":     "delete_unused_ephemeral_transcript",
+			})
+		}
+		return nil, err
+	}
+
+	response.Ephemeral = true
+	return response, nil
+}
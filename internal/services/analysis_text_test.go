@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisService_AnalyzeText(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.EphemeralTranscriptCleanup = true
+	service := NewAnalysisService(db, cfg)
+
+	response, err := service.AnalyzeText(&AnalyzeTextRequest{
+		Content:  "Host: Welcome to the show.\r\nGuest: Thanks for having me.",
+		Filename: "../notes/episode.txt",
+	}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.True(t, response.Ephemeral)
+	assert.Equal(t, "pending", response.Status)
+
+	var transcript models.Transcript
+	require.NoError(t, db.Where("id = ?", response.TranscriptID).First(&transcript).Error)
+	assert.True(t, transcript.Ephemeral)
+	assert.Equal(t, "episode.txt", transcript.Filename)
+	assert.Equal(t, 10, transcript.WordCount)
+
+	// With every agent disabled the job finishes at once, and its content is then removed
+	assert.Eventually(t, func() bool {
+		var analysis models.AnalysisResult
+		if db.Where("job_id = ?", response.JobID).First(&analysis).Error != nil || analysis.Status != "completed" {
+			return false
+		}
+		_, statErr := os.Stat(transcript.FilePath)
+		return os.IsNotExist(statErr)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// The record stays for the results, but is not listed with uploaded transcripts
+	var count int64
+	db.Model(&models.Transcript{}).Where("id = ?", transcript.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+	listed, total, err := NewTranscriptService(db, cfg).GetTranscripts(1, 10, DateRange{})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	assert.Equal(t, int64(0), total)
+}
+
+func TestAnalysisService_AnalyzeText_KeepsContentWithoutCleanup(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.EphemeralTranscriptCleanup = true
+	service := NewAnalysisService(db, cfg)
+	cleanup := false
+
+	response, err := service.AnalyzeText(&AnalyzeTextRequest{Content: "Host: Keep this one around.", Cleanup: &cleanup}, "test-correlation-id")
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		var analysis models.AnalysisResult
+		return db.Where("job_id = ?", response.JobID).First(&analysis).Error == nil && analysis.Status == "completed"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	var transcript models.Transcript
+	require.NoError(t, db.Where("id = ?", response.TranscriptID).First(&transcript).Error)
+	assert.Equal(t, defaultEphemeralFilename, transcript.Filename)
+	content, err := NewTranscriptService(db, cfg).ReadTranscriptContent(&transcript)
+	require.NoError(t, err)
+	assert.Equal(t, "Host: Keep this one around.", content)
+}
+
+func TestAnalysisService_runAnalysisJob_KeepsContentOfUnfinishedJob(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewAnalysisService(db, cfg)
+
+	transcript, err := NewTranscriptService(db, cfg).CreateEphemeralTranscript("Host: Try this again later.", "", "test-correlation-id")
+	require.NoError(t, err)
+	// A cancelled job cannot move to processing, so it ends without completing
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "cancelled"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	service.runAnalysisJob(context.Background(), analysis, AnalysisOptions{CleanupTranscript: true}, "test-correlation-id")
+
+	_, err = os.Stat(transcript.FilePath)
+	assert.NoError(t, err)
+}
+
+func TestAnalysisService_AnalyzeText_Validation(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.MaxFileSize = 32
+	service := NewAnalysisService(db, cfg)
+
+	tests := []struct {
+		name    string
+		req     *AnalyzeTextRequest
+		field   string
+		message string
+	}{
+		{name: "empty content", req: &AnalyzeTextRequest{Content: "  \n "}, field: "content", message: "required"},
+		{name: "content too large", req: &AnalyzeTextRequest{Content: strings.Repeat("word ", 10)}, field: "content", message: "too large"},
+		{name: "invalid encoding", req: &AnalyzeTextRequest{Content: "caf\xe9"}, field: "content", message: "UTF-8"},
+		{name: "invalid analysis option", req: &AnalyzeTextRequest{Content: "Host: Hello.", AnalysisJobRequest: AnalysisJobRequest{SummaryStyle: "poetic"}}, field: "summary_style", message: "invalid summary style"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.AnalyzeText(tt.req, "test-correlation-id")
+
+			var validationErrs utils.ValidationErrors
+			require.ErrorAs(t, err, &validationErrs)
+			assert.Equal(t, tt.field, validationErrs[0].Field)
+			assert.Contains(t, validationErrs[0].Message, tt.message)
+		})
+	}
+
+	// A rejected job leaves no ephemeral transcript behind
+	var count int64
+	db.Model(&models.Transcript{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+	entries, err := os.ReadDir(cfg.StoragePath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/metrics"
+)
+
+// ErrContentBudgetExceeded is returned when too much transcript content is already held in
+// memory and none was freed within the allowed wait
+var ErrContentBudgetExceeded = errors.New("too much transcript content in memory, retry later")
+
+// ContentBudget caps the approximate bytes of transcript content held in memory at once by
+// uploads and analysis jobs, so a burst of large transcripts waits instead of exhausting
+// memory. Each holder reserves its size up front and gives it back when it is done.
+type ContentBudget struct {
+	limit int64
+
+	mu       sync.Mutex
+	inFlight int64
+	freed    chan struct{} // closed and replaced whenever bytes are given back
+}
+
+// contentBudget is the budget shared by every upload and analysis job in the process
+var (
+	contentBudgetMu sync.Mutex
+	contentBudget   *ContentBudget
+)
+
+// NewContentBudget creates a budget admitting up to limit bytes at once
+func NewContentBudget(limit int64) *ContentBudget {
+	b := &ContentBudget{limit: limit, freed: make(chan struct{})}
+	b.publishLocked()
+	return b
+}
+
+// getContentBudget returns the shared budget, or nil when in-flight content is unlimited
+func getContentBudget(cfg *config.Config) *ContentBudget {
+	if cfg.MaxInflightContentBytes <= 0 {
+		return nil
+	}
+
+	contentBudgetMu.Lock()
+	defer contentBudgetMu.Unlock()
+
+	if contentBudget == nil {
+		contentBudget = NewContentBudget(cfg.MaxInflightContentBytes)
+	}
+	return contentBudget
+}
+
+// Acquire reserves size bytes, waiting for other holders to release theirs, and returns
+// the function that gives them back. A reservation larger than the whole budget is cut
+// down to it, so an oversized transcript still runs once it has the budget to itself. It
+// gives up with ErrContentBudgetExceeded if the context ends first. A nil budget admits
+// everything immediately.
+func (b *ContentBudget) Acquire(ctx context.Context, size int64) (func(), error) {
+	if b == nil {
+		return func() {}, nil
+	}
+	if size > b.limit {
+		size = b.limit
+	}
+	if size < 0 {
+		size = 0
+	}
+
+	for {
+		b.mu.Lock()
+		if b.inFlight+size <= b.limit {
+			b.inFlight += size
+			b.publishLocked()
+			b.mu.Unlock()
+
+			var once sync.Once
+			return func() { once.Do(func() { b.release(size) }) }, nil
+		}
+		freed := b.freed
+		b.mu.Unlock()
+
+		select {
+		case <-freed:
+		case <-ctx.Done():
+			metrics.AddCounter("content_budget_rejections", 1)
+			return nil, ErrContentBudgetExceeded
+		}
+	}
+}
+
+// InFlight returns the bytes currently reserved
+func (b *ContentBudget) InFlight() int64 {
+	if b == nil {
+		return 0
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.inFlight
+}
+
+// release gives back size bytes and wakes every waiter to retry
+func (b *ContentBudget) release(size int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.inFlight -= size
+	close(b.freed)
+	b.freed = make(chan struct{})
+	b.publishLocked()
+}
+
+// publishLocked reports the reserved bytes; callers must hold b.mu
+func (b *ContentBudget) publishLocked() {
+	metrics.SetGauge("inflight_content_bytes", float64(b.inFlight))
+}
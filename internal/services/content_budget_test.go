@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentBudget_AcquireAndRelease(t *testing.T) {
+	budget := NewContentBudget(100)
+
+	release, err := budget.Acquire(context.Background(), 60)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), budget.InFlight())
+
+	// Releasing twice only gives the bytes back once
+	release()
+	release()
+	assert.Equal(t, int64(0), budget.InFlight())
+}
+
+func TestContentBudget_GivesUpWhenContextEnds(t *testing.T) {
+	budget := NewContentBudget(100)
+
+	release, err := budget.Acquire(context.Background(), 80)
+	require.NoError(t, err)
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = budget.Acquire(ctx, 30)
+	assert.ErrorIs(t, err, ErrContentBudgetExceeded)
+	assert.Equal(t, int64(80), budget.InFlight())
+}
+
+func TestContentBudget_WaiterAdmittedOnRelease(t *testing.T) {
+	budget := NewContentBudget(100)
+
+	release, err := budget.Acquire(context.Background(), 80)
+	require.NoError(t, err)
+
+	admitted := make(chan func())
+	go func() {
+		waiterRelease, err := budget.Acquire(context.Background(), 50)
+		if err == nil {
+			admitted <- waiterRelease
+		}
+	}()
+
+	select {
+	case <-admitted:
+		t.Fatal("waiter admitted while the budget was full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	release()
+	select {
+	case waiterRelease := <-admitted:
+		assert.Equal(t, int64(50), budget.InFlight())
+		waiterRelease()
+	case <-time.After(time.Second):
+		t.Fatal("waiter not admitted after release")
+	}
+}
+
+func TestContentBudget_OversizedRequestRunsAlone(t *testing.T) {
+	budget := NewContentBudget(100)
+
+	release, err := budget.Acquire(context.Background(), 500)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), budget.InFlight())
+	release()
+}
+
+func TestContentBudget_NilAdmitsEverything(t *testing.T) {
+	var budget *ContentBudget
+
+	release, err := budget.Acquire(context.Background(), 1<<40)
+	require.NoError(t, err)
+	release()
+	assert.Equal(t, int64(0), budget.InFlight())
+}
+
+func TestTranscriptService_UploadTranscript_ContentBudgetExceeded(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.MaxInflightContentBytes = 1024
+	cfg.InflightContentWait = 10 * time.Millisecond
+	service := NewTranscriptService(db, cfg)
+
+	contentBudget = NewContentBudget(cfg.MaxInflightContentBytes)
+	t.Cleanup(func() { contentBudget = nil })
+
+	// Fill the budget as a running analysis would
+	release, err := contentBudget.Acquire(context.Background(), cfg.MaxInflightContentBytes)
+	require.NoError(t, err)
+
+	fileHeader := createTestFileHeader(t, "test.txt", "This is a test transcript content")
+	_, err = service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+	assert.ErrorIs(t, err, ErrContentBudgetExceeded)
+
+	// The upload goes through once the analysis gives its bytes back, and returns its own
+	release()
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, resp.TranscriptID)
+	assert.Equal(t, int64(0), contentBudget.InFlight())
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/requestctx"
+)
+
+// ErrAgentNotFound is returned when a debug run names an agent that does not exist
+var ErrAgentNotFound = errors.New("agent not found")
+
+// DebugAgentRequest is ad-hoc text to run through a single agent
+type DebugAgentRequest struct {
+	Content string            `json:"content"`
+	Options DebugAgentOptions `json:"options"`
+}
+
+// DebugAgentOptions are the agents.ProcessingOptions a debug run may set
+type DebugAgentOptions struct {
+	Summary      string `json:"summary"`
+	MaxResults   int    `json:"max_results"`
+	Instructions string `json:"instructions"`
+}
+
+// optionsAgent is an agent accepting per-run processing options
+type optionsAgent interface {
+	agents.Agent
+	ProcessWithOptions(ctx context.Context, content string, opts agents.ProcessingOptions) (agents.Result, error)
+}
+
+// newDebugAgent instantiates the named agent. The fact checker runs without the claim
+// cache so every run reflects the current prompts.
+func (s *AnalysisService) newDebugAgent(name string) (optionsAgent, error) {
+	switch name {
+	case "summarizer":
+		return agents.NewSummarizerAgent(s.config), nil
+	case "takeaway_extractor":
+		return agents.NewTakeawayExtractorAgent(s.config), nil
+	case "fact_checker":
+		return agents.NewFactCheckerAgent(s.config), nil
+	case "takeaway_synthesizer":
+		return agents.NewTakeawaySynthesizerAgent(s.config), nil
+	}
+	return nil, ErrAgentNotFound
+}
+
+// RunAgent runs one agent directly against the given text, without a transcript or job,
+// and returns its raw result. It exists for prompt tuning.
+func (s *AnalysisService) RunAgent(ctx context.Context, name string, req *DebugAgentRequest, correlationID string) (agents.Result, error) {
+	agent, err := s.newDebugAgent(name)
+	if err != nil {
+		return agents.Result{}, err
+	}
+
+	log := logger.WithCorrelationID(correlationID)
+	log.WithFields(map[string]interface{}{
+		"agent":          name,
+		"content_length": len(req.Content),
+		"operation":      "debug_run_agent",
+	}).Info("Running agent for debug request")
+
+	ctx = requestctx.WithCorrelationID(ctx, correlationID)
+	start := time.Now()
+	result, err := agent.ProcessWithOptions(ctx, req.Content, agents.ProcessingOptions{
+		Summary:      req.Options.Summary,
+		MaxResults:   req.Options.MaxResults,
+		Instructions: req.Options.Instructions,
+	})
+	if err != nil {
+		return agents.Result{}, err
+	}
+
+	log.WithFields(map[string]interface{}{
+		"agent":       name,
+		"duration_ms": time.Since(start).Milliseconds(),
+		"operation":   "debug_run_agent",
+	}).Info("Debug agent run completed")
+	return result, nil
+}
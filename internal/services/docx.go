@@ -0,0 +1,116 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"podcast-analyzer/internal/utils"
+)
+
+// docxExtension is converted to plain text on upload instead of being stored as-is
+const docxExtension = ".docx"
+
+// docxBodyPath is the part of a .docx archive holding the document text
+const docxBodyPath = "word/document.xml"
+
+// maxDocxBodyBytes caps the uncompressed size of word/document.xml so a crafted archive
+// cannot expand without bound
+const maxDocxBodyBytes = 50 * 1024 * 1024
+
+// wordprocessingNamespace is the XML namespace of the elements that carry document text
+const wordprocessingNamespace = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+
+// oleSignature starts an OLE compound file; Word stores password-protected documents in
+// this format rather than as a zip archive
+var oleSignature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// extractDocxText returns the text of a Word document with one line per paragraph
+func extractDocxText(content []byte) (string, error) {
+	if bytes.HasPrefix(content, oleSignature) {
+		return "", utils.NewValidationError("file.format", "docx file is password-protected or not a Word 2007+ document")
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", utils.NewValidationError("file.format", fmt.Sprintf("invalid docx file: %v", err))
+	}
+
+	var body *zip.File
+	for _, file := range archive.File {
+		if file.Name == docxBodyPath {
+			body = file
+			break
+		}
+	}
+	if body == nil {
+		return "", utils.NewValidationError("file.format", "invalid docx file: missing "+docxBodyPath)
+	}
+
+	reader, err := body.Open()
+	if err != nil {
+		return "", utils.NewValidationError("file.format", fmt.Sprintf("invalid docx file: %v", err))
+	}
+	defer reader.Close()
+
+	text, err := parseDocxBody(io.LimitReader(reader, maxDocxBodyBytes))
+	if err != nil {
+		return "", utils.NewValidationError("file.format", fmt.Sprintf("invalid docx file: %v", err))
+	}
+	if strings.TrimSpace(text) == "" {
+		return "", utils.NewValidationError("file.format", "docx file contains no text")
+	}
+	return text, nil
+}
+
+// parseDocxBody walks word/document.xml collecting run text. Paragraphs end with a
+// newline and tabs and breaks are kept; deleted (tracked change) text is skipped.
+func parseDocxBody(r io.Reader) (string, error) {
+	decoder := xml.NewDecoder(r)
+	var text strings.Builder
+	inText := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Space != wordprocessingNamespace {
+				continue
+			}
+			switch element.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteString("\t")
+			case "br", "cr":
+				text.WriteString("\n")
+			}
+		case xml.EndElement:
+			if element.Name.Space != wordprocessingNamespace {
+				continue
+			}
+			switch element.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text.WriteString("\n")
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(element)
+			}
+		}
+	}
+
+	return strings.TrimSpace(text.String()), nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildDocx returns a minimal .docx archive with the given document.xml body
+func buildDocx(t *testing.T, documentXML string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	entry, err := archive.Create("[Content_Types].xml")
+	require.NoError(t, err)
+	_, err = entry.Write([]byte(`<?xml version="1.0"?><Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"/>`))
+	require.NoError(t, err)
+
+	if documentXML != "" {
+		entry, err = archive.Create(docxBodyPath)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(documentXML))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+const testDocxXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+  <w:body>
+    <w:p><w:r><w:t>Host: Welcome to the show.</w:t></w:r></w:p>
+    <w:p><w:r><w:t xml:space="preserve">Guest: Thanks </w:t></w:r><w:r><w:t>for having me.</w:t></w:r></w:p>
+    <w:p><w:r><w:delText>Removed in review</w:delText></w:r><w:r><w:t>Host:</w:t><w:tab/><w:t>Let's begin.</w:t></w:r></w:p>
+  </w:body>
+</w:document>`
+
+func TestExtractDocxText(t *testing.T) {
+	text, err := extractDocxText(buildDocx(t, testDocxXML))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Host: Welcome to the show.\nGuest: Thanks for having me.\nHost:\tLet's begin.", text)
+}
+
+func TestExtractDocxText_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  []byte
+		errorMsg string
+	}{
+		{
+			name:     "password protected",
+			content:  append(append([]byte{}, oleSignature...), make([]byte, 64)...),
+			errorMsg: "password-protected",
+		},
+		{
+			name:     "not a zip archive",
+			content:  []byte("This is plain text with a .docx extension"),
+			errorMsg: "invalid docx file",
+		},
+		{
+			name:     "missing document body",
+			content:  buildDocx(t, ""),
+			errorMsg: "missing word/document.xml",
+		},
+		{
+			name:     "malformed document body",
+			content:  buildDocx(t, `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`),
+			errorMsg: "invalid docx file",
+		},
+		{
+			name:     "no text",
+			content:  buildDocx(t, `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body><w:p/></w:body></w:document>`),
+			errorMsg: "contains no text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := extractDocxText(tt.content)
+
+			var validationErrs utils.ValidationErrors
+			require.ErrorAs(t, err, &validationErrs)
+			assert.Equal(t, "file.format", validationErrs[0].Field)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestTranscriptService_UploadTranscript_Docx(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+
+	fileHeader := createTestFileHeader(t, "episode.docx", string(buildDocx(t, testDocxXML)))
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, 13, resp.WordCount)
+
+	var transcript models.Transcript
+	require.NoError(t, db.First(&transcript, "id = ?", resp.TranscriptID).Error)
+
+	var metadata map[string]interface{}
+	require.NoError(t, json.Unmarshal(transcript.TranscriptMetadata, &metadata))
+	assert.Equal(t, "docx", metadata["format"])
+
+	// The extracted text is stored so analysis reads it like any other transcript
+	stored, err := os.ReadFile(transcript.FilePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(stored), "Guest: Thanks for having me.")
+}
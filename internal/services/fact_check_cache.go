@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// factCheckCache is a database-backed agents.FactCheckCache shared by all workers
+type factCheckCache struct {
+	db  *gorm.DB
+	ttl time.Duration
+}
+
+// newFactCheckCache creates a cache whose entries expire after ttl
+func newFactCheckCache(db *gorm.DB, ttl time.Duration) *factCheckCache {
+	return &factCheckCache{db: db, ttl: ttl}
+}
+
+// normalizeClaim lowercases a claim, collapses whitespace, and drops surrounding
+// punctuation so trivially different phrasings share a cache entry
+func normalizeClaim(claim string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(claim)), " ")
+	return strings.TrimFunc(normalized, func(r rune) bool {
+		return unicode.IsPunct(r) || unicode.IsSpace(r)
+	})
+}
+
+// claimHash returns the cache key for a claim
+func claimHash(claim string) string {
+	sum := sha256.Sum256([]byte(normalizeClaim(claim)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached verification of a claim if it was checked within the TTL
+func (c *factCheckCache) Get(ctx context.Context, claim string) (agents.FactCheck, bool) {
+	var entry models.FactCheckCacheEntry
+	err := c.db.WithContext(ctx).
+		Where("claim_hash = ? AND checked_at >= ?", claimHash(claim), time.Now().Add(-c.ttl)).
+		First(&entry).Error
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			logger.LogErrorWithStack(err, map[string]interface{}{
+				"operation": "get_fact_check_cache",
+			})
+		}
+		return agents.FactCheck{}, false
+	}
+
+	var sources []string
+	if entry.Sources != nil {
+		json.Unmarshal(entry.Sources, &sources)
+	}
+
+	return agents.FactCheck{
+		Claim:      entry.Claim,
+		Verdict:    entry.Verdict,
+		Confidence: entry.Confidence,
+		Evidence:   entry.Evidence,
+		Sources:    sources,
+	}, true
+}
+
+// Put stores or refreshes the cached verification of a claim
+func (c *factCheckCache) Put(ctx context.Context, factCheck agents.FactCheck) {
+	sourcesJSON, _ := json.Marshal(factCheck.Sources)
+	entry := &models.FactCheckCacheEntry{
+		ClaimHash:  claimHash(factCheck.Claim),
+		Claim:      factCheck.Claim,
+		Verdict:    factCheck.Verdict,
+		Confidence: factCheck.Confidence,
+		Evidence:   factCheck.Evidence,
+		Sources:    sourcesJSON,
+		CheckedAt:  time.Now(),
+	}
+
+	err := c.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "claim_hash"}},
+		UpdateAll: true,
+	}).Create(entry).Error
+	if err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation": "put_fact_check_cache",
+		})
+	}
+}
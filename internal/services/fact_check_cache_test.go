@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeClaim(t *testing.T) {
+	assert.Equal(t, "the earth is round", normalizeClaim("  The Earth   is ROUND. "))
+	assert.Equal(t, claimHash("The earth is round"), claimHash("the earth is round!"))
+	assert.NotEqual(t, claimHash("The earth is round"), claimHash("The earth is flat"))
+}
+
+func TestFactCheckCache_PutAndGet(t *testing.T) {
+	db := setupTestDB(t)
+	cache := newFactCheckCache(db, time.Hour)
+	ctx := context.Background()
+
+	_, ok := cache.Get(ctx, "The Earth is round")
+	assert.False(t, ok)
+
+	cache.Put(ctx, agents.FactCheck{
+		Claim:      "The Earth is round",
+		Verdict:    "true",
+		Confidence: 0.99,
+		Evidence:   "Satellite imagery",
+		Sources:    []string{"https://nasa.gov"},
+	})
+	// Refreshing an entry replaces it rather than failing on the primary key
+	cache.Put(ctx, agents.FactCheck{
+		Claim:      "the earth is round.",
+		Verdict:    "true",
+		Confidence: 0.97,
+		Evidence:   "Updated evidence",
+		Sources:    []string{"https://esa.int"},
+	})
+
+	cached, ok := cache.Get(ctx, "THE EARTH IS ROUND")
+	require.True(t, ok)
+	assert.Equal(t, models.VerdictTrue, cached.Verdict)
+	assert.Equal(t, 0.97, cached.Confidence)
+	assert.Equal(t, "Updated evidence", cached.Evidence)
+	assert.Equal(t, []string{"https://esa.int"}, cached.Sources)
+}
+
+func TestFactCheckCache_ExpiredEntriesAreIgnored(t *testing.T) {
+	db := setupTestDB(t)
+	cache := newFactCheckCache(db, time.Hour)
+
+	require.NoError(t, db.Create(&models.FactCheckCacheEntry{
+		ClaimHash:  claimHash("Pluto is a planet"),
+		Claim:      "Pluto is a planet",
+		Verdict:    "true",
+		Confidence: 0.9,
+		CheckedAt:  time.Now().Add(-2 * time.Hour),
+	}).Error)
+
+	_, ok := cache.Get(context.Background(), "Pluto is a planet")
+	assert.False(t, ok)
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/clients"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/requestctx"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Errors returned when an analysis's fact checks cannot be re-run
+var (
+	ErrFactCheckDisabled        = errors.New("fact checking is disabled")
+	ErrAnalysisNotCompleted     = errors.New("analysis is not completed")
+	ErrFactCheckRerunInProgress = errors.New("fact checks are already being re-run for this analysis")
+)
+
+// defaultFactCheckRerunTimeout bounds a re-run when FACT_CHECKER_TIMEOUT is unset, so a
+// hung call cannot hold the analysis's re-run slot forever
+const defaultFactCheckRerunTimeout = 30 * time.Minute
+
+// factCheckReruns holds the analyses whose fact checks are being re-run, so a second
+// request for the same analysis is turned away rather than racing the first
+var factCheckReruns sync.Map
+
+// FactCheckRerunResponse acknowledges a fact-check re-run started in the background
+type FactCheckRerunResponse struct {
+	AnalysisID uuid.UUID `json:"analysis_id"`
+	JobID      uuid.UUID `json:"job_id"` // The re-run is recorded in this job's events
+	Status     string    `json:"status"`
+	Message    string    `json:"message"`
+}
+
+// RerunFactChecks re-runs only the fact checker against a completed analysis's stored
+// transcript, in the background, and replaces its fact checks once it finishes. The
+// summary and takeaways are left as they are. If the fact checker fails, the existing fact
+// checks are kept.
+func (s *AnalysisService) RerunFactChecks(analysisID uuid.UUID, correlationID string) (*FactCheckRerunResponse, error) {
+	if !s.config.EnableFactCheck {
+		return nil, ErrFactCheckDisabled
+	}
+
+	var analysis models.AnalysisResult
+	if err := s.db.Where("id = ?", analysisID).First(&analysis).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("analysis not found")
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"analysis_id": analysisID,
+			"operation":   "find_analysis_for_fact_check_rerun",
+		})
+		return nil, fmt.Errorf("failed to find analysis: %w", err)
+	}
+	if analysis.Status != "completed" {
+		return nil, ErrAnalysisNotCompleted
+	}
+
+	if _, running := factCheckReruns.LoadOrStore(analysisID, struct{}{}); running {
+		return nil, ErrFactCheckRerunInProgress
+	}
+
+	s.recordJobEvent(analysis.JobID, analysis.Status, "fact_checker", "Fact check re-run started")
+	go func() {
+		defer factCheckReruns.Delete(analysisID)
+		timeout := s.config.FactCheckerTimeout
+		if timeout <= 0 {
+			timeout = defaultFactCheckRerunTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		s.rerunFactChecks(ctx, &analysis, correlationID)
+	}()
+
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"analysis_id": analysisID,
+		"job_id":      analysis.JobID,
+	}).Info("Fact check re-run started")
+
+	return &FactCheckRerunResponse{
+		AnalysisID: analysis.ID,
+		JobID:      analysis.JobID,
+		Status:     "running",
+		Message:    "Fact checks are being re-run; results update when it finishes",
+	}, nil
+}
+
+// rerunFactChecks runs the fact checker over the analysis's transcript, with the ad filter,
+// claim categories, instructions and model version the analysis was run with, and stores
+// the outcome
+func (s *AnalysisService) rerunFactChecks(ctx context.Context, analysis *models.AnalysisResult, correlationID string) {
+	log := logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"analysis_id": analysis.ID,
+		"job_id":      analysis.JobID,
+	})
+
+	var transcript models.Transcript
+	if err := s.db.Where("id = ?", analysis.TranscriptID).First(&transcript).Error; err != nil {
+		s.failFactCheckRerun(analysis, "Transcript not found", err, correlationID)
+		return
+	}
+	release, err := getContentBudget(s.config).Acquire(ctx, transcriptContentSize(&transcript, s.config))
+	if err != nil {
+		s.failFactCheckRerun(analysis, "Timed out waiting for memory to read the transcript", err, correlationID)
+		return
+	}
+	defer release()
+	content, err := NewTranscriptService(s.db, s.config).ReadTranscriptContent(&transcript)
+	if err != nil {
+		s.failFactCheckRerun(analysis, "Failed to read transcript content", err, correlationID)
+		return
+	}
+
+	options := AnalysisOptions{
+		StripAds:        adFilterWasEnabled(analysis.AnalysisMetadata),
+		ClaimCategories: storedClaimCategories(analysis.AnalysisMetadata),
+	}
+	if analysis.Instructions != nil {
+		options.Instructions = *analysis.Instructions
+	}
+	// An analysis that used several versions has no single one to reproduce, so it gets
+	// the configured model
+	if analysis.Model != nil && !strings.Contains(*analysis.Model, ",") {
+		options.Model = *analysis.Model
+	}
+	content, _ = s.applyAdFilter(content, options, analysis.JobID, correlationID)
+
+	ctx = requestctx.WithJobID(requestctx.WithCorrelationID(ctx, correlationID), analysis.JobID)
+	if options.Model != "" {
+		ctx = clients.WithModel(ctx, options.Model)
+	}
+	start := time.Now()
+	result, status, err := s.runFactCheckerAgent(ctx, content, options, analysis.JobID, correlationID)
+	if err == nil && status == models.FactCheckStatusFailed {
+		err = errors.New("fact checker failed")
+	}
+	if err != nil {
+		s.failFactCheckRerun(analysis, "Fact checker failed", err, correlationID)
+		return
+	}
+
+	results, err := s.transformAnalysisResults("", nil, result.FactChecks, analysis.JobID, correlationID)
+	if err != nil {
+		s.failFactCheckRerun(analysis, "Failed to convert fact checks", err, correlationID)
+		return
+	}
+	annotateTimestamps(results, content, nil)
+
+	if err := s.replaceFactChecks(analysis.ID, status, degradedFactCheckMetadata(result), results.FactChecks); err != nil {
+		s.failFactCheckRerun(analysis, "Failed to save fact checks", err, correlationID)
+		return
+	}
+
+	log.WithFields(map[string]interface{}{
+		"fact_checks":       len(results.FactChecks),
+		"fact_check_status": status,
+		"duration":          time.Since(start),
+	}).Info("Fact check re-run completed")
+	s.recordJobEvent(analysis.JobID, analysis.Status, "fact_checker",
+		fmt.Sprintf("Fact check re-run completed: %d fact checks (%s)", len(results.FactChecks), status))
+}
+
+// replaceFactChecks swaps an analysis's fact checks for a new set and records the new
+// fact-check status and degraded details (nil when the run was not degraded), in one
+// transaction so readers never see a partial set
+func (s *AnalysisService) replaceFactChecks(analysisID uuid.UUID, status string, degraded map[string]interface{}, factChecks []FactCheckResult) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("analysis_id = ?", analysisID).Delete(&models.FactCheck{}).Error; err != nil {
+			return err
+		}
+		for _, fc := range factChecks {
+			sourcesJSON, _ := json.Marshal(fc.Sources)
+			evidence := fc.Evidence
+			factCheck := &models.FactCheck{
+				ID:          uuid.New(),
+				AnalysisID:  analysisID,
+				Claim:       fc.Claim,
+				Verdict:     fc.Verdict,
+				Confidence:  fc.Confidence,
+				Evidence:    &evidence,
+				Sources:     sourcesJSON,
+				CheckedAt:   time.Now(),
+				Cached:      fc.Cached,
+				SearchQuery: fc.SearchQuery,
+			}
+			if err := tx.Create(factCheck).Error; err != nil {
+				return err
+			}
+		}
+
+		var analysis models.AnalysisResult
+		if err := tx.Select("analysis_metadata").Where("id = ?", analysisID).First(&analysis).Error; err != nil {
+			return err
+		}
+		metadata, err := withFactCheckMetadata(analysis.AnalysisMetadata, degraded)
+		if err != nil {
+			return err
+		}
+		return tx.Model(&models.AnalysisResult{}).Where("id = ?", analysisID).Updates(map[string]interface{}{
+			"fact_check_status": status,
+			"analysis_metadata": metadata,
+		}).Error
+	})
+}
+
+// withFactCheckMetadata returns analysis metadata with its fact_check entry replaced by
+// degraded, or removed when degraded is nil
+func withFactCheckMetadata(metadata []byte, degraded map[string]interface{}) (datatypes.JSON, error) {
+	fields := make(map[string]interface{})
+	if len(metadata) > 0 {
+		if err := json.Unmarshal(metadata, &fields); err != nil {
+			return nil, fmt.Errorf("failed to decode analysis metadata: %w", err)
+		}
+	}
+	if degraded != nil {
+		fields["fact_check"] = degraded
+	} else {
+		delete(fields, "fact_check")
+	}
+	return json.Marshal(fields)
+}
+
+// failFactCheckRerun logs and records a re-run that stopped early, leaving the existing
+// fact checks in place
+func (s *AnalysisService) failFactCheckRerun(analysis *models.AnalysisResult, message string, err error, correlationID string) {
+	logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+		"analysis_id": analysis.ID,
+		"job_id":      analysis.JobID,
+		"operation":   "rerun_fact_checks",
+	})
+	s.recordJobEvent(analysis.JobID, analysis.Status, "fact_checker",
+		fmt.Sprintf("Fact check re-run failed, previous fact checks kept: %s", message))
+}
+
+// adFilterWasEnabled reports whether an analysis's metadata records that ads were stripped
+// before its agents ran
+func adFilterWasEnabled(metadata []byte) bool {
+	var stored struct {
+		AdFilter struct {
+			Enabled bool `json:"enabled"`
+		} `json:"ad_filter"`
+	}
+	if len(metadata) == 0 || json.Unmarshal(metadata, &stored) != nil {
+		return false
+	}
+	return stored.AdFilter.Enabled
+}
+
+// storedClaimCategories returns the claim categories an analysis's metadata records it was
+// limited to, or nil when it checked every kind of claim
+func storedClaimCategories(metadata []byte) []agents.ClaimCategory {
+	var stored struct {
+		ClaimCategories []agents.ClaimCategory `json:"claim_categories"`
+	}
+	if len(metadata) == 0 || json.Unmarshal(metadata, &stored) != nil {
+		return nil
+	}
+	return stored.ClaimCategories
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createRerunTestAnalysis(t *testing.T, service *AnalysisService, status string) *models.AnalysisResult {
+	t.Helper()
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: uuid.NewString(), UploadedAt: time.Now()}
+	require.NoError(t, service.db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: status, FactCheckStatus: models.FactCheckStatusDegraded}
+	require.NoError(t, service.db.Create(analysis).Error)
+	return analysis
+}
+
+func TestAnalysisService_RerunFactChecks_Rejected(t *testing.T) {
+	cfg := setupAnalysisTestConfig(t)
+	cfg.EnableFactCheck = true
+	service := NewAnalysisService(setupAnalysisTestDB(t), cfg)
+
+	t.Run("unknown analysis", func(t *testing.T) {
+		_, err := service.RerunFactChecks(uuid.New(), "test-correlation-id")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("analysis not completed", func(t *testing.T) {
+		analysis := createRerunTestAnalysis(t, service, "processing")
+		_, err := service.RerunFactChecks(analysis.ID, "test-correlation-id")
+		assert.ErrorIs(t, err, ErrAnalysisNotCompleted)
+	})
+
+	t.Run("re-run already in progress", func(t *testing.T) {
+		analysis := createRerunTestAnalysis(t, service, "completed")
+		factCheckReruns.Store(analysis.ID, struct{}{})
+		defer factCheckReruns.Delete(analysis.ID)
+
+		_, err := service.RerunFactChecks(analysis.ID, "test-correlation-id")
+		assert.ErrorIs(t, err, ErrFactCheckRerunInProgress)
+	})
+
+	t.Run("fact checking disabled", func(t *testing.T) {
+		disabled := NewAnalysisService(service.db, setupAnalysisTestConfig(t))
+		analysis := createRerunTestAnalysis(t, disabled, "completed")
+		_, err := disabled.RerunFactChecks(analysis.ID, "test-correlation-id")
+		assert.ErrorIs(t, err, ErrFactCheckDisabled)
+	})
+}
+
+func TestAnalysisService_ReplaceFactChecks(t *testing.T) {
+	service := NewAnalysisService(setupAnalysisTestDB(t), setupAnalysisTestConfig(t))
+	analysis := createRerunTestAnalysis(t, service, "completed")
+	service.saveFactChecks(context.Background(), analysis.ID, []FactCheckResult{
+		{Claim: "Old claim", Verdict: models.VerdictUnverifiable, Sources: map[string]interface{}{"sources": []string{}}},
+	}, "test-correlation-id")
+
+	err := service.replaceFactChecks(analysis.ID, models.FactCheckStatusCompleted, nil, []FactCheckResult{
+		{Claim: "New claim", Verdict: models.VerdictTrue, Confidence: 0.9, Evidence: "Checked", Sources: map[string]interface{}{"sources": []string{"https://example.com"}}},
+	})
+	require.NoError(t, err)
+
+	response, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	require.Len(t, response.FactChecks, 1)
+	assert.Equal(t, "New claim", response.FactChecks[0].Claim)
+	assert.Equal(t, []string{"https://example.com"}, response.FactChecks[0].Sources)
+	assert.Equal(t, models.FactCheckStatusCompleted, response.FactCheckStatus)
+}
+
+func TestAnalysisService_ReplaceFactChecks_RefreshesDegradedFlag(t *testing.T) {
+	service := NewAnalysisService(setupAnalysisTestDB(t), setupAnalysisTestConfig(t))
+	analysis := createRerunTestAnalysis(t, service, "completed")
+	require.NoError(t, service.db.Model(analysis).Update("analysis_metadata",
+		`{"agents_run": ["fact_checker"], "fact_check": {"degraded": true, "reason": "search unavailable"}}`).Error)
+
+	// A healthy re-run clears the flag and keeps the rest of the metadata
+	require.NoError(t, service.replaceFactChecks(analysis.ID, models.FactCheckStatusCompleted, nil, nil))
+	var stored models.AnalysisResult
+	require.NoError(t, service.db.Where("id = ?", analysis.ID).First(&stored).Error)
+	assert.JSONEq(t, `{"agents_run": ["fact_checker"]}`, string(stored.AnalysisMetadata))
+
+	// A degraded re-run sets it again
+	degraded := degradedFactCheckMetadata(agents.Result{Degraded: true, DegradedReason: "rate limited"})
+	require.NoError(t, service.replaceFactChecks(analysis.ID, models.FactCheckStatusDegraded, degraded, nil))
+	require.NoError(t, service.db.Where("id = ?", analysis.ID).First(&stored).Error)
+	assert.JSONEq(t, `{"agents_run": ["fact_checker"], "fact_check": {"degraded": true, "reason": "rate limited"}}`, string(stored.AnalysisMetadata))
+	assert.Equal(t, models.FactCheckStatusDegraded, stored.FactCheckStatus)
+}
+
+func TestAdFilterWasEnabled(t *testing.T) {
+	assert.True(t, adFilterWasEnabled([]byte(`{"ad_filter": {"enabled": true, "segments_removed": 2}}`)))
+	assert.False(t, adFilterWasEnabled([]byte(`{"ad_filter": {"enabled": false}}`)))
+	assert.False(t, adFilterWasEnabled(nil))
+}
+
+func TestStoredClaimCategories(t *testing.T) {
+	assert.Equal(t, []agents.ClaimCategory{agents.ClaimCategoryDates}, storedClaimCategories([]byte(`{"ad_filter":{"enabled":false},"claim_categories":["dates"]}`)))
+	assert.Nil(t, storedClaimCategories([]byte(`{"ad_filter":{"enabled":true}}`)))
+	assert.Nil(t, storedClaimCategories(nil))
+}
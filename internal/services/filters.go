@@ -0,0 +1,62 @@
+package services
+
+import (
+	"time"
+
+	"podcast-analyzer/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// DateRange restricts list queries to records created within an inclusive time range
+type DateRange struct {
+	After  *time.Time
+	Before *time.Time
+}
+
+// apply adds the range bounds on the given timestamp column to a query
+func (d DateRange) apply(query *gorm.DB, column string) *gorm.DB {
+	if d.After != nil {
+		query = query.Where(column+" >= ?", *d.After)
+	}
+	if d.Before != nil {
+		query = query.Where(column+" <= ?", *d.Before)
+	}
+	return query
+}
+
+// FactCheckFilter narrows and pages the fact checks embedded in an analysis result. The
+// zero value returns all of them.
+type FactCheckFilter struct {
+	Verdict models.Verdict // Only fact checks with this verdict, when set
+	Limit   int            // Maximum fact checks to return; 0 means no limit
+	Offset  int            // Fact checks to skip before the first one returned
+}
+
+// IsZero reports whether the filter returns every fact check
+func (f FactCheckFilter) IsZero() bool {
+	return f == FactCheckFilter{}
+}
+
+// apply adds the verdict condition to a fact check query
+func (f FactCheckFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.Verdict != "" {
+		query = query.Where("verdict = ?", f.Verdict)
+	}
+	return query
+}
+
+// page adds the limit and offset to a fact check query, ordering it so pages are stable
+func (f FactCheckFilter) page(query *gorm.DB) *gorm.DB {
+	if f.Limit <= 0 && f.Offset <= 0 {
+		return query
+	}
+	query = query.Order("checked_at ASC").Order("id ASC")
+	if f.Limit > 0 {
+		query = query.Limit(f.Limit)
+	}
+	if f.Offset > 0 {
+		query = query.Offset(f.Offset)
+	}
+	return query
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// JobEventResponse represents one entry of a job's timeline
+type JobEventResponse struct {
+	Status    string    `json:"status"`
+	Stage     string    `json:"stage,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// JobEventsResponse represents the timeline of an analysis job
+type JobEventsResponse struct {
+	JobID  uuid.UUID          `json:"job_id"`
+	Events []JobEventResponse `json:"events"`
+}
+
+// recordJobEvent appends an entry to a job's timeline. The timeline is for auditing, so
+// a failed write is logged rather than failing the job.
+func (s *AnalysisService) recordJobEvent(jobID uuid.UUID, status, stage, message string) {
+	event := &models.JobEvent{
+		JobID:     jobID,
+		Status:    status,
+		Stage:     stage,
+		CreatedAt: time.Now(),
+	}
+	if message != "" {
+		event.Message = &message
+	}
+
+	if err := s.db.Create(event).Error; err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"job_id":    jobID,
+			"status":    status,
+			"stage":     stage,
+			"operation": "record_job_event",
+		})
+	}
+}
+
+// GetJobEvents returns a job's state changes and stages, oldest first
+func (s *AnalysisService) GetJobEvents(jobID uuid.UUID, correlationID string) (*JobEventsResponse, error) {
+	var analysis models.AnalysisResult
+	if err := s.db.Select("id").Where("job_id = ?", jobID).First(&analysis).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("analysis job not found")
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":    jobID,
+			"operation": "find_job_for_events",
+		})
+		return nil, fmt.Errorf("failed to find job: %w", err)
+	}
+
+	var events []models.JobEvent
+	if err := s.db.Where("job_id = ?", jobID).Order("created_at ASC").Find(&events).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":    jobID,
+			"operation": "list_job_events",
+		})
+		return nil, fmt.Errorf("failed to get job events: %w", err)
+	}
+
+	response := &JobEventsResponse{
+		JobID:  jobID,
+		Events: make([]JobEventResponse, len(events)),
+	}
+	for i, event := range events {
+		response.Events[i] = JobEventResponse{
+			Status:    event.Status,
+			Stage:     event.Stage,
+			CreatedAt: event.CreatedAt,
+		}
+		if event.Message != nil {
+			response.Events[i].Message = *event.Message
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisService_GetJobEvents_RecordsTimeline(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	service := NewAnalysisService(db, cfg)
+
+	job := &models.AnalysisResult{
+		TranscriptID: uuid.New(),
+		JobID:        uuid.New(),
+		Status:       "pending",
+		CreatedAt:    time.Now(),
+	}
+	require.NoError(t, db.Create(job).Error)
+
+	require.NoError(t, service.UpdateJobStatus(job.JobID, "processing", ""))
+	service.recordJobEvent(job.JobID, "processing", "summarizer", "")
+	require.NoError(t, service.UpdateJobStatus(job.JobID, "failed", "Summarizer failed"))
+
+	// Rejected transitions are not recorded
+	require.Error(t, service.UpdateJobStatus(job.JobID, "completed", ""))
+
+	response, err := service.GetJobEvents(job.JobID, "test-correlation-id")
+	require.NoError(t, err)
+
+	assert.Equal(t, job.JobID, response.JobID)
+	require.Len(t, response.Events, 3)
+	assert.Equal(t, "processing", response.Events[0].Status)
+	assert.Equal(t, "", response.Events[0].Stage)
+	assert.Equal(t, "summarizer", response.Events[1].Stage)
+	assert.Equal(t, "failed", response.Events[2].Status)
+	assert.Equal(t, "Summarizer failed", response.Events[2].Message)
+	assert.False(t, response.Events[2].CreatedAt.Before(response.Events[0].CreatedAt))
+}
+
+func TestAnalysisService_GetJobEvents_NotFound(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	service := NewAnalysisService(db, cfg)
+
+	response, err := service.GetJobEvents(uuid.New(), "test-correlation-id")
+
+	assert.Nil(t, response)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
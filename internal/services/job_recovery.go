@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Defaults applied when job recovery batching is not configured
+const (
+	defaultJobRecoveryBatchSize = 100
+	interruptedJobMessage       = "Interrupted by a server restart before it finished"
+)
+
+// JobRecoverySummary reports the outcome of recovering interrupted jobs
+type JobRecoverySummary struct {
+	Recovered int // Jobs marked failed
+	Batches   int // Batches processed
+}
+
+// interruptedJob identifies one job left pending or processing
+type interruptedJob struct {
+	ID    uuid.UUID
+	JobID uuid.UUID
+}
+
+// RecoverInterruptedJobs fails the jobs a previous server process left pending or
+// processing, since their goroutines died with it. Jobs created at or after startedAt
+// belong to this process and are left alone. Jobs are updated in batches with a pause
+// between them so large tables are never locked for long.
+func (s *AnalysisService) RecoverInterruptedJobs(ctx context.Context, startedAt time.Time, correlationID string) (*JobRecoverySummary, error) {
+	log := logger.WithCorrelationID(correlationID)
+	batchSize := s.config.JobRecoveryBatchSize
+	if batchSize <= 0 {
+		batchSize = defaultJobRecoveryBatchSize
+	}
+
+	summary := &JobRecoverySummary{}
+	var cursor uuid.UUID
+	for {
+		var batch []interruptedJob
+		if err := s.db.Model(&models.AnalysisResult{}).
+			Select("id", "job_id").
+			Where("status IN ? AND created_at < ? AND id > ?", []string{"pending", "processing"}, startedAt, cursor).
+			Order("id").
+			Limit(batchSize).
+			Find(&batch).Error; err != nil {
+			logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+				"recovered": summary.Recovered,
+				"operation": "find_interrupted_jobs",
+			})
+			return summary, fmt.Errorf("failed to find interrupted jobs: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		recovered, err := s.failInterruptedJobs(batch)
+		if err != nil {
+			logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+				"recovered": summary.Recovered,
+				"operation": "fail_interrupted_jobs",
+			})
+			return summary, fmt.Errorf("failed to recover interrupted jobs: %w", err)
+		}
+		summary.Recovered += recovered
+		summary.Batches++
+		cursor = batch[len(batch)-1].ID
+
+		log.WithFields(map[string]interface{}{
+			"batch":     summary.Batches,
+			"batch_len": len(batch),
+			"recovered": summary.Recovered,
+		}).Info("Recovered batch of interrupted jobs")
+
+		if len(batch) < batchSize {
+			break
+		}
+		select {
+		case <-time.After(s.config.JobRecoveryBatchDelay):
+		case <-ctx.Done():
+			return summary, ctx.Err()
+		}
+	}
+
+	log.WithFields(map[string]interface{}{
+		"recovered": summary.Recovered,
+		"batches":   summary.Batches,
+	}).Info("Interrupted job recovery completed")
+	return summary, nil
+}
+
+// failInterruptedJobs marks one batch of jobs failed in a single update, skipping any that
+// moved on since they were read, and records an event for each job it failed
+func (s *AnalysisService) failInterruptedJobs(batch []interruptedJob) (int, error) {
+	ids := make([]uuid.UUID, len(batch))
+	for i, job := range batch {
+		ids[i] = job.ID
+	}
+
+	result := s.db.Model(&models.AnalysisResult{}).
+		Where("id IN ? AND status IN ?", ids, []string{"pending", "processing"}).
+		Updates(map[string]interface{}{
+			"status":        "failed",
+			"error_message": interruptedJobMessage,
+			"completed_at":  time.Now(),
+		})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	var failed []uuid.UUID
+	if err := s.db.Model(&models.AnalysisResult{}).
+		Where("id IN ? AND status = ? AND error_message = ?", ids, "failed", interruptedJobMessage).
+		Pluck("job_id", &failed).Error; err != nil {
+		return int(result.RowsAffected), err
+	}
+	for _, jobID := range failed {
+		s.recordJobEvent(jobID, "failed", "", interruptedJobMessage)
+	}
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisService_RecoverInterruptedJobs(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	cfg.JobRecoveryBatchSize = 2
+	cfg.JobRecoveryBatchDelay = time.Millisecond
+	service := NewAnalysisService(db, cfg)
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: "recoveryhash", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+
+	startedAt := time.Now()
+	before := startedAt.Add(-time.Hour)
+	newJob := func(status string, createdAt time.Time) *models.AnalysisResult {
+		analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: status, CreatedAt: createdAt}
+		require.NoError(t, db.Create(analysis).Error)
+		return analysis
+	}
+
+	var interrupted []*models.AnalysisResult
+	for i := 0; i < 3; i++ {
+		interrupted = append(interrupted, newJob("processing", before))
+	}
+	interrupted = append(interrupted, newJob("pending", before))
+	completed := newJob("completed", before)
+	current := newJob("processing", startedAt.Add(time.Second))
+
+	summary, err := service.RecoverInterruptedJobs(context.Background(), startedAt, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, 4, summary.Recovered)
+	assert.Equal(t, 2, summary.Batches)
+
+	for _, analysis := range interrupted {
+		var stored models.AnalysisResult
+		require.NoError(t, db.First(&stored, "id = ?", analysis.ID).Error)
+		assert.Equal(t, "failed", stored.Status)
+		require.NotNil(t, stored.ErrorMessage)
+		assert.Equal(t, interruptedJobMessage, *stored.ErrorMessage)
+		assert.NotNil(t, stored.CompletedAt)
+
+		var events []models.JobEvent
+		require.NoError(t, db.Where("job_id = ?", analysis.JobID).Find(&events).Error)
+		require.Len(t, events, 1)
+		assert.Equal(t, "failed", events[0].Status)
+	}
+
+	for _, analysis := range []*models.AnalysisResult{completed, current} {
+		var stored models.AnalysisResult
+		require.NoError(t, db.First(&stored, "id = ?", analysis.ID).Error)
+		assert.Equal(t, analysis.Status, stored.Status)
+	}
+}
+
+func TestAnalysisService_RecoverInterruptedJobs_Canceled(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	cfg := setupAnalysisTestConfig(t)
+	cfg.JobRecoveryBatchSize = 1
+	cfg.JobRecoveryBatchDelay = time.Hour
+	service := NewAnalysisService(db, cfg)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, db.Create(&models.AnalysisResult{ID: uuid.New(), TranscriptID: uuid.New(), JobID: uuid.New(), Status: "processing", CreatedAt: time.Now().Add(-time.Hour)}).Error)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	summary, err := service.RecoverInterruptedJobs(ctx, time.Now(), "test-correlation-id")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, summary.Recovered)
+}
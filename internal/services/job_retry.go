@@ -0,0 +1,137 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// ErrJobNotRetryable is returned when a job that has not failed is asked to retry
+var ErrJobNotRetryable = errors.New("only failed jobs can be retried")
+
+// storedJobOptions is the form of AnalysisOptions saved with a job. Instructions and the
+// summary style have their own columns.
+type storedJobOptions struct {
+	StripAds          bool                   `json:"strip_ads"`
+	MaxTakeaways      int                    `json:"max_takeaways,omitempty"`
+	Model             string                 `json:"model,omitempty"`
+	TakeawaysSummary  bool                   `json:"takeaways_summary"`
+	Headline          bool                   `json:"headline"`
+	ClaimCategories   []agents.ClaimCategory `json:"claim_categories,omitempty"`
+	CleanupTranscript bool                   `json:"cleanup_transcript"`
+}
+
+// encodeJobOptions serializes the options a job is started with
+func encodeJobOptions(options AnalysisOptions) datatypes.JSON {
+	encoded, _ := json.Marshal(storedJobOptions{
+		StripAds:          options.StripAds,
+		MaxTakeaways:      options.MaxTakeaways,
+		Model:             options.Model,
+		TakeawaysSummary:  options.TakeawaysSummary,
+		Headline:          options.Headline,
+		ClaimCategories:   options.ClaimCategories,
+		CleanupTranscript: options.CleanupTranscript,
+	})
+	return encoded
+}
+
+// jobOptions rebuilds the options a job was started with. Jobs created before options were
+// stored get the configured defaults.
+func (s *AnalysisService) jobOptions(analysis *models.AnalysisResult) AnalysisOptions {
+	stored := storedJobOptions{
+		StripAds:         s.config.AdFilterEnabled,
+		TakeawaysSummary: s.config.EnableTakeawaysSummary,
+		Headline:         s.config.EnableSummaryHeadline,
+	}
+	if len(analysis.JobOptions) > 0 {
+		_ = json.Unmarshal(analysis.JobOptions, &stored)
+	}
+
+	options := AnalysisOptions{
+		StripAds:          stored.StripAds,
+		MaxTakeaways:      stored.MaxTakeaways,
+		Model:             stored.Model,
+		SummaryStyle:      agents.SummaryStyle(analysis.SummaryStyle),
+		TakeawaysSummary:  stored.TakeawaysSummary,
+		Headline:          stored.Headline,
+		ClaimCategories:   stored.ClaimCategories,
+		CleanupTranscript: stored.CleanupTranscript,
+	}
+	if analysis.Instructions != nil {
+		options.Instructions = *analysis.Instructions
+	}
+	return options
+}
+
+// RetryAnalysisJob runs a failed job again under its existing job ID, with the options it
+// was started with. Stages the failed attempt completed are reused from their stored
+// output, so the retry only pays for the stages that had not finished.
+func (s *AnalysisService) RetryAnalysisJob(jobID uuid.UUID, correlationID string) (*AnalysisJobResponse, error) {
+	var analysis models.AnalysisResult
+	if err := s.db.Where("job_id = ?", jobID).First(&analysis).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("job not found")
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":    jobID,
+			"operation": "find_job_for_retry",
+		})
+		return nil, fmt.Errorf("failed to find job: %w", err)
+	}
+	if analysis.Status != "failed" {
+		return nil, fmt.Errorf("%w: job is %s", ErrJobNotRetryable, analysis.Status)
+	}
+
+	// Failed is otherwise terminal, so the job is requeued directly rather than through
+	// UpdateJobStatus, after the same jobs-in-progress check new jobs get. Fact checks a
+	// crashed attempt saved part of are dropped; the new attempt saves the full set.
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.checkJobsInProgress(tx, analysis.TranscriptID, correlationID); err != nil {
+			return err
+		}
+		result := tx.Model(&models.AnalysisResult{}).
+			Where("id = ? AND status = ?", analysis.ID, "failed").
+			Updates(map[string]interface{}{"status": "pending", "error_message": nil, "completed_at": nil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: job changed concurrently", ErrJobNotRetryable)
+		}
+		return tx.Where("analysis_id = ?", analysis.ID).Delete(&models.FactCheck{}).Error
+	})
+	if errors.Is(err, ErrJobNotRetryable) || errors.Is(err, ErrJobsInProgress) {
+		return nil, err
+	}
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"job_id":    jobID,
+			"operation": "requeue_failed_job",
+		})
+		return nil, fmt.Errorf("failed to requeue job: %w", err)
+	}
+	s.recordJobEvent(analysis.JobID, "pending", "", "Job queued for retry")
+
+	s.startAnalysisJob(&analysis, s.jobOptions(&analysis), correlationID)
+
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"job_id":        analysis.JobID,
+		"transcript_id": analysis.TranscriptID,
+		"analysis_id":   analysis.ID,
+	}).Info("Analysis job queued for retry")
+
+	return &AnalysisJobResponse{
+		JobID:        analysis.JobID,
+		TranscriptID: analysis.TranscriptID,
+		Status:       "pending",
+		Message:      "Analysis job queued for retry; stages that already finished are reused",
+	}, nil
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/agents"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalysisService_RetryAnalysisJob_ResumesStoredStages(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.EnableSummarizer = true
+	cfg.EnableTakeaways = true
+	cfg.EnableFactCheck = true
+	service := NewAnalysisService(db, cfg)
+	correlationID := "test-correlation-retry"
+
+	upload, err := NewTranscriptService(db, cfg).UploadTranscript(&UploadTranscriptRequest{
+		File: createTestFileHeader(t, "episode.txt", "Host: Welcome to the show.\nGuest: Thanks for having me."),
+	}, correlationID)
+	require.NoError(t, err)
+
+	errorMessage := "Analysis failed after the summarizer"
+	analysis := &models.AnalysisResult{
+		ID:           uuid.New(),
+		TranscriptID: upload.TranscriptID,
+		JobID:        uuid.New(),
+		Status:       "failed",
+		SummaryStyle: "prose",
+		JobOptions:   encodeJobOptions(AnalysisOptions{TakeawaysSummary: true}),
+		ErrorMessage: &errorMessage,
+	}
+	require.NoError(t, db.Create(analysis).Error)
+	partial := "Partial"
+	require.NoError(t, db.Create(&models.FactCheck{ID: uuid.New(), AnalysisID: analysis.ID, Claim: "Partial claim", Verdict: models.VerdictTrue, Evidence: &partial}).Error)
+
+	// Every stage is stored, so the retry succeeds without calling the API
+	service.saveAgentRun(analysis.JobID, "summarizer", agentRunSummary{Text: "Stored summary"}, correlationID)
+	service.saveAgentRun(analysis.JobID, "takeaway_extractor", agentRunTakeaways{Takeaways: []string{"Stored takeaway"}}, correlationID)
+	service.saveAgentRun(analysis.JobID, "takeaway_synthesizer", agentRunText{Text: "Stored synthesis"}, correlationID)
+	service.saveAgentRun(analysis.JobID, "fact_checker", agents.Result{FactChecks: []agents.FactCheck{
+		{Claim: "Stored claim", Verdict: models.VerdictTrue, Confidence: 0.9, Evidence: "Stored evidence"},
+	}}, correlationID)
+
+	response, err := service.RetryAnalysisJob(analysis.JobID, correlationID)
+	require.NoError(t, err)
+	assert.Equal(t, analysis.JobID, response.JobID)
+	assert.Equal(t, "pending", response.Status)
+
+	var retried models.AnalysisResult
+	require.Eventually(t, func() bool {
+		return db.Where("id = ?", analysis.ID).First(&retried).Error == nil && retried.Status == "completed"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NotNil(t, retried.Summary)
+	assert.Equal(t, "Stored summary", *retried.Summary)
+	require.NotNil(t, retried.TakeawaysSummary)
+	assert.Equal(t, "Stored synthesis", *retried.TakeawaysSummary)
+	assert.Contains(t, string(retried.AnalysisMetadata), `"resumed_agents":["summarizer","takeaway_extractor","takeaway_synthesizer","fact_checker"]`)
+
+	var factChecks []models.FactCheck
+	require.NoError(t, db.Where("analysis_id = ?", analysis.ID).Find(&factChecks).Error)
+	require.Len(t, factChecks, 1)
+	assert.Equal(t, "Stored claim", factChecks[0].Claim)
+}
+
+func TestAnalysisService_RetryAnalysisJob_OnlyFailedJobs(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewAnalysisService(db, setupTestConfig(t))
+
+	for _, status := range []string{"pending", "processing", "completed", "cancelled"} {
+		analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: uuid.New(), JobID: uuid.New(), Status: status}
+		require.NoError(t, db.Create(analysis).Error)
+
+		_, err := service.RetryAnalysisJob(analysis.JobID, "test-correlation-id")
+		assert.ErrorIs(t, err, ErrJobNotRetryable, status)
+	}
+
+	_, err := service.RetryAnalysisJob(uuid.New(), "test-correlation-id")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestAnalysisService_RetryAnalysisJob_JobsInProgress(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.MaxConcurrentJobsPerTranscript = 1
+	service := NewAnalysisService(db, cfg)
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: "retryhash", FilePath: "episode.txt", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	failed := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "failed"}
+	require.NoError(t, db.Create(failed).Error)
+	require.NoError(t, db.Create(&models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}).Error)
+
+	_, err := service.RetryAnalysisJob(failed.JobID, "test-correlation-id")
+
+	assert.ErrorIs(t, err, ErrJobsInProgress)
+	var stored models.AnalysisResult
+	require.NoError(t, db.Where("id = ?", failed.ID).First(&stored).Error)
+	assert.Equal(t, "failed", stored.Status)
+}
+
+func TestAnalysisService_jobOptions(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.AdFilterEnabled = true
+	cfg.EnableSummaryHeadline = true
+	service := NewAnalysisService(setupTestDB(t), cfg)
+	instructions := "Focus on the guest"
+
+	options := AnalysisOptions{
+		MaxTakeaways:      3,
+		Model:             "claude-test-20250101",
+		Instructions:      instructions,
+		SummaryStyle:      agents.SummaryStyleExecutive,
+		TakeawaysSummary:  true,
+		ClaimCategories:   []agents.ClaimCategory{agents.ClaimCategoryStatistics},
+		CleanupTranscript: true,
+	}
+	analysis := &models.AnalysisResult{
+		SummaryStyle: string(options.SummaryStyle),
+		Instructions: &instructions,
+		JobOptions:   encodeJobOptions(options),
+	}
+	assert.Equal(t, options, service.jobOptions(analysis))
+
+	// Jobs created before options were stored get the configured defaults
+	legacy := service.jobOptions(&models.AnalysisResult{SummaryStyle: "prose"})
+	assert.True(t, legacy.StripAds)
+	assert.True(t, legacy.Headline)
+	assert.False(t, legacy.TakeawaysSummary)
+	assert.Equal(t, agents.SummaryStyleProse, legacy.SummaryStyle)
+}
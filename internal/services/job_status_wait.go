@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// MaxJobStatusWait caps how long a status request is held, keeping it inside the
+// server's 30 second write timeout
+const MaxJobStatusWait = 25 * time.Second
+
+// jobStatusPollInterval is how often a held status request re-reads the job
+var jobStatusPollInterval = 500 * time.Millisecond
+
+// WaitForJobStatus long-polls a job: it returns as soon as the job's status differs from
+// since, or the current status once wait (capped at MaxJobStatusWait) runs out. It stops
+// with the context's error if the request goes away first.
+func (s *AnalysisService) WaitForJobStatus(ctx context.Context, jobID uuid.UUID, since string, wait time.Duration, correlationID string) (*JobStatusResponse, error) {
+	if _, known := jobStatusTransitions[since]; !known {
+		return nil, utils.NewValidationError("since", "since must be one of pending, processing, completed, failed, cancelled")
+	}
+	if wait > MaxJobStatusWait {
+		wait = MaxJobStatusWait
+	}
+
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(jobStatusPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := s.findJobStatus(jobID, correlationID)
+		if err != nil || status.Status != since {
+			return status, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline.C:
+			logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+				"job_id": jobID,
+				"status": status.Status,
+				"wait":   wait.String(),
+			}).Info("Job status unchanged before wait ran out")
+			return s.findJobStatus(jobID, correlationID)
+		case <-ticker.C:
+		}
+	}
+}
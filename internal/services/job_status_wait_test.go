@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createWaitTestJob(t *testing.T, service *AnalysisService, status string) uuid.UUID {
+	t.Helper()
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: uuid.NewString(), UploadedAt: time.Now()}
+	require.NoError(t, service.db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: status}
+	require.NoError(t, service.db.Create(analysis).Error)
+	return analysis.JobID
+}
+
+func shortenJobStatusPolling(t *testing.T) {
+	t.Helper()
+	previous := jobStatusPollInterval
+	jobStatusPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { jobStatusPollInterval = previous })
+}
+
+func TestAnalysisService_WaitForJobStatus_ReturnsOnChange(t *testing.T) {
+	shortenJobStatusPolling(t)
+	service := NewAnalysisService(setupAnalysisTestDB(t), setupAnalysisTestConfig(t))
+	jobID := createWaitTestJob(t, service, "processing")
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		service.db.Model(&models.AnalysisResult{}).Where("job_id = ?", jobID).Update("status", "completed")
+	}()
+
+	start := time.Now()
+	status, err := service.WaitForJobStatus(context.Background(), jobID, "processing", 5*time.Second, "test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, "completed", status.Status)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestAnalysisService_WaitForJobStatus_AlreadyDifferent(t *testing.T) {
+	service := NewAnalysisService(setupAnalysisTestDB(t), setupAnalysisTestConfig(t))
+	jobID := createWaitTestJob(t, service, "completed")
+
+	status, err := service.WaitForJobStatus(context.Background(), jobID, "pending", 5*time.Second, "test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, "completed", status.Status)
+}
+
+func TestAnalysisService_WaitForJobStatus_TimesOut(t *testing.T) {
+	shortenJobStatusPolling(t)
+	service := NewAnalysisService(setupAnalysisTestDB(t), setupAnalysisTestConfig(t))
+	jobID := createWaitTestJob(t, service, "pending")
+
+	status, err := service.WaitForJobStatus(context.Background(), jobID, "pending", 50*time.Millisecond, "test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, "pending", status.Status)
+}
+
+func TestAnalysisService_WaitForJobStatus_ContextCancelled(t *testing.T) {
+	shortenJobStatusPolling(t)
+	service := NewAnalysisService(setupAnalysisTestDB(t), setupAnalysisTestConfig(t))
+	jobID := createWaitTestJob(t, service, "pending")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	status, err := service.WaitForJobStatus(ctx, jobID, "pending", 5*time.Second, "test-correlation-id")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, status)
+}
+
+func TestAnalysisService_WaitForJobStatus_Errors(t *testing.T) {
+	service := NewAnalysisService(setupAnalysisTestDB(t), setupAnalysisTestConfig(t))
+
+	_, err := service.WaitForJobStatus(context.Background(), uuid.New(), "stuck", time.Second, "test-correlation-id")
+	var validationErrs utils.ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	assert.Equal(t, "since", validationErrs[0].Field)
+
+	_, err = service.WaitForJobStatus(context.Background(), uuid.New(), "pending", time.Second, "test-correlation-id")
+	assert.ErrorContains(t, err, "not found")
+}
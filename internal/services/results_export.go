@@ -0,0 +1,97 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExportFormatCSV is the only format analysis results can be exported in
+const ExportFormatCSV = "csv"
+
+// Export tables available from an analysis result
+const (
+	ExportTableFactChecks = "fact_checks"
+	ExportTableTakeaways  = "takeaways"
+)
+
+var factCheckCSVHeader = []string{"analysis_id", "transcript_filename", "claim", "verdict", "confidence", "evidence", "source"}
+
+var takeawayCSVHeader = []string{"analysis_id", "transcript_filename", "position", "takeaway", "timestamp"}
+
+// ExportFilename returns the download name of an analysis result's CSV export
+func ExportFilename(results *AnalysisResultsResponse, table string) string {
+	return fmt.Sprintf("analysis-%s-%s.csv", results.ID, table)
+}
+
+// formulaPrefixes are the leading characters that make a spreadsheet treat a cell as a formula
+const formulaPrefixes = "=+-@\t\r"
+
+// csvText escapes free text for a CSV cell, prefixing a single quote when it would
+// otherwise be run as a spreadsheet formula
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune(formulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// WriteResultsCSV writes one table of an analysis result as CSV: one row per fact check
+// with its first source, or one row per takeaway. Text from transcripts and Claude is
+// escaped so it cannot run as a formula when the file is opened in a spreadsheet.
+func WriteResultsCSV(w io.Writer, results *AnalysisResultsResponse, table string) error {
+	filename := ""
+	if results.TranscriptFilename != nil {
+		filename = *results.TranscriptFilename
+	}
+
+	writer := csv.NewWriter(w)
+	switch table {
+	case ExportTableFactChecks:
+		if err := writer.Write(factCheckCSVHeader); err != nil {
+			return err
+		}
+		for _, fc := range results.FactChecks {
+			evidence, source := "", ""
+			if fc.Evidence != nil {
+				evidence = *fc.Evidence
+			}
+			if len(fc.Sources) > 0 {
+				source = fc.Sources[0]
+			}
+			if err := writer.Write([]string{
+				results.ID.String(),
+				csvText(filename),
+				csvText(fc.Claim),
+				string(fc.Verdict),
+				strconv.FormatFloat(fc.Confidence, 'f', -1, 64),
+				csvText(evidence),
+				csvText(source),
+			}); err != nil {
+				return err
+			}
+		}
+	case ExportTableTakeaways:
+		if err := writer.Write(takeawayCSVHeader); err != nil {
+			return err
+		}
+		for i, takeaway := range results.Takeaways {
+			if err := writer.Write([]string{
+				results.ID.String(),
+				csvText(filename),
+				strconv.Itoa(i + 1),
+				csvText(takeaway),
+				csvText(results.TakeawayTimestamps[i]),
+			}); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported export table %q", table)
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
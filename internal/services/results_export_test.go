@@ -0,0 +1,90 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteResultsCSV_FactChecks(t *testing.T) {
+	filename := "episode.txt"
+	evidence := "Quoted \"evidence\",\nacross lines"
+	results := &AnalysisResultsResponse{
+		ID:                 uuid.New(),
+		TranscriptFilename: &filename,
+		FactChecks: []FactCheckResultResponse{
+			{Claim: "Apollo 11 landed in 1969", Verdict: models.VerdictTrue, Confidence: 0.95, Evidence: &evidence,
+				Sources: []string{"https://nasa.gov/apollo", "https://example.com/other"}},
+			{Claim: "Mars has three moons", Verdict: models.VerdictFalse, Confidence: 0.8},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteResultsCSV(&buf, results, ExportTableFactChecks))
+
+	// Reading the output back proves embedded quotes, commas and newlines were quoted
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, factCheckCSVHeader, rows[0])
+	assert.Equal(t, []string{results.ID.String(), "episode.txt", "Apollo 11 landed in 1969", "true", "0.95", evidence, "https://nasa.gov/apollo"}, rows[1])
+	assert.Equal(t, []string{results.ID.String(), "episode.txt", "Mars has three moons", "false", "0.8", "", ""}, rows[2])
+}
+
+func TestWriteResultsCSV_Takeaways(t *testing.T) {
+	results := &AnalysisResultsResponse{
+		ID:                 uuid.New(),
+		Takeaways:          []string{"Space is hard", "Funding matters"},
+		TakeawayTimestamps: map[int]string{1: "00:05:10"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteResultsCSV(&buf, results, ExportTableTakeaways))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, takeawayCSVHeader, rows[0])
+	assert.Equal(t, []string{results.ID.String(), "", "1", "Space is hard", ""}, rows[1])
+	assert.Equal(t, []string{results.ID.String(), "", "2", "Funding matters", "00:05:10"}, rows[2])
+}
+
+func TestWriteResultsCSV_EscapesFormulas(t *testing.T) {
+	filename := "=cmd|'/C calc'!A0.txt"
+	evidence := "@SUM(1+1)"
+	results := &AnalysisResultsResponse{
+		ID:                 uuid.New(),
+		TranscriptFilename: &filename,
+		FactChecks: []FactCheckResultResponse{
+			{Claim: "+1 more claim", Verdict: models.VerdictTrue, Confidence: 0.9, Evidence: &evidence,
+				Sources: []string{"-2+3"}},
+		},
+		Takeaways:          []string{"\t=HYPERLINK(\"http://evil.example\")", "Funding - and time - matter"},
+		TakeawayTimestamps: map[int]string{},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteResultsCSV(&buf, results, ExportTableFactChecks))
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{results.ID.String(), "'" + filename, "'+1 more claim", "true", "0.9", "'@SUM(1+1)", "'-2+3"}, rows[1])
+
+	buf.Reset()
+	require.NoError(t, WriteResultsCSV(&buf, results, ExportTableTakeaways))
+	rows, err = csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, "'\t=HYPERLINK(\"http://evil.example\")", rows[1][3])
+	assert.Equal(t, "Funding - and time - matter", rows[2][3])
+}
+
+func TestWriteResultsCSV_UnknownTable(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Error(t, WriteResultsCSV(&buf, &AnalysisResultsResponse{ID: uuid.New()}, "sources"))
+	assert.Empty(t, buf.String())
+}
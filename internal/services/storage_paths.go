@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"podcast-analyzer/internal/logger"
+)
+
+// ErrPathOutsideStorage is returned when a stored transcript path resolves outside every
+// allowed storage directory
+var ErrPathOutsideStorage = errors.New("transcript file path is outside the storage directory")
+
+// storageRoots returns the directories transcript files may live in: the storage path and
+// any extra allowed paths, cleaned and made absolute
+func (s *TranscriptService) storageRoots() []string {
+	var roots []string
+	for _, root := range append([]string{s.config.StoragePath}, s.config.StorageAllowedPaths...) {
+		if root == "" {
+			continue
+		}
+		absolute, err := filepath.Abs(root)
+		if err != nil {
+			continue
+		}
+		roots = append(roots, absolute)
+	}
+	return roots
+}
+
+// resolveStoragePath cleans a stored transcript path and checks it lies within an allowed
+// storage directory, so a tampered path cannot reach other files. It must be called before
+// any file operation on a path read from the database.
+func (s *TranscriptService) resolveStoragePath(path string) (string, error) {
+	if path == "" {
+		return "", ErrPathOutsideStorage
+	}
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve transcript file path: %w", err)
+	}
+
+	for _, root := range s.storageRoots() {
+		if pathWithin(absolute, root) {
+			return absolute, nil
+		}
+	}
+
+	logger.Log.WithFields(map[string]interface{}{
+		"file_path": path,
+		"resolved":  absolute,
+		"operation": "resolve_storage_path",
+	}).Error("Rejected transcript file path outside the storage directory")
+	return "", fmt.Errorf("%w: %s", ErrPathOutsideStorage, path)
+}
+
+// pathWithin reports whether a cleaned absolute path is inside root. The separator is part
+// of the prefix so /storage-other is not taken to be inside /storage.
+func pathWithin(path, root string) bool {
+	if path == root {
+		return false
+	}
+	return strings.HasPrefix(path, strings.TrimSuffix(root, string(filepath.Separator))+string(filepath.Separator))
+}
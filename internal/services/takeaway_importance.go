@@ -0,0 +1,26 @@
+package services
+
+// RankedTakeaway is a takeaway with the importance Claude gave it, from 1 (minor) to 5
+// (essential); importance is omitted where Claude gave none
+type RankedTakeaway struct {
+	Text       string `json:"text"`
+	Importance int    `json:"importance,omitempty"`
+}
+
+// annotateImportance stores ranked takeaways alongside the flat list, which is kept so
+// readers that predate ranking still find the takeaways. Nothing is stored when the
+// takeaways were not ranked.
+func annotateImportance(results *AnalysisResults, takeaways []string, importance []int) {
+	if len(importance) == 0 || results.Takeaways == nil {
+		return
+	}
+
+	ranked := make([]RankedTakeaway, len(takeaways))
+	for i, takeaway := range takeaways {
+		ranked[i] = RankedTakeaway{Text: takeaway}
+		if i < len(importance) {
+			ranked[i].Importance = importance[i]
+		}
+	}
+	results.Takeaways["ranked"] = ranked
+}
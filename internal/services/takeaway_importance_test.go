@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnnotateImportance(t *testing.T) {
+	takeaways := []string{"Sleep matters", "Exercise improves focus"}
+	results := &AnalysisResults{Takeaways: map[string]interface{}{"takeaways": takeaways}}
+
+	annotateImportance(results, takeaways, []int{5, 0})
+
+	assert.Equal(t, []RankedTakeaway{
+		{Text: "Sleep matters", Importance: 5},
+		{Text: "Exercise improves focus"},
+	}, results.Takeaways["ranked"])
+	assert.Equal(t, takeaways, results.Takeaways["takeaways"])
+}
+
+func TestAnnotateImportance_NotRankedLeavesResultsUnchanged(t *testing.T) {
+	takeaways := []string{"Sleep matters"}
+	results := &AnalysisResults{Takeaways: map[string]interface{}{"takeaways": takeaways}}
+
+	annotateImportance(results, takeaways, nil)
+
+	assert.NotContains(t, results.Takeaways, "ranked")
+}
+
+func TestDecodeStoredTakeaways(t *testing.T) {
+	plain := decodeStoredTakeaways([]byte(`["Sleep matters"]`))
+	assert.Equal(t, []string{"Sleep matters"}, plain.Takeaways)
+	assert.Nil(t, plain.Ranked)
+
+	stored := decodeStoredTakeaways([]byte(`{"takeaways": ["Sleep matters"], "timestamps": {"0": "00:01:00"}, "ranked": [{"text": "Sleep matters", "importance": 4}]}`))
+	assert.Equal(t, []string{"Sleep matters"}, stored.Takeaways)
+	assert.Equal(t, map[int]string{0: "00:01:00"}, stored.Timestamps)
+	assert.Equal(t, []RankedTakeaway{{Text: "Sleep matters", Importance: 4}}, stored.Ranked)
+}
+
+func TestAnalysisService_RankedTakeaways_RoundTrip(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: "ranked", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	takeaways := []string{"Sleep matters", "Exercise improves focus"}
+	results, err := service.transformAnalysisResults("Summary", takeaways, nil, analysis.JobID, "test-correlation-id")
+	require.NoError(t, err)
+	annotateImportance(results, takeaways, []int{3, 5})
+
+	_, err = service.saveAnalysisResults(context.Background(), analysis.JobID, results, "test-correlation-id")
+	require.NoError(t, err)
+
+	response, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, takeaways, response.Takeaways)
+	assert.Equal(t, []RankedTakeaway{
+		{Text: "Sleep matters", Importance: 3},
+		{Text: "Exercise improves focus", Importance: 5},
+	}, response.RankedTakeaways)
+}
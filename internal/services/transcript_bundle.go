@@ -0,0 +1,153 @@
+package services
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// BundleFilename returns the download name of a transcript's zip bundle
+func BundleFilename(transcript *models.Transcript) string {
+	name := strings.TrimSuffix(filepath.Base(transcript.Filename), filepath.Ext(transcript.Filename))
+	if name == "" || name == "." {
+		name = transcript.ID.String()
+	}
+	return name + "-bundle.zip"
+}
+
+// WriteTranscriptBundle streams a zip archive with the raw transcript, every analysis of it
+// as JSON, and a markdown summary. Entries are written one at a time so only a single
+// analysis is held in memory.
+func (s *TranscriptService) WriteTranscriptBundle(w io.Writer, transcript *models.Transcript, correlationID string) error {
+	archive := zip.NewWriter(w)
+
+	if err := s.writeBundleTranscript(archive, transcript); err != nil {
+		return err
+	}
+
+	var analysisIDs []uuid.UUID
+	if err := s.db.Model(&models.AnalysisResult{}).
+		Where("transcript_id = ?", transcript.ID).
+		Order("created_at ASC").
+		Pluck("id", &analysisIDs).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": transcript.ID,
+			"operation":     "list_bundle_analyses",
+		})
+		return fmt.Errorf("failed to list analyses: %w", err)
+	}
+
+	analysisService := NewAnalysisService(s.db, s.config)
+	var summary strings.Builder
+	writeBundleSummaryHeader(&summary, transcript, len(analysisIDs))
+
+	for i, analysisID := range analysisIDs {
+		analysis, err := analysisService.GetAnalysisResults(analysisID, FactCheckFilter{}, correlationID)
+		if err != nil {
+			return err
+		}
+
+		entry, err := archive.Create(fmt.Sprintf("analyses/%02d-%s.json", i+1, analysisID))
+		if err != nil {
+			return fmt.Errorf("failed to add analysis to bundle: %w", err)
+		}
+		encoder := json.NewEncoder(entry)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(analysis); err != nil {
+			return fmt.Errorf("failed to write analysis to bundle: %w", err)
+		}
+
+		writeBundleSummaryAnalysis(&summary, i+1, analysis)
+	}
+
+	entry, err := archive.Create("summary.md")
+	if err != nil {
+		return fmt.Errorf("failed to add summary to bundle: %w", err)
+	}
+	if _, err := io.WriteString(entry, summary.String()); err != nil {
+		return fmt.Errorf("failed to write summary to bundle: %w", err)
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"transcript_id":  transcript.ID,
+		"analysis_count": len(analysisIDs),
+	}).Info("Transcript bundle written")
+
+	return nil
+}
+
+// writeBundleTranscript copies the stored transcript file into the archive
+func (s *TranscriptService) writeBundleTranscript(archive *zip.Writer, transcript *models.Transcript) error {
+	file, err := s.OpenTranscriptContent(transcript)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	entry, err := archive.CreateHeader(&zip.FileHeader{
+		Name:     "transcript/" + filepath.Base(transcript.Filename),
+		Method:   zip.Deflate,
+		Modified: transcript.UploadedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add transcript to bundle: %w", err)
+	}
+	if _, err := io.Copy(entry, file); err != nil {
+		return fmt.Errorf("failed to write transcript to bundle: %w", err)
+	}
+	return nil
+}
+
+// writeBundleSummaryHeader writes the transcript section of the markdown summary
+func writeBundleSummaryHeader(summary *strings.Builder, transcript *models.Transcript, analysisCount int) {
+	fmt.Fprintf(summary, "# %s\n\n", transcript.Filename)
+	fmt.Fprintf(summary, "- Transcript ID: %s\n", transcript.ID)
+	fmt.Fprintf(summary, "- Uploaded: %s\n", transcript.UploadedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(summary, "- Words: %d\n", transcript.WordCount)
+	fmt.Fprintf(summary, "- Analyses: %d\n", analysisCount)
+}
+
+// writeBundleSummaryAnalysis writes one analysis section of the markdown summary
+func writeBundleSummaryAnalysis(summary *strings.Builder, number int, analysis *AnalysisResultsResponse) {
+	fmt.Fprintf(summary, "\n## Analysis %d (%s)\n\n", number, analysis.Status)
+	fmt.Fprintf(summary, "- Analysis ID: %s\n", analysis.ID)
+	fmt.Fprintf(summary, "- Created: %s\n", analysis.CreatedAt.UTC().Format(time.RFC3339))
+
+	if analysis.Summary != nil && *analysis.Summary != "" {
+		fmt.Fprintf(summary, "\n### Summary\n\n%s\n", *analysis.Summary)
+	}
+
+	if len(analysis.Takeaways) > 0 {
+		summary.WriteString("\n### Key Takeaways\n\n")
+		for i, takeaway := range analysis.Takeaways {
+			if timestamp := analysis.TakeawayTimestamps[i]; timestamp != "" {
+				takeaway = fmt.Sprintf("[%s] %s", timestamp, takeaway)
+			}
+			fmt.Fprintf(summary, "- %s\n", takeaway)
+		}
+	}
+
+	if len(analysis.FactChecks) > 0 {
+		summary.WriteString("\n### Fact Checks\n\n")
+		for _, fc := range analysis.FactChecks {
+			claim := fc.Claim
+			if fc.Timestamp != "" {
+				claim = fmt.Sprintf("[%s] %s", fc.Timestamp, claim)
+			}
+			fmt.Fprintf(summary, "- **%s** (%.0f%% confidence): %s\n", fc.Verdict, fc.Confidence*100, claim)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readZipEntry(t *testing.T, file *zip.File) string {
+	reader, err := file.Open()
+	require.NoError(t, err)
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestTranscriptService_WriteTranscriptBundle(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+
+	filePath := filepath.Join(cfg.StoragePath, "episode.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("Host: Welcome to the show."), 0644))
+
+	transcript := &models.Transcript{
+		ID:          uuid.New(),
+		Filename:    "episode.txt",
+		FilePath:    filePath,
+		ContentHash: "bundlehash",
+		WordCount:   5,
+		UploadedAt:  time.Now(),
+	}
+	require.NoError(t, db.Create(transcript).Error)
+
+	summary := "A short show intro."
+	analysis := &models.AnalysisResult{
+		ID:           uuid.New(),
+		TranscriptID: transcript.ID,
+		JobID:        uuid.New(),
+		Status:       "completed",
+		Summary:      &summary,
+		Takeaways:    []byte(`["Welcome matters"]`),
+		CreatedAt:    time.Now(),
+	}
+	require.NoError(t, db.Create(analysis).Error)
+	require.NoError(t, db.Create(&models.FactCheck{
+		AnalysisID: analysis.ID,
+		Claim:      "The show exists",
+		Verdict:    "true",
+		Confidence: 0.9,
+		CheckedAt:  time.Now(),
+	}).Error)
+
+	var buf bytes.Buffer
+	require.NoError(t, service.WriteTranscriptBundle(&buf, transcript, "test-correlation-id"))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 3)
+
+	assert.Equal(t, "transcript/episode.txt", archive.File[0].Name)
+	assert.Equal(t, "Host: Welcome to the show.", readZipEntry(t, archive.File[0]))
+
+	assert.Equal(t, "analyses/01-"+analysis.ID.String()+".json", archive.File[1].Name)
+	var stored AnalysisResultsResponse
+	require.NoError(t, json.Unmarshal([]byte(readZipEntry(t, archive.File[1])), &stored))
+	assert.Equal(t, analysis.ID, stored.ID)
+	assert.Len(t, stored.FactChecks, 1)
+
+	assert.Equal(t, "summary.md", archive.File[2].Name)
+	markdown := readZipEntry(t, archive.File[2])
+	assert.Contains(t, markdown, "# episode.txt")
+	assert.Contains(t, markdown, "A short show intro.")
+	assert.Contains(t, markdown, "- Welcome matters")
+	assert.Contains(t, markdown, "**true** (90% confidence): The show exists")
+}
+
+func TestBundleFilename(t *testing.T) {
+	assert.Equal(t, "episode-bundle.zip", BundleFilename(&models.Transcript{Filename: "episode.json"}))
+
+	id := uuid.New()
+	assert.Equal(t, id.String()+"-bundle.zip", BundleFilename(&models.Transcript{ID: id, Filename: ".txt"}))
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// speakerLabelPattern matches a "Speaker: text" line in a text transcript, allowing a
+// leading [HH:MM:SS] marker. Labels are short and start with a capital letter or digit.
+var speakerLabelPattern = regexp.MustCompile(`^(?:\[\d{1,2}:\d{2}(?::\d{2})?\]\s*)?([\p{Lu}\d][\p{L}\d .'_-]{0,39}):\s+\S`)
+
+// Diarization quality thresholds: a transcript is flagged when too few lines carry a
+// speaker label or every labeled line belongs to one speaker
+const (
+	minDiarizationLabeledRatio = 0.8
+	minDiarizationSpeakers     = 2
+)
+
+// diarizationSegment is one line or JSON segment of a transcript
+type diarizationSegment struct {
+	speaker string // "" when the segment has no speaker label
+	text    string
+}
+
+// DiarizationQuality summarizes how well a transcript's speakers are labeled
+type DiarizationQuality struct {
+	LabeledRatio    float64 `json:"labeled_ratio"`     // Share of segments with a speaker label
+	Speakers        int     `json:"speakers"`          // Distinct speaker labels
+	AvgSegmentWords float64 `json:"avg_segment_words"` // Mean words per labeled segment
+	LowQuality      bool    `json:"low_quality"`       // Summaries may attribute statements poorly
+}
+
+// measureDiarization computes diarization quality, returning nil when no segment has a
+// speaker label
+func measureDiarization(segments []diarizationSegment) *DiarizationQuality {
+	var total, labeled, words int
+	speakers := make(map[string]bool)
+	for _, segment := range segments {
+		if strings.TrimSpace(segment.text) == "" {
+			continue
+		}
+		total++
+		if segment.speaker == "" {
+			continue
+		}
+		labeled++
+		words += countWords(segment.text)
+		speakers[strings.ToLower(segment.speaker)] = true
+	}
+	if labeled == 0 {
+		return nil
+	}
+
+	quality := &DiarizationQuality{
+		LabeledRatio:    roundTo(float64(labeled)/float64(total), 2),
+		Speakers:        len(speakers),
+		AvgSegmentWords: roundTo(float64(words)/float64(labeled), 1),
+	}
+	quality.LowQuality = quality.LabeledRatio < minDiarizationLabeledRatio || quality.Speakers < minDiarizationSpeakers
+	return quality
+}
+
+// diarizationFromMetadata reads the diarization quality stored in transcript metadata
+func diarizationFromMetadata(metadata []byte) *DiarizationQuality {
+	var fields struct {
+		Diarization *DiarizationQuality `json:"diarization"`
+	}
+	if json.Unmarshal(metadata, &fields) != nil {
+		return nil
+	}
+	return fields.Diarization
+}
+
+// textDiarizationSegments splits a text transcript into lines, reading "Speaker: text"
+// labels
+func textDiarizationSegments(content string) []diarizationSegment {
+	lines := strings.Split(content, "\n")
+	segments := make([]diarizationSegment, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if match := speakerLabelPattern.FindStringSubmatchIndex(line); match != nil {
+			segments = append(segments, diarizationSegment{
+				speaker: strings.TrimSpace(line[match[2]:match[3]]),
+				text:    line[match[3]+1:],
+			})
+			continue
+		}
+		segments = append(segments, diarizationSegment{text: line})
+	}
+	return segments
+}
+
+// jsonDiarizationSegments reads the speaker field of each segment of a JSON transcript
+// array; a string transcript is read as text
+func jsonDiarizationSegments(transcript interface{}) []diarizationSegment {
+	if text, ok := transcript.(string); ok {
+		return textDiarizationSegments(text)
+	}
+	items, _ := transcript.([]interface{})
+	segments := make([]diarizationSegment, 0, len(items))
+	for _, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		text, _ := itemMap["text"].(string)
+		speaker, _ := itemMap["speaker"].(string)
+		segments = append(segments, diarizationSegment{speaker: strings.TrimSpace(speaker), text: text})
+	}
+	return segments
+}
+
+// roundTo rounds a value to the given number of decimal places
+func roundTo(value float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(value*scale) / scale
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasureDiarization_Text(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *DiarizationQuality
+	}{
+		{
+			name:     "no speaker labels",
+			content:  "Welcome to the show.\nToday we talk about sleep.",
+			expected: nil,
+		},
+		{
+			name:    "two speakers",
+			content: "Host: Welcome to the show.\n\n[00:00:05] Guest: Thanks for having me here.\nHost: Let's begin.",
+			expected: &DiarizationQuality{
+				LabeledRatio: 1, Speakers: 2, AvgSegmentWords: 3.7, LowQuality: false,
+			},
+		},
+		{
+			name:    "one speaker for everything",
+			content: "SPEAKER 1: Welcome to the show.\nSPEAKER 1: Thanks for having me.",
+			expected: &DiarizationQuality{
+				LabeledRatio: 1, Speakers: 1, AvgSegmentWords: 4, LowQuality: true,
+			},
+		},
+		{
+			name:    "mostly unlabeled lines",
+			content: "Host: Welcome to the show.\nThanks for having me.\nSo tell us about it.\nGuest: Sure.",
+			expected: &DiarizationQuality{
+				LabeledRatio: 0.5, Speakers: 2, AvgSegmentWords: 2.5, LowQuality: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, measureDiarization(textDiarizationSegments(tt.content)))
+		})
+	}
+}
+
+func TestMeasureDiarization_JSON(t *testing.T) {
+	transcript := []interface{}{
+		map[string]interface{}{"speaker": "Host", "text": "Welcome to the show"},
+		map[string]interface{}{"speaker": "host", "text": "Our guest today"},
+		map[string]interface{}{"text": "Thanks"},
+		map[string]interface{}{"speaker": "Guest", "text": "Glad to be here"},
+	}
+
+	quality := measureDiarization(jsonDiarizationSegments(transcript))
+	require.NotNil(t, quality)
+	assert.Equal(t, DiarizationQuality{LabeledRatio: 0.75, Speakers: 2, AvgSegmentWords: 3.7, LowQuality: true}, *quality)
+}
+
+func TestParseTranscriptContent_Diarization(t *testing.T) {
+	service := &TranscriptService{}
+
+	_, metadata, err := service.parseTranscriptContent([]byte("Host: Welcome to the show.\nGuest: Thanks."), ".txt")
+	require.NoError(t, err)
+	assert.Equal(t, &DiarizationQuality{LabeledRatio: 1, Speakers: 2, AvgSegmentWords: 2.5}, diarizationFromMetadata(metadata))
+
+	_, metadata, err = service.parseTranscriptContent([]byte("No speakers here."), ".txt")
+	require.NoError(t, err)
+	assert.Equal(t, "null", string(metadata))
+	assert.Nil(t, diarizationFromMetadata(metadata))
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// defaultEphemeralFilename names pasted content submitted without a filename
+const defaultEphemeralFilename = "pasted-text.txt"
+
+// ephemeralContentHash gives an ephemeral transcript a hash of its own, so pasted content
+// never collides with, or is reported as a duplicate of, an uploaded transcript
+func ephemeralContentHash(id uuid.UUID) string {
+	return "ephemeral-" + id.String()
+}
+
+// ephemeralFilename reduces a client-supplied filename to a base name, falling back to the
+// default when none is usable
+func ephemeralFilename(filename string) string {
+	name := filepath.Base(strings.TrimSpace(strings.ReplaceAll(filename, "\\", "/")))
+	if name == "." || name == "/" || name == "" {
+		return defaultEphemeralFilename
+	}
+	if len(name) > 255 {
+		name = name[:255]
+	}
+	return name
+}
+
+// CreateEphemeralTranscript stores pasted text as a transcript flagged ephemeral. The text
+// is held to the same size and encoding limits as an upload and parsed as plain text.
+func (s *TranscriptService) CreateEphemeralTranscript(content, filename, correlationID string) (*models.Transcript, error) {
+	var validationErrs utils.ValidationErrors
+	if strings.TrimSpace(content) == "" {
+		validationErrs.Add("content", "content is required")
+	}
+	if int64(len(content)) > s.config.MaxFileSize {
+		validationErrs.Add("content", fmt.Sprintf("content too large: %d bytes. Maximum: %d bytes", len(content), s.config.MaxFileSize))
+	}
+	if !utf8.ValidString(content) {
+		validationErrs.Add("content", "content must be UTF-8 encoded")
+	}
+	if len(validationErrs) > 0 {
+		return nil, validationErrs
+	}
+
+	normalized, normalization := normalizeTextContent([]byte(content))
+	counts, metadata, err := s.parseTranscriptContent(normalized, ".txt")
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "parse_ephemeral_transcript",
+		})
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+
+	id := uuid.New()
+	transcript := &models.Transcript{
+		ID:                 id,
+		Filename:           ephemeralFilename(filename),
+		ContentHash:        ephemeralContentHash(id),
+		WordCount:          counts.words,
+		CharCount:          counts.chars,
+		TranscriptMetadata: normalization.annotate(metadata),
+		Ephemeral:          true,
+		UploadedAt:         time.Now(),
+	}
+	if err := s.saveTranscriptToStorage(transcript, normalized, correlationID); err != nil {
+		return nil, err
+	}
+
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"transcript_id": transcript.ID,
+		"filename":      transcript.Filename,
+		"word_count":    transcript.WordCount,
+	}).Info("Ephemeral transcript created")
+	return transcript, nil
+}
+
+// CleanupEphemeralTranscript removes the stored content of an ephemeral transcript once its
+// analysis has finished. The record is kept, since the analysis results refer to it.
+// Transcripts that are not ephemeral are left alone.
+func (s *TranscriptService) CleanupEphemeralTranscript(id uuid.UUID, correlationID string) error {
+	log := logger.WithCorrelationID(correlationID)
+
+	var transcript models.Transcript
+	if err := s.db.Where("id = ?", id).First(&transcript).Error; err != nil {
+		return fmt.Errorf("failed to find ephemeral transcript: %w", err)
+	}
+	if !transcript.Ephemeral {
+		return nil
+	}
+
+	filePath, err := s.resolveStoragePath(transcript.FilePath)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": id,
+			"file_path":     filePath,
+			"operation":     "cleanup_ephemeral_transcript",
+		})
+		return fmt.Errorf("failed to remove ephemeral transcript file: %w", err)
+	}
+
+	log.WithField("transcript_id", id).Info("Ephemeral transcript content removed")
+	return nil
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// contentHashPattern matches a hex-encoded SHA-256 digest
+var contentHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// TranscriptExistsResponse says whether a transcript with a given content hash is stored
+type TranscriptExistsResponse struct {
+	Exists       bool       `json:"exists"`
+	TranscriptID *uuid.UUID `json:"transcript_id,omitempty"`
+}
+
+// TranscriptExists looks up a transcript by content hash, so clients can skip uploading a
+// transcript that would be rejected as a duplicate. The hash must be computed the way
+// uploads are hashed; see hashTranscriptContent.
+func (s *TranscriptService) TranscriptExists(contentHash string, correlationID string) (*TranscriptExistsResponse, error) {
+	contentHash = strings.ToLower(strings.TrimSpace(contentHash))
+	if !contentHashPattern.MatchString(contentHash) {
+		return nil, utils.NewValidationError("hash", "hash must be a hex-encoded SHA-256 digest (64 characters)")
+	}
+
+	var transcript models.Transcript
+	err := s.db.Select("id").Where("content_hash = ?", contentHash).First(&transcript).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &TranscriptExistsResponse{Exists: false}, nil
+	}
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"content_hash": contentHash,
+			"operation":    "find_transcript_by_hash",
+		})
+		return nil, fmt.Errorf("failed to look up transcript by hash: %w", err)
+	}
+
+	return &TranscriptExistsResponse{Exists: true, TranscriptID: &transcript.ID}, nil
+}
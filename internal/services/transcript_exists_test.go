@@ -0,0 +1,39 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"podcast-analyzer/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptService_TranscriptExists(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+
+	content := "This is a test transcript content"
+	fileHeader := createTestFileHeader(t, "test.txt", content)
+	uploaded, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+	require.NoError(t, err)
+
+	// The hash of the uploaded text finds it, whatever the case of the hex digits
+	hash := hashTranscriptContent([]byte(content))
+	resp, err := service.TranscriptExists(strings.ToUpper(hash), "test-correlation-id")
+	require.NoError(t, err)
+	assert.True(t, resp.Exists)
+	require.NotNil(t, resp.TranscriptID)
+	assert.Equal(t, uploaded.TranscriptID, *resp.TranscriptID)
+
+	resp, err = service.TranscriptExists(hashTranscriptContent([]byte("Something else entirely")), "test-correlation-id")
+	require.NoError(t, err)
+	assert.False(t, resp.Exists)
+	assert.Nil(t, resp.TranscriptID)
+
+	_, err = service.TranscriptExists("not-a-hash", "test-correlation-id")
+	var validationErrs utils.ValidationErrors
+	assert.ErrorAs(t, err, &validationErrs)
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// Longest language and tag values stored; longer values are cut to fit their columns
+const (
+	maxLanguageLength = 35
+	maxTagLength      = 100
+)
+
+// FacetCount is one distinct filter value and the number of transcripts that have it
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// TranscriptFacets lists the distinct languages and tags across listed transcripts, most
+// common first
+type TranscriptFacets struct {
+	Languages []FacetCount `json:"languages"`
+	Tags      []FacetCount `json:"tags"`
+}
+
+// facetsCache holds the last computed facets until they expire
+type facetsCache struct {
+	mu        sync.Mutex
+	facets    *TranscriptFacets
+	expiresAt time.Time
+}
+
+// transcriptLabels reads the language and tags a JSON upload carried in its metadata. Tags
+// may be a list or a comma-separated string. Values are trimmed, lowercased and deduplicated.
+func transcriptLabels(metadata []byte) (string, []string) {
+	var fields struct {
+		Language interface{} `json:"language"`
+		Tags     interface{} `json:"tags"`
+	}
+	if len(metadata) == 0 || json.Unmarshal(metadata, &fields) != nil {
+		return "", nil
+	}
+
+	language, _ := fields.Language.(string)
+	language = normalizeLabel(language, maxLanguageLength)
+
+	var rawTags []string
+	switch tags := fields.Tags.(type) {
+	case string:
+		rawTags = strings.Split(tags, ",")
+	case []interface{}:
+		for _, tag := range tags {
+			if text, ok := tag.(string); ok {
+				rawTags = append(rawTags, text)
+			}
+		}
+	}
+
+	var tags []string
+	seen := make(map[string]bool)
+	for _, tag := range rawTags {
+		tag = normalizeLabel(tag, maxTagLength)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	return language, tags
+}
+
+// normalizeLabel trims and lowercases a language or tag so equal values group together.
+// Values are cut to maxLength characters, not bytes, matching how the columns count them.
+func normalizeLabel(value string, maxLength int) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if runes := []rune(value); len(runes) > maxLength {
+		value = strings.TrimSpace(string(runes[:maxLength]))
+	}
+	return value
+}
+
+// addTranscriptTags tags a transcript, leaving tags it already has in place
+func (s *TranscriptService) addTranscriptTags(transcriptID uuid.UUID, tags []string, correlationID string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	rows := make([]models.TranscriptTag, len(tags))
+	for i, tag := range tags {
+		rows[i] = models.TranscriptTag{TranscriptID: transcriptID, Tag: tag}
+	}
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": transcriptID,
+			"tags":          tags,
+			"operation":     "add_transcript_tags",
+		})
+		return fmt.Errorf("failed to save transcript tags: %w", err)
+	}
+	return nil
+}
+
+// GetTranscriptFacets returns the distinct languages and tags of listed transcripts with
+// their counts. Results are cached for the configured TTL, since filter UIs ask often and
+// the values change rarely.
+func (s *TranscriptService) GetTranscriptFacets(correlationID string) (*TranscriptFacets, error) {
+	ttl := s.config.TranscriptFacetsCacheTTL
+	if ttl > 0 {
+		s.facets.mu.Lock()
+		defer s.facets.mu.Unlock()
+		if s.facets.facets != nil && time.Now().Before(s.facets.expiresAt) {
+			return s.facets.facets, nil
+		}
+	}
+
+	facets := &TranscriptFacets{Languages: []FacetCount{}, Tags: []FacetCount{}}
+
+	// Ephemeral transcripts are not listed, so they are not offered as filter values either
+	if err := s.db.Model(&models.Transcript{}).
+		Select("language AS value, COUNT(*) AS count").
+		Where("ephemeral = ? AND language <> ?", false, "").
+		Group("language").
+		Order("count DESC, value ASC").
+		Scan(&facets.Languages).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "count_transcript_languages",
+		})
+		return nil, fmt.Errorf("failed to count transcript languages: %w", err)
+	}
+
+	if err := s.db.Model(&models.TranscriptTag{}).
+		Select("transcript_tags.tag AS value, COUNT(*) AS count").
+		Joins("JOIN transcripts ON transcripts.id = transcript_tags.transcript_id").
+		Where("transcripts.ephemeral = ?", false).
+		Group("transcript_tags.tag").
+		Order("count DESC, value ASC").
+		Scan(&facets.Tags).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"operation": "count_transcript_tags",
+		})
+		return nil, fmt.Errorf("failed to count transcript tags: %w", err)
+	}
+
+	if ttl > 0 {
+		s.facets.facets = facets
+		s.facets.expiresAt = time.Now().Add(ttl)
+	}
+	return facets, nil
+}
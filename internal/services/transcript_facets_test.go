@@ -0,0 +1,114 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptLabels(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata string
+		language string
+		tags     []string
+	}{
+		{name: "list of tags", metadata: `{"language": " EN ", "tags": ["Tech", "news", "tech", 3, ""]}`, language: "en", tags: []string{"tech", "news"}},
+		{name: "comma-separated tags", metadata: `{"tags": "Sports, ,Football"}`, tags: []string{"sports", "football"}},
+		{name: "non-string language", metadata: `{"language": 7}`},
+		{name: "long CJK tag cut by characters", metadata: `{"language": "中文", "tags": ["` + strings.Repeat("播客", 60) + `"]}`, language: "中文", tags: []string{strings.Repeat("播客", 50)}},
+		{name: "no metadata", metadata: ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			language, tags := transcriptLabels([]byte(tt.metadata))
+			assert.Equal(t, tt.language, language)
+			assert.Equal(t, tt.tags, tags)
+		})
+	}
+}
+
+func TestTranscriptService_UploadTranscript_StoresLanguageAndTags(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	fileHeader := createTestFileHeader(t, "episode.json", `{"language": "ES", "tags": ["Politics", "news"], "transcript": "Hola a todos"}`)
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+	require.NoError(t, err)
+
+	transcript, err := service.GetTranscript(resp.TranscriptID)
+	require.NoError(t, err)
+	assert.Equal(t, "es", transcript.Language)
+
+	var tags []string
+	require.NoError(t, db.Model(&models.TranscriptTag{}).Where("transcript_id = ?", resp.TranscriptID).Order("tag").Pluck("tag", &tags).Error)
+	assert.Equal(t, []string{"news", "politics"}, tags)
+}
+
+func TestTranscriptService_GetTranscriptFacets(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.TranscriptFacetsCacheTTL = time.Minute
+	service := NewTranscriptService(db, cfg)
+
+	addTranscript := func(language string, ephemeral bool, tags ...string) {
+		transcript := &models.Transcript{ID: uuid.New(), Filename: "t.txt", ContentHash: uuid.NewString(), Language: language, Ephemeral: ephemeral, UploadedAt: time.Now()}
+		require.NoError(t, db.Create(transcript).Error)
+		require.NoError(t, service.addTranscriptTags(transcript.ID, tags, "test-correlation-id"))
+	}
+	addTranscript("en", false, "tech", "news")
+	addTranscript("en", false, "tech")
+	addTranscript("de", false)
+	addTranscript("", false, "misc")
+	addTranscript("fr", true, "hidden")
+
+	facets, err := service.GetTranscriptFacets("test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, []FacetCount{{Value: "en", Count: 2}, {Value: "de", Count: 1}}, facets.Languages)
+	assert.Equal(t, []FacetCount{{Value: "tech", Count: 2}, {Value: "misc", Count: 1}, {Value: "news", Count: 1}}, facets.Tags)
+
+	// Served from the cache until it expires
+	addTranscript("it", false)
+	cached, err := service.GetTranscriptFacets("test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, facets, cached)
+
+	service.facets.expiresAt = time.Now().Add(-time.Second)
+	refreshed, err := service.GetTranscriptFacets("test-correlation-id")
+	require.NoError(t, err)
+	assert.Len(t, refreshed.Languages, 3)
+}
+
+func TestTranscriptService_GetTranscriptFacets_Empty(t *testing.T) {
+	service := NewTranscriptService(setupTestDB(t), setupTestConfig(t))
+
+	facets, err := service.GetTranscriptFacets("test-correlation-id")
+
+	require.NoError(t, err)
+	assert.Equal(t, []FacetCount{}, facets.Languages)
+	assert.Equal(t, []FacetCount{}, facets.Tags)
+}
+
+func TestTranscriptService_ReparseTranscript_UpdatesLanguageAndAddsTags(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	stale := createStaleTranscript(t, service, "episode.json",
+		`{"language": "en", "tags": ["tech"], "transcript": "Welcome to the show"}`, `{}`)
+	require.NoError(t, service.addTranscriptTags(stale.ID, []string{"auto"}, "test-correlation-id"))
+
+	transcript, err := service.ReparseTranscript(stale.ID, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, "en", transcript.Language)
+
+	var tags []string
+	require.NoError(t, db.Model(&models.TranscriptTag{}).Where("transcript_id = ?", stale.ID).Order("tag").Pluck("tag", &tags).Error)
+	assert.Equal(t, []string{"auto", "tech"}, tags)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+)
+
+// defaultPreviewChars applies when no preview length is configured
+const defaultPreviewChars = 200
+
+// minPreviewReadBytes is the smallest head read for a preview. JSON transcripts spend
+// much of their head on keys, speakers and timestamps, so reading only previewChars
+// bytes would often leave nothing to show.
+const minPreviewReadBytes = 4096
+
+// jsonTextFieldPattern matches "text" and "transcript" string values in the head of a
+// JSON transcript. The closing quote is optional because the head may cut a value short.
+var jsonTextFieldPattern = regexp.MustCompile(`"(?:text|transcript)"\s*:\s*"((?:[^"\\]|\\.)*)`)
+
+// LoadPreviews sets Preview on each transcript from the head of its stored file. A file
+// that cannot be read is logged and left without a preview rather than failing the list.
+func (s *TranscriptService) LoadPreviews(transcripts []*models.Transcript, correlationID string) {
+	limit := s.config.TranscriptPreviewChars
+	if limit <= 0 {
+		limit = defaultPreviewChars
+	}
+
+	for _, transcript := range transcripts {
+		preview, err := s.readTranscriptPreview(transcript, limit)
+		if err != nil {
+			logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+				"transcript_id": transcript.ID,
+				"operation":     "read_transcript_preview",
+			})
+			continue
+		}
+		transcript.Preview = preview
+	}
+}
+
+// readTranscriptPreview reads only the head of a stored transcript and returns its first
+// limit characters with whitespace collapsed
+func (s *TranscriptService) readTranscriptPreview(transcript *models.Transcript, limit int) (string, error) {
+	file, err := s.OpenTranscriptContent(transcript)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	readBytes := limit * 8
+	if readBytes < minPreviewReadBytes {
+		readBytes = minPreviewReadBytes
+	}
+	head, err := io.ReadAll(io.LimitReader(file, int64(readBytes)))
+	if err != nil {
+		return "", err
+	}
+
+	text := string(head)
+	if strings.ToLower(filepath.Ext(transcript.FilePath)) == ".json" {
+		text = jsonPreviewText(head)
+	}
+	return truncatePreview(text, limit), nil
+}
+
+// jsonPreviewText pulls the transcript text out of the head of a JSON transcript without
+// parsing the whole document
+func jsonPreviewText(head []byte) string {
+	var parts []string
+	for _, match := range jsonTextFieldPattern.FindAllSubmatch(head, -1) {
+		raw := string(match[1])
+		// A value cut short may end halfway through an escape sequence
+		raw = strings.TrimSuffix(raw, `\`)
+
+		var value string
+		if err := json.Unmarshal([]byte(`"`+raw+`"`), &value); err != nil {
+			value = raw
+		}
+		parts = append(parts, value)
+	}
+	return strings.Join(parts, " ")
+}
+
+// truncatePreview collapses whitespace and cuts text to at most limit characters, ending
+// on a word boundary where possible
+func truncatePreview(text string, limit int) string {
+	// The head read may end mid-character
+	text = strings.ToValidUTF8(text, "")
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+
+	runes := []rune(text)
+	cut := string(runes[:limit])
+	if runes[limit] == ' ' {
+		return cut + "..."
+	}
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return cut + "..."
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"podcast-analyzer/internal/config"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePreviewFile writes a transcript file into the configured storage directory
+func writePreviewFile(t *testing.T, cfg *config.Config, name, content string) string {
+	path := filepath.Join(cfg.StoragePath, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// readPreview stores content and reads its preview through a test service
+func readPreview(t *testing.T, name, content string, limit int) (string, error) {
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(setupTestDB(t), cfg)
+	return service.readTranscriptPreview(&models.Transcript{ID: uuid.New(), FilePath: writePreviewFile(t, cfg, name, content)}, limit)
+}
+
+func TestReadTranscriptPreview(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		limit    int
+		expected string
+	}{
+		{
+			name:     "short plain text",
+			filename: "episode.txt",
+			content:  "Host: Welcome\n\nto   the show.",
+			limit:    200,
+			expected: "Host: Welcome to the show.",
+		},
+		{
+			name:     "long plain text cut on a word boundary",
+			filename: "episode.txt",
+			content:  "Host: Welcome to the show everyone",
+			limit:    20,
+			expected: "Host: Welcome to the...",
+		},
+		{
+			name:     "json segments",
+			filename: "episode.json",
+			content:  `{"transcript": [{"speaker": "Host", "timestamp": "00:00", "text": "Welcome to the show."}, {"speaker": "Guest", "text": "Thanks \"for\" having me."}]}`,
+			limit:    200,
+			expected: `Welcome to the show. Thanks "for" having me.`,
+		},
+		{
+			name:     "json string transcript",
+			filename: "episode.json",
+			content:  `{"title": "Episode 1", "transcript": "Host: Welcome.\nGuest: Hello."}`,
+			limit:    200,
+			expected: "Host: Welcome. Guest: Hello.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview, err := readPreview(t, tt.filename, tt.content, tt.limit)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, preview)
+		})
+	}
+}
+
+func TestReadTranscriptPreview_ReadsOnlyHead(t *testing.T) {
+	// The transcript field sits beyond the head read, so a full read would find it
+	content := `{"notes": "` + strings.Repeat("x", minPreviewReadBytes) + `", "transcript": "Welcome to the show."}`
+	preview, err := readPreview(t, "episode.json", content, 100)
+
+	require.NoError(t, err)
+	assert.Empty(t, preview)
+}
+
+func TestReadTranscriptPreview_TruncatedJSON(t *testing.T) {
+	// A text value cut off by the head read still contributes what was read
+	content := `{"transcript": [{"text": "` + strings.Repeat("word ", minPreviewReadBytes) + `"}]}`
+	preview, err := readPreview(t, "episode.json", content, 20)
+
+	require.NoError(t, err)
+	assert.Equal(t, "word word word word...", preview)
+}
+
+func TestTranscriptService_LoadPreviews(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.TranscriptPreviewChars = 13
+	service := NewTranscriptService(db, cfg)
+
+	transcripts := []*models.Transcript{
+		{ID: uuid.New(), FilePath: writePreviewFile(t, cfg, "episode.txt", "Welcome to the show.")},
+		{ID: uuid.New(), FilePath: filepath.Join(cfg.StoragePath, "missing.txt")},
+	}
+
+	service.LoadPreviews(transcripts, "test-correlation-id")
+
+	assert.Equal(t, "Welcome to...", transcripts[0].Preview)
+	// An unreadable file leaves the transcript without a preview
+	assert.Empty(t, transcripts[1].Preview)
+}
@@ -0,0 +1,190 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// reparseBatchSize is how many transcripts a bulk reparse loads at a time
+const reparseBatchSize = 100
+
+// uploadMetadataKeys are metadata fields recorded at upload time that cannot be derived
+// again from the stored file, which has already been normalized
+var uploadMetadataKeys = []string{"bom_removed", "line_endings_normalized"}
+
+// ReparseFailure names a transcript a bulk reparse could not update
+type ReparseFailure struct {
+	TranscriptID uuid.UUID `json:"transcript_id"`
+	Error        string    `json:"error"`
+}
+
+// ReparseSummary reports the outcome of reparsing every transcript
+type ReparseSummary struct {
+	Processed int              `json:"processed"`
+	Updated   int              `json:"updated"`
+	Failed    []ReparseFailure `json:"failed"`
+}
+
+// ReparseTranscript runs a stored transcript through the current parser and saves the
+// recomputed word count, character count and metadata
+func (s *TranscriptService) ReparseTranscript(id uuid.UUID, correlationID string) (*models.Transcript, error) {
+	var transcript models.Transcript
+	if err := s.db.Where("id = ?", id).First(&transcript).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("transcript not found")
+		}
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": id,
+			"operation":     "find_transcript_for_reparse",
+		})
+		return nil, fmt.Errorf("failed to find transcript: %w", err)
+	}
+
+	if _, err := s.reparse(&transcript, correlationID); err != nil {
+		return nil, err
+	}
+	return &transcript, nil
+}
+
+// ReparseAllTranscripts reparses every stored transcript in batches. A transcript that
+// fails is recorded in the summary without stopping the run.
+func (s *TranscriptService) ReparseAllTranscripts(correlationID string) (*ReparseSummary, error) {
+	summary := &ReparseSummary{Failed: []ReparseFailure{}}
+
+	var batch []*models.Transcript
+	result := s.db.Order("uploaded_at").FindInBatches(&batch, reparseBatchSize, func(tx *gorm.DB, _ int) error {
+		for _, transcript := range batch {
+			summary.Processed++
+			changed, err := s.reparse(transcript, correlationID)
+			if err != nil {
+				summary.Failed = append(summary.Failed, ReparseFailure{TranscriptID: transcript.ID, Error: err.Error()})
+				continue
+			}
+			if changed {
+				summary.Updated++
+			}
+		}
+		return nil
+	})
+	if result.Error != nil {
+		logger.LogErrorWithStackAndCorrelation(result.Error, correlationID, map[string]interface{}{
+			"processed": summary.Processed,
+			"operation": "reparse_all_transcripts",
+		})
+		return nil, fmt.Errorf("failed to load transcripts: %w", result.Error)
+	}
+
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"processed": summary.Processed,
+		"updated":   summary.Updated,
+		"failed":    len(summary.Failed),
+	}).Info("Reparsed all transcripts")
+	return summary, nil
+}
+
+// reparse recomputes a transcript's counts and metadata from its stored file, saving and
+// reporting whether anything changed
+func (s *TranscriptService) reparse(transcript *models.Transcript, correlationID string) (bool, error) {
+	content, err := s.ReadTranscriptContent(transcript)
+	if err != nil {
+		return false, err
+	}
+
+	ext := strings.ToLower(filepath.Ext(transcript.Filename))
+	counts, metadata, err := s.parseTranscriptContent([]byte(content), ext)
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": transcript.ID,
+			"extension":     ext,
+			"operation":     "reparse_transcript_content",
+		})
+		return false, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+	metadata = carryUploadMetadata(transcript.TranscriptMetadata, metadata)
+	language, tags := transcriptLabels(metadata)
+
+	// Tags may also come from elsewhere, so the file's tags are added rather than replacing them
+	if err := s.addTranscriptTags(transcript.ID, tags, correlationID); err != nil {
+		return false, err
+	}
+
+	if counts.words == transcript.WordCount && counts.chars == transcript.CharCount && language == transcript.Language && jsonEqual(metadata, transcript.TranscriptMetadata) {
+		return false, nil
+	}
+
+	if err := s.db.Model(transcript).Updates(map[string]interface{}{
+		"word_count":          counts.words,
+		"char_count":          counts.chars,
+		"language":            language,
+		"transcript_metadata": datatypes.JSON(metadata),
+	}).Error; err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": transcript.ID,
+			"operation":     "save_reparsed_transcript",
+		})
+		return false, fmt.Errorf("failed to save transcript: %w", err)
+	}
+
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"transcript_id":  transcript.ID,
+		"old_word_count": transcript.WordCount,
+		"word_count":     counts.words,
+	}).Info("Transcript reparsed")
+
+	transcript.WordCount = counts.words
+	transcript.CharCount = counts.chars
+	transcript.Language = language
+	transcript.TranscriptMetadata = metadata
+	return true, nil
+}
+
+// carryUploadMetadata copies upload-time fields from the previous metadata into freshly
+// parsed metadata
+func carryUploadMetadata(previous, parsed []byte) []byte {
+	var old map[string]interface{}
+	if json.Unmarshal(previous, &old) != nil || len(old) == 0 {
+		return parsed
+	}
+
+	var fields map[string]interface{}
+	_ = json.Unmarshal(parsed, &fields)
+	carried := false
+	for _, key := range uploadMetadataKeys {
+		if value, ok := old[key]; ok {
+			if fields == nil {
+				fields = make(map[string]interface{})
+			}
+			fields[key] = value
+			carried = true
+		}
+	}
+	if !carried {
+		return parsed
+	}
+
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return parsed
+	}
+	return merged
+}
+
+// jsonEqual reports whether two JSON documents hold the same value
+func jsonEqual(a, b []byte) bool {
+	var left, right interface{}
+	if json.Unmarshal(a, &left) != nil || json.Unmarshal(b, &right) != nil {
+		return string(a) == string(b)
+	}
+	leftJSON, _ := json.Marshal(left)
+	rightJSON, _ := json.Marshal(right)
+	return string(leftJSON) == string(rightJSON)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+// createStaleTranscript stores a transcript file with outdated counts and metadata
+func createStaleTranscript(t *testing.T, service *TranscriptService, filename, content string, metadata string) *models.Transcript {
+	t.Helper()
+	transcript := &models.Transcript{
+		ID:                 uuid.New(),
+		Filename:           filename,
+		ContentHash:        uuid.NewString(),
+		WordCount:          1,
+		CharCount:          1,
+		TranscriptMetadata: datatypes.JSON(metadata),
+		UploadedAt:         time.Now(),
+	}
+	path, err := service.saveFile(transcript.ID, []byte(content))
+	require.NoError(t, err)
+	transcript.FilePath = path
+	require.NoError(t, service.db.Create(transcript).Error)
+	return transcript
+}
+
+func TestTranscriptService_ReparseTranscript(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	stale := createStaleTranscript(t, service, "episode.json",
+		`{"title": "Episode 1", "transcript": [{"speaker": "Host", "text": "Welcome to the show"}, {"speaker": "Guest", "text": "你好"}]}`,
+		`{"line_endings_normalized": true}`)
+
+	transcript, err := service.ReparseTranscript(stale.ID, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, 6, transcript.WordCount)
+
+	var stored models.Transcript
+	require.NoError(t, db.First(&stored, "id = ?", stale.ID).Error)
+	assert.Equal(t, 6, stored.WordCount)
+	assert.Equal(t, 18, stored.CharCount)
+	assert.JSONEq(t, `{"title": "Episode 1", "line_endings_normalized": true,
+		"diarization": {"labeled_ratio": 1, "speakers": 2, "avg_segment_words": 3, "low_quality": false}}`, string(stored.TranscriptMetadata))
+
+	_, err = service.ReparseTranscript(uuid.New(), "test-correlation-id")
+	assert.EqualError(t, err, "transcript not found")
+}
+
+func TestTranscriptService_ReparseAllTranscripts(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	stale := createStaleTranscript(t, service, "stale.txt", "Host: Welcome to the show.", "null")
+	createStaleTranscript(t, service, "current.txt", "A", "null") // Counts already match
+	missing := createStaleTranscript(t, service, "missing.txt", "Gone", "null")
+	require.NoError(t, os.Remove(missing.FilePath))
+
+	summary, err := service.ReparseAllTranscripts("test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Processed)
+	assert.Equal(t, 1, summary.Updated)
+	require.Len(t, summary.Failed, 1)
+	assert.Equal(t, missing.ID, summary.Failed[0].TranscriptID)
+	assert.Contains(t, summary.Failed[0].Error, filepath.Base(missing.FilePath))
+
+	var stored models.Transcript
+	require.NoError(t, db.First(&stored, "id = ?", stale.ID).Error)
+	assert.Equal(t, 5, stored.WordCount)
+
+	// A second run finds nothing to change
+	summary, err = service.ReparseAllTranscripts("test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, 0, summary.Updated)
+}
@@ -1,19 +1,28 @@
 package services
 
 import (
+	"bytes"
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime"
 	"mime/multipart"
+	"net/http"
 	"os"
 	"path/filepath"
+	"podcast-analyzer/internal/clients"
 	"podcast-analyzer/internal/config"
 	"podcast-analyzer/internal/models"
 	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/requestctx"
+	"podcast-analyzer/internal/utils"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -23,47 +32,118 @@ import (
 type TranscriptService struct {
 	db     *gorm.DB
 	config *config.Config
+
+	// uploadSlots bounds concurrent uploads; nil when uploads are unlimited
+	uploadSlots chan struct{}
+
+	// webhooks delivers upload callbacks
+	webhooks clients.WebhookSender
+
+	// facets caches the language and tag counts served to filter UIs
+	facets facetsCache
 }
 
 func NewTranscriptService(db *gorm.DB, cfg *config.Config) *TranscriptService {
-	return &TranscriptService{
-		db:     db,
-		config: cfg,
+	service := &TranscriptService{
+		db:       db,
+		config:   cfg,
+		webhooks: clients.NewWebhookClient(cfg),
+	}
+	if cfg.MaxConcurrentUploads > 0 {
+		service.uploadSlots = make(chan struct{}, cfg.MaxConcurrentUploads)
 	}
+	return service
+}
+
+// ErrUploadsSaturated is returned when every upload slot is taken. Uploads are rejected
+// immediately rather than queued so a burst cannot tie up connections and memory.
+var ErrUploadsSaturated = errors.New("too many uploads in progress, retry later")
+
+// acquireUploadSlot claims an upload slot without waiting and returns the function that
+// releases it
+func (s *TranscriptService) acquireUploadSlot() (func(), error) {
+	if s.uploadSlots == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.uploadSlots <- struct{}{}:
+		return func() { <-s.uploadSlots }, nil
+	default:
+		return nil, ErrUploadsSaturated
+	}
+}
+
+// uploadContentCopies approximates how many copies of an upload's bytes are held at once
+// while it is read, normalized and saved
+const uploadContentCopies = 2
+
+// acquireUploadContent reserves room in the shared content budget for an upload of the
+// given size, waiting up to InflightContentWait for other work to finish
+func (s *TranscriptService) acquireUploadContent(size int64) (func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.InflightContentWait)
+	defer cancel()
+	return getContentBudget(s.config).Acquire(ctx, size*uploadContentCopies)
 }
 
 // UploadTranscriptRequest represents the upload request
 type UploadTranscriptRequest struct {
-	File *multipart.FileHeader
+	File        *multipart.FileHeader
+	CallbackURL string // Receives the UploadTranscriptResponse once the transcript is saved
 }
 
+// uploadCallbackEvent names the webhook event sent to upload callback URLs
+const uploadCallbackEvent = "transcript.uploaded"
+
 // UploadTranscriptResponse represents the upload response
 type UploadTranscriptResponse struct {
 	TranscriptID uuid.UUID `json:"transcript_id"`
 	Filename     string    `json:"filename"`
 	WordCount    int       `json:"word_count"`
+	CharCount    int       `json:"char_count"`
+	Diarization  *DiarizationQuality `json:"diarization,omitempty"` // Speaker-label quality, for transcripts with speaker labels
 	Message      string    `json:"message"`
 }
 
+// BatchUploadResult holds the outcome of one file in a batch upload
+type BatchUploadResult struct {
+	Filename string
+	Response *UploadTranscriptResponse
+	Err      error
+}
+
+// DefaultMaxBatchFiles applies when no batch limit is configured
+const DefaultMaxBatchFiles = 20
+
+// jsonTranscriptShapes describes the accepted layouts of the "transcript" field in .json uploads
+var jsonTranscriptShapes = []string{
+	`"transcript": "<full transcript text>"`,
+	`"transcript": [{"text": "...", "speaker": "...", "timestamp": "..."}]`,
+}
+
+// TranscriptSchemaError indicates a .json upload parsed but does not match the expected transcript schema
+type TranscriptSchemaError struct {
+	Reason   string
+	Expected []string
+}
+
+func (e *TranscriptSchemaError) Error() string {
+	return fmt.Sprintf("invalid transcript JSON: %s (expected one of: %s)", e.Reason, strings.Join(e.Expected, " | "))
+}
+
+// newTranscriptSchemaError creates a schema error listing the accepted transcript layouts
+func newTranscriptSchemaError(reason string) *TranscriptSchemaError {
+	return &TranscriptSchemaError{
+		Reason:   reason,
+		Expected: jsonTranscriptShapes,
+	}
+}
+
 // UploadTranscript handles file upload and validation
 // validateUploadedFile validates file extension, size, and encoding
 func (s *TranscriptService) validateUploadedFile(req *UploadTranscriptRequest, correlationID string) (string, []byte, error) {
-	// Validate file extension
-	ext := strings.ToLower(filepath.Ext(req.File.Filename))
-	isValidExt := false
-	for _, allowedExt := range s.config.AllowedExts {
-		if ext == allowedExt {
-			isValidExt = true
-			break
-		}
-	}
-	if !isValidExt {
-		return "", nil, fmt.Errorf("invalid file extension: %s. Allowed: %v", ext, s.config.AllowedExts)
-	}
-
-	// Validate file size
-	if req.File.Size > s.config.MaxFileSize {
-		return "", nil, fmt.Errorf("file too large: %d bytes. Maximum: %d bytes", req.File.Size, s.config.MaxFileSize)
+	ext, err := s.checkUploadHeader(req)
+	if err != nil {
+		return "", nil, err
 	}
 
 	// Open and read file
@@ -86,14 +166,93 @@ func (s *TranscriptService) validateUploadedFile(req *UploadTranscriptRequest, c
 		return "", nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	// Word documents are zip archives, so they are converted to text rather than sniffed
+	if ext == docxExtension {
+		text, err := extractDocxText(content)
+		if err != nil {
+			return "", nil, err
+		}
+		return ext, []byte(text), nil
+	}
+
+	// Sniff the content so a binary renamed to an allowed extension is caught
+	if err := s.checkContentType(content, req.File.Filename, correlationID); err != nil {
+		return "", nil, err
+	}
+
 	// Validate UTF-8 encoding
 	if !isValidUTF8(content) {
-		return "", nil, fmt.Errorf("file must be UTF-8 encoded")
+		return "", nil, utils.NewValidationError("file.encoding", "file must be UTF-8 encoded")
 	}
 
 	return ext, content, nil
 }
 
+// checkUploadHeader validates the extension and declared size of an upload before any of
+// it is read, returning the lowercased extension
+func (s *TranscriptService) checkUploadHeader(req *UploadTranscriptRequest) (string, error) {
+	// Validate file extension
+	ext := strings.ToLower(filepath.Ext(req.File.Filename))
+	isValidExt := false
+	for _, allowedExt := range s.config.AllowedExts {
+		if ext == allowedExt {
+			isValidExt = true
+			break
+		}
+	}
+	var validationErrs utils.ValidationErrors
+	if !isValidExt {
+		validationErrs.Add("file.extension", fmt.Sprintf("invalid file extension: %s. Allowed: %v", ext, s.config.AllowedExts))
+	}
+
+	// Validate file size
+	if req.File.Size > s.config.MaxFileSize {
+		validationErrs.Add("file.size", fmt.Sprintf("file too large: %d bytes. Maximum: %d bytes", req.File.Size, s.config.MaxFileSize))
+	}
+	if len(validationErrs) > 0 {
+		return "", validationErrs
+	}
+	return ext, nil
+}
+
+// defaultAllowedMIMETypes applies when no allowed content types are configured
+var defaultAllowedMIMETypes = []string{"text/plain", "application/json"}
+
+// checkContentType compares the sniffed content type with the allowed types. Depending on
+// the configured mode a mismatch is rejected, only logged, or not checked at all.
+func (s *TranscriptService) checkContentType(content []byte, filename, correlationID string) error {
+	mode := s.config.MIMECheckMode
+	if mode == "off" {
+		return nil
+	}
+
+	detected := http.DetectContentType(content)
+	mediaType, _, err := mime.ParseMediaType(detected)
+	if err != nil {
+		mediaType = detected
+	}
+
+	allowed := s.config.AllowedMIMETypes
+	if len(allowed) == 0 {
+		allowed = defaultAllowedMIMETypes
+	}
+	for _, allowedType := range allowed {
+		if strings.EqualFold(mediaType, allowedType) {
+			return nil
+		}
+	}
+
+	if mode == "warn" {
+		logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+			"filename":      filename,
+			"content_type":  mediaType,
+			"allowed_types": allowed,
+		}).Warn("Uploaded file content type is not allowed")
+		return nil
+	}
+	return fmt.Errorf("unsupported content type: %s. Allowed: %v", mediaType, allowed)
+}
+
 // checkForDuplicates checks if transcript with same content hash already exists
 func (s *TranscriptService) checkForDuplicates(contentHash string, correlationID string) error {
 	log := logger.WithCorrelationID(correlationID)
@@ -107,9 +266,9 @@ func (s *TranscriptService) checkForDuplicates(contentHash string, correlationID
 }
 
 // processTranscriptFile processes file content and creates transcript record
-func (s *TranscriptService) processTranscriptFile(req *UploadTranscriptRequest, content []byte, ext string, contentHash string, correlationID string) (*models.Transcript, error) {
-	// Parse content and calculate word count
-	wordCount, metadata, err := s.parseTranscriptContent(content, ext)
+func (s *TranscriptService) processTranscriptFile(req *UploadTranscriptRequest, content []byte, ext string, contentHash string, normalization textNormalization, correlationID string) (*models.Transcript, error) {
+	// Parse content and calculate word and character counts
+	counts, metadata, err := s.parseTranscriptContent(content, ext)
 	if err != nil {
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"filename":   req.File.Filename,
@@ -118,13 +277,15 @@ func (s *TranscriptService) processTranscriptFile(req *UploadTranscriptRequest,
 		})
 		return nil, fmt.Errorf("failed to parse transcript: %w", err)
 	}
+	metadata = normalization.annotate(metadata)
 
 	// Create transcript record
 	transcript := &models.Transcript{
 		ID:                 uuid.New(),
 		Filename:           req.File.Filename,
 		ContentHash:        contentHash,
-		WordCount:          wordCount,
+		WordCount:          counts.words,
+		CharCount:          counts.chars,
 		TranscriptMetadata: metadata,
 		UploadedAt:         time.Now(),
 	}
@@ -144,7 +305,15 @@ func (s *TranscriptService) saveTranscriptToStorage(transcript *models.Transcrip
 		})
 		return fmt.Errorf("failed to save file: %w", err)
 	}
+	return s.createTranscriptRecord(transcript, filePath, correlationID)
+}
+
+// createTranscriptRecord saves the database record of a transcript whose file is already
+// stored at filePath, removing the file if the record cannot be saved
+func (s *TranscriptService) createTranscriptRecord(transcript *models.Transcript, filePath string, correlationID string) error {
 	transcript.FilePath = filePath
+	language, tags := transcriptLabels(transcript.TranscriptMetadata)
+	transcript.Language = language
 
 	// Save to database
 	if err := s.db.Create(transcript).Error; err != nil {
@@ -158,21 +327,25 @@ func (s *TranscriptService) saveTranscriptToStorage(transcript *models.Transcrip
 		return fmt.Errorf("failed to save transcript to database: %w", err)
 	}
 
+	// Tags only help filtering, so the upload stands even if they could not be saved
+	_ = s.addTranscriptTags(transcript.ID, tags, correlationID)
+
 	return nil
 }
 
-func (s *TranscriptService) UploadTranscript(req *UploadTranscriptRequest, correlationID string) (*UploadTranscriptResponse, error) {
-	log := logger.WithCorrelationID(correlationID)
-
+// storeBufferedUpload reads a whole upload into memory, checks and parses it, and saves it
+func (s *TranscriptService) storeBufferedUpload(req *UploadTranscriptRequest, correlationID string) (*models.Transcript, error) {
 	// Validate uploaded file
 	ext, content, err := s.validateUploadedFile(req, correlationID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Normalize before hashing so the same text saved on Windows is still a duplicate
+	content, normalization := normalizeTextContent(content)
+
 	// Calculate content hash
-	hash := sha256.Sum256(content)
-	contentHash := hex.EncodeToString(hash[:])
+	contentHash := hashTranscriptContent(content)
 
 	// Check for duplicates
 	if err := s.checkForDuplicates(contentHash, correlationID); err != nil {
@@ -180,7 +353,7 @@ func (s *TranscriptService) UploadTranscript(req *UploadTranscriptRequest, corre
 	}
 
 	// Process transcript file
-	transcript, err := s.processTranscriptFile(req, content, ext, contentHash, correlationID)
+	transcript, err := s.processTranscriptFile(req, content, ext, contentHash, normalization, correlationID)
 	if err != nil {
 		return nil, err
 	}
@@ -189,31 +362,123 @@ func (s *TranscriptService) UploadTranscript(req *UploadTranscriptRequest, corre
 	if err := s.saveTranscriptToStorage(transcript, content, correlationID); err != nil {
 		return nil, err
 	}
+	return transcript, nil
+}
+
+func (s *TranscriptService) UploadTranscript(req *UploadTranscriptRequest, correlationID string) (*UploadTranscriptResponse, error) {
+	log := logger.WithCorrelationID(correlationID)
+
+	if req.CallbackURL != "" {
+		if err := clients.ValidateWebhookURL(req.CallbackURL); err != nil {
+			return nil, utils.NewValidationError("callback_url", err.Error())
+		}
+	}
+
+	release, err := s.acquireUploadSlot()
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"filename":               req.File.Filename,
+			"max_concurrent_uploads": s.config.MaxConcurrentUploads,
+		}).Warn("Upload rejected, concurrency limit reached")
+		return nil, err
+	}
+	defer release()
+
+	releaseContent, err := s.acquireUploadContent(req.File.Size)
+	if err != nil {
+		log.WithFields(map[string]interface{}{
+			"filename":                   req.File.Filename,
+			"file_size":                  req.File.Size,
+			"max_inflight_content_bytes": s.config.MaxInflightContentBytes,
+		}).Warn("Upload rejected, in-flight content budget exhausted")
+		return nil, err
+	}
+	defer releaseContent()
+
+	// Word documents have to be unzipped whole; other files are streamed to storage
+	var transcript *models.Transcript
+	if strings.EqualFold(filepath.Ext(req.File.Filename), docxExtension) {
+		transcript, err = s.storeBufferedUpload(req, correlationID)
+	} else {
+		transcript, err = s.storeStreamedUpload(req, correlationID)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	log.WithFields(map[string]interface{}{
 		"transcript_id": transcript.ID,
 		"filename":      transcript.Filename,
 		"word_count":    transcript.WordCount,
+		"char_count":    transcript.CharCount,
 		"file_size":     req.File.Size,
 	}).Info("Transcript uploaded successfully")
 
-	return &UploadTranscriptResponse{
+	response := &UploadTranscriptResponse{
 		TranscriptID: transcript.ID,
 		Filename:     transcript.Filename,
 		WordCount:    transcript.WordCount,
+		CharCount:    transcript.CharCount,
+		Diarization:  diarizationFromMetadata(transcript.TranscriptMetadata),
 		Message:      "Transcript uploaded successfully",
-	}, nil
+	}
+
+	if req.CallbackURL != "" {
+		// Delivery retries run in the background so they never add to upload latency
+		go s.sendUploadCallback(req.CallbackURL, *response, correlationID)
+	}
+
+	return response, nil
+}
+
+// sendUploadCallback posts a completed upload to the caller's callback URL. Failures are
+// logged only; the transcript is already saved.
+func (s *TranscriptService) sendUploadCallback(callbackURL string, response UploadTranscriptResponse, correlationID string) {
+	ctx := requestctx.WithCorrelationID(context.Background(), correlationID)
+	if err := s.webhooks.Deliver(ctx, callbackURL, uploadCallbackEvent, response); err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": response.TranscriptID,
+			"operation":     "send_upload_callback",
+		})
+	}
+}
+
+// UploadTranscripts uploads several files independently, so one bad file does not fail the batch
+func (s *TranscriptService) UploadTranscripts(files []*multipart.FileHeader, correlationID string) ([]BatchUploadResult, error) {
+	maxFiles := s.config.MaxBatchFiles
+	if maxFiles <= 0 {
+		maxFiles = DefaultMaxBatchFiles
+	}
+	if len(files) == 0 {
+		return nil, utils.NewValidationError("file", "no files uploaded")
+	}
+	if len(files) > maxFiles {
+		return nil, utils.NewValidationError("file", fmt.Sprintf("too many files: %d. Maximum: %d", len(files), maxFiles))
+	}
+
+	results := make([]BatchUploadResult, len(files))
+	for i, file := range files {
+		response, err := s.UploadTranscript(&UploadTranscriptRequest{File: file}, correlationID)
+		results[i] = BatchUploadResult{
+			Filename: file.Filename,
+			Response: response,
+			Err:      err,
+		}
+	}
+
+	return results, nil
 }
 
-// GetTranscripts returns paginated list of transcripts
-func (s *TranscriptService) GetTranscripts(page, perPage int) ([]*models.Transcript, int64, error) {
+// GetTranscripts returns paginated list of transcripts uploaded within the given date range
+func (s *TranscriptService) GetTranscripts(page, perPage int, dateRange DateRange) ([]*models.Transcript, int64, error) {
 	var transcripts []*models.Transcript
 	var total int64
 
 	offset := (page - 1) * perPage
 
 	// Count total
-	if err := s.db.Model(&models.Transcript{}).Count(&total).Error; err != nil {
+	// Ephemeral transcripts exist only for their one analysis, so they are not listed
+	if err := dateRange.apply(s.db.Model(&models.Transcript{}), "uploaded_at").Where("ephemeral = ?", false).Count(&total).Error; err != nil {
 		logger.LogErrorWithStack(err, map[string]interface{}{
 			"operation": "count_transcripts",
 			"page":      page,
@@ -223,7 +488,7 @@ func (s *TranscriptService) GetTranscripts(page, perPage int) ([]*models.Transcr
 	}
 
 	// Get paginated results
-	if err := s.db.Offset(offset).Limit(perPage).Order("uploaded_at DESC").Find(&transcripts).Error; err != nil {
+	if err := dateRange.apply(s.db, "uploaded_at").Where("ephemeral = ?", false).Offset(offset).Limit(perPage).Order("uploaded_at DESC").Find(&transcripts).Error; err != nil {
 		logger.LogErrorWithStack(err, map[string]interface{}{
 			"operation": "get_transcripts_list",
 			"page":      page,
@@ -233,6 +498,15 @@ func (s *TranscriptService) GetTranscripts(page, perPage int) ([]*models.Transcr
 		return nil, 0, fmt.Errorf("failed to get transcripts: %w", err)
 	}
 
+	if err := s.loadAnalysisStats(transcripts); err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"operation": "get_transcripts_analysis_stats",
+			"page":      page,
+			"per_page":  perPage,
+		})
+		return nil, 0, fmt.Errorf("failed to get transcript analysis stats: %w", err)
+	}
+
 	return transcripts, total, nil
 }
 
@@ -249,9 +523,50 @@ func (s *TranscriptService) GetTranscript(id uuid.UUID) (*models.Transcript, err
 		})
 		return nil, fmt.Errorf("failed to get transcript: %w", err)
 	}
+	if err := s.loadAnalysisStats([]*models.Transcript{&transcript}); err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"transcript_id": id,
+			"operation":     "get_transcript_analysis_stats",
+		})
+		return nil, fmt.Errorf("failed to get transcript analysis stats: %w", err)
+	}
 	return &transcript, nil
 }
 
+// loadAnalysisStats sets AnalysisCount and LastAnalyzedAt on each transcript from its
+// completed analyses, in a single query. Re-analyzing a transcript adds to the count.
+func (s *TranscriptService) loadAnalysisStats(transcripts []*models.Transcript) error {
+	if len(transcripts) == 0 {
+		return nil
+	}
+	byID := make(map[uuid.UUID]*models.Transcript, len(transcripts))
+	ids := make([]uuid.UUID, len(transcripts))
+	for i, transcript := range transcripts {
+		byID[transcript.ID] = transcript
+		ids[i] = transcript.ID
+	}
+
+	var completed []struct {
+		TranscriptID uuid.UUID
+		CompletedAt  *time.Time
+	}
+	if err := s.db.Model(&models.AnalysisResult{}).
+		Select("transcript_id", "completed_at").
+		Where("transcript_id IN ? AND status = ?", ids, "completed").
+		Scan(&completed).Error; err != nil {
+		return err
+	}
+
+	for _, analysis := range completed {
+		transcript := byID[analysis.TranscriptID]
+		transcript.AnalysisCount++
+		if analysis.CompletedAt != nil && (transcript.LastAnalyzedAt == nil || analysis.CompletedAt.After(*transcript.LastAnalyzedAt)) {
+			transcript.LastAnalyzedAt = analysis.CompletedAt
+		}
+	}
+	return nil
+}
+
 // DeleteTranscript deletes a transcript and its file
 func (s *TranscriptService) DeleteTranscript(id uuid.UUID, correlationID string) error {
 	log := logger.WithCorrelationID(correlationID)
@@ -268,13 +583,28 @@ func (s *TranscriptService) DeleteTranscript(id uuid.UUID, correlationID string)
 		return fmt.Errorf("failed to find transcript: %w", err)
 	}
 
-	// Delete file
-	if err := os.Remove(transcript.FilePath); err != nil && !os.IsNotExist(err) {
+	// Delete file, leaving paths outside the storage directory alone. The record still goes,
+	// so a bad stored path cannot make a transcript undeletable.
+	filePath, err := s.resolveStoragePath(transcript.FilePath)
+	if err != nil {
+		log.WithError(err).WithFields(map[string]interface{}{
+			"transcript_id": id,
+			"file_path":     transcript.FilePath,
+		}).Warn("Transcript file is outside the storage directory, not deleting it")
+	} else if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
 		log.WithError(err).Warn("Failed to delete transcript file")
 	}
 
-	// Delete from database (cascade deletes analyses and fact checks)
-	if err := s.db.Delete(&transcript).Error; err != nil {
+	// Delete from database (cascade deletes analyses and fact checks). Job events only
+	// reference the job ID, so the cascade misses them and they are deleted first.
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		jobIDs := tx.Model(&models.AnalysisResult{}).Select("job_id").Where("transcript_id = ?", transcript.ID)
+		if err := tx.Where("job_id IN (?)", jobIDs).Delete(&models.JobEvent{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&transcript).Error
+	})
+	if err != nil {
 		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
 			"transcript_id": id,
 			"operation":     "delete_transcript_from_database",
@@ -290,7 +620,7 @@ func (s *TranscriptService) DeleteTranscript(id uuid.UUID, correlationID string)
 
 func (s *TranscriptService) saveFile(transcriptID uuid.UUID, content []byte) (string, error) {
 	// Ensure storage directory exists
-	if err := os.MkdirAll(s.config.StoragePath, 0755); err != nil {
+	if err := os.MkdirAll(s.config.StoragePath, s.storageDirMode()); err != nil {
 		logger.LogErrorWithStack(err, map[string]interface{}{
 			"storage_path": s.config.StoragePath,
 			"operation":    "create_storage_directory",
@@ -298,9 +628,7 @@ func (s *TranscriptService) saveFile(transcriptID uuid.UUID, content []byte) (st
 		return "", fmt.Errorf("failed to create storage directory: %w", err)
 	}
 
-	filename := transcriptID.String() + ".txt"
-	filePath := filepath.Join(s.config.StoragePath, filename)
-
+	filePath := s.transcriptFilePath(transcriptID)
 	if err := os.WriteFile(filePath, content, 0644); err != nil {
 		logger.LogErrorWithStack(err, map[string]interface{}{
 			"file_path":     filePath,
@@ -313,6 +641,35 @@ func (s *TranscriptService) saveFile(transcriptID uuid.UUID, content []byte) (st
 	return filePath, nil
 }
 
+// transcriptFilePath returns where the content of a transcript is stored
+func (s *TranscriptService) transcriptFilePath(transcriptID uuid.UUID) string {
+	return filepath.Join(s.config.StoragePath, transcriptID.String()+".txt")
+}
+
+// storageDirMode returns the configured storage directory permissions, defaulting to 0755
+func (s *TranscriptService) storageDirMode() os.FileMode {
+	if s.config.StorageDirMode == 0 {
+		return 0755
+	}
+	return s.config.StorageDirMode
+}
+
+// ValidateStorage ensures the storage directory exists (creating it if needed) and is writable
+func (s *TranscriptService) ValidateStorage() error {
+	if err := os.MkdirAll(s.config.StoragePath, s.storageDirMode()); err != nil {
+		return fmt.Errorf("storage path %s cannot be created: %w", s.config.StoragePath, err)
+	}
+
+	probe, err := os.CreateTemp(s.config.StoragePath, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("storage path %s is not writable: %w", s.config.StoragePath, err)
+	}
+	probe.Close()
+	_ = os.Remove(probe.Name())
+
+	return nil
+}
+
 // extractJSONMetadata extracts metadata from JSON transcript data
 func (s *TranscriptService) extractJSONMetadata(jsonData map[string]interface{}) map[string]interface{} {
 	metadata := make(map[string]interface{})
@@ -324,29 +681,79 @@ func (s *TranscriptService) extractJSONMetadata(jsonData map[string]interface{})
 	return metadata
 }
 
-// countWordsInTranscript counts words in transcript field (array or string format)
-func (s *TranscriptService) countWordsInTranscript(transcript interface{}) int {
+// validateJSONTranscript checks that the transcript field exists, has a supported shape, and contains text
+func (s *TranscriptService) validateJSONTranscript(jsonData map[string]interface{}) error {
+	transcript, ok := jsonData["transcript"]
+	if !ok {
+		return newTranscriptSchemaError(`missing required "transcript" field`)
+	}
+
+	switch value := transcript.(type) {
+	case string:
+		if strings.TrimSpace(value) == "" {
+			return newTranscriptSchemaError(`"transcript" field is empty`)
+		}
+	case []interface{}:
+		if len(value) == 0 {
+			return newTranscriptSchemaError(`"transcript" array is empty`)
+		}
+		for i, item := range value {
+			itemMap, ok := item.(map[string]interface{})
+			if !ok {
+				return newTranscriptSchemaError(fmt.Sprintf(`"transcript" segment %d is not an object`, i))
+			}
+			if _, ok := itemMap["text"].(string); !ok {
+				return newTranscriptSchemaError(fmt.Sprintf(`"transcript" segment %d is missing a "text" string`, i))
+			}
+		}
+	default:
+		return newTranscriptSchemaError(`"transcript" field must be a string or an array of segments`)
+	}
+
+	if s.countTranscriptText(transcript).words == 0 {
+		return newTranscriptSchemaError(`"transcript" contains no words`)
+	}
+
+	return nil
+}
+
+// textCounts holds the word and character counts of transcript text
+type textCounts struct {
+	words int
+	chars int
+}
+
+// countText computes word and character counts for a block of text
+func countText(text string) textCounts {
+	return textCounts{words: countWords(text), chars: countChars(text)}
+}
+
+// countTranscriptText counts words and characters in transcript field (array or string format)
+func (s *TranscriptService) countTranscriptText(transcript interface{}) textCounts {
 	if transcriptArray, ok := transcript.([]interface{}); ok {
 		// Array format: [{"text": "...", "speaker": "...", "timestamp": "..."}, ...]
-		wordCount := 0
+		var counts textCounts
 		for _, item := range transcriptArray {
 			if itemMap, ok := item.(map[string]interface{}); ok {
 				if text, ok := itemMap["text"].(string); ok {
-					wordCount += countWords(text)
+					segment := countText(text)
+					counts.words += segment.words
+					counts.chars += segment.chars
 				}
 			}
 		}
-		return wordCount
+		return counts
 	} else if transcriptText, ok := transcript.(string); ok {
 		// String format
-		return countWords(transcriptText)
+		return countText(transcriptText)
 	}
-	return 0
+	return textCounts{}
 }
 
-func (s *TranscriptService) parseTranscriptContent(content []byte, ext string) (int, []byte, error) {
-	var wordCount int
+func (s *TranscriptService) parseTranscriptContent(content []byte, ext string) (textCounts, []byte, error) {
+	var counts textCounts
 	var metadata map[string]interface{}
+	var diarization *DiarizationQuality
 
 	if ext == ".json" {
 		var jsonData map[string]interface{}
@@ -354,41 +761,132 @@ func (s *TranscriptService) parseTranscriptContent(content []byte, ext string) (
 			logger.LogErrorWithStack(err, map[string]interface{}{
 				"operation": "unmarshal_json_transcript",
 			})
-			return 0, nil, fmt.Errorf("invalid JSON format: %w", err)
+			return textCounts{}, nil, fmt.Errorf("invalid JSON format: %w", err)
+		}
+
+		// Validate transcript schema before trusting the content
+		if err := s.validateJSONTranscript(jsonData); err != nil {
+			return textCounts{}, nil, err
 		}
 
 		// Extract metadata
 		metadata = s.extractJSONMetadata(jsonData)
 
 		// Count words in transcript field
-		if transcript, ok := jsonData["transcript"]; ok {
-			wordCount = s.countWordsInTranscript(transcript)
-		}
+		counts = s.countTranscriptText(jsonData["transcript"])
+		diarization = measureDiarization(jsonDiarizationSegments(jsonData["transcript"]))
+	} else if ext == docxExtension {
+		// Content was already converted to plain text during validation
+		counts = countText(string(content))
+		metadata = map[string]interface{}{"format": "docx"}
+		diarization = measureDiarization(textDiarizationSegments(string(content)))
 	} else {
 		// Plain text format
-		wordCount = countWords(string(content))
+		counts = countText(string(content))
+		diarization = measureDiarization(textDiarizationSegments(string(content)))
+	}
+
+	// Record speaker-label quality for transcripts with speaker labels
+	if diarization != nil {
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+		metadata["diarization"] = diarization
 	}
 
 	metadataBytes, _ := json.Marshal(metadata)
-	return wordCount, metadataBytes, nil
+	return counts, metadataBytes, nil
 }
 
+// countWords counts space-delimited words. Chinese and Japanese are written without spaces,
+// so each Han, Hiragana, or Katakana character is counted as its own word, matching how
+// word processors count CJK text
 func countWords(text string) int {
-	words := strings.Fields(strings.TrimSpace(text))
-	return len(words)
+	count := 0
+	for _, field := range strings.Fields(text) {
+		if !strings.ContainsFunc(field, isCJK) {
+			count++
+			continue
+		}
+		count += countCJKField(field)
+	}
+	return count
 }
 
-// ReadTranscriptContent reads the content of a transcript file (matches Python async def read_transcript_content)
-func (s *TranscriptService) ReadTranscriptContent(transcript *models.Transcript) (string, error) {
-	if _, err := os.Stat(transcript.FilePath); os.IsNotExist(err) {
+// countCJKField counts words in a field containing CJK characters: one per CJK character, plus one
+// per run of other letters or digits (e.g. embedded Latin words), ignoring punctuation
+func countCJKField(field string) int {
+	count := 0
+	inRun := false
+	for _, r := range field {
+		switch {
+		case isCJK(r):
+			count++
+			inRun = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inRun {
+				count++
+				inRun = true
+			}
+		default:
+			inRun = false
+		}
+	}
+	return count
+}
+
+// isCJK reports whether a rune belongs to a script written without spaces between words
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+// countChars counts non-whitespace characters
+func countChars(text string) int {
+	count := 0
+	for _, r := range text {
+		if !unicode.IsSpace(r) {
+			count++
+		}
+	}
+	return count
+}
+
+// OpenTranscriptContent opens a transcript file for streaming. Callers must close the
+// reader; prefer it over ReadTranscriptContent for large transcripts.
+func (s *TranscriptService) OpenTranscriptContent(transcript *models.Transcript) (io.ReadCloser, error) {
+	filePath, err := s.resolveStoragePath(transcript.FilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
 		logger.Log.WithFields(map[string]interface{}{
 			"transcript_id": transcript.ID,
 			"file_path": transcript.FilePath,
 		}).Error("Transcript file not found")
-		return "", fmt.Errorf("transcript file not found: %s", transcript.FilePath)
+		return nil, fmt.Errorf("transcript file not found: %s", transcript.FilePath)
 	}
+	if err != nil {
+		logger.LogErrorWithStack(err, map[string]interface{}{
+			"transcript_id": transcript.ID,
+			"file_path":     transcript.FilePath,
+			"operation":     "open_transcript_file",
+		})
+		return nil, fmt.Errorf("failed to open transcript file: %w", err)
+	}
+	return file, nil
+}
+
+// ReadTranscriptContent reads the whole content of a transcript file into memory (matches Python async def read_transcript_content)
+func (s *TranscriptService) ReadTranscriptContent(transcript *models.Transcript) (string, error) {
+	reader, err := s.OpenTranscriptContent(transcript)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
 
-	content, err := os.ReadFile(transcript.FilePath)
+	content, err := io.ReadAll(reader)
 	if err != nil {
 		logger.LogErrorWithStack(err, map[string]interface{}{
 			"transcript_id": transcript.ID,
@@ -406,6 +904,81 @@ func (s *TranscriptService) ReadTranscriptContent(transcript *models.Transcript)
 	return string(content), nil
 }
 
+// utf8BOM is the byte order mark Windows editors often prepend to UTF-8 text
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// textNormalization records what normalizeTextContent changed in an upload
+type textNormalization struct {
+	bomRemoved           bool
+	lineEndingsConverted bool
+}
+
+// normalizeTextContent strips a leading UTF-8 byte order mark and converts CRLF line
+// endings to LF, so neither leaks into word counts, hashes or agent prompts
+func normalizeTextContent(content []byte) ([]byte, textNormalization) {
+	var normalization textNormalization
+	if bytes.HasPrefix(content, utf8BOM) {
+		content = content[len(utf8BOM):]
+		normalization.bomRemoved = true
+	}
+	if bytes.Contains(content, []byte("\r\n")) {
+		content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+		normalization.lineEndingsConverted = true
+	}
+	return content, normalization
+}
+
+// hashTranscriptContent returns the SHA-256 of content with formatting that does not change
+// the transcript ignored: trailing whitespace on each line, the line ending style and runs
+// of blank lines. The stored file has its byte order mark and CRLF line endings removed
+// by normalizeTextContent too, but keeps its whitespace and blank lines; folding those
+// is hash-only.
+func hashTranscriptContent(content []byte) string {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	content = bytes.ReplaceAll(content, []byte("\r"), []byte("\n"))
+
+	lines := bytes.Split(content, []byte("\n"))
+	normalized := make([][]byte, 0, len(lines))
+	previousBlank := false
+	for _, line := range lines {
+		line = bytes.TrimRight(line, " \t\f\v")
+		blank := len(line) == 0
+		if blank && previousBlank {
+			continue
+		}
+		normalized = append(normalized, line)
+		previousBlank = blank
+	}
+
+	hash := sha256.Sum256(bytes.Join(normalized, []byte("\n")))
+	return hex.EncodeToString(hash[:])
+}
+
+// annotate notes the normalization in the transcript metadata
+func (n textNormalization) annotate(metadata []byte) []byte {
+	if !n.bomRemoved && !n.lineEndingsConverted {
+		return metadata
+	}
+
+	var fields map[string]interface{}
+	_ = json.Unmarshal(metadata, &fields)
+	if fields == nil {
+		fields = make(map[string]interface{})
+	}
+	if n.bomRemoved {
+		fields["bom_removed"] = true
+	}
+	if n.lineEndingsConverted {
+		fields["line_endings_normalized"] = true
+	}
+
+	annotated, err := json.Marshal(fields)
+	if err != nil {
+		return metadata
+	}
+	return annotated
+}
+
 func isValidUTF8(data []byte) bool {
 	return strings.ToValidUTF8(string(data), "") == string(data)
 }
\ No newline at end of file
@@ -3,10 +3,15 @@ package services
 import (
 	"podcast-analyzer/internal/config"
 	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
 	"bytes"
+	"encoding/json"
+	"context"
+	"io"
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,8 +34,20 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			file_path TEXT NOT NULL,
 			content_hash TEXT NOT NULL UNIQUE,
 			word_count INTEGER NOT NULL,
+			char_count INTEGER NOT NULL DEFAULT 0,
 			uploaded_at DATETIME,
-			transcript_metadata TEXT
+			transcript_metadata TEXT,
+			ephemeral BOOLEAN NOT NULL DEFAULT 0,
+			language TEXT NOT NULL DEFAULT ''
+		)
+	`).Error
+	require.NoError(t, err)
+	
+	err = db.Exec(`
+		CREATE TABLE transcript_tags (
+			transcript_id TEXT NOT NULL,
+			tag TEXT NOT NULL,
+			PRIMARY KEY (transcript_id, tag)
 		)
 	`).Error
 	require.NoError(t, err)
@@ -42,7 +59,16 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			job_id TEXT NOT NULL UNIQUE,
 			status TEXT NOT NULL DEFAULT 'pending',
 			summary TEXT,
+			headline TEXT,
 			takeaways TEXT,
+			takeaways_summary TEXT,
+			instructions TEXT,
+			summary_style TEXT NOT NULL DEFAULT 'prose',
+			analysis_metadata TEXT,
+			model TEXT,
+			truncated BOOLEAN NOT NULL DEFAULT 0,
+			fact_check_status TEXT,
+			job_options TEXT,
 			created_at DATETIME,
 			completed_at DATETIME,
 			error_message TEXT
@@ -59,7 +85,57 @@ func setupTestDB(t *testing.T) *gorm.DB {
 			confidence REAL NOT NULL,
 			evidence TEXT,
 			sources TEXT,
-			checked_at DATETIME
+			checked_at DATETIME,
+			cached BOOLEAN NOT NULL DEFAULT 0,
+			search_query TEXT
+		)
+	`).Error
+	require.NoError(t, err)
+	
+	err = db.Exec(`
+		CREATE TABLE fact_check_cache (
+			claim_hash TEXT PRIMARY KEY,
+			claim TEXT NOT NULL,
+			verdict TEXT NOT NULL,
+			confidence REAL NOT NULL,
+			evidence TEXT,
+			sources TEXT,
+			checked_at DATETIME NOT NULL
+		)
+	`).Error
+	require.NoError(t, err)
+	
+	err = db.Exec(`
+		CREATE TABLE job_events (
+			id TEXT PRIMARY KEY,
+			job_id TEXT NOT NULL,
+			status TEXT NOT NULL,
+			stage TEXT,
+			message TEXT,
+			created_at DATETIME NOT NULL
+		)
+	`).Error
+	require.NoError(t, err)
+	
+	err = db.Exec(`
+		CREATE TABLE agent_runs (
+			id TEXT PRIMARY KEY,
+			job_id TEXT NOT NULL,
+			agent TEXT NOT NULL,
+			output TEXT NOT NULL,
+			created_at DATETIME NOT NULL,
+			UNIQUE (job_id, agent)
+		)
+	`).Error
+	require.NoError(t, err)
+	
+	err = db.Exec(`
+		CREATE TABLE analysis_notes (
+			id TEXT PRIMARY KEY,
+			analysis_id TEXT NOT NULL,
+			author TEXT NOT NULL,
+			body TEXT NOT NULL,
+			created_at DATETIME NOT NULL
 		)
 	`).Error
 	require.NoError(t, err)
@@ -72,7 +148,7 @@ func setupTestConfig(t *testing.T) *config.Config {
 	return &config.Config{
 		StoragePath:   tempDir,
 		MaxFileSize:   10 * 1024 * 1024, // 10MB
-		AllowedExts:   []string{".txt", ".json"},
+		AllowedExts:   []string{".txt", ".json", ".docx"},
 		DatabaseURL:   "sqlite://:memory:",
 		ServerPort:    "8000",
 		LogLevel:      "DEBUG",
@@ -207,6 +283,131 @@ func TestTranscriptService_UploadTranscript_FileTooLarge(t *testing.T) {
 	assert.Nil(t, resp)
 }
 
+func TestTranscriptService_UploadTranscript_ReportsEachFailedConstraint(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.MaxFileSize = 100
+	service := NewTranscriptService(db, cfg)
+
+	fileHeader := createTestFileHeader(t, "large.pdf", strings.Repeat("a", 200))
+	_, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+
+	var validationErrs utils.ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	require.Len(t, validationErrs, 2)
+	assert.Equal(t, "file.extension", validationErrs[0].Field)
+	assert.Equal(t, "file.size", validationErrs[1].Field)
+}
+
+func TestTranscriptService_UploadTranscript_ContentTypeCheck(t *testing.T) {
+	// Valid UTF-8, but the NUL bytes make it sniff as binary
+	binaryContent := "fake transcript\x00\x00\x00\x01 with embedded binary data"
+
+	tests := []struct {
+		name          string
+		mode          string
+		content       string
+		expectedError string
+	}{
+		{name: "binary rejected", mode: "reject", content: binaryContent, expectedError: "unsupported content type: application/octet-stream"},
+		{name: "binary rejected by default", mode: "", content: binaryContent, expectedError: "unsupported content type"},
+		{name: "binary allowed in warn mode", mode: "warn", content: binaryContent},
+		{name: "binary allowed when off", mode: "off", content: binaryContent},
+		{name: "plain text accepted", mode: "reject", content: "Just a regular transcript about podcasts."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := setupTestDB(t)
+			cfg := setupTestConfig(t)
+			cfg.MIMECheckMode = tt.mode
+			service := NewTranscriptService(db, cfg)
+
+			req := &UploadTranscriptRequest{File: createTestFileHeader(t, "renamed.txt", tt.content)}
+			resp, err := service.UploadTranscript(req, "test-correlation-id")
+
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, resp)
+			} else {
+				require.NoError(t, err)
+				assert.NotNil(t, resp)
+			}
+		})
+	}
+}
+
+func TestTranscriptService_UploadTranscripts(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.MaxBatchFiles = 3
+	service := NewTranscriptService(db, cfg)
+
+	files := []*multipart.FileHeader{
+		createTestFileHeader(t, "first.txt", "The first episode transcript"),
+		createTestFileHeader(t, "second.pdf", "Unsupported extension"),
+		createTestFileHeader(t, "third.txt", "The first episode transcript"),
+	}
+
+	results, err := service.UploadTranscripts(files, "test-correlation-id")
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "first.txt", results[0].Filename)
+	assert.NotEqual(t, uuid.Nil, results[0].Response.TranscriptID)
+
+	assert.Error(t, results[1].Err)
+	assert.Contains(t, results[1].Err.Error(), "invalid file extension")
+	assert.Nil(t, results[1].Response)
+
+	// Same content as the first file is rejected as a duplicate
+	assert.Error(t, results[2].Err)
+	assert.Contains(t, results[2].Err.Error(), "duplicate")
+
+	// Enforce the batch limit
+	tooMany := append(files, createTestFileHeader(t, "fourth.txt", "Another transcript"))
+	_, err = service.UploadTranscripts(tooMany, "test-correlation-id")
+	assert.EqualError(t, err, "too many files: 4. Maximum: 3")
+
+	_, err = service.UploadTranscripts(nil, "test-correlation-id")
+	assert.EqualError(t, err, "no files uploaded")
+}
+
+func TestTranscriptService_ValidateStorage(t *testing.T) {
+	cfg := setupTestConfig(t)
+	cfg.StoragePath = filepath.Join(cfg.StoragePath, "nested", "transcripts")
+	cfg.StorageDirMode = 0750
+	service := NewTranscriptService(nil, cfg)
+
+	require.NoError(t, service.ValidateStorage())
+
+	info, err := os.Stat(cfg.StoragePath)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+	assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+
+	// The write probe is cleaned up
+	entries, err := os.ReadDir(cfg.StoragePath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestTranscriptService_ValidateStorage_Unusable(t *testing.T) {
+	cfg := setupTestConfig(t)
+
+	// A regular file where the directory should be cannot be created or written to
+	blocker := filepath.Join(cfg.StoragePath, "not-a-dir")
+	require.NoError(t, os.WriteFile(blocker, []byte("x"), 0644))
+	cfg.StoragePath = filepath.Join(blocker, "transcripts")
+	service := NewTranscriptService(nil, cfg)
+
+	err := service.ValidateStorage()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be created")
+}
+
 func TestTranscriptService_GetTranscripts(t *testing.T) {
 	db := setupTestDB(t)
 	cfg := setupTestConfig(t)
@@ -251,7 +452,7 @@ func TestTranscriptService_GetTranscripts(t *testing.T) {
 	}
 
 	// Test pagination
-	page1Transcripts, total, err := service.GetTranscripts(1, 2)
+	page1Transcripts, total, err := service.GetTranscripts(1, 2, DateRange{})
 	assert.NoError(t, err)
 	assert.Equal(t, int64(3), total)
 	assert.Len(t, page1Transcripts, 2)
@@ -260,11 +461,26 @@ func TestTranscriptService_GetTranscripts(t *testing.T) {
 	assert.Equal(t, id3, page1Transcripts[0].ID)  // newest (test3)
 	assert.Equal(t, id2, page1Transcripts[1].ID)  // middle (test2)
 
-	page2Transcripts, total2, err := service.GetTranscripts(2, 2)
+	page2Transcripts, total2, err := service.GetTranscripts(2, 2, DateRange{})
 	assert.NoError(t, err)
 	assert.Equal(t, int64(3), total2)
 	assert.Len(t, page2Transcripts, 1)
 	assert.Equal(t, id1, page2Transcripts[0].ID)   // oldest (test1)
+
+	// Test date range combined with pagination
+	after := now.Add(-90 * time.Minute)
+	ranged, rangedTotal, err := service.GetTranscripts(1, 1, DateRange{After: &after})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rangedTotal)
+	assert.Len(t, ranged, 1)
+	assert.Equal(t, id3, ranged[0].ID)
+
+	before := now.Add(-30 * time.Minute)
+	ranged, rangedTotal, err = service.GetTranscripts(1, 10, DateRange{After: &after, Before: &before})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rangedTotal)
+	require.Len(t, ranged, 1)
+	assert.Equal(t, id2, ranged[0].ID)
 }
 
 func TestTranscriptService_GetTranscript(t *testing.T) {
@@ -299,6 +515,40 @@ func TestTranscriptService_GetTranscript(t *testing.T) {
 	assert.Nil(t, transcript)
 }
 
+func TestTranscriptService_AnalysisStats(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	analyzed := &models.Transcript{ID: uuid.New(), Filename: "analyzed.txt", ContentHash: "analyzed", UploadedAt: time.Now()}
+	fresh := &models.Transcript{ID: uuid.New(), Filename: "fresh.txt", ContentHash: "fresh", UploadedAt: time.Now().Add(-time.Hour)}
+	require.NoError(t, db.Create(analyzed).Error)
+	require.NoError(t, db.Create(fresh).Error)
+
+	first := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Second)
+	latest := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	for _, analysis := range []*models.AnalysisResult{
+		{ID: uuid.New(), TranscriptID: analyzed.ID, JobID: uuid.New(), Status: "completed", CompletedAt: &latest},
+		{ID: uuid.New(), TranscriptID: analyzed.ID, JobID: uuid.New(), Status: "completed", CompletedAt: &first},
+		{ID: uuid.New(), TranscriptID: analyzed.ID, JobID: uuid.New(), Status: "failed", CompletedAt: &latest},
+		{ID: uuid.New(), TranscriptID: analyzed.ID, JobID: uuid.New(), Status: "processing"},
+	} {
+		require.NoError(t, db.Create(analysis).Error)
+	}
+
+	transcript, err := service.GetTranscript(analyzed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, transcript.AnalysisCount)
+	require.NotNil(t, transcript.LastAnalyzedAt)
+	assert.True(t, latest.Equal(*transcript.LastAnalyzedAt))
+
+	transcripts, _, err := service.GetTranscripts(1, 10, DateRange{})
+	require.NoError(t, err)
+	require.Len(t, transcripts, 2)
+	assert.Equal(t, 2, transcripts[0].AnalysisCount)
+	assert.Equal(t, 0, transcripts[1].AnalysisCount)
+	assert.Nil(t, transcripts[1].LastAnalyzedAt)
+}
+
 func TestTranscriptService_DeleteTranscript(t *testing.T) {
 	db := setupTestDB(t)
 	cfg := setupTestConfig(t)
@@ -387,13 +637,13 @@ func TestParseTranscriptContent(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			wordCount, metadata, err := service.parseTranscriptContent([]byte(tt.content), tt.ext)
+			counts, metadata, err := service.parseTranscriptContent([]byte(tt.content), tt.ext)
 			
 			if tt.expectError {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedWords, wordCount)
+				assert.Equal(t, tt.expectedWords, counts.words)
 				assert.NotNil(t, metadata)
 			}
 		})
@@ -411,6 +661,10 @@ func TestCountWords(t *testing.T) {
 		{"  hello   world  ", 2},
 		{"hello\nworld\ttest", 3},
 		{"hello, world! how are you?", 5},
+		{"我们今天讨论经济", 8},
+		{"今日は、いい天気です。", 9},
+		{"我们用 Go 语言", 6},
+		{"AI模型很强大", 6},
 	}
 
 	for _, tt := range tests {
@@ -421,6 +675,25 @@ func TestCountWords(t *testing.T) {
 	}
 }
 
+func TestCountChars(t *testing.T) {
+	assert.Equal(t, 0, countChars(""))
+	assert.Equal(t, 10, countChars("hello world"))
+	assert.Equal(t, 8, countChars("我们今天讨论经济"))
+	assert.Equal(t, 4, countChars(" 我们 Go\n"))
+}
+
+func TestParseTranscriptContent_CJK(t *testing.T) {
+	cfg := setupTestConfig(t)
+	service := &TranscriptService{config: cfg}
+
+	content := `{"transcript": [{"text": "大家好", "speaker": "Host"}, {"text": "Hello there", "speaker": "Guest"}]}`
+	counts, _, err := service.parseTranscriptContent([]byte(content), ".json")
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, counts.words)
+	assert.Equal(t, 13, counts.chars)
+}
+
 func TestIsValidUTF8(t *testing.T) {
 	tests := []struct {
 		input    []byte
@@ -438,4 +711,381 @@ func TestIsValidUTF8(t *testing.T) {
 			assert.Equal(t, tt.expected, result)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestParseTranscriptContent_JSONSchemaValidation(t *testing.T) {
+	cfg := setupTestConfig(t)
+	service := &TranscriptService{config: cfg}
+
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "missing transcript field",
+			content:       `{"title": "Episode 1", "text": "Hello world"}`,
+			expectedError: `missing required "transcript" field`,
+		},
+		{
+			name:          "empty transcript string",
+			content:       `{"transcript": "   "}`,
+			expectedError: `"transcript" field is empty`,
+		},
+		{
+			name:          "empty transcript array",
+			content:       `{"transcript": []}`,
+			expectedError: `"transcript" array is empty`,
+		},
+		{
+			name:          "segment without text",
+			content:       `{"transcript": [{"speaker": "Host"}]}`,
+			expectedError: `segment 0 is missing a "text" string`,
+		},
+		{
+			name:          "segment is not an object",
+			content:       `{"transcript": ["Hello world"]}`,
+			expectedError: `segment 0 is not an object`,
+		},
+		{
+			name:          "unsupported transcript type",
+			content:       `{"transcript": 42}`,
+			expectedError: "must be a string or an array of segments",
+		},
+		{
+			name:          "segments with no words",
+			content:       `{"transcript": [{"text": ""}, {"text": " "}]}`,
+			expectedError: `"transcript" contains no words`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts, metadata, err := service.parseTranscriptContent([]byte(tt.content), ".json")
+
+			require.Error(t, err)
+			var schemaErr *TranscriptSchemaError
+			require.ErrorAs(t, err, &schemaErr)
+			assert.Contains(t, err.Error(), tt.expectedError)
+			assert.NotEmpty(t, schemaErr.Expected)
+			assert.Equal(t, 0, counts.words)
+			assert.Nil(t, metadata)
+		})
+	}
+}
+
+func TestTranscriptService_UploadTranscript_Saturated(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.MaxConcurrentUploads = 1
+	service := NewTranscriptService(db, cfg)
+
+	// Hold the only slot as an in-flight upload would
+	release, err := service.acquireUploadSlot()
+	require.NoError(t, err)
+
+	fileHeader := createTestFileHeader(t, "test.txt", "This is a test transcript content")
+	_, err = service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+	assert.ErrorIs(t, err, ErrUploadsSaturated)
+
+	// The slot is free again once the in-flight upload finishes
+	release()
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, resp.TranscriptID)
+
+	// A finished upload gives its slot back
+	release, err = service.acquireUploadSlot()
+	require.NoError(t, err)
+	release()
+}
+
+// webhookCall records one delivery made through recordingWebhookSender
+type webhookCall struct {
+	url     string
+	event   string
+	payload interface{}
+}
+
+// recordingWebhookSender captures deliveries instead of sending them
+type recordingWebhookSender struct {
+	calls chan webhookCall
+}
+
+func (r *recordingWebhookSender) Deliver(ctx context.Context, callbackURL, event string, payload interface{}) error {
+	r.calls <- webhookCall{url: callbackURL, event: event, payload: payload}
+	return nil
+}
+
+func TestTranscriptService_UploadTranscript_Callback(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+	sender := &recordingWebhookSender{calls: make(chan webhookCall, 1)}
+	service.webhooks = sender
+
+	fileHeader := createTestFileHeader(t, "test.txt", "This is a test transcript content")
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{
+		File:        fileHeader,
+		CallbackURL: "https://hooks.example.com/uploads",
+	}, "test-correlation-id")
+	require.NoError(t, err)
+
+	select {
+	case call := <-sender.calls:
+		assert.Equal(t, "https://hooks.example.com/uploads", call.url)
+		assert.Equal(t, uploadCallbackEvent, call.event)
+		assert.Equal(t, *resp, call.payload)
+	case <-time.After(time.Second):
+		t.Fatal("upload callback was not delivered")
+	}
+}
+
+func TestTranscriptService_UploadTranscript_InvalidCallbackURL(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+	sender := &recordingWebhookSender{calls: make(chan webhookCall, 1)}
+	service.webhooks = sender
+
+	fileHeader := createTestFileHeader(t, "test.txt", "This is a test transcript content")
+	_, err := service.UploadTranscript(&UploadTranscriptRequest{
+		File:        fileHeader,
+		CallbackURL: "http://192.168.1.10/hook",
+	}, "test-correlation-id")
+
+	var validationErrs utils.ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	assert.Equal(t, "callback_url", validationErrs[0].Field)
+
+	// Nothing is saved when the callback is rejected
+	var count int64
+	db.Model(&models.Transcript{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+	assert.Empty(t, sender.calls)
+}
+
+func TestTranscriptService_OpenTranscriptContent(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+
+	filePath := filepath.Join(cfg.StoragePath, "episode.txt")
+	require.NoError(t, os.WriteFile(filePath, []byte("Host: Welcome to the show."), 0644))
+	transcript := &models.Transcript{ID: uuid.New(), FilePath: filePath}
+
+	reader, err := service.OpenTranscriptContent(transcript)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	// Read a little at a time, as a streaming consumer would
+	head := make([]byte, 5)
+	_, err = io.ReadFull(reader, head)
+	require.NoError(t, err)
+	assert.Equal(t, "Host:", string(head))
+
+	rest, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, " Welcome to the show.", string(rest))
+
+	content, err := service.ReadTranscriptContent(transcript)
+	require.NoError(t, err)
+	assert.Equal(t, "Host: Welcome to the show.", content)
+}
+
+func TestTranscriptService_OpenTranscriptContent_NotFound(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+	transcript := &models.Transcript{ID: uuid.New(), FilePath: filepath.Join(cfg.StoragePath, "missing.txt")}
+
+	_, err := service.OpenTranscriptContent(transcript)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transcript file not found")
+
+	_, err = service.ReadTranscriptContent(transcript)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "transcript file not found")
+}
+
+func TestTranscriptService_OpenTranscriptContent_RejectsPathsOutsideStorage(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+
+	outside := filepath.Join(t.TempDir(), "secret.txt")
+	require.NoError(t, os.WriteFile(outside, []byte("not a transcript"), 0644))
+
+	paths := []string{
+		outside,
+		filepath.Join(cfg.StoragePath, "..", filepath.Base(filepath.Dir(outside)), "secret.txt"),
+		cfg.StoragePath + "-other/episode.txt",
+		cfg.StoragePath,
+		"",
+	}
+	for _, path := range paths {
+		_, err := service.OpenTranscriptContent(&models.Transcript{ID: uuid.New(), FilePath: path})
+		assert.ErrorIs(t, err, ErrPathOutsideStorage, path)
+	}
+
+	// An allowed extra directory is accepted
+	cfg.StorageAllowedPaths = []string{filepath.Dir(outside)}
+	content, err := service.ReadTranscriptContent(&models.Transcript{ID: uuid.New(), FilePath: outside})
+	require.NoError(t, err)
+	assert.Equal(t, "not a transcript", content)
+}
+
+func TestTranscriptService_DeleteTranscript_DeletesJobEvents(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "events.txt", ContentHash: "eventshash", FilePath: "missing.txt", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "completed"}
+	require.NoError(t, db.Create(analysis).Error)
+	otherJobID := uuid.New()
+	for _, jobID := range []uuid.UUID{analysis.JobID, analysis.JobID, otherJobID} {
+		require.NoError(t, db.Create(&models.JobEvent{ID: uuid.New(), JobID: jobID, Status: "pending", CreatedAt: time.Now()}).Error)
+	}
+
+	require.NoError(t, service.DeleteTranscript(transcript.ID, "test-correlation-id"))
+
+	var count int64
+	db.Model(&models.JobEvent{}).Where("job_id = ?", analysis.JobID).Count(&count)
+	assert.Equal(t, int64(0), count)
+	db.Model(&models.JobEvent{}).Where("job_id = ?", otherJobID).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestTranscriptService_DeleteTranscript_KeepsFileOutsideStorage(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	outside := filepath.Join(t.TempDir(), "keep.txt")
+	require.NoError(t, os.WriteFile(outside, []byte("keep me"), 0644))
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "keep.txt", ContentHash: "keephash", FilePath: outside, UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+
+	err := service.DeleteTranscript(transcript.ID, "test-correlation-id")
+
+	require.NoError(t, err)
+	assert.FileExists(t, outside)
+	var count int64
+	db.Model(&models.Transcript{}).Where("id = ?", transcript.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestNormalizeTextContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    string
+		bomRemoved  bool
+		lineEndings bool
+	}{
+		{name: "unchanged", content: "Host: Hello\nGuest: Hi", expected: "Host: Hello\nGuest: Hi"},
+		{name: "bom", content: "\xEF\xBB\xBFHost: Hello", expected: "Host: Hello", bomRemoved: true},
+		{name: "crlf", content: "Host: Hello\r\nGuest: Hi\r\n", expected: "Host: Hello\nGuest: Hi\n", lineEndings: true},
+		{name: "bom and crlf", content: "\xEF\xBB\xBFHost: Hello\r\nGuest: Hi", expected: "Host: Hello\nGuest: Hi", bomRemoved: true, lineEndings: true},
+		{name: "bom only stripped at start", content: "Host: \xEF\xBB\xBFHello", expected: "Host: \xEF\xBB\xBFHello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, normalization := normalizeTextContent([]byte(tt.content))
+
+			assert.Equal(t, tt.expected, string(content))
+			assert.Equal(t, tt.bomRemoved, normalization.bomRemoved)
+			assert.Equal(t, tt.lineEndings, normalization.lineEndingsConverted)
+		})
+	}
+}
+
+func TestTranscriptService_UploadTranscript_NormalizesWindowsText(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	windows := createTestFileHeader(t, "windows.txt", "\xEF\xBB\xBFHost: Welcome to the show.\r\nGuest: Thanks.\r\n")
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{File: windows}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, 7, resp.WordCount)
+
+	var transcript models.Transcript
+	require.NoError(t, db.First(&transcript, "id = ?", resp.TranscriptID).Error)
+	assert.JSONEq(t, `{"bom_removed": true, "line_endings_normalized": true,
+		"diarization": {"labeled_ratio": 1, "speakers": 2, "avg_segment_words": 2.5, "low_quality": false}}`, string(transcript.TranscriptMetadata))
+
+	stored, err := os.ReadFile(transcript.FilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Host: Welcome to the show.\nGuest: Thanks.\n", string(stored))
+
+	// The same text saved with Unix conventions is a duplicate
+	unix := createTestFileHeader(t, "unix.txt", "Host: Welcome to the show.\nGuest: Thanks.\n")
+	_, err = service.UploadTranscript(&UploadTranscriptRequest{File: unix}, "test-correlation-id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate")
+}
+
+func TestHashTranscriptContent(t *testing.T) {
+	base := hashTranscriptContent([]byte("Host: Welcome to the show.\n\nGuest: Thanks.\n"))
+
+	equivalent := []struct {
+		name    string
+		content string
+	}{
+		{name: "trailing spaces", content: "Host: Welcome to the show.   \n\nGuest: Thanks.\t\n"},
+		{name: "crlf", content: "Host: Welcome to the show.\r\n\r\nGuest: Thanks.\r\n"},
+		{name: "bare cr", content: "Host: Welcome to the show.\r\rGuest: Thanks.\r"},
+		{name: "blank line run", content: "Host: Welcome to the show.\n\n\n  \n\nGuest: Thanks.\n"},
+	}
+	for _, tt := range equivalent {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, base, hashTranscriptContent([]byte(tt.content)))
+		})
+	}
+
+	different := []string{
+		"Host: Welcome to the show.\nGuest: Thanks.\n",
+		"Host:  Welcome to the show.\n\nGuest: Thanks.\n",
+		"  Host: Welcome to the show.\n\nGuest: Thanks.\n",
+		"Host: Welcome to the show.\n\nGuest: Thanks!\n",
+	}
+	for _, content := range different {
+		assert.NotEqual(t, base, hashTranscriptContent([]byte(content)), content)
+	}
+}
+
+func TestTranscriptService_UploadTranscript_DuplicateIgnoresFormatting(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	original := createTestFileHeader(t, "original.txt", "Host: Welcome to the show.  \n\n\n\nGuest: Thanks.\n")
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{File: original}, "test-correlation-id")
+	require.NoError(t, err)
+
+	// The stored file keeps the upload's formatting
+	var transcript models.Transcript
+	require.NoError(t, db.First(&transcript, "id = ?", resp.TranscriptID).Error)
+	stored, err := os.ReadFile(transcript.FilePath)
+	require.NoError(t, err)
+	assert.Equal(t, "Host: Welcome to the show.  \n\n\n\nGuest: Thanks.\n", string(stored))
+
+	reformatted := createTestFileHeader(t, "reformatted.txt", "Host: Welcome to the show.\n\nGuest: Thanks.   \n")
+	_, err = service.UploadTranscript(&UploadTranscriptRequest{File: reformatted}, "test-correlation-id")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate")
+}
+
+func TestTranscriptService_UploadTranscript_JSONWithBOM(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	fileHeader := createTestFileHeader(t, "episode.json", "\xEF\xBB\xBF{\"title\": \"Episode 1\", \"transcript\": \"Host: Welcome.\"}")
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+	require.NoError(t, err)
+
+	var transcript models.Transcript
+	require.NoError(t, db.First(&transcript, "id = ?", resp.TranscriptID).Error)
+
+	var metadata map[string]interface{}
+	require.NoError(t, json.Unmarshal(transcript.TranscriptMetadata, &metadata))
+	assert.Equal(t, "Episode 1", metadata["title"])
+	assert.Equal(t, true, metadata["bom_removed"])
+}
@@ -0,0 +1,359 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/models"
+	"podcast-analyzer/internal/utils"
+)
+
+// contentSniffLen is how much of an upload http.DetectContentType looks at
+const contentSniffLen = 512
+
+// storeStreamedUpload copies an upload to a temporary file in the storage directory in one
+// pass, normalizing it, hashing it and checking its size and encoding on the way, then
+// parses the saved file. Only the parse holds the content in memory, so a large upload
+// costs roughly half the memory of reading it whole. Results match storeBufferedUpload.
+func (s *TranscriptService) storeStreamedUpload(req *UploadTranscriptRequest, correlationID string) (*models.Transcript, error) {
+	ext, err := s.checkUploadHeader(req)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"filename":  req.File.Filename,
+			"operation": "open_upload_file",
+		})
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	// Sniff the content so a binary renamed to an allowed extension is caught
+	reader := bufio.NewReaderSize(file, contentSniffLen)
+	head, err := reader.Peek(contentSniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"filename":  req.File.Filename,
+			"operation": "read_file_content",
+		})
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if err := s.checkContentType(head, req.File.Filename, correlationID); err != nil {
+		return nil, err
+	}
+
+	tempPath, upload, err := s.streamToTempFile(reader, req.File.Filename, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	stored := false
+	defer func() {
+		if !stored {
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	if upload.size > s.config.MaxFileSize {
+		return nil, utils.NewValidationError("file.size", fmt.Sprintf("file too large: more than %d bytes. Maximum: %d bytes", s.config.MaxFileSize, s.config.MaxFileSize))
+	}
+	if !upload.validUTF8 {
+		return nil, utils.NewValidationError("file.encoding", "file must be UTF-8 encoded")
+	}
+
+	if err := s.checkForDuplicates(upload.contentHash, correlationID); err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(tempPath)
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"filename":  req.File.Filename,
+			"file_path": tempPath,
+			"operation": "read_streamed_upload",
+		})
+		return nil, fmt.Errorf("failed to read saved upload: %w", err)
+	}
+	transcript, err := s.processTranscriptFile(req, content, ext, upload.contentHash, upload.normalization, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := s.transcriptFilePath(transcript.ID)
+	if err := os.Rename(tempPath, filePath); err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"transcript_id": transcript.ID,
+			"filename":      transcript.Filename,
+			"operation":     "save_file",
+		})
+		return nil, fmt.Errorf("failed to save file: %w", err)
+	}
+	stored = true
+
+	if err := s.createTranscriptRecord(transcript, filePath, correlationID); err != nil {
+		return nil, err
+	}
+	return transcript, nil
+}
+
+// streamedUpload describes an upload copied to storage by streamToTempFile
+type streamedUpload struct {
+	size          int64 // Bytes read from the upload, up to one past the maximum file size
+	contentHash   string
+	normalization textNormalization
+	validUTF8     bool
+}
+
+// streamToTempFile copies an upload into a new temporary file in the storage directory,
+// reading at most one byte past the maximum file size. The caller removes the file.
+func (s *TranscriptService) streamToTempFile(reader io.Reader, filename, correlationID string) (string, streamedUpload, error) {
+	if err := os.MkdirAll(s.config.StoragePath, s.storageDirMode()); err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"storage_path": s.config.StoragePath,
+			"operation":    "create_storage_directory",
+		})
+		return "", streamedUpload{}, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	temp, err := os.CreateTemp(s.config.StoragePath, ".upload-*")
+	if err != nil {
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"storage_path": s.config.StoragePath,
+			"operation":    "create_upload_file",
+		})
+		return "", streamedUpload{}, fmt.Errorf("failed to create file: %w", err)
+	}
+	tempPath := temp.Name()
+
+	// The raw bytes are checked for UTF-8; the normalized text is hashed and written
+	hasher := newContentHasher()
+	normalizer := &textNormalizer{dst: io.MultiWriter(temp, hasher)}
+	validator := &utf8Validator{}
+	size, err := io.Copy(normalizer, io.TeeReader(io.LimitReader(reader, s.config.MaxFileSize+1), validator))
+	if err == nil {
+		err = normalizer.Close()
+	}
+	if err == nil {
+		err = temp.Chmod(0644)
+	}
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		logger.LogErrorWithStackAndCorrelation(err, correlationID, map[string]interface{}{
+			"filename":  filename,
+			"file_path": tempPath,
+			"operation": "stream_upload_to_storage",
+		})
+		return "", streamedUpload{}, fmt.Errorf("failed to save file: %w", err)
+	}
+
+	return tempPath, streamedUpload{
+		size:          size,
+		contentHash:   hasher.Sum(),
+		normalization: normalizer.normalization,
+		validUTF8:     validator.Valid(),
+	}, nil
+}
+
+// textNormalizer is the streaming form of normalizeTextContent: it strips a leading UTF-8
+// byte order mark and converts CRLF line endings to LF while copying to dst. Close must be
+// called to write a trailing carriage return.
+type textNormalizer struct {
+	dst           io.Writer
+	normalization textNormalization
+	head          []byte // Leading bytes held until a byte order mark is ruled out
+	started       bool
+	pendingCR     bool // A carriage return ended the last write
+}
+
+func (n *textNormalizer) Write(p []byte) (int, error) {
+	written := len(p)
+	if !n.started {
+		n.head = append(n.head, p...)
+		if len(n.head) < len(utf8BOM) && bytes.HasPrefix(utf8BOM, n.head) {
+			return written, nil
+		}
+		p, n.head, n.started = n.head, nil, true
+		if bytes.HasPrefix(p, utf8BOM) {
+			p = p[len(utf8BOM):]
+			n.normalization.bomRemoved = true
+		}
+	}
+	return written, n.write(p)
+}
+
+// write converts the line endings of p, holding back a final carriage return until the
+// next byte shows whether it starts a CRLF
+func (n *textNormalizer) write(p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	out := make([]byte, 0, len(p)+1)
+	if n.pendingCR {
+		n.pendingCR = false
+		if p[0] == '\n' {
+			n.normalization.lineEndingsConverted = true
+		} else {
+			out = append(out, '\r')
+		}
+	}
+	for i, b := range p {
+		if b == '\r' {
+			if i == len(p)-1 {
+				n.pendingCR = true
+				continue
+			}
+			if p[i+1] == '\n' {
+				n.normalization.lineEndingsConverted = true
+				continue
+			}
+		}
+		out = append(out, b)
+	}
+	_, err := n.dst.Write(out)
+	return err
+}
+
+// Close writes anything still held back
+func (n *textNormalizer) Close() error {
+	if !n.started {
+		n.started = true
+		if _, err := n.dst.Write(n.head); err != nil {
+			return err
+		}
+		n.head = nil
+	}
+	if n.pendingCR {
+		n.pendingCR = false
+		_, err := n.dst.Write([]byte{'\r'})
+		return err
+	}
+	return nil
+}
+
+// utf8Validator checks that everything written to it is valid UTF-8, allowing a character
+// to be split across writes
+type utf8Validator struct {
+	tail    []byte // An incomplete character at the end of the last write
+	invalid bool
+}
+
+func (v *utf8Validator) Write(p []byte) (int, error) {
+	if v.invalid {
+		return len(p), nil
+	}
+	data := p
+	if len(v.tail) > 0 {
+		data = append(v.tail, p...)
+		v.tail = nil
+	}
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size == 1 {
+			if !utf8.FullRune(data) {
+				v.tail = append([]byte(nil), data...)
+			} else {
+				v.invalid = true
+			}
+			break
+		}
+		data = data[size:]
+	}
+	return len(p), nil
+}
+
+// Valid reports whether the content written so far is valid UTF-8 and ends on a whole character
+func (v *utf8Validator) Valid() bool {
+	return !v.invalid && len(v.tail) == 0
+}
+
+// contentHasher is the streaming form of hashTranscriptContent. Content is hashed as it is
+// written, holding back only the whitespace at the end of the current line.
+type contentHasher struct {
+	hash          hash.Hash
+	out           []byte // Normalized bytes waiting to be hashed
+	whitespace    []byte // Whitespace that is dropped unless more text follows on the line
+	lineStarted   bool   // The current line has text
+	lines         int    // Lines hashed so far
+	previousBlank bool
+	pendingCR     bool // A carriage return ended the last line; a following LF is part of it
+}
+
+func newContentHasher() *contentHasher {
+	return &contentHasher{hash: sha256.New()}
+}
+
+func (h *contentHasher) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if h.pendingCR {
+			h.pendingCR = false
+			if b == '\n' {
+				continue
+			}
+		}
+		switch b {
+		case '\r':
+			h.endLine()
+			h.pendingCR = true
+		case '\n':
+			h.endLine()
+		case ' ', '\t', '\f', '\v':
+			h.whitespace = append(h.whitespace, b)
+		default:
+			if !h.lineStarted {
+				h.startLine()
+				h.lineStarted = true
+			}
+			h.out = append(h.out, h.whitespace...)
+			h.out = append(h.out, b)
+			h.whitespace = h.whitespace[:0]
+		}
+	}
+	h.hash.Write(h.out)
+	h.out = h.out[:0]
+	return len(p), nil
+}
+
+// startLine separates a new line from the previous one
+func (h *contentHasher) startLine() {
+	if h.lines > 0 {
+		h.out = append(h.out, '\n')
+	}
+	h.lines++
+}
+
+// endLine drops the line's trailing whitespace and keeps a blank line only when the line
+// before it was not blank
+func (h *contentHasher) endLine() {
+	h.whitespace = h.whitespace[:0]
+	if h.lineStarted {
+		h.lineStarted = false
+		h.previousBlank = false
+		return
+	}
+	if !h.previousBlank {
+		h.startLine()
+		h.previousBlank = true
+	}
+}
+
+// Sum finishes the last line and returns the hex-encoded hash. Nothing may be written after it.
+func (h *contentHasher) Sum() string {
+	h.endLine()
+	h.hash.Write(h.out)
+	h.out = nil
+	return hex.EncodeToString(h.hash.Sum(nil))
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"podcast-analyzer/internal/utils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streamSamples cover the formatting the streaming writers must treat exactly like their
+// whole-content counterparts
+var streamSamples = []string{
+	"",
+	"a",
+	"\r",
+	"\n\n\n",
+	"Host: Welcome to the show.\n\nGuest: Thanks.\n",
+	"Host: Welcome to the show.   \n\n\n  \n\nGuest: Thanks.\t",
+	"\xEF\xBB\xBFHost: Welcome.\r\nGuest: Thanks.\r\n",
+	"\xEF\xBBHost",
+	"Host: Welcome.\r\rGuest: Thanks.\r",
+	"  leading\tspace \f\v\r\n\r\n\r\ntrailing",
+	"Caf\xC3\xA9 \xE2\x82\xAC \xF0\x9F\x8E\x99",
+}
+
+// writeInChunks writes content in pieces of the given size, so state carried between
+// writes is exercised
+func writeInChunks(t *testing.T, w interface{ Write([]byte) (int, error) }, content []byte, size int) {
+	for len(content) > 0 {
+		n := size
+		if n > len(content) {
+			n = len(content)
+		}
+		_, err := w.Write(content[:n])
+		require.NoError(t, err)
+		content = content[n:]
+	}
+}
+
+func TestContentHasher_MatchesHashTranscriptContent(t *testing.T) {
+	for _, sample := range streamSamples {
+		for _, size := range []int{1, 2, 3, 1024} {
+			hasher := newContentHasher()
+			writeInChunks(t, hasher, []byte(sample), size)
+			assert.Equal(t, hashTranscriptContent([]byte(sample)), hasher.Sum(), "%q in chunks of %d", sample, size)
+		}
+	}
+}
+
+func TestTextNormalizer_MatchesNormalizeTextContent(t *testing.T) {
+	for _, sample := range streamSamples {
+		for _, size := range []int{1, 2, 3, 1024} {
+			var out bytes.Buffer
+			normalizer := &textNormalizer{dst: &out}
+			writeInChunks(t, normalizer, []byte(sample), size)
+			require.NoError(t, normalizer.Close())
+
+			expected, normalization := normalizeTextContent([]byte(sample))
+			assert.Equal(t, string(expected), out.String(), "%q in chunks of %d", sample, size)
+			assert.Equal(t, normalization, normalizer.normalization, "%q in chunks of %d", sample, size)
+		}
+	}
+}
+
+func TestUTF8Validator(t *testing.T) {
+	samples := append([]string{"\xFFbad", "ok\xC3", "\xE2\x82", "a\xC3(b"}, streamSamples...)
+	for _, sample := range samples {
+		for _, size := range []int{1, 2, 3, 1024} {
+			validator := &utf8Validator{}
+			writeInChunks(t, validator, []byte(sample), size)
+			assert.Equal(t, isValidUTF8([]byte(sample)), validator.Valid(), "%q in chunks of %d", sample, size)
+		}
+	}
+}
+
+func TestTranscriptService_UploadTranscript_StreamedRejectionLeavesNoFile(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+
+	fileHeader := createTestFileHeader(t, "latin1.txt", "Host: Caf\xE9 talk.")
+	_, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+
+	var validationErrs utils.ValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	assert.Equal(t, "file.encoding", validationErrs[0].Field)
+
+	entries, err := os.ReadDir(cfg.StoragePath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestTranscriptService_UploadTranscript_StreamedFileStored(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+
+	fileHeader := createTestFileHeader(t, "episode.txt", "Host: Welcome to the show.\r\nGuest: Thanks.")
+	resp, err := service.UploadTranscript(&UploadTranscriptRequest{File: fileHeader}, "test-correlation-id")
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(cfg.StoragePath)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, resp.TranscriptID.String()+".txt", entries[0].Name())
+
+	info, err := os.Stat(filepath.Join(cfg.StoragePath, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// timestampMarkerPattern matches inline [HH:MM:SS] (or [MM:SS]) markers in text transcripts
+var timestampMarkerPattern = regexp.MustCompile(`\[(\d{1,2}:\d{2}(?::\d{2})?)\]`)
+
+// nonWordPattern matches runs of characters ignored when matching text to the transcript
+var nonWordPattern = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// Fuzzy matching thresholds: a takeaway or claim is placed in a segment only when enough
+// of its distinctive words appear there
+const (
+	minTimestampMatchWords = 2
+	minTimestampMatchScore = 0.6
+	minTimestampWordLength = 4
+)
+
+// timestampSegment is the transcript text following one timestamp marker
+type timestampSegment struct {
+	timestamp string
+	start     int // Offset of the segment in timestampIndex.text
+	words     map[string]bool
+}
+
+// timestampIndex maps text back to the nearest preceding timestamp marker
+type timestampIndex struct {
+	text     string // Normalized transcript text from the first marker on
+	segments []timestampSegment
+}
+
+// buildTimestampIndex indexes the segments between timestamp markers, returning nil when
+// the transcript has none
+func buildTimestampIndex(content string) *timestampIndex {
+	markers := timestampMarkerPattern.FindAllStringSubmatchIndex(content, -1)
+	if len(markers) == 0 {
+		return nil
+	}
+
+	index := &timestampIndex{}
+	var text strings.Builder
+	for i, marker := range markers {
+		end := len(content)
+		if i+1 < len(markers) {
+			end = markers[i+1][0]
+		}
+		normalized := normalizeForMatch(content[marker[1]:end])
+
+		index.segments = append(index.segments, timestampSegment{
+			timestamp: normalizeTimestamp(content[marker[2]:marker[3]]),
+			start:     text.Len(),
+			words:     matchWords(normalized),
+		})
+		text.WriteString(normalized)
+		text.WriteString(" ")
+	}
+	index.text = text.String()
+	return index
+}
+
+// locate returns the timestamp of the segment where text appears, or "" when it cannot be
+// placed. A verbatim match wins; otherwise the segment (together with the one after it,
+// for text spanning a marker) sharing the most distinctive words is used.
+func (idx *timestampIndex) locate(text string) string {
+	query := normalizeForMatch(text)
+	if query == "" {
+		return ""
+	}
+
+	if pos := strings.Index(idx.text, query); pos >= 0 {
+		return idx.segmentAt(pos).timestamp
+	}
+
+	words := matchWords(query)
+	if len(words) < minTimestampMatchWords {
+		return ""
+	}
+
+	// Ties go to the segment holding more of the words itself, so text that sits wholly in
+	// one segment is not credited to the segment before it
+	best, bestHits, bestOwnHits := -1, 0, 0
+	for i, segment := range idx.segments {
+		hits, ownHits := 0, 0
+		for word := range words {
+			if segment.words[word] {
+				hits++
+				ownHits++
+			} else if i+1 < len(idx.segments) && idx.segments[i+1].words[word] {
+				hits++
+			}
+		}
+		if ownHits > 0 && (hits > bestHits || (hits == bestHits && ownHits > bestOwnHits)) {
+			best, bestHits, bestOwnHits = i, hits, ownHits
+		}
+	}
+	if best < 0 || bestHits < minTimestampMatchWords || float64(bestHits)/float64(len(words)) < minTimestampMatchScore {
+		return ""
+	}
+	return idx.segments[best].timestamp
+}
+
+// segmentAt returns the segment containing an offset of the normalized text
+func (idx *timestampIndex) segmentAt(pos int) timestampSegment {
+	segment := idx.segments[0]
+	for _, candidate := range idx.segments[1:] {
+		if candidate.start > pos {
+			break
+		}
+		segment = candidate
+	}
+	return segment
+}
+
+// annotateTimestamps records where each takeaway and fact-checked claim appears in a
+// transcript with inline timestamp markers. Takeaway timestamps are stored by index
+// alongside the takeaways and claim timestamps alongside each claim's sources; items that
+// cannot be placed are left without one.
+func annotateTimestamps(results *AnalysisResults, content string, takeaways []string) {
+	index := buildTimestampIndex(content)
+	if index == nil {
+		return
+	}
+
+	takeawayTimestamps := make(map[string]string)
+	for i, takeaway := range takeaways {
+		if timestamp := index.locate(takeaway); timestamp != "" {
+			takeawayTimestamps[strconv.Itoa(i)] = timestamp
+		}
+	}
+	if len(takeawayTimestamps) > 0 && results.Takeaways != nil {
+		results.Takeaways["timestamps"] = takeawayTimestamps
+	}
+
+	for i := range results.FactChecks {
+		if timestamp := index.locate(results.FactChecks[i].Claim); timestamp != "" && results.FactChecks[i].Sources != nil {
+			results.FactChecks[i].Sources["timestamp"] = timestamp
+		}
+	}
+}
+
+// normalizeForMatch lowercases text and reduces it to single-space separated words
+func normalizeForMatch(text string) string {
+	return strings.TrimSpace(nonWordPattern.ReplaceAllString(strings.ToLower(text), " "))
+}
+
+// matchWords returns the distinctive words of normalized text, skipping short ones
+func matchWords(normalized string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(normalized) {
+		if len([]rune(word)) >= minTimestampWordLength {
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// normalizeTimestamp pads a marker to HH:MM:SS
+func normalizeTimestamp(marker string) string {
+	parts := strings.Split(marker, ":")
+	if len(parts) == 2 {
+		parts = append([]string{"0"}, parts...)
+	}
+	for i, part := range parts {
+		if len(part) < 2 {
+			parts[i] = "0" + part
+		}
+	}
+	return strings.Join(parts, ":")
+}
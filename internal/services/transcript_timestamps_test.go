@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const timestampedTranscript = `Intro music.
+[00:00:05] Host: Welcome to the show. Today we talk about space exploration.
+[00:02:30] Guest: The Apollo 11 mission landed on the moon in 1969, which changed everything.
+[0:05:10] Host: Funding for science has dropped sharply over the last decade.
+[12:45] Guest: Private companies are now launching most satellites.`
+
+func TestTimestampIndex_Locate(t *testing.T) {
+	index := buildTimestampIndex(timestampedTranscript)
+	require.NotNil(t, index)
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "verbatim", text: "The Apollo 11 mission landed on the moon in 1969", expected: "00:02:30"},
+		{name: "verbatim ignoring case and punctuation", text: "welcome to the show!", expected: "00:00:05"},
+		{name: "paraphrased", text: "Science funding dropped sharply during the last decade", expected: "00:05:10"},
+		{name: "short marker padded", text: "Private companies launch most satellites now", expected: "00:12:45"},
+		{name: "no match", text: "Quantum computers will break encryption", expected: ""},
+		{name: "short verbatim", text: "the moon", expected: "00:02:30"},
+		{name: "too few distinctive words", text: "the stars", expected: ""},
+		{name: "spanning a marker", text: "changed everything for science funding", expected: "00:02:30"},
+		{name: "empty", text: "  ", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, index.locate(tt.text))
+		})
+	}
+}
+
+func TestBuildTimestampIndex_NoMarkers(t *testing.T) {
+	assert.Nil(t, buildTimestampIndex("Host: Welcome to the show.\nGuest: Thanks."))
+}
+
+func TestAnnotateTimestamps(t *testing.T) {
+	takeaways := []string{"Apollo 11 landed on the moon in 1969", "Podcasts are popular"}
+	results := &AnalysisResults{
+		Takeaways: map[string]interface{}{"takeaways": takeaways},
+		FactChecks: []FactCheckResult{
+			{Claim: "Funding for science has dropped sharply", Sources: map[string]interface{}{"sources": []string{}}},
+			{Claim: "Mars has two moons", Sources: map[string]interface{}{"sources": []string{}}},
+		},
+	}
+
+	annotateTimestamps(results, timestampedTranscript, takeaways)
+
+	assert.Equal(t, map[string]string{"0": "00:02:30"}, results.Takeaways["timestamps"])
+	assert.Equal(t, "00:05:10", results.FactChecks[0].Sources["timestamp"])
+	assert.NotContains(t, results.FactChecks[1].Sources, "timestamp")
+}
+
+func TestAnnotateTimestamps_NoMarkersLeavesResultsUnchanged(t *testing.T) {
+	takeaways := []string{"Apollo 11 landed on the moon in 1969"}
+	results := &AnalysisResults{
+		Takeaways:  map[string]interface{}{"takeaways": takeaways},
+		FactChecks: []FactCheckResult{{Claim: "Apollo 11 landed in 1969", Sources: map[string]interface{}{"sources": []string{}}}},
+	}
+
+	annotateTimestamps(results, "Apollo 11 landed on the moon in 1969.", takeaways)
+
+	assert.NotContains(t, results.Takeaways, "timestamps")
+	assert.NotContains(t, results.FactChecks[0].Sources, "timestamp")
+}
+
+func TestAnalysisService_Timestamps_RoundTrip(t *testing.T) {
+	db := setupAnalysisTestDB(t)
+	service := NewAnalysisService(db, setupAnalysisTestConfig(t))
+
+	transcript := &models.Transcript{ID: uuid.New(), Filename: "episode.txt", ContentHash: "timestamps", UploadedAt: time.Now()}
+	require.NoError(t, db.Create(transcript).Error)
+	analysis := &models.AnalysisResult{ID: uuid.New(), TranscriptID: transcript.ID, JobID: uuid.New(), Status: "processing"}
+	require.NoError(t, db.Create(analysis).Error)
+
+	takeaways := []string{"Apollo 11 landed on the moon in 1969", "Podcasts are popular"}
+	results, err := service.transformAnalysisResults("Summary", takeaways, nil, analysis.JobID, "test-correlation-id")
+	require.NoError(t, err)
+	results.FactChecks = []FactCheckResult{{
+		Claim:   "Funding for science has dropped sharply",
+		Verdict: models.VerdictTrue,
+		Sources: map[string]interface{}{"sources": []string{"https://example.com/funding"}},
+	}}
+	annotateTimestamps(results, timestampedTranscript, takeaways)
+
+	saved, err := service.saveAnalysisResults(context.Background(), analysis.JobID, results, "test-correlation-id")
+	require.NoError(t, err)
+	service.saveFactChecks(context.Background(), saved.ID, results.FactChecks, "test-correlation-id")
+
+	response, err := service.GetAnalysisResults(analysis.ID, FactCheckFilter{}, "test-correlation-id")
+	require.NoError(t, err)
+	assert.Equal(t, takeaways, response.Takeaways)
+	assert.Equal(t, map[int]string{0: "00:02:30"}, response.TakeawayTimestamps)
+	require.Len(t, response.FactChecks, 1)
+	assert.Equal(t, "00:05:10", response.FactChecks[0].Timestamp)
+	assert.Equal(t, []string{"https://example.com/funding"}, response.FactChecks[0].Sources)
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"podcast-analyzer/internal/logger"
+	"podcast-analyzer/internal/utils"
+
+	"github.com/google/uuid"
+)
+
+// TranscriptValidationResponse says whether an upload would be accepted and what it would
+// store, without storing anything
+type TranscriptValidationResponse struct {
+	Valid       bool                `json:"valid"`
+	Filename    string              `json:"filename"`
+	WordCount   int                 `json:"word_count"`
+	CharCount   int                 `json:"char_count"`
+	ContentHash string              `json:"content_hash,omitempty"`
+	Language    string              `json:"language,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Metadata    json.RawMessage     `json:"metadata,omitempty"`     // Metadata detected in the file, as it would be saved
+	Diarization *DiarizationQuality `json:"diarization,omitempty"`  // Speaker-label quality, for transcripts with speaker labels
+	DuplicateOf *uuid.UUID          `json:"duplicate_of,omitempty"` // Stored transcript with the same content
+	Errors      []utils.FieldError  `json:"errors,omitempty"`       // Why the upload would be rejected
+}
+
+// ValidateTranscript runs an upload's checks (extension, size, content type, encoding,
+// parsing and duplicates) and reports the counts and metadata it would store, without
+// writing to disk or the database. A file that would be rejected is not an error; its
+// problems are listed in the response. Errors are returned only when the checks could
+// not run.
+func (s *TranscriptService) ValidateTranscript(req *UploadTranscriptRequest, correlationID string) (*TranscriptValidationResponse, error) {
+	release, err := s.acquireUploadSlot()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	releaseContent, err := s.acquireUploadContent(req.File.Size)
+	if err != nil {
+		return nil, err
+	}
+	defer releaseContent()
+
+	response := &TranscriptValidationResponse{Filename: req.File.Filename}
+
+	ext, content, err := s.validateUploadedFile(req, correlationID)
+	if err != nil {
+		if response.Errors = uploadFileErrors(err); response.Errors == nil {
+			return nil, err
+		}
+		return response, nil
+	}
+
+	content, normalization := normalizeTextContent(content)
+	response.ContentHash = hashTranscriptContent(content)
+
+	transcript, err := s.processTranscriptFile(req, content, ext, response.ContentHash, normalization, correlationID)
+	if err != nil {
+		// Anything that goes wrong parsing is a problem with the file's content
+		if response.Errors = uploadFileErrors(err); response.Errors == nil {
+			response.Errors = []utils.FieldError{{Field: "file.content", Message: err.Error()}}
+		}
+		return response, nil
+	}
+	response.WordCount = transcript.WordCount
+	response.CharCount = transcript.CharCount
+	response.Language, response.Tags = transcriptLabels(transcript.TranscriptMetadata)
+	response.Diarization = diarizationFromMetadata(transcript.TranscriptMetadata)
+	if string(transcript.TranscriptMetadata) != "null" {
+		response.Metadata = json.RawMessage(transcript.TranscriptMetadata)
+	}
+
+	existing, err := s.TranscriptExists(response.ContentHash, correlationID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Exists {
+		response.DuplicateOf = existing.TranscriptID
+		response.Errors = append(response.Errors, utils.FieldError{
+			Field:   "file",
+			Message: fmt.Sprintf("duplicate transcript already exists with ID: %s", existing.TranscriptID),
+		})
+	}
+
+	response.Valid = len(response.Errors) == 0
+	logger.WithCorrelationID(correlationID).WithFields(map[string]interface{}{
+		"filename":   response.Filename,
+		"valid":      response.Valid,
+		"word_count": response.WordCount,
+	}).Info("Transcript validated")
+	return response, nil
+}
+
+// uploadFileErrors lists the problems an upload check found with the file, or returns nil
+// when err says nothing about the file, like a failure to read it
+func uploadFileErrors(err error) []utils.FieldError {
+	var validationErrs utils.ValidationErrors
+	var schemaErr *TranscriptSchemaError
+	switch {
+	case errors.As(err, &validationErrs):
+		return validationErrs
+	case errors.As(err, &schemaErr):
+		return []utils.FieldError{{Field: "file.schema", Message: schemaErr.Error()}}
+	case strings.Contains(err.Error(), "unsupported content type"):
+		return []utils.FieldError{{Field: "file.content_type", Message: err.Error()}}
+	}
+	return nil
+}
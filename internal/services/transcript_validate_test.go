@@ -0,0 +1,87 @@
+package services
+
+import (
+	"os"
+	"testing"
+
+	"podcast-analyzer/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptService_ValidateTranscript(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	service := NewTranscriptService(db, cfg)
+
+	content := `{"transcript": "Welcome to the show about gardening.", "language": "en", "tags": ["gardening"]}`
+	response, err := service.ValidateTranscript(&UploadTranscriptRequest{File: createTestFileHeader(t, "episode.json", content)}, "test-correlation-id")
+
+	require.NoError(t, err)
+	assert.True(t, response.Valid)
+	assert.Equal(t, "episode.json", response.Filename)
+	assert.Equal(t, 6, response.WordCount)
+	assert.Equal(t, "en", response.Language)
+	assert.Equal(t, []string{"gardening"}, response.Tags)
+	assert.JSONEq(t, `{"language": "en", "tags": ["gardening"]}`, string(response.Metadata))
+	assert.Len(t, response.ContentHash, 64)
+	assert.Empty(t, response.Errors)
+
+	// Nothing is stored
+	var count int64
+	require.NoError(t, db.Model(&models.Transcript{}).Count(&count).Error)
+	assert.Zero(t, count)
+	entries, err := os.ReadDir(cfg.StoragePath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestTranscriptService_ValidateTranscript_Rejected(t *testing.T) {
+	db := setupTestDB(t)
+	cfg := setupTestConfig(t)
+	cfg.MaxFileSize = 100
+	service := NewTranscriptService(db, cfg)
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		fields   []string
+	}{
+		{name: "extension and size", filename: "episode.pdf", content: string(make([]byte, 200)), fields: []string{"file.extension", "file.size"}},
+		{name: "invalid JSON", filename: "episode.json", content: `{"transcript": `, fields: []string{"file.content"}},
+		{name: "schema", filename: "episode.json", content: `{"text": "hello"}`, fields: []string{"file.schema"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := service.ValidateTranscript(&UploadTranscriptRequest{File: createTestFileHeader(t, tt.filename, tt.content)}, "test-correlation-id")
+
+			require.NoError(t, err)
+			assert.False(t, response.Valid)
+			var fields []string
+			for _, fieldErr := range response.Errors {
+				fields = append(fields, fieldErr.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestTranscriptService_ValidateTranscript_Duplicate(t *testing.T) {
+	db := setupTestDB(t)
+	service := NewTranscriptService(db, setupTestConfig(t))
+
+	content := "This is test content for duplicate detection."
+	uploaded, err := service.UploadTranscript(&UploadTranscriptRequest{File: createTestFileHeader(t, "test.txt", content)}, "test-correlation-id")
+	require.NoError(t, err)
+
+	response, err := service.ValidateTranscript(&UploadTranscriptRequest{File: createTestFileHeader(t, "again.txt", content)}, "test-correlation-id")
+
+	require.NoError(t, err)
+	assert.False(t, response.Valid)
+	require.NotNil(t, response.DuplicateOf)
+	assert.Equal(t, uploaded.TranscriptID, *response.DuplicateOf)
+	assert.Equal(t, 7, response.WordCount)
+}
@@ -1,42 +1,205 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
+
+	"podcast-analyzer/internal/requestctx"
 
 	"github.com/google/uuid"
 )
 
-// getCorrelationID gets or generates a correlation ID for request tracing
+// DefaultCorrelationIDHeaders are the request headers checked for a correlation ID when none
+// are configured, in order of preference
+var DefaultCorrelationIDHeaders = []string{"X-Correlation-ID", "X-Request-ID"}
+
+// GetCorrelationID gets or generates a correlation ID for request tracing. An ID already
+// chosen by the request ID middleware takes precedence over the request headers.
 func GetCorrelationID(r *http.Request) string {
-	if id := r.Header.Get("X-Correlation-ID"); id != "" {
+	if id := requestctx.CorrelationID(r.Context()); id != "" {
 		return id
 	}
-	if id := r.Header.Get("X-Request-ID"); id != "" {
+	if id := CorrelationIDFromHeaders(r, DefaultCorrelationIDHeaders); id != "" {
 		return id
 	}
 	return uuid.New().String()
 }
 
+// CorrelationIDFromHeaders returns the value of the first of headers present on the request,
+// or "" when none is. A W3C traceparent header contributes its trace ID.
+func CorrelationIDFromHeaders(r *http.Request, headers []string) string {
+	for _, header := range headers {
+		value := strings.TrimSpace(r.Header.Get(header))
+		if value == "" {
+			continue
+		}
+		if strings.EqualFold(header, "traceparent") {
+			value = traceIDFromTraceparent(value)
+			if value == "" {
+				continue
+			}
+		}
+		return value
+	}
+	return ""
+}
+
+// traceIDFromTraceparent extracts the trace ID from a traceparent value of the form
+// version-traceid-parentid-flags, returning "" when the value is malformed
+func traceIDFromTraceparent(value string) string {
+	parts := strings.Split(value, "-")
+	if len(parts) < 4 || len(parts[1]) != 32 {
+		return ""
+	}
+	if _, err := hex.DecodeString(parts[1]); err != nil || strings.Trim(parts[1], "0") == "" {
+		return ""
+	}
+	return strings.ToLower(parts[1])
+}
+
 // SetCORSHeaders sets CORS headers on the response writer
 func SetCORSHeaders(w http.ResponseWriter) {
 	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token, X-Correlation-ID, X-Request-ID")
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token, X-Correlation-ID, X-Request-ID, If-None-Match")
 	w.Header().Set("Access-Control-Allow-Credentials", "false")
 }
 
-// writeJSON writes a JSON response with proper headers
+// negotiatedWriter records whether the client prefers plain-text error responses
+type negotiatedWriter struct {
+	http.ResponseWriter
+	plainTextErrors bool
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (nw *negotiatedWriter) Unwrap() http.ResponseWriter {
+	return nw.ResponseWriter
+}
+
+// WithErrorNegotiation wraps a response writer so error writers honor the request's Accept header
+func WithErrorNegotiation(w http.ResponseWriter, r *http.Request) http.ResponseWriter {
+	return &negotiatedWriter{ResponseWriter: w, plainTextErrors: PrefersPlainText(r)}
+}
+
+// PrefersPlainText reports whether the Accept header ranks text/plain above application/json.
+// JSON wins ties, so wildcards and missing headers keep the JSON default.
+func PrefersPlainText(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return false
+	}
+	textQ := acceptQuality(accept, "text", "plain")
+	return textQ > 0 && textQ > acceptQuality(accept, "application", "json")
+}
+
+// acceptQuality returns the q-value the Accept header assigns to a media type, using the most specific match
+func acceptQuality(accept, mainType, subType string) float64 {
+	quality, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(params[0]))
+
+		matched := -1
+		switch mediaType {
+		case mainType + "/" + subType:
+			matched = 2
+		case mainType + "/*":
+			matched = 1
+		case "*/*":
+			matched = 0
+		}
+		if matched <= specificity {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range params[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		quality, specificity = q, matched
+	}
+	return quality
+}
+
+// writePlainTextError writes an error body as a single line of text
+func writePlainTextError(w http.ResponseWriter, status int, errBody map[string]interface{}) error {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	SetCORSHeaders(w)
+	w.WriteHeader(status)
+
+	line := fmt.Sprintf("%v: %v", errBody["code"], errBody["message"])
+	if correlationID, ok := errBody["correlation_id"]; ok && correlationID != "" {
+		line += fmt.Sprintf(" (correlation_id: %v)", correlationID)
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// writeJSON writes a JSON response with proper headers. Error payloads of the form
+// {"error": {...}} are written as plain text when the client prefers it.
 func WriteJSON(w http.ResponseWriter, status int, data interface{}) error {
+	if nw, ok := w.(*negotiatedWriter); ok && nw.plainTextErrors && status >= 400 {
+		if body, ok := data.(map[string]interface{}); ok {
+			if errBody, ok := body["error"].(map[string]interface{}); ok {
+				return writePlainTextError(w, status, errBody)
+			}
+		}
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	SetCORSHeaders(w)
 	w.WriteHeader(status)
 	return json.NewEncoder(w).Encode(data)
 }
 
+// WriteJSONWithETag writes a JSON response tagged with a hash of its body. When the
+// request's If-None-Match already names that tag, a bodiless 304 is written instead, so
+// clients polling an unchanged resource skip the download.
+func WriteJSONWithETag(w http.ResponseWriter, r *http.Request, status int, data interface{}) error {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	SetCORSHeaders(w)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Access-Control-Expose-Headers", "ETag")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, err = w.Write(append(body, '\n'))
+	return err
+}
+
+// etagMatches reports whether an If-None-Match header names etag, using the weak
+// comparison RFC 9110 specifies for conditional GETs
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 // writeError writes a standardized error response
 func WriteError(w http.ResponseWriter, status int, code, message string) {
 	WriteJSON(w, status, map[string]interface{}{
@@ -58,6 +221,19 @@ func WriteErrorWithCorrelation(w http.ResponseWriter, status int, code, message,
 	})
 }
 
+// WriteValidationErrors writes a 422 response listing each invalid field alongside the
+// standard error object
+func WriteValidationErrors(w http.ResponseWriter, errs ValidationErrors, correlationID string) {
+	WriteJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":           "VALIDATION_ERROR",
+			"message":        errs.Error(),
+			"correlation_id": correlationID,
+		},
+		"errors": errs,
+	})
+}
+
 // getClientIP extracts the real client IP address
 func GetClientIP(r *http.Request) string {
 	// Check X-Forwarded-For header
@@ -101,4 +277,30 @@ func GetQueryParamInt(r *http.Request, key string, defaultValue int) int {
 		}
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// GetQueryParamTime parses an RFC3339 timestamp query parameter, returning nil when it is absent
+func GetQueryParamTime(r *http.Request, key string) (*time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC3339 timestamp", key)
+	}
+	return &t, nil
+}
+
+// GetQueryParamBool parses a boolean query parameter, returning defaultValue when it is absent
+func GetQueryParamBool(r *http.Request, key string, defaultValue bool) (bool, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue, fmt.Errorf("%s must be true or false", key)
+	}
+	return b, nil
+}
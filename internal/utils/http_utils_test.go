@@ -5,9 +5,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+
+	"podcast-analyzer/internal/requestctx"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetCorrelationID(t *testing.T) {
@@ -72,6 +76,21 @@ func TestGetCorrelationID(t *testing.T) {
 	}
 }
 
+func TestGetCorrelationID_PrefersContext(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Correlation-ID", "header-id")
+	req = req.WithContext(requestctx.WithCorrelationID(req.Context(), "context-id"))
+
+	assert.Equal(t, "context-id", GetCorrelationID(req))
+}
+
+func TestTraceIDFromTraceparent(t *testing.T) {
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traceIDFromTraceparent("00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"))
+	assert.Equal(t, "", traceIDFromTraceparent("00-00000000000000000000000000000000-00f067aa0ba902b7-01"))
+	assert.Equal(t, "", traceIDFromTraceparent("00-short-00f067aa0ba902b7-01"))
+	assert.Equal(t, "", traceIDFromTraceparent("garbage"))
+}
+
 func TestSetCORSHeaders(t *testing.T) {
 	recorder := httptest.NewRecorder()
 	
@@ -80,7 +99,7 @@ func TestSetCORSHeaders(t *testing.T) {
 	expectedHeaders := map[string]string{
 		"Access-Control-Allow-Origin":      "*",
 		"Access-Control-Allow-Methods":     "GET, POST, PUT, DELETE, OPTIONS",
-		"Access-Control-Allow-Headers":     "Accept, Authorization, Content-Type, X-CSRF-Token, X-Correlation-ID, X-Request-ID",
+		"Access-Control-Allow-Headers":     "Accept, Authorization, Content-Type, X-CSRF-Token, X-Correlation-ID, X-Request-ID, If-None-Match",
 		"Access-Control-Allow-Credentials": "false",
 	}
 
@@ -141,6 +160,51 @@ func TestWriteJSON(t *testing.T) {
 	}
 }
 
+func TestWriteJSONWithETag(t *testing.T) {
+	data := map[string]interface{}{"status": "processing"}
+
+	first := httptest.NewRecorder()
+	require.NoError(t, WriteJSONWithETag(first, httptest.NewRequest(http.MethodGet, "/api/results/1", nil), http.StatusOK, data))
+	etag := first.Header().Get("ETag")
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.JSONEq(t, `{"status": "processing"}`, first.Body.String())
+
+	tests := []struct {
+		name           string
+		ifNoneMatch    string
+		data           interface{}
+		expectedStatus int
+	}{
+		{name: "matching tag", ifNoneMatch: etag, data: data, expectedStatus: http.StatusNotModified},
+		{name: "weak tag in a list", ifNoneMatch: `"other", W/` + etag, data: data, expectedStatus: http.StatusNotModified},
+		{name: "wildcard", ifNoneMatch: "*", data: data, expectedStatus: http.StatusNotModified},
+		{name: "stale tag", ifNoneMatch: etag, data: map[string]interface{}{"status": "completed"}, expectedStatus: http.StatusOK},
+		{name: "no header", data: data, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/results/1", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			recorder := httptest.NewRecorder()
+
+			require.NoError(t, WriteJSONWithETag(recorder, req, http.StatusOK, tt.data))
+
+			assert.Equal(t, tt.expectedStatus, recorder.Code)
+			assert.NotEmpty(t, recorder.Header().Get("ETag"))
+			if tt.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, recorder.Body.String())
+			} else {
+				assert.NotEmpty(t, recorder.Body.String())
+			}
+		})
+	}
+}
+
 func TestWriteError(t *testing.T) {
 	recorder := httptest.NewRecorder()
 	
@@ -419,4 +483,131 @@ func TestWriteJSON_Integration(t *testing.T) {
 	assert.Equal(t, "Test Item", decoded["name"])
 	assert.Equal(t, true, decoded["active"])
 	assert.Equal(t, float64(42), decoded["count"]) // JSON numbers decode as float64
-}
\ No newline at end of file
+}
+func TestGetQueryParamTime(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test?since=2024-03-01T12:00:00Z&bad=2024-03-01", nil)
+
+	result, err := GetQueryParamTime(req, "since")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC), result.UTC())
+
+	result, err = GetQueryParamTime(req, "missing")
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	result, err = GetQueryParamTime(req, "bad")
+	assert.EqualError(t, err, "bad must be an RFC3339 timestamp")
+	assert.Nil(t, result)
+}
+
+func TestPrefersPlainText(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{"", false},
+		{"application/json", false},
+		{"text/plain", true},
+		{"text/plain, application/json", false},
+		{"application/json;q=0.5, text/plain", true},
+		{"text/*", true},
+		{"*/*", false},
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", false},
+		{"text/plain;q=0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/test", nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			assert.Equal(t, tt.expected, PrefersPlainText(req))
+		})
+	}
+}
+
+func TestWriteErrorWithCorrelation_PlainText(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept", "text/plain")
+	recorder := httptest.NewRecorder()
+
+	WriteErrorWithCorrelation(WithErrorNegotiation(recorder, req), http.StatusNotFound,
+		"TRANSCRIPT_NOT_FOUND", "Transcript not found", "corr-123")
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "TRANSCRIPT_NOT_FOUND: Transcript not found (correlation_id: corr-123)\n", recorder.Body.String())
+}
+
+func TestWriteError_NegotiatedDefaultsToJSON(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	recorder := httptest.NewRecorder()
+
+	WriteError(WithErrorNegotiation(recorder, req), http.StatusBadRequest, "INVALID_REQUEST", "Bad input")
+
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	var decoded map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &decoded))
+	errorObj := decoded["error"].(map[string]interface{})
+	assert.Equal(t, "INVALID_REQUEST", errorObj["code"])
+	assert.Equal(t, "Bad input", errorObj["message"])
+}
+
+func TestWriteJSON_PlainTextOnlyAffectsErrors(t *testing.T) {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("Accept", "text/plain")
+	recorder := httptest.NewRecorder()
+
+	WriteJSON(WithErrorNegotiation(recorder, req), http.StatusOK, map[string]interface{}{"status": "ok"})
+
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+}
+
+func TestWriteValidationErrors(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	var errs ValidationErrors
+	errs.Add("created_after", "created_after must be an RFC3339 timestamp")
+	errs.Add("created_before", "created_before must be an RFC3339 timestamp")
+
+	WriteValidationErrors(recorder, errs, "test-correlation-123")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, recorder.Code)
+
+	var response struct {
+		Error  map[string]interface{} `json:"error"`
+		Errors []FieldError           `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "VALIDATION_ERROR", response.Error["code"])
+	assert.Equal(t, "test-correlation-123", response.Error["correlation_id"])
+	assert.Equal(t, []FieldError(errs), response.Errors)
+}
+
+func TestGetQueryParamBool(t *testing.T) {
+	tests := []struct {
+		name        string
+		queryString string
+		expected    bool
+		expectError bool
+	}{
+		{name: "missing parameter - use default", queryString: "", expected: false},
+		{name: "true", queryString: "?include_preview=true", expected: true},
+		{name: "numeric true", queryString: "?include_preview=1", expected: true},
+		{name: "false", queryString: "?include_preview=false", expected: false},
+		{name: "invalid value", queryString: "?include_preview=yes", expected: false, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test"+tt.queryString, nil)
+			result, err := GetQueryParamBool(req, "include_preview", false)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
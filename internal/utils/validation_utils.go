@@ -80,4 +80,31 @@ func ValidateAndParseUUID(idStr string, fieldName string) (uuid.UUID, error) {
 	}
 	
 	return id, nil
-}
\ No newline at end of file
+}
+// FieldError describes a problem with a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors collects the field errors of one request. Handlers respond with
+// 422 and the individual errors when a service returns it.
+type ValidationErrors []FieldError
+
+func (v ValidationErrors) Error() string {
+	messages := make([]string, len(v))
+	for i, fieldErr := range v {
+		messages[i] = fieldErr.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Add appends an error for field
+func (v *ValidationErrors) Add(field, message string) {
+	*v = append(*v, FieldError{Field: field, Message: message})
+}
+
+// NewValidationError creates validation errors holding a single field error
+func NewValidationError(field, message string) ValidationErrors {
+	return ValidationErrors{{Field: field, Message: message}}
+}
@@ -500,4 +500,13 @@ func TestMatchPath_EdgeCases(t *testing.T) {
 			assert.Equal(t, tt.expectedMatch, match, tt.description)
 		})
 	}
-}
\ No newline at end of file
+}
+func TestValidationErrors(t *testing.T) {
+	var errs ValidationErrors
+	errs.Add("file.extension", "invalid file extension: .pdf")
+	errs.Add("file.size", "file too large")
+
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "invalid file extension: .pdf; file too large", errs.Error())
+	assert.Equal(t, ValidationErrors{{Field: "instructions", Message: "too long"}}, NewValidationError("instructions", "too long"))
+}